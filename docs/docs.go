@@ -57,6 +57,18 @@ const docTemplate = `{
                         "name": "order_dir",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated presence facets to count (effect, owner); each respects every other filter except its own",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression over name, effect, owner and stats, e.g. patk\u003e=50 and effect~\\",
+                        "name": "filter",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "Page number (default 1)",
@@ -68,6 +80,42 @@ const docTemplate = `{
                         "description": "Page size (default 10, max 100)",
                         "name": "page_size",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset pagination cursor from next_cursor or prev_cursor; send it empty for the first page. Setting cursor or limit switches the response to a helpers.CursorResponse",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Keyset page size (default 10, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the matching rows in keyset mode",
+                        "name": "include_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return for each item, e.g. name,patk,owner; id is always returned",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "List the accessories and their owners as they were at this RFC 3339 time",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated IDs to fetch in one request (max 100); the response is then a helpers.BatchResponse listing missing IDs under not_found, and other parameters except fields are ignored",
+                        "name": "ids",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -75,6 +123,20 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/helpers.PaginatedResponse-domain_AccessoryListItemResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag for the page, derived from the filter, page, and result set"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Newest modification among matching items; If-Modified-Since is ignored, use If-None-Match"
+                            },
+                            "Link": {
+                                "type": "string",
+                                "description": "RFC 8288 links to the first, prev, next and last pages, keeping the request's filters"
+                            }
                         }
                     },
                     "400": {
@@ -92,9 +154,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "authenticate user and receive JWT token",
+        "/accessories/by-slug/{slug}": {
+            "get": {
+                "description": "get accessory information by its URL-safe slug",
                 "consumes": [
                     "application/json"
                 ],
@@ -102,35 +164,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "accessories"
                 ],
-                "summary": "User login",
+                "summary": "Get accessory by slug",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "Accessory slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. name,patk,owner; id is always returned",
+                        "name": "fields",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/controller.DataResponse-domain_LoginResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/controller.ErrorResponse"
+                            "$ref": "#/definitions/domain.AccessoryListItemResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/controller.ErrorResponse"
                         }
@@ -144,14 +204,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/travellers": {
+        "/change-requests": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "get traveller list with optional filters and pagination",
+                "description": "list proposed changes to travellers and accessories, oldest first. List status=pending for the review queue.",
                 "consumes": [
                     "application/json"
                 ],
@@ -159,26 +219,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "travellers"
+                    "change-requests"
                 ],
-                "summary": "Get list",
+                "summary": "List change requests",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by name (case insensitive)",
-                        "name": "name",
+                        "description": "Status (pending, approved, rejected)",
+                        "name": "status",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by influence name",
-                        "name": "influence",
+                        "description": "Entity type (traveller, accessory)",
+                        "name": "entity_type",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by job name",
-                        "name": "job",
+                        "type": "integer",
+                        "description": "Entity ID",
+                        "name": "entity_id",
                         "in": "query"
                     },
                     {
@@ -198,7 +258,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/helpers.PaginatedResponse-domain_TravellerListItemResponse"
+                            "$ref": "#/definitions/helpers.PaginatedResponse-domain_ChangeRequestListItemResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "RFC 8288 links to the first, prev, next and last pages, keeping the request's filters"
+                            }
                         }
                     },
                     "400": {
@@ -221,7 +287,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "create a new traveller with optional accessory",
+                "description": "propose a change to a traveller or accessory as a JSON merge patch (RFC 7396) against its update document, e.g. {\"rarity\": 5} or {\"accessory\": {\"hp\": 150}} for a traveller.\nThe change is recorded against the record's current version and can only be approved while the record is still at it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -229,49 +295,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "travellers"
+                    "change-requests"
                 ],
-                "summary": "Create traveller",
+                "summary": "Propose a change",
                 "parameters": [
                     {
-                        "description": "Traveller data",
+                        "description": "Proposed change",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CreateTravellerRequest"
+                            "$ref": "#/definitions/domain.CreateChangeRequestRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version the change is proposed against",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.TravellerResponse"
+                            "$ref": "#/definitions/domain.ChangeRequestResponse"
                         },
                         "headers": {
-                            "ETag": {
-                                "type": "string",
-                                "description": "Entity tag for caching"
-                            },
-                            "Last-Modified": {
-                                "type": "string",
-                                "description": "Last modified timestamp"
-                            },
                             "Location": {
                                 "type": "string",
-                                "description": "URI of the created resource"
+                                "description": "URI of the change request"
                             }
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid request, or a patch that leaves an invalid record or changes nothing",
                         "schema": {
                             "$ref": "#/definitions/controller.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "The record changed since the If-Match version",
                         "schema": {
                             "$ref": "#/definitions/controller.ErrorResponse"
                         }
@@ -285,14 +355,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/travellers/{id}": {
+        "/change-requests/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "get traveller information by ID",
+                "description": "get a change request with its comments. A pending one also lists the field-level changes it would make to the record as it is now, and stale is true once the record changed since the proposal.",
                 "consumes": [
                     "application/json"
                 ],
@@ -300,13 +370,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "travellers"
+                    "change-requests"
                 ],
-                "summary": "Get by ID",
+                "summary": "Get change request",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Traveller ID",
+                        "description": "Change request ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -316,17 +386,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.TravellerResponse"
-                        },
-                        "headers": {
-                            "ETag": {
-                                "type": "string",
-                                "description": "Entity tag for caching"
-                            },
-                            "Last-Modified": {
-                                "type": "string",
-                                "description": "Last modified timestamp"
-                            }
+                            "$ref": "#/definitions/domain.ChangeRequestResponse"
                         }
                     },
                     "400": {
@@ -348,14 +408,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/change-requests/{id}/approve": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "update an existing traveller by ID with optimistic locking support via If-Match header",
+                "description": "apply a pending change request to its record and close it, optionally with a comment. Only editors can review change requests.\nFails with 409, leaving the change request pending, if the record changed since the change was proposed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -363,48 +425,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "travellers"
+                    "change-requests"
                 ],
-                "summary": "Update traveller",
+                "summary": "Approve change request",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Traveller ID",
+                        "description": "Change request ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated traveller data",
+                        "description": "Review comment",
                         "name": "body",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.UpdateTravellerRequest"
+                            "$ref": "#/definitions/domain.ReviewChangeRequestRequest"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "ETag for optimistic locking",
-                        "name": "If-Match",
-                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.TravellerResponse"
-                        },
-                        "headers": {
-                            "ETag": {
-                                "type": "string",
-                                "description": "Updated entity tag"
-                            },
-                            "Last-Modified": {
-                                "type": "string",
-                                "description": "Updated timestamp"
-                            }
+                            "$ref": "#/definitions/domain.ChangeRequestResponse"
                         }
                     },
                     "400": {
@@ -413,14 +458,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/controller.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "The caller is not an editor",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/controller.ErrorResponse"
                         }
                     },
-                    "412": {
-                        "description": "Precondition Failed - resource was modified",
+                    "409": {
+                        "description": "Already reviewed, or the record changed since the proposal",
                         "schema": {
                             "$ref": "#/definitions/controller.ErrorResponse"
                         }
@@ -432,14 +483,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/change-requests/{id}/comments": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "soft delete a traveller by ID",
+                "description": "add a comment to the discussion of a change request",
                 "consumes": [
                     "application/json"
                 ],
@@ -447,21 +500,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "travellers"
+                    "change-requests"
                 ],
-                "summary": "Delete traveller",
+                "summary": "Comment on change request",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Traveller ID",
+                        "description": "Change request ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CommentChangeRequestRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ChangeRequestCommentResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -483,259 +548,2844 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "controller.DataResponse-domain_LoginResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/domain.LoginResponse"
-                }
-            }
         },
-        "controller.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "errors": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/controller.FieldError"
+        "/change-requests/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
                     }
-                },
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "controller.FieldError": {
-            "type": "object",
-            "properties": {
-                "field": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.AccessoryListItemResponse": {
-            "type": "object",
-            "properties": {
-                "crit": {
-                    "type": "integer"
-                },
-                "eatk": {
-                    "type": "integer"
-                },
-                "edef": {
-                    "type": "integer"
-                },
-                "effect": {
-                    "type": "string"
-                },
-                "hp": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "owner": {
-                    "type": "string"
-                },
-                "patk": {
-                    "type": "integer"
-                },
+                ],
+                "description": "close a pending change request without applying it, optionally with a comment. Only editors can review change requests.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "change-requests"
+                ],
+                "summary": "Reject change request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Change request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review comment",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReviewChangeRequestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ChangeRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The caller is not an editor",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "authenticate user and receive JWT token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "User login",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.DataResponse-domain_LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the status of a write accepted with Prefer: respond-async. Once it succeeded, location is the resource it wrote; once it failed, error is the response the request would have got had it run synchronously. Finished operations are kept for a limited time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "operations"
+                ],
+                "summary": "Get operation status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OperationResponse"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "string",
+                                "description": "Seconds to wait before polling again, while the operation is running"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "search traveller names and banners, and accessory names and effects. Words are matched by stem, so \"elemental\" also finds \"element\". Results are ranked and grouped by type, and each group is paginated on its own.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Full-text search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text; supports quoted phrases, or, and -word to exclude",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated types to search (traveller, accessory); defaults to all",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number for every group (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size for every group (default 10, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggest": {
+            "get": {
+                "description": "complete a partially typed traveller or accessory name. Names starting with q come first, followed by names that closely resemble one of its words, so small typos still match.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Name autocomplete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Partial name",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity type (traveller, accessory); defaults to traveller",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of suggestions (default 5, max 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuggestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash": {
+            "get": {
+                "description": "list deleted travellers and accessories, most recently deleted first. Travellers can be restored with POST /travellers/{id}/restore until they are purged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "List deleted records",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Entity type (traveller, accessory); defaults to both",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 10, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.PaginatedResponse-domain_TrashItem"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "RFC 8288 links to the first, prev, next and last pages, keeping the request's filters"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "permanently delete the records deleted longer ago than older_than, or the configured retention period. This cannot be undone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Empty the trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Entity type (traveller, accessory); defaults to both",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum time since deletion, e.g. 720h or 0s for everything; defaults to the retention period",
+                        "name": "older_than",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PurgeTrashResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/{type}/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "permanently delete one deleted traveller or accessory. Live records can't be purged; delete them first. This cannot be undone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Purge deleted record",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Entity type (traveller, accessory)",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No deleted record of the type has the ID",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/travellers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get traveller list with optional filters, ordering, and pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "travellers"
+                ],
+                "summary": "Get list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by name (case insensitive)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by comma-separated influence names",
+                        "name": "influence",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by comma-separated job names",
+                        "name": "job",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum rarity (1-5)",
+                        "name": "rarity_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum rarity (1-5)",
+                        "name": "rarity_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Released on or after date (DD-MM-YYYY)",
+                        "name": "released_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Released on or before date (DD-MM-YYYY)",
+                        "name": "released_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by whether the traveller has an accessory",
+                        "name": "has_accessory",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by banner (case insensitive)",
+                        "name": "banner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort keys (name, rarity, release_date, created_at, updated_at, influence, job); influence and job sort by name",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated directions (asc, desc in any case), one per key or one for all",
+                        "name": "order_dir",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated facets to count (job, influence, rarity); each respects every other filter except its own",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression, e.g. rarity\u003e=4 and job in (Warrior,Dancer) and name~\\",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 10, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset pagination cursor from next_cursor or prev_cursor; send it empty for the first page. Setting cursor or limit switches the response to a helpers.CursorResponse",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Keyset page size (default 10, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the matching rows in keyset mode",
+                        "name": "include_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return for each item, e.g. name,rarity,job; id is always returned",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated relations to embed in each item (accessory)",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "List the travellers, and included accessories, as they were at this RFC 3339 time",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated IDs to fetch in one request (max 100); the response is then a helpers.BatchResponse listing missing IDs under not_found, and other parameters except fields and include are ignored",
+                        "name": "ids",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.PaginatedResponse-domain_TravellerListItemResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag for the page, derived from the filter, page, and result set"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Newest modification among matching items; If-Modified-Since is ignored, use If-None-Match"
+                            },
+                            "Link": {
+                                "type": "string",
+                                "description": "RFC 8288 links to the first, prev, next and last pages, keeping the request's filters"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "create a new traveller with optional accessory",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "travellers"
+                ],
+                "summary": "Create traveller",
+                "parameters": [
+                    {
+                        "description": "Traveller data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateTravellerRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "return=minimal for an empty 204, return=representation (default) for the traveller, respond-async to run in the background",
+                        "name": "Prefer",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TravellerResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag for caching"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Last modified timestamp"
+                            },
+                            "Location": {
+                                "type": "string",
+                                "description": "URI of the created resource"
+                            }
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted with Prefer: respond-async; poll the Location for the result",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OperationResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URI of the operation status"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "Created with Prefer: return=minimal; Location, ETag and Last-Modified are set"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/travellers/by-external/{source}/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "create or update the traveller synced from an external source under the source's own ID, in one atomic write.\nA mapped ID updates its traveller like PUT /travellers/{id}; an unmapped ID adopts the traveller with the same name, or creates one.\nThe accessory is mapped under the same external ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "travellers"
+                ],
+                "summary": "Upsert traveller by external ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "External source, e.g. cotc-wiki",
+                        "name": "source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the traveller in the source",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Traveller data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateTravellerRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag for optimistic locking; fails if the traveller would be created",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "return=minimal for an empty 204, return=representation (default) for the traveller, respond-async to run in the background",
+                        "name": "Prefer",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TravellerResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Updated entity tag"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Updated timestamp"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TravellerResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URI of the created resource"
+                            }
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted with Prefer: respond-async; poll the Location for the result",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OperationResponse"
+                        }
+                    },
+                    "204": {
+                        "description": "Written with Prefer: return=minimal; Location, ETag and Last-Modified are set"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed - resource was modified",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/travellers/by-slug/{slug}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get traveller information by its URL-safe slug",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "travellers"
+                ],
+                "summary": "Get by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Traveller slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. name,rarity,job; id is always returned",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated relations to return even when fields leaves them out (accessory)",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TravellerResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag for caching"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Last modified timestamp"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/travellers/compare": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "compare travellers side by side, with accessory stats aligned per field, best and worst markers per stat, and the differences in job, influence, rarity and release date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "travellers"
+                ],
+                "summary": "Compare travellers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated traveller IDs, in display order (at least 2, at most TRAVELLER_COMPARE_LIMIT)",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TravellerComparisonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/travellers/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "report pairs of travellers that may be the same one entered twice, most likely first.\nPairs with similar names, compared ignoring case, spacing and Unicode form, are scored from 0 to 100 on their name similarity and on sharing job, influence, rarity and release date.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "travellers"
+                ],
+                "summary": "Get duplicate candidates",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Lowest score to report (default 60)",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of candidates (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DuplicateReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/travellers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get traveller information by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "travellers"
+                ],
+                "summary": "Get by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Traveller ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. name,rarity,job; id is always returned",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated relations to return even when fields leaves them out (accessory)",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return the traveller as it was at this RFC 3339 time, e.g. 2024-10-01T09:00:00Z",
+                        "name": "as_of",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TravellerResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag for caching"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Last modified timestamp"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "update an existing traveller by ID with optimistic locking support via If-Match header",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "travellers"
+                ],
+                "summary": "Update traveller",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Traveller ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated traveller data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateTravellerRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag for optimistic locking",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "return=minimal for an empty 204, return=representation (default) for the traveller, respond-async to run in the background",
+                        "name": "Prefer",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TravellerResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Updated entity tag"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Updated timestamp"
+                            }
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted with Prefer: respond-async; poll the Location for the result",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OperationResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URI of the operation status"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "Updated with Prefer: return=minimal; Location, ETag and Last-Modified are set"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed - resource was modified",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "soft delete a traveller by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "travellers"
+                ],
+                "summary": "Delete traveller",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Traveller ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "partially update a traveller with a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) document.\nThe patch is applied to the traveller in update-request form and the result is validated like a PUT.\nbanner, release_date and accessory can be cleared with null (merge patch) or remove (JSON patch).",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "travellers"
+                ],
+                "summary": "Patch traveller",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Traveller ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag for optimistic locking",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Patch document",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateTravellerRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "return=minimal for an empty 204, return=representation (default) for the traveller, respond-async to run in the background",
+                        "name": "Prefer",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TravellerResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Updated entity tag"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Updated timestamp"
+                            }
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted with Prefer: respond-async; poll the Location for the result",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OperationResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URI of the operation status"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "Patched with Prefer: return=minimal; Location, ETag and Last-Modified are set"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed - resource was modified",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/travellers/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "list the revisions of a traveller and of its current accessory, most recent first, with who made each one,\nin which request, and the fields it changed. A revision's ID can be passed to POST /travellers/{id}/revert.\nThe scheduled publisher records a revision with the publish action when it publishes the traveller or accessory.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "travellers"
+                ],
+                "summary": "Get traveller history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Traveller ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 10, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.PaginatedResponse-domain_RevisionResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "RFC 8288 links to the first, prev, next and last pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/travellers/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "merge a duplicate traveller into this one in one transaction. Attributes this traveller leaves empty are taken from the duplicate,\nits accessory moves over if this traveller has none, and its external IDs are re-pointed here. When both have an accessory,\nkeep_accessory picks the one kept; the other stays without a traveller. Pending change requests for the duplicate are closed,\nand the duplicate is then deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "travellers"
+                ],
+                "summary": "Merge travellers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID of the traveller to keep",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Traveller to merge in",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.MergeTravellerRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the kept traveller for optimistic locking",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "return=minimal for an empty 204, return=representation (default) for the traveller, respond-async to run in the background",
+                        "name": "Prefer",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TravellerResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Updated entity tag"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Updated timestamp"
+                            }
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted with Prefer: respond-async; poll the Location for the result",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OperationResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URI of the operation status"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "Merged with Prefer: return=minimal; Location, ETag and Last-Modified are set"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed - resource was modified",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/travellers/{id}/recommended-accessories": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the top accessory candidates for a traveller, scored against the stat-weight profile of its job",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "travellers"
+                ],
+                "summary": "Get recommended accessories",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Traveller ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of candidates (default 5, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include accessories equipped by other travellers",
+                        "name": "include_owned",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AccessoryRecommendationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/travellers/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "bring a deleted traveller back from the trash. It fails with 409 if a live traveller has taken its name since;\nif its accessory has gone to another traveller, it comes back without one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "travellers"
+                ],
+                "summary": "Restore traveller",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Traveller ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "return=minimal for an empty 204, return=representation (default) for the traveller",
+                        "name": "Prefer",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TravellerResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Updated entity tag"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Updated timestamp"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "Restored with Prefer: return=minimal; Location, ETag and Last-Modified are set"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No deleted traveller has the ID",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A live traveller has the same name",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/travellers/{id}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "set the fields one revision of a traveller or its current accessory changed back to their values before it.\nIt fails with 409 if any of those fields has changed again since; only revisions that changed fields can be reverted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "travellers"
+                ],
+                "summary": "Revert traveller revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Traveller ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision ID, from GET /travellers/{id}/history",
+                        "name": "revision",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "return=minimal for an empty 204, return=representation (default) for the traveller",
+                        "name": "Prefer",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TravellerResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Updated entity tag"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Updated timestamp"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "Reverted with Prefer: return=minimal; Location, ETag and Last-Modified are set"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No traveller, or no revision of it or its accessory, has the ID",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A reverted field has changed since the revision",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controller.DataResponse-domain_LoginResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.LoginResponse"
+                }
+            }
+        },
+        "controller.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AccessoryListItemResponse": {
+            "type": "object",
+            "properties": {
+                "crit": {
+                    "type": "integer"
+                },
+                "eatk": {
+                    "type": "integer"
+                },
+                "edef": {
+                    "type": "integer"
+                },
+                "effect": {
+                    "type": "string"
+                },
+                "hp": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "links": {
+                    "$ref": "#/definitions/domain.ResourceLinks"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "patk": {
+                    "type": "integer"
+                },
+                "pdef": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "sp": {
+                    "type": "integer"
+                },
+                "spd": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AccessoryRecommendation": {
+            "type": "object",
+            "properties": {
+                "accessory": {
+                    "$ref": "#/definitions/domain.AccessoryListItemResponse"
+                },
+                "explanation": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.StatContribution"
+                    }
+                },
+                "score": {
+                    "type": "number",
+                    "example": 78.4
+                },
+                "summary": {
+                    "type": "string",
+                    "example": "Strongest in patk, crit, hp for Warrior"
+                }
+            }
+        },
+        "domain.AccessoryRecommendationResponse": {
+            "type": "object",
+            "properties": {
+                "job": {
+                    "type": "string",
+                    "example": "Dancer"
+                },
+                "recommendations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.AccessoryRecommendation"
+                    }
+                },
+                "traveller": {
+                    "type": "string",
+                    "example": "Viola"
+                },
+                "weights": {
+                    "$ref": "#/definitions/domain.StatWeights"
+                }
+            }
+        },
+        "domain.AccessoryResponse": {
+            "type": "object",
+            "properties": {
+                "crit": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "eatk": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "edef": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "effect": {
+                    "type": "string",
+                    "example": "Increases elemental damage by 15%"
+                },
+                "hp": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Crimson Cloak"
+                },
+                "patk": {
+                    "type": "integer",
+                    "example": 120
+                },
                 "pdef": {
-                    "type": "integer"
+                    "type": "integer",
+                    "example": 80
+                },
+                "publish_at": {
+                    "type": "string",
+                    "example": "2024-10-01T09:00:00Z"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "crimson-cloak"
+                },
+                "sp": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "spd": {
+                    "type": "integer",
+                    "example": 45
+                },
+                "status": {
+                    "type": "string",
+                    "example": "published"
+                }
+            }
+        },
+        "domain.AuditInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-10-01T09:30:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "isla"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-10-02T18:00:00Z"
+                },
+                "updated_by": {
+                    "type": "string",
+                    "example": "isla"
+                }
+            }
+        },
+        "domain.ChangeRequestCommentResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "isla"
+                },
+                "body": {
+                    "type": "string",
+                    "example": "The wiki lists 01-10-2024 too"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-10-02T18:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "domain.ChangeRequestListItemResponse": {
+            "type": "object",
+            "properties": {
+                "base_version": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-10-02T18:00:00Z"
+                },
+                "entity_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "entity_type": {
+                    "type": "string",
+                    "example": "traveller"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "proposed_by": {
+                    "type": "string",
+                    "example": "alfyn"
+                },
+                "reviewed_at": {
+                    "type": "string",
+                    "example": "2024-10-03T09:00:00Z"
+                },
+                "reviewed_by": {
+                    "type": "string",
+                    "example": "isla"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "summary": {
+                    "type": "string",
+                    "example": "Fix Viola's release date"
+                }
+            }
+        },
+        "domain.ChangeRequestResponse": {
+            "type": "object",
+            "properties": {
+                "base_version": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FieldChange"
+                    }
+                },
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ChangeRequestCommentResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-10-02T18:00:00Z"
+                },
+                "current_version": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "entity_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "entity_type": {
+                    "type": "string",
+                    "example": "traveller"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "patch": {
+                    "type": "object"
+                },
+                "proposed_by": {
+                    "type": "string",
+                    "example": "alfyn"
+                },
+                "reviewed_at": {
+                    "type": "string",
+                    "example": "2024-10-03T09:00:00Z"
+                },
+                "reviewed_by": {
+                    "type": "string",
+                    "example": "isla"
+                },
+                "stale": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "summary": {
+                    "type": "string",
+                    "example": "Fix Viola's release date"
+                }
+            }
+        },
+        "domain.CommentChangeRequestRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "The wiki lists 01-10-2024 too"
+                }
+            }
+        },
+        "domain.CreateAccessoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "crit": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "eatk": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "edef": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "effect": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Increases elemental damage by 15%"
+                },
+                "hp": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Crimson Cloak"
+                },
+                "patk": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "pdef": {
+                    "type": "integer",
+                    "example": 80
+                },
+                "publish_at": {
+                    "type": "string",
+                    "example": "2024-10-01T09:00:00Z"
+                },
+                "sp": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "spd": {
+                    "type": "integer",
+                    "example": 45
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "scheduled",
+                        "published"
+                    ],
+                    "example": "draft"
+                }
+            }
+        },
+        "domain.CreateChangeRequestRequest": {
+            "type": "object",
+            "required": [
+                "entity_id",
+                "entity_type",
+                "patch"
+            ],
+            "properties": {
+                "entity_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "entity_type": {
+                    "type": "string",
+                    "enum": [
+                        "traveller",
+                        "accessory"
+                    ],
+                    "example": "traveller"
+                },
+                "patch": {
+                    "type": "object"
+                },
+                "summary": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Fix Viola's release date"
+                }
+            }
+        },
+        "domain.CreateTravellerRequest": {
+            "type": "object",
+            "required": [
+                "influence",
+                "job",
+                "name",
+                "rarity"
+            ],
+            "properties": {
+                "accessory": {
+                    "$ref": "#/definitions/domain.CreateAccessoryRequest"
+                },
+                "banner": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Standard Banner"
+                },
+                "influence": {
+                    "type": "string",
+                    "example": "Wind"
+                },
+                "job": {
+                    "type": "string",
+                    "example": "Dancer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Viola"
                 },
-                "sp": {
-                    "type": "integer"
+                "publish_at": {
+                    "type": "string",
+                    "example": "2024-10-01T09:00:00Z"
                 },
-                "spd": {
-                    "type": "integer"
+                "rarity": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 5
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "01-10-2024"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "scheduled",
+                        "published"
+                    ],
+                    "example": "scheduled"
                 }
             }
         },
-        "domain.AccessoryResponse": {
+        "domain.DuplicateCandidate": {
             "type": "object",
             "properties": {
-                "crit": {
-                    "type": "integer",
-                    "example": 25
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "job",
+                        "influence",
+                        "rarity"
+                    ]
                 },
-                "eatk": {
-                    "type": "integer",
-                    "example": 150
+                "name_similarity": {
+                    "type": "number",
+                    "example": 0.89
                 },
-                "edef": {
+                "score": {
+                    "type": "number",
+                    "example": 92.5
+                },
+                "travellers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TravellerListItemResponse"
+                    }
+                }
+            }
+        },
+        "domain.DuplicateReportResponse": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DuplicateCandidate"
+                    }
+                }
+            }
+        },
+        "domain.FieldChange": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "string",
+                    "example": "5"
+                },
+                "before": {
+                    "type": "string",
+                    "example": "4"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "rarity"
+                }
+            }
+        },
+        "domain.FieldComparison": {
+            "type": "object",
+            "properties": {
+                "differs": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "field": {
+                    "type": "string",
+                    "example": "job"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "domain.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "domain.MergeTravellerRequest": {
+            "type": "object",
+            "required": [
+                "source_id"
+            ],
+            "properties": {
+                "keep_accessory": {
+                    "description": "KeepAccessory picks the accessory the merged traveller keeps; it is required when both have one",
+                    "type": "string",
+                    "enum": [
+                        "target",
+                        "source"
+                    ],
+                    "example": "target"
+                },
+                "source_id": {
                     "type": "integer",
-                    "example": 100
+                    "minimum": 1,
+                    "example": 12
+                }
+            }
+        },
+        "domain.OperationError": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FieldError"
+                    }
                 },
-                "effect": {
+                "message": {
                     "type": "string",
-                    "example": "Increases elemental damage by 15%"
+                    "example": "resource was modified"
                 },
-                "hp": {
+                "status": {
                     "type": "integer",
-                    "example": 500
+                    "example": 412
+                }
+            }
+        },
+        "domain.OperationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "$ref": "#/definitions/domain.OperationError"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5f0c6f43-9d5b-4b6e-8a49-8d1d0c2b7a61"
+                },
+                "links": {
+                    "$ref": "#/definitions/domain.ResourceLinks"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "/api/v1/travellers/1"
                 },
                 "name": {
                     "type": "string",
-                    "example": "Crimson Cloak"
+                    "example": "create traveller"
                 },
-                "patk": {
+                "status": {
+                    "type": "string",
+                    "example": "succeeded"
+                }
+            }
+        },
+        "domain.PurgeTrashResponse": {
+            "type": "object",
+            "properties": {
+                "deleted_before": {
+                    "type": "string",
+                    "example": "2024-09-01T09:30:00Z"
+                },
+                "purged": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "domain.ResourceLinks": {
+            "type": "object",
+            "properties": {
+                "self": {
+                    "type": "string",
+                    "example": "/api/v1/travellers/1"
+                }
+            }
+        },
+        "domain.ReviewChangeRequestRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Matches the official announcement"
+                }
+            }
+        },
+        "domain.RevisionResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "actor": {
+                    "type": "string",
+                    "example": "isla"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FieldChange"
+                    }
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-10-02T18:00:00Z"
+                },
+                "entity_id": {
                     "type": "integer",
-                    "example": 120
+                    "example": 1
                 },
-                "pdef": {
+                "entity_type": {
+                    "type": "string",
+                    "example": "traveller"
+                },
+                "id": {
                     "type": "integer",
-                    "example": 80
+                    "example": 31
                 },
-                "sp": {
+                "request_id": {
+                    "type": "string",
+                    "example": "0b9e4f3a-5d2c-4e8e-9f0a-1c2d3e4f5a6b"
+                }
+            }
+        },
+        "domain.SearchGroup": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SearchHit"
+                    }
+                },
+                "total": {
                     "type": "integer",
-                    "example": 50
+                    "example": 3
                 },
-                "spd": {
+                "total_pages": {
                     "type": "integer",
-                    "example": 45
+                    "example": 1
+                },
+                "type": {
+                    "type": "string",
+                    "example": "accessory"
                 }
             }
         },
-        "domain.CreateAccessoryRequest": {
+        "domain.SearchHit": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "crit": {
+                "id": {
                     "type": "integer",
-                    "example": 25
+                    "example": 1
                 },
-                "eatk": {
+                "name": {
+                    "type": "string",
+                    "example": "Crown of Wisdom"
+                },
+                "rank": {
+                    "type": "number",
+                    "example": 0.42
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "crown-of-wisdom"
+                },
+                "snippet": {
+                    "type": "string",
+                    "example": "Crown of Wisdom · Increases \u003cmark\u003eelemental\u003c/mark\u003e damage by 15%"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "accessory"
+                }
+            }
+        },
+        "domain.SearchResponse": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SearchGroup"
+                    }
+                },
+                "page": {
                     "type": "integer",
-                    "example": 150
+                    "example": 1
                 },
-                "edef": {
+                "page_size": {
                     "type": "integer",
-                    "example": 100
+                    "example": 10
                 },
-                "effect": {
+                "query": {
                     "type": "string",
-                    "maxLength": 200,
-                    "example": "Increases elemental damage by 15%"
+                    "example": "element"
+                }
+            }
+        },
+        "domain.StatComparison": {
+            "type": "object",
+            "properties": {
+                "best": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "hp": {
-                    "type": "integer",
-                    "example": 500
+                "stat": {
+                    "type": "string",
+                    "example": "patk"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "worst": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "domain.StatContribution": {
+            "type": "object",
+            "properties": {
+                "contribution": {
+                    "type": "number",
+                    "example": 31.25
                 },
-                "name": {
+                "stat": {
                     "type": "string",
-                    "maxLength": 50,
-                    "example": "Crimson Cloak"
+                    "example": "patk"
                 },
-                "patk": {
+                "value": {
                     "type": "integer",
                     "example": 120
                 },
-                "pdef": {
-                    "type": "integer",
-                    "example": 80
-                },
-                "sp": {
-                    "type": "integer",
-                    "example": 50
-                },
-                "spd": {
-                    "type": "integer",
-                    "example": 45
+                "weight": {
+                    "type": "number",
+                    "example": 1
                 }
             }
         },
-        "domain.CreateTravellerRequest": {
+        "domain.StatWeights": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "number"
+            }
+        },
+        "domain.SuggestResponse": {
             "type": "object",
-            "required": [
-                "influence",
-                "job",
-                "name",
-                "rarity"
-            ],
             "properties": {
-                "accessory": {
-                    "$ref": "#/definitions/domain.CreateAccessoryRequest"
-                },
-                "banner": {
+                "query": {
                     "type": "string",
-                    "maxLength": 50,
-                    "example": "Standard Banner"
+                    "example": "fio"
                 },
-                "influence": {
-                    "type": "string",
-                    "example": "Wind"
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Suggestion"
+                    }
                 },
-                "job": {
+                "type": {
                     "type": "string",
-                    "example": "Dancer"
+                    "example": "traveller"
+                }
+            }
+        },
+        "domain.Suggestion": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
                 "name": {
                     "type": "string",
-                    "maxLength": 50,
-                    "example": "Viola"
+                    "example": "Fiore"
                 },
-                "rarity": {
-                    "type": "integer",
-                    "maximum": 5,
-                    "minimum": 1,
-                    "example": 5
+                "score": {
+                    "type": "number",
+                    "example": 0.8
                 },
-                "release_date": {
+                "slug": {
                     "type": "string",
-                    "example": "01-10-2024"
+                    "example": "fiore"
                 }
             }
         },
-        "domain.LoginRequest": {
+        "domain.TrashItem": {
             "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
             "properties": {
-                "password": {
+                "deleted_at": {
                     "type": "string",
-                    "example": "password123"
+                    "example": "2024-10-01T09:30:00Z"
                 },
-                "username": {
+                "deleted_by": {
                     "type": "string",
-                    "example": "admin"
+                    "example": "isla"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Viola"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "viola"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "traveller"
                 }
             }
         },
-        "domain.LoginResponse": {
+        "domain.TravellerComparisonResponse": {
             "type": "object",
             "properties": {
-                "token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                "accessory_stats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.StatComparison"
+                    }
                 },
-                "username": {
-                    "type": "string",
-                    "example": "admin"
+                "differences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FieldComparison"
+                    }
+                },
+                "travellers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TravellerResponse"
+                    }
                 }
             }
         },
         "domain.TravellerListItemResponse": {
             "type": "object",
             "properties": {
+                "accessory": {
+                    "description": "Accessory is only loaded when requested with include=accessory",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AccessoryResponse"
+                        }
+                    ]
+                },
                 "banner": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "influence": {
                     "type": "string"
                 },
                 "job": {
                     "type": "string"
                 },
+                "links": {
+                    "$ref": "#/definitions/domain.ResourceLinks"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -744,6 +3394,12 @@ const docTemplate = `{
                 },
                 "release_date": {
                     "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
@@ -753,10 +3409,17 @@ const docTemplate = `{
                 "accessory": {
                     "$ref": "#/definitions/domain.AccessoryResponse"
                 },
+                "audit": {
+                    "$ref": "#/definitions/domain.AuditInfo"
+                },
                 "banner": {
                     "type": "string",
                     "example": "Standard Banner"
                 },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "influence": {
                     "type": "string",
                     "example": "Wind"
@@ -765,10 +3428,17 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Dancer"
                 },
+                "links": {
+                    "$ref": "#/definitions/domain.ResourceLinks"
+                },
                 "name": {
                     "type": "string",
                     "example": "Viola"
                 },
+                "publish_at": {
+                    "type": "string",
+                    "example": "2024-10-01T09:00:00Z"
+                },
                 "rarity": {
                     "type": "integer",
                     "example": 5
@@ -776,6 +3446,14 @@ const docTemplate = `{
                 "release_date": {
                     "type": "string",
                     "example": "01-10-2024"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "viola"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "published"
                 }
             }
         },
@@ -811,11 +3489,22 @@ const docTemplate = `{
                 "pdef": {
                     "type": "integer"
                 },
+                "publish_at": {
+                    "type": "string"
+                },
                 "sp": {
                     "type": "integer"
                 },
                 "spd": {
                     "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "scheduled",
+                        "published"
+                    ]
                 }
             }
         },
@@ -849,6 +3538,10 @@ const docTemplate = `{
                     "maxLength": 50,
                     "example": "Viola"
                 },
+                "publish_at": {
+                    "type": "string",
+                    "example": "2024-10-01T09:00:00Z"
+                },
                 "rarity": {
                     "type": "integer",
                     "maximum": 5,
@@ -858,6 +3551,53 @@ const docTemplate = `{
                 "release_date": {
                     "type": "string",
                     "example": "01-10-2024"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "scheduled",
+                        "published"
+                    ],
+                    "example": "scheduled"
+                }
+            }
+        },
+        "helpers.FacetCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "value": {
+                    "type": "string",
+                    "example": "Warrior"
+                }
+            }
+        },
+        "helpers.PageLinks": {
+            "type": "object",
+            "properties": {
+                "first": {
+                    "type": "string",
+                    "example": "/api/v1/travellers?page=1\u0026page_size=10\u0026rarity_min=4"
+                },
+                "last": {
+                    "type": "string",
+                    "example": "/api/v1/travellers?page=5\u0026page_size=10\u0026rarity_min=4"
+                },
+                "next": {
+                    "type": "string",
+                    "example": "/api/v1/travellers?page=3\u0026page_size=10\u0026rarity_min=4"
+                },
+                "prev": {
+                    "type": "string",
+                    "example": "/api/v1/travellers?page=1\u0026page_size=10\u0026rarity_min=4"
+                },
+                "self": {
+                    "type": "string",
+                    "example": "/api/v1/travellers?page=2\u0026page_size=10\u0026rarity_min=4"
                 }
             }
         },
@@ -870,6 +3610,175 @@ const docTemplate = `{
                         "$ref": "#/definitions/domain.AccessoryListItemResponse"
                     }
                 },
+                "did_you_mean": {
+                    "description": "DidYouMean suggests close names when a name search matched nothing",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "facets": {
+                    "description": "Facets holds per-value counts for the facets requested with the facets parameter",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/helpers.FacetCount"
+                        }
+                    }
+                },
+                "links": {
+                    "description": "Links point to the neighbouring pages; set by SetPageLinks",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/helpers.PageLinks"
+                        }
+                    ]
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "helpers.PaginatedResponse-domain_ChangeRequestListItemResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ChangeRequestListItemResponse"
+                    }
+                },
+                "did_you_mean": {
+                    "description": "DidYouMean suggests close names when a name search matched nothing",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "facets": {
+                    "description": "Facets holds per-value counts for the facets requested with the facets parameter",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/helpers.FacetCount"
+                        }
+                    }
+                },
+                "links": {
+                    "description": "Links point to the neighbouring pages; set by SetPageLinks",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/helpers.PageLinks"
+                        }
+                    ]
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "helpers.PaginatedResponse-domain_RevisionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RevisionResponse"
+                    }
+                },
+                "did_you_mean": {
+                    "description": "DidYouMean suggests close names when a name search matched nothing",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "facets": {
+                    "description": "Facets holds per-value counts for the facets requested with the facets parameter",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/helpers.FacetCount"
+                        }
+                    }
+                },
+                "links": {
+                    "description": "Links point to the neighbouring pages; set by SetPageLinks",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/helpers.PageLinks"
+                        }
+                    ]
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "helpers.PaginatedResponse-domain_TrashItem": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TrashItem"
+                    }
+                },
+                "did_you_mean": {
+                    "description": "DidYouMean suggests close names when a name search matched nothing",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "facets": {
+                    "description": "Facets holds per-value counts for the facets requested with the facets parameter",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/helpers.FacetCount"
+                        }
+                    }
+                },
+                "links": {
+                    "description": "Links point to the neighbouring pages; set by SetPageLinks",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/helpers.PageLinks"
+                        }
+                    ]
+                },
                 "page": {
                     "type": "integer"
                 },
@@ -893,6 +3802,31 @@ const docTemplate = `{
                         "$ref": "#/definitions/domain.TravellerListItemResponse"
                     }
                 },
+                "did_you_mean": {
+                    "description": "DidYouMean suggests close names when a name search matched nothing",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "facets": {
+                    "description": "Facets holds per-value counts for the facets requested with the facets parameter",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/helpers.FacetCount"
+                        }
+                    }
+                },
+                "links": {
+                    "description": "Links point to the neighbouring pages; set by SetPageLinks",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/helpers.PageLinks"
+                        }
+                    ]
+                },
                 "page": {
                     "type": "integer"
                 },
@@ -13,6 +13,9 @@ import (
 
 type AccessoryService interface {
 	GetList(ctx context.Context, filter domain.ListAccessoryRequest, params helpers.PaginationParams) (res helpers.PaginatedResponse[domain.AccessoryListItemResponse], err error)
+	GetPage(ctx context.Context, filter domain.ListAccessoryRequest, params helpers.CursorParams) (res helpers.CursorResponse[domain.AccessoryListItemResponse], err error)
+	GetBySlug(ctx context.Context, slug string) (res domain.AccessoryListItemResponse, err error)
+	GetByIDs(ctx context.Context, input domain.BatchGetRequest) (res helpers.BatchResponse[domain.AccessoryListItemResponse], err error)
 }
 
 type AccessoryHandler struct {
@@ -28,6 +31,7 @@ func NewAccessoryHandler(e *echo.Group, svc AccessoryService, logger *logging.Lo
 	group := e.Group("/accessories")
 
 	group.GET("", handler.GetList)
+	group.GET("/by-slug/:slug", handler.GetBySlug)
 
 	return handler
 }
@@ -43,15 +47,36 @@ func NewAccessoryHandler(e *echo.Group, svc AccessoryService, logger *logging.Lo
 //	@Param			effect			query	string	false	"Filter by effect (case insensitive)"
 //	@Param			order_by		query	string	false	"Order by field (hp, sp, patk, pdef, eatk, edef, spd, crit)"
 //	@Param			order_dir		query	string	false	"Order direction (asc, desc)"
+//	@Param			facets			query	string	false	"Comma-separated presence facets to count (effect, owner); each respects every other filter except its own"
+//	@Param			filter			query	string	false	"Filter expression over name, effect, owner and stats, e.g. patk>=50 and effect~\"boost\""
 //	@Param			page			query	int		false	"Page number (default 1)"
 //	@Param			page_size		query	int		false	"Page size (default 10, max 100)"
+//	@Param			cursor			query	string	false	"Keyset pagination cursor from next_cursor or prev_cursor; send it empty for the first page. Setting cursor or limit switches the response to a helpers.CursorResponse"
+//	@Param			limit			query	int		false	"Keyset page size (default 10, max 100)"
+//	@Param			include_total	query	bool	false	"Count the matching rows in keyset mode"
+//	@Param			fields			query	string	false	"Comma-separated fields to return for each item, e.g. name,patk,owner; id is always returned"
+//	@Param			as_of			query	string	false	"List the accessories and their owners as they were at this RFC 3339 time"
+//	@Param			ids			query	string	false	"Comma-separated IDs to fetch in one request (max 100); the response is then a helpers.BatchResponse listing missing IDs under not_found, and other parameters except fields are ignored"
 //	@Success		200	{object}	helpers.PaginatedResponse[domain.AccessoryListItemResponse]
+//	@Header			200	{string}	ETag	"Entity tag for the page, derived from the filter, page, and result set"
+//	@Header			200	{string}	Last-Modified	"Newest modification among matching items; If-Modified-Since is ignored, use If-None-Match"
+//	@Header			200	{string}	Link	"RFC 8288 links to the first, prev, next and last pages, keeping the request's filters"
 //	@Failure		400	{object}	controller.ErrorResponse
 //	@Failure		500	{object}	controller.ErrorResponse
 //	@Router			/accessories [get]
 func (h *AccessoryHandler) GetList(ctx echo.Context) error {
+	fields, err := controller.ParseFieldset(ctx, domain.AccessoryListItemResponse{})
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	// A list of ids turns the request into a multi-get; other filters and pagination don't apply
+	if ctx.QueryParams().Has("ids") {
+		return h.getByIDs(ctx, fields)
+	}
+
 	var filter domain.ListAccessoryRequest
-	err := ctx.Bind(&filter)
+	err = ctx.Bind(&filter)
 	if err != nil {
 		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid request body")
 	}
@@ -61,6 +86,11 @@ func (h *AccessoryHandler) GetList(ctx echo.Context) error {
 		return controller.ResponseErrorValidation(ctx, err)
 	}
 
+	// A cursor or limit switches to keyset pagination
+	if ctx.QueryParams().Has("cursor") || ctx.QueryParams().Has("limit") {
+		return h.getPage(ctx, filter, fields)
+	}
+
 	var params helpers.PaginationParams
 	err = ctx.Bind(&params)
 	if err != nil {
@@ -72,8 +102,71 @@ func (h *AccessoryHandler) GetList(ctx echo.Context) error {
 		return controller.HandleServiceError(ctx, err, "get accessory list", h.logger)
 	}
 
-	// Set cache headers for list responses
-	helpers.SetListCacheHeaders(ctx)
+	// Set cache headers and check if client has valid cached page
+	if helpers.SetListCacheHeaders(ctx, result.ETag(ctx.QueryParams()), result.LastModified()) {
+		return helpers.RespondNotModified(ctx)
+	}
+
+	helpers.SetPageLinks(ctx, &result)
+	return controller.Ok(ctx, result.Select(fields))
+}
+
+// getPage serves GET /accessories?cursor=...&limit=..., a keyset-paginated page of the filtered list
+func (h *AccessoryHandler) getPage(ctx echo.Context, filter domain.ListAccessoryRequest, fields helpers.Fieldset) error {
+	var params helpers.CursorParams
+	err := ctx.Bind(&params)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid pagination parameters")
+	}
+
+	result, err := h.Service.GetPage(ctx.Request().Context(), filter, params)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "get accessory page", h.logger)
+	}
+
+	helpers.SetCursorLinks(ctx, &result)
+	return controller.Ok(ctx, result.Select(fields))
+}
+
+// getByIDs serves GET /accessories?ids=..., returning the records in request order with per-ID not-found reporting
+func (h *AccessoryHandler) getByIDs(ctx echo.Context, fields helpers.Fieldset) error {
+	var request domain.BatchGetRequest
+	err := ctx.Bind(&request)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid query parameters")
+	}
+
+	result, err := h.Service.GetByIDs(ctx.Request().Context(), request)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "get accessories by ids", h.logger)
+	}
+
+	return controller.Ok(ctx, result.Select(fields))
+}
+
+// GetBySlug godoc
+//
+//	@Summary		Get accessory by slug
+//	@Description	get accessory information by its URL-safe slug
+//	@Tags			accessories
+//	@Accept			json
+//	@Produce		json
+//	@Param			slug	path		string	true	"Accessory slug"
+//	@Param			fields	query		string	false	"Comma-separated fields to return, e.g. name,patk,owner; id is always returned"
+//	@Success		200		{object}	domain.AccessoryListItemResponse
+//	@Failure		404		{object}	controller.ErrorResponse
+//	@Failure		500		{object}	controller.ErrorResponse
+//	@Router			/accessories/by-slug/{slug} [get]
+func (h *AccessoryHandler) GetBySlug(ctx echo.Context) error {
+	fields, err := controller.ParseFieldset(ctx, domain.AccessoryListItemResponse{})
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	result, err := h.Service.GetBySlug(ctx.Request().Context(), ctx.Param("slug"))
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "get accessory by slug", h.logger)
+	}
 
-	return controller.Ok(ctx, result)
+	return controller.Ok(ctx, fields.Select(result))
 }
@@ -249,3 +249,196 @@ func (s *AccessoryHandlerSuite) TestAccessoryHandler_GetList() {
 		})
 	}
 }
+
+func (s *AccessoryHandlerSuite) TestAccessoryHandler_GetBySlug() {
+	accessory := domain.AccessoryListItemResponse{ID: 1, Slug: "crown-of-wisdom", Name: "Crown of Wisdom", HP: 150, Owner: "Viola"}
+
+	tests := []struct {
+		name         string
+		slug         string
+		responseBody interface{}
+		statusCode   int
+		beforeTest   func(ctx echo.Context)
+	}{
+		{
+			name: "success get accessory",
+			slug: "crown-of-wisdom",
+			responseBody: controller.DataResponse[domain.AccessoryListItemResponse]{
+				Data: accessory,
+			},
+			statusCode: http.StatusOK,
+			beforeTest: func(ctx echo.Context) {
+				s.accessoryService.On("GetBySlug", ctx.Request().Context(), "crown-of-wisdom").Return(accessory, nil).Once()
+			},
+		},
+		{
+			name:       "failed accessory not found",
+			slug:       "unknown",
+			statusCode: http.StatusNotFound,
+			beforeTest: func(ctx echo.Context) {
+				s.accessoryService.On("GetBySlug", ctx.Request().Context(), "unknown").Return(domain.AccessoryListItemResponse{}, domain.NewNotFoundError("accessory", "unknown", nil)).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pathParam := map[string]string{"slug": tt.slug}
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/accessories/by-slug/"+tt.slug, nil, nil, pathParam)
+
+			tt.beforeTest(ctx)
+
+			err := s.handler.GetBySlug(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.statusCode, ctx.Response().Status)
+
+			if tt.responseBody != nil {
+				wantRespBytes, err := json.Marshal(tt.responseBody)
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
+			}
+		})
+	}
+}
+
+func (s *AccessoryHandlerSuite) TestAccessoryHandler_GetByIDs() {
+	batch := helpers.BatchResponse[domain.AccessoryListItemResponse]{
+		Data:     []domain.AccessoryListItemResponse{{ID: 2, Name: "Ring of Power"}, {ID: 1, Name: "Crown of Wisdom", Owner: "Viola"}},
+		NotFound: []int{9},
+	}
+
+	tests := []struct {
+		name         string
+		ids          string
+		responseBody interface{}
+		statusCode   int
+		beforeTest   func(ctx echo.Context)
+	}{
+		{
+			name: "success multi-get via list endpoint",
+			ids:  "2,1,9",
+			responseBody: controller.DataResponse[helpers.BatchResponse[domain.AccessoryListItemResponse]]{
+				Data: batch,
+			},
+			statusCode: http.StatusOK,
+			beforeTest: func(ctx echo.Context) {
+				s.accessoryService.On("GetByIDs", ctx.Request().Context(), domain.BatchGetRequest{IDs: "2,1,9"}).Return(batch, nil).Once()
+			},
+		},
+		{
+			name:       "failed invalid ids",
+			ids:        "abc",
+			statusCode: http.StatusBadRequest,
+			beforeTest: func(ctx echo.Context) {
+				s.accessoryService.On("GetByIDs", ctx.Request().Context(), domain.BatchGetRequest{IDs: "abc"}).
+					Return(helpers.BatchResponse[domain.AccessoryListItemResponse]{}, domain.NewValidationError([]domain.FieldError{{Field: "ids", Message: "invalid"}})).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/accessories", nil, url.Values{"ids": {tt.ids}}, nil)
+
+			tt.beforeTest(ctx)
+
+			err := s.handler.GetList(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.statusCode, ctx.Response().Status)
+
+			if tt.responseBody != nil {
+				wantRespBytes, err := json.Marshal(tt.responseBody)
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
+			}
+		})
+	}
+}
+
+func (s *AccessoryHandlerSuite) TestAccessoryHandler_GetPage() {
+	page := helpers.CursorResponse[domain.AccessoryListItemResponse]{
+		Data:       []domain.AccessoryListItemResponse{{ID: 2, Name: "Ring of Power"}},
+		Limit:      10,
+		PrevCursor: "prev",
+	}
+
+	tests := []struct {
+		name         string
+		query        url.Values
+		responseBody interface{}
+		statusCode   int
+		beforeTest   func(ctx echo.Context)
+	}{
+		{
+			name:  "success empty cursor starts keyset pagination",
+			query: url.Values{"cursor": {""}, "order_by": {"patk"}},
+			responseBody: controller.DataResponse[helpers.CursorResponse[domain.AccessoryListItemResponse]]{
+				Data: func() helpers.CursorResponse[domain.AccessoryListItemResponse] {
+					want := page
+					want.Links = &helpers.PageLinks{
+						Self:  "/accessories?cursor=&limit=10&order_by=patk",
+						First: "/accessories?cursor=&limit=10&order_by=patk",
+						Prev:  "/accessories?cursor=prev&limit=10&order_by=patk",
+					}
+					return want
+				}(),
+			},
+			statusCode: http.StatusOK,
+			beforeTest: func(ctx echo.Context) {
+				s.accessoryService.On("GetPage", ctx.Request().Context(), domain.ListAccessoryRequest{OrderBy: "patk"}, helpers.CursorParams{}).Return(page, nil).Once()
+			},
+		},
+		{
+			name:       "failed invalid cursor",
+			query:      url.Values{"cursor": {"forged"}, "include_total": {"true"}},
+			statusCode: http.StatusBadRequest,
+			beforeTest: func(ctx echo.Context) {
+				s.accessoryService.On("GetPage", ctx.Request().Context(), domain.ListAccessoryRequest{}, helpers.CursorParams{Cursor: "forged", IncludeTotal: true}).
+					Return(helpers.CursorResponse[domain.AccessoryListItemResponse]{}, domain.NewValidationError([]domain.FieldError{{Field: "cursor", Message: "invalid"}})).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/accessories", nil, tt.query, nil)
+
+			tt.beforeTest(ctx)
+
+			err := s.handler.GetList(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.statusCode, ctx.Response().Status)
+
+			if tt.responseBody != nil {
+				wantRespBytes, err := json.Marshal(tt.responseBody)
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
+			}
+		})
+	}
+}
+
+func (s *AccessoryHandlerSuite) TestAccessoryHandler_Fields() {
+	batch := helpers.BatchResponse[domain.AccessoryListItemResponse]{
+		Data:     []domain.AccessoryListItemResponse{{ID: 1, Name: "Crown of Wisdom", PAtk: 45, Owner: "Viola", Links: domain.ResourceLinks{Self: "/api/v1/accessories/by-slug/crown-of-wisdom"}}},
+		NotFound: []int{},
+	}
+
+	s.Run("multi-get returns only the requested fields", func() {
+		rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/accessories", nil, url.Values{"ids": {"1"}, "fields": {"patk,owner"}}, nil)
+		s.accessoryService.On("GetByIDs", ctx.Request().Context(), domain.BatchGetRequest{IDs: "1"}).Return(batch, nil).Once()
+
+		err := s.handler.GetList(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusOK, ctx.Response().Status)
+		assert.Equal(s.T(), `{"data":{"data":[{"id":1,"links":{"self":"/api/v1/accessories/by-slug/crown-of-wisdom"},"owner":"Viola","patk":45}],"not_found":[]}}`, strings.TrimSpace(rec.Body.String()))
+	})
+
+	s.Run("failed unknown field", func() {
+		_, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/accessories", nil, url.Values{"fields": {"power"}}, nil)
+
+		err := s.handler.GetList(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusBadRequest, ctx.Response().Status)
+	})
+}
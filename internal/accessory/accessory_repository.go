@@ -2,9 +2,17 @@ package accessory
 
 import (
 	"context"
+	"errors"
+	"lizobly/ctc-db-api/pkg/audit"
+	"lizobly/ctc-db-api/pkg/constants"
 	"lizobly/ctc-db-api/pkg/domain"
+	filterexpr "lizobly/ctc-db-api/pkg/filter"
+	"lizobly/ctc-db-api/pkg/helpers"
 	"lizobly/ctc-db-api/pkg/logging"
 	"lizobly/ctc-db-api/pkg/telemetry"
+	"slices"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"gorm.io/gorm"
@@ -28,7 +36,14 @@ func (r *accessoryRepository) Create(ctx context.Context, input *domain.Accessor
 	)
 	defer op.End(err)
 
-	err = r.db.WithContext(ctx).Create(input).Error
+	err = helpers.RetrySlugConflict(func() error {
+		slug, err := helpers.UniqueSlug(r.db.WithContext(ctx), "m_accessory", input.Name, 0)
+		if err != nil {
+			return err
+		}
+		input.Slug = slug
+		return r.db.WithContext(ctx).Create(input).Error
+	})
 
 	if err != nil {
 		// r.logger.WithContext(ctx).Error("failed to create accessory",
@@ -44,6 +59,8 @@ func (r *accessoryRepository) Create(ctx context.Context, input *domain.Accessor
 	return
 }
 
+// Update writes an accessory's fields, and its publication when one is set. A non-zero
+// input.Version must match the stored one. It runs in the transaction carried by ctx, if any.
 func (r *accessoryRepository) Update(ctx context.Context, input *domain.Accessory) (err error) {
 	ctx, op := telemetry.StartDBSpan(ctx, "repository.accessory", "AccessoryRepository.Update", "update", "m_accessory",
 		attribute.Int64("accessory.id", input.ID),
@@ -52,18 +69,48 @@ func (r *accessoryRepository) Update(ctx context.Context, input *domain.Accessor
 	defer op.End(err)
 
 	updateData := map[string]interface{}{
-		"name":   input.Name,
-		"hp":     input.HP,
-		"sp":     input.SP,
-		"patk":   input.PAtk,
-		"pdef":   input.PDef,
-		"eatk":   input.EAtk,
-		"edef":   input.EDef,
-		"spd":    input.Spd,
-		"crit":   input.Crit,
-		"effect": input.Effect,
-	}
-	err = r.db.WithContext(ctx).Model(&domain.Accessory{}).Where("id = ?", input.ID).Updates(updateData).Error
+		"name":    input.Name,
+		"hp":      input.HP,
+		"sp":      input.SP,
+		"patk":    input.PAtk,
+		"pdef":    input.PDef,
+		"eatk":    input.EAtk,
+		"edef":    input.EDef,
+		"spd":     input.Spd,
+		"crit":    input.Crit,
+		"effect":  input.Effect,
+		"version": gorm.Expr("version + 1"),
+	}
+	// An accessory written without a status keeps its stored one
+	for column, value := range input.Publication.Columns() {
+		updateData[column] = value
+	}
+	err = helpers.SlugTransaction(helpers.Conn(ctx, r.db), func(tx *gorm.DB) error {
+		var existing domain.Accessory
+		if err := tx.Select("id", "version").First(&existing, input.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.NewNotFoundError("accessory", input.ID, nil)
+			}
+			return err
+		}
+		if input.Version != 0 && input.Version != existing.Version {
+			return domain.NewPreconditionFailedError(constants.MessagePreconditionFailed, nil)
+		}
+
+		slug, err := helpers.SyncSlug(tx, "m_accessory", input.ID, input.Name)
+		if err != nil {
+			return err
+		}
+		input.Slug = slug
+		updateData["slug"] = slug
+
+		if err := tx.Model(&domain.Accessory{}).Where("id = ?", input.ID).Updates(updateData).Error; err != nil {
+			return err
+		}
+		// The owning traveller embeds this accessory, so its version (and ETag) must change too
+		return tx.Model(&domain.Traveller{}).Where("accessory_id = ?", input.ID).
+			UpdateColumn("version", gorm.Expr("version + 1")).Error
+	})
 
 	if err != nil {
 		// r.logger.WithContext(ctx).Error("failed to update accessory",
@@ -79,28 +126,109 @@ func (r *accessoryRepository) Update(ctx context.Context, input *domain.Accessor
 	return
 }
 
-func (r *accessoryRepository) GetList(ctx context.Context, filter domain.ListAccessoryRequest, offset, limit int) (result []*domain.Accessory, ownerNames map[int64]string, total int64, err error) {
-	ctx, op := telemetry.StartDBSpan(ctx, "repository.accessory", "AccessoryRepository.GetList", "select", "m_accessory")
+// GetByID returns an accessory, reading through the transaction carried by ctx, if any
+func (r *accessoryRepository) GetByID(ctx context.Context, id int) (result *domain.Accessory, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.accessory", "AccessoryRepository.GetByID", "select", "m_accessory",
+		attribute.Int("accessory.id", id),
+	)
+	defer op.End(err)
+
+	result = &domain.Accessory{}
+	err = helpers.Conn(ctx, r.db).First(result, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.NewNotFoundError("accessory", id, nil)
+	}
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to get accessory", zap.Int("accessory.id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return
+}
+
+func (r *accessoryRepository) GetBySlug(ctx context.Context, slug string) (result *domain.Accessory, owner string, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.accessory", "AccessoryRepository.GetBySlug", "select", "m_accessory",
+		attribute.String("accessory.slug", slug),
+	)
 	defer op.End(err)
 
-	query := r.db.WithContext(ctx).
+	var row struct {
+		domain.Accessory
+		Owner string
+	}
+	err = r.db.WithContext(ctx).
 		Model(&domain.Accessory{}).
 		Select("m_accessory.*, m_traveller.name as owner").
-		Joins("LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id")
+		Joins("LEFT JOIN m_traveller ON "+ownerJoinCondition(ctx)).
+		Where("m_accessory.slug = ?", slug).
+		First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", domain.NewNotFoundError("accessory", slug, nil)
+		}
+		return
+	}
+
+	return &row.Accessory, row.Owner, nil
+}
 
-	if filter.Effect != "" {
-		query = query.Where("LOWER(m_accessory.effect) LIKE LOWER(?)", "%"+filter.Effect+"%")
+// GetByIDs returns the accessories with the given ids and their owners' names; missing ids are left out
+func (r *accessoryRepository) GetByIDs(ctx context.Context, ids []int) (result []*domain.Accessory, ownerNames map[int64]string, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.accessory", "AccessoryRepository.GetByIDs", "select", "m_accessory",
+		attribute.IntSlice("accessory.ids", ids),
+	)
+	defer op.End(err)
+
+	var rows []struct {
+		domain.Accessory
+		Owner string
+	}
+	err = r.db.WithContext(ctx).
+		Model(&domain.Accessory{}).
+		Select("m_accessory.*, m_traveller.name as owner").
+		Joins("LEFT JOIN m_traveller ON "+ownerJoinCondition(ctx)).
+		Where("m_accessory.id IN ?", ids).
+		Find(&rows).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to get accessories by ids", zap.Error(err))
+		return
 	}
 
-	if filter.Owner != "" {
-		query = query.Where("LOWER(m_traveller.name) LIKE LOWER(?)", "%"+filter.Owner+"%")
+	result = make([]*domain.Accessory, len(rows))
+	ownerNames = make(map[int64]string)
+	for i, row := range rows {
+		result[i] = &row.Accessory
+		if row.Owner != "" {
+			ownerNames[row.Accessory.ID] = row.Owner
+		}
 	}
 
-	err = query.Count(&total).Error
+	return
+}
+
+func (r *accessoryRepository) GetList(ctx context.Context, filter domain.ListAccessoryRequest, offset, limit int) (result []*domain.Accessory, ownerNames map[int64]string, total int64, lastModified time.Time, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.accessory", "AccessoryRepository.GetList", "select", "m_accessory")
+	defer op.End(err)
+
+	query := applyAccessoryFilters(joinOwners(r.db.WithContext(ctx), filter).
+		Select("m_accessory.*, m_traveller.name as owner"), filter, "")
+
+	// The owner name comes from the traveller, so its updates change the list too
+	var stats struct {
+		Total        int64
+		LastModified *time.Time
+	}
+	err = query.Session(&gorm.Session{}).
+		Select("COUNT(*) AS total, MAX(GREATEST(m_accessory.updated_at, m_traveller.updated_at)) AS last_modified").
+		Scan(&stats).Error
 	if err != nil {
 		// r.logger.WithContext(ctx).Error("failed to count accessories", zap.Error(err))
 		return
 	}
+	total = stats.Total
+	if stats.LastModified != nil {
+		lastModified = *stats.LastModified
+	}
 
 	// Apply ordering if specified
 	if filter.OrderBy != "" {
@@ -135,3 +263,209 @@ func (r *accessoryRepository) GetList(ctx context.Context, filter domain.ListAcc
 
 	return
 }
+
+// accessoryStatValues reads each order_by stat from an accessory, to build cursors
+var accessoryStatValues = map[string]func(a *domain.Accessory) int{
+	"hp":   func(a *domain.Accessory) int { return a.HP },
+	"sp":   func(a *domain.Accessory) int { return a.SP },
+	"patk": func(a *domain.Accessory) int { return a.PAtk },
+	"pdef": func(a *domain.Accessory) int { return a.PDef },
+	"eatk": func(a *domain.Accessory) int { return a.EAtk },
+	"edef": func(a *domain.Accessory) int { return a.EDef },
+	"spd":  func(a *domain.Accessory) int { return a.Spd },
+	"crit": func(a *domain.Accessory) int { return a.Crit },
+}
+
+// accessoryKeyset returns the keyset columns for the list order, ending with id as the unique
+// tiebreak, and the name of the ordering that cursors are tied to. Like GetList, order_by
+// sorts descending unless order_dir says otherwise.
+func accessoryKeyset(filter domain.ListAccessoryRequest) (columns []helpers.KeysetColumn, order string) {
+	if _, ok := accessoryStatValues[filter.OrderBy]; ok {
+		desc := !strings.EqualFold(filter.OrderDir, "asc")
+		columns = append(columns, helpers.KeysetColumn{Column: "m_accessory." + filter.OrderBy, Desc: desc})
+		order = filter.OrderBy + ":asc,"
+		if desc {
+			order = filter.OrderBy + ":desc,"
+		}
+	}
+	columns = append(columns, helpers.KeysetColumn{Column: "m_accessory.id"})
+	return columns, order + "id"
+}
+
+// accessoryCursorKeys returns the keyset values of an accessory for the list order
+func accessoryCursorKeys(a *domain.Accessory, filter domain.ListAccessoryRequest) []interface{} {
+	if value, ok := accessoryStatValues[filter.OrderBy]; ok {
+		return []interface{}{value(a), a.ID}
+	}
+	return []interface{}{a.ID}
+}
+
+// GetPage returns up to limit accessories after the cursor position, or before it for a backward
+// cursor, in list order. A nil cursor starts at the beginning. hasMore reports whether more rows
+// follow in the direction read.
+func (r *accessoryRepository) GetPage(ctx context.Context, filter domain.ListAccessoryRequest, cursor *helpers.Cursor, limit int) (result []*domain.Accessory, ownerNames map[int64]string, hasMore bool, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.accessory", "AccessoryRepository.GetPage", "select", "m_accessory",
+		attribute.Int("limit", limit),
+	)
+	defer op.End(err)
+
+	columns, _ := accessoryKeyset(filter)
+	query := applyAccessoryFilters(joinOwners(r.db.WithContext(ctx), filter).
+		Select("m_accessory.*, m_traveller.name as owner"), filter, "")
+
+	backward := false
+	if cursor != nil {
+		backward = cursor.Backward
+		var values []interface{}
+		values, err = cursor.Values(accessoryCursorKeys(&domain.Accessory{}, filter)...)
+		if err != nil {
+			return
+		}
+		query = query.Where(helpers.KeysetCondition(columns, values, backward))
+	}
+
+	// Fetch one extra row to learn whether another page follows
+	var rows []struct {
+		domain.Accessory
+		Owner string
+	}
+	err = query.Order(helpers.KeysetOrder(columns, backward)).Limit(limit + 1).Find(&rows).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to get accessory page", zap.Error(err))
+		return
+	}
+
+	if len(rows) > limit {
+		rows = rows[:limit]
+		hasMore = true
+	}
+	if backward {
+		slices.Reverse(rows)
+	}
+
+	result = make([]*domain.Accessory, len(rows))
+	ownerNames = make(map[int64]string)
+	for i := range rows {
+		result[i] = &rows[i].Accessory
+		if rows[i].Owner != "" {
+			ownerNames[rows[i].Accessory.ID] = rows[i].Owner
+		}
+	}
+
+	return
+}
+
+// Count returns the number of accessories matching the list filters
+func (r *accessoryRepository) Count(ctx context.Context, filter domain.ListAccessoryRequest) (total int64, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.accessory", "AccessoryRepository.Count", "select", "m_accessory")
+	defer op.End(err)
+
+	err = applyAccessoryFilters(joinOwners(r.db.WithContext(ctx), filter), filter, "").Count(&total).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to count accessories", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// accessoryFilterSchema lists the fields and operators accepted by the filter expression
+var accessoryFilterSchema = filterexpr.Schema{
+	"name":   {Column: "m_accessory.name", Kind: filterexpr.String},
+	"effect": {Column: "COALESCE(m_accessory.effect, '')", Kind: filterexpr.String},
+	"owner":  {Column: "COALESCE(m_traveller.name, '')", Kind: filterexpr.String},
+	"hp":     {Column: "m_accessory.hp", Kind: filterexpr.Number},
+	"sp":     {Column: "m_accessory.sp", Kind: filterexpr.Number},
+	"patk":   {Column: "m_accessory.patk", Kind: filterexpr.Number},
+	"pdef":   {Column: "m_accessory.pdef", Kind: filterexpr.Number},
+	"eatk":   {Column: "m_accessory.eatk", Kind: filterexpr.Number},
+	"edef":   {Column: "m_accessory.edef", Kind: filterexpr.Number},
+	"spd":    {Column: "m_accessory.spd", Kind: filterexpr.Number},
+	"crit":   {Column: "m_accessory.crit", Kind: filterexpr.Number},
+}
+
+// accessoryFacetExpressions maps presence facets to the boolean they group by
+var accessoryFacetExpressions = map[string]string{
+	"effect": "COALESCE(m_accessory.effect, '') <> ''",
+	"owner":  "m_traveller.id IS NOT NULL",
+}
+
+// joinOwners starts a list query joining each accessory with the traveller that owns it. With
+// as_of both are read as they were then.
+func joinOwners(db *gorm.DB, filter domain.ListAccessoryRequest) *gorm.DB {
+	query := db.Model(&domain.Accessory{})
+	condition := ownerJoinCondition(db.Statement.Context)
+	if filter.AsOfTime.IsZero() {
+		return query.Joins("LEFT JOIN m_traveller ON " + condition)
+	}
+	return audit.AsOf(query, domain.TrashTypeAccessory, filter.AsOfTime).
+		Joins("LEFT JOIN (?) AS m_traveller ON "+condition, audit.Snapshot(db, domain.TrashTypeTraveller, filter.AsOfTime))
+}
+
+// ownerJoinCondition matches accessories with their owners. The publication callbacks don't
+// reach joins, so readers who aren't editors are only matched with published owners here.
+func ownerJoinCondition(ctx context.Context) string {
+	if logging.IsEditor(ctx) {
+		return "m_accessory.id = m_traveller.accessory_id"
+	}
+	return "m_accessory.id = m_traveller.accessory_id AND m_traveller.status = 'published'"
+}
+
+// applyAccessoryFilters adds the list filters to a query joined with m_traveller.
+// The filters on skipFacet's own field, including filter= terms that test only it, are left
+// out so its facet counts both sides.
+func applyAccessoryFilters(query *gorm.DB, filter domain.ListAccessoryRequest, skipFacet string) *gorm.DB {
+	if filter.Effect != "" && skipFacet != "effect" {
+		query = query.Where("LOWER(m_accessory.effect) LIKE LOWER(?)", filterexpr.ContainsPattern(filter.Effect))
+	}
+
+	if filter.Owner != "" && skipFacet != "owner" {
+		query = query.Where("LOWER(m_traveller.name) LIKE LOWER(?)", filterexpr.ContainsPattern(filter.Owner))
+	}
+
+	condition := filter.FilterCondition
+	if facetless, ok := condition.Without(skipFacet); ok {
+		condition = facetless
+	}
+	if condition != nil {
+		query = query.Where(condition.SQL, condition.Args...)
+	}
+
+	return query
+}
+
+// GetFacets counts the filtered accessories with and without each requested field
+func (r *accessoryRepository) GetFacets(ctx context.Context, filter domain.ListAccessoryRequest, facets []string) (result map[string]map[bool]int64, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.accessory", "AccessoryRepository.GetFacets", "select", "m_accessory",
+		attribute.StringSlice("accessory.facets", facets),
+	)
+	defer op.End(err)
+
+	result = make(map[string]map[bool]int64, len(facets))
+	for _, facet := range facets {
+		expression, ok := accessoryFacetExpressions[facet]
+		if !ok {
+			continue
+		}
+
+		var rows []struct {
+			Value bool
+			Count int64
+		}
+		err = applyAccessoryFilters(joinOwners(r.db.WithContext(ctx), filter), filter, facet).
+			Select(expression + " AS value, COUNT(*) AS count").
+			Group("value").
+			Scan(&rows).Error
+		if err != nil {
+			// r.logger.WithContext(ctx).Error("failed to count accessory facet", zap.String("facet", facet), zap.Error(err))
+			return nil, err
+		}
+
+		result[facet] = make(map[bool]int64, len(rows))
+		for _, row := range rows {
+			result[facet][row.Value] = row.Count
+		}
+	}
+
+	return
+}
@@ -2,9 +2,11 @@ package accessory
 
 import (
 	"context"
+	"errors"
 	"lizobly/ctc-db-api/pkg/domain"
 	"lizobly/ctc-db-api/pkg/helpers"
 	"lizobly/ctc-db-api/pkg/logging"
+	"lizobly/ctc-db-api/pkg/publication"
 	"regexp"
 	"testing"
 	"time"
@@ -57,15 +59,278 @@ func (s *AccessoryRepositorySuite) TestAccessoryRepository_Create() {
 		},
 	}
 
+	s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "slug" FROM "m_accessory" WHERE (slug = $1 OR slug LIKE $2) AND id <> $3`)).
+		WithArgs("crown-of-wisdom", "crown-of-wisdom-%", 0).
+		WillReturnRows(sqlmock.NewRows([]string{"slug"}))
 	s.mock.ExpectBegin()
-	s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_accessory" ("created_by","updated_by","deleted_by","created_at","updated_at","deleted_at","name","hp","sp","patk","pdef","eatk","edef","spd","crit","effect") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16) RETURNING "id"`)).
-		WithArgs(accessory.CreatedBy, accessory.UpdatedBy, accessory.DeletedBy, accessory.CreatedAt, accessory.UpdatedAt, accessory.DeletedAt, accessory.Name, accessory.HP, accessory.SP, accessory.PAtk, accessory.PDef, accessory.EAtk, accessory.EDef, accessory.Spd, accessory.Crit, accessory.Effect).
+	s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_accessory" ("created_by","updated_by","deleted_by","created_at","updated_at","version","deleted_at","name","slug","hp","sp","patk","pdef","eatk","edef","spd","crit","effect","status","publish_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20) RETURNING "id"`)).
+		WithArgs(accessory.CreatedBy, accessory.UpdatedBy, accessory.DeletedBy, accessory.CreatedAt, accessory.UpdatedAt, int64(1), accessory.DeletedAt, accessory.Name, "crown-of-wisdom", accessory.HP, accessory.SP, accessory.PAtk, accessory.PDef, accessory.EAtk, accessory.EDef, accessory.Spd, accessory.Crit, accessory.Effect, domain.PublishStatusPublished, accessory.PublishAt).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 	s.mock.ExpectCommit()
 
 	err := s.repo.Create(context.TODO(), accessory)
 	assert.NoError(s.T(), err)
 	assert.Equal(s.T(), int64(1), accessory.ID)
+	assert.Equal(s.T(), "crown-of-wisdom", accessory.Slug)
+	assert.Equal(s.T(), int64(1), accessory.Version)
+}
+
+func (s *AccessoryRepositorySuite) TestAccessoryRepository_Update() {
+	existing := regexp.QuoteMeta(`SELECT "id","version" FROM "m_accessory" WHERE "m_accessory"."id" = $1 AND "m_accessory"."deleted_at" IS NULL ORDER BY "m_accessory"."id" LIMIT $2`)
+	publishAt := time.Date(2024, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	s.Run("writes the accessory, its publication and its owner's version", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(existing).WithArgs(9, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "version"}).AddRow(9, 2))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "name","slug" FROM "m_accessory" WHERE id = $1 LIMIT $2`)).
+			WithArgs(int64(9), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "slug"}).AddRow("Crimson Cloak", "crimson-cloak"))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_accessory" SET "crit"=$1,"eatk"=$2,"edef"=$3,"effect"=$4,"hp"=$5,"name"=$6,"patk"=$7,"pdef"=$8,"publish_at"=$9,"slug"=$10,"sp"=$11,"spd"=$12,"status"=$13,"version"=version + 1,"updated_at"=$14 WHERE id = $15 AND "m_accessory"."deleted_at" IS NULL`)).
+			WithArgs(0, 0, 0, "", 150, "Crimson Cloak", 0, 0, &publishAt, "crimson-cloak", 0, 0, domain.PublishStatusScheduled, helpers.AnyTime{}, int64(9)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "version"=version + 1 WHERE accessory_id = $1 AND "m_traveller"."deleted_at" IS NULL`)).
+			WithArgs(int64(9)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectCommit()
+
+		err := s.repo.Update(context.TODO(), &domain.Accessory{
+			CommonModel: domain.CommonModel{ID: 9, Version: 2},
+			Name:        "Crimson Cloak",
+			HP:          150,
+			Publication: domain.Publication{Status: domain.PublishStatusScheduled, PublishAt: &publishAt},
+		})
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("stale version", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(existing).WithArgs(9, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "version"}).AddRow(9, 3))
+		s.mock.ExpectRollback()
+
+		err := s.repo.Update(context.TODO(), &domain.Accessory{CommonModel: domain.CommonModel{ID: 9, Version: 2}, Name: "Crimson Cloak"})
+		var pfe *domain.PreconditionFailedError
+		assert.ErrorAs(s.T(), err, &pfe)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("not found", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(existing).WithArgs(9, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "version"}))
+		s.mock.ExpectRollback()
+
+		err := s.repo.Update(context.TODO(), &domain.Accessory{CommonModel: domain.CommonModel{ID: 9}, Name: "Crimson Cloak"})
+		var nfe *domain.NotFoundError
+		assert.ErrorAs(s.T(), err, &nfe)
+	})
+}
+
+func (s *AccessoryRepositorySuite) TestAccessoryRepository_GetByID() {
+	query := regexp.QuoteMeta(`SELECT * FROM "m_accessory" WHERE "m_accessory"."id" = $1 AND "m_accessory"."deleted_at" IS NULL ORDER BY "m_accessory"."id" LIMIT $2`)
+
+	s.Run("found", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(query).
+			WithArgs(9, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "version"}).AddRow(9, "Crimson Cloak", 2))
+
+		res, err := s.repo.GetByID(context.TODO(), 9)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(9), res.ID)
+		assert.Equal(s.T(), int64(2), res.Version)
+	})
+
+	s.Run("not found", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(query).
+			WithArgs(9, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		res, err := s.repo.GetByID(context.TODO(), 9)
+		assert.Nil(s.T(), res)
+		var nfe *domain.NotFoundError
+		assert.ErrorAs(s.T(), err, &nfe)
+	})
+}
+
+func (s *AccessoryRepositorySuite) TestAccessoryRepository_GetBySlug() {
+	query := regexp.QuoteMeta(`SELECT m_accessory.*, m_traveller.name as owner FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id AND m_traveller.status = 'published' WHERE m_accessory.slug = $1 AND "m_accessory"."deleted_at" IS NULL ORDER BY "m_accessory"."id" LIMIT $2`)
+
+	s.Run("found", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(query).
+			WithArgs("crown-of-wisdom", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug", "hp", "owner"}).AddRow(1, "Crown of Wisdom", "crown-of-wisdom", 150, "Viola"))
+
+		res, owner, err := s.repo.GetBySlug(context.TODO(), "crown-of-wisdom")
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(1), res.ID)
+		assert.Equal(s.T(), "crown-of-wisdom", res.Slug)
+		assert.Equal(s.T(), 150, res.HP)
+		assert.Equal(s.T(), "Viola", owner)
+	})
+
+	s.Run("not found", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(query).
+			WithArgs("unknown", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		res, _, err := s.repo.GetBySlug(context.TODO(), "unknown")
+		assert.Nil(s.T(), res)
+		var nfe *domain.NotFoundError
+		assert.True(s.T(), errors.As(err, &nfe), "expected NotFoundError")
+	})
+}
+
+func (s *AccessoryRepositorySuite) TestAccessoryRepository_GetByIDs() {
+	query := regexp.QuoteMeta(`SELECT m_accessory.*, m_traveller.name as owner FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id AND m_traveller.status = 'published' WHERE m_accessory.id IN ($1,$2,$3) AND "m_accessory"."deleted_at" IS NULL`)
+
+	s.Run("found with owners", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(query).
+			WithArgs(2, 1, 7).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "owner"}).AddRow(1, "Crown of Wisdom", "Viola").AddRow(2, "Ring of Power", nil))
+
+		res, ownerNames, err := s.repo.GetByIDs(context.TODO(), []int{2, 1, 7})
+		assert.NoError(s.T(), err)
+		assert.Len(s.T(), res, 2)
+		assert.Equal(s.T(), map[int64]string{1: "Viola"}, ownerNames)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("database error", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(query).WithArgs(2, 1, 7).WillReturnError(gorm.ErrInvalidDB)
+
+		_, _, err := s.repo.GetByIDs(context.TODO(), []int{2, 1, 7})
+		assert.Error(s.T(), err)
+	})
+}
+
+func (s *AccessoryRepositorySuite) TestAccessoryRepository_GetFacets() {
+	filter := domain.ListAccessoryRequest{Owner: "Viola", Effect: "damage"}
+
+	s.Run("each facet ignores only its own filter", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT m_traveller.id IS NOT NULL AS value, COUNT(*) AS count FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id AND m_traveller.status = 'published' WHERE LOWER(m_accessory.effect) LIKE LOWER($1) AND "m_accessory"."deleted_at" IS NULL GROUP BY "value"`)).
+			WithArgs("%damage%").
+			WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).AddRow(true, 2).AddRow(false, 5))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(m_accessory.effect, '') <> '' AS value, COUNT(*) AS count FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id AND m_traveller.status = 'published' WHERE LOWER(m_traveller.name) LIKE LOWER($1) AND "m_accessory"."deleted_at" IS NULL GROUP BY "value"`)).
+			WithArgs("%Viola%").
+			WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).AddRow(true, 1))
+
+		res, err := s.repo.GetFacets(context.TODO(), filter, []string{"owner", "effect"})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), map[string]map[bool]int64{
+			"owner":  {true: 2, false: 5},
+			"effect": {true: 1},
+		}, res)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *AccessoryRepositorySuite) TestAccessoryRepository_HidesDrafts() {
+	s.SetupTest()
+	s.Require().NoError(publication.Register(s.db))
+	published := `FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id AND m_traveller.status = 'published' WHERE "m_accessory"."status" = $1 AND "m_accessory"."deleted_at" IS NULL`
+
+	s.Run("list total and last modified leave drafts out", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total, MAX(GREATEST(m_accessory.updated_at, m_traveller.updated_at)) AS last_modified ` + published)).
+			WithArgs(domain.PublishStatusPublished).
+			WillReturnRows(sqlmock.NewRows([]string{"total", "last_modified"}).AddRow(1, nil))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT m_accessory.*, m_traveller.name as owner `+published)).
+			WithArgs(domain.PublishStatusPublished, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "owner"}).AddRow(1, "Fiore"))
+
+		res, _, total, _, err := s.repo.GetList(context.TODO(), domain.ListAccessoryRequest{}, 0, 10)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(len(res)), total)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("facets leave drafts out", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT m_traveller.id IS NOT NULL AS value, COUNT(*) AS count ` + published)).
+			WithArgs(domain.PublishStatusPublished).
+			WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).AddRow(true, 1))
+
+		_, err := s.repo.GetFacets(context.TODO(), domain.ListAccessoryRequest{}, []string{"owner"})
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *AccessoryRepositorySuite) TestAccessoryRepository_GetPage() {
+	filter := domain.ListAccessoryRequest{OrderBy: "patk"}
+
+	s.Run("after a cursor, stat descending by default", func() {
+		s.SetupTest()
+		cursor, _ := helpers.NewCursor("patk:desc,id", false, 80, int64(2))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT m_accessory.*, m_traveller.name as owner FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id AND m_traveller.status = 'published' WHERE (((m_accessory.patk < $1) OR (m_accessory.patk = $2 AND m_accessory.id > $3))) AND "m_accessory"."deleted_at" IS NULL ORDER BY m_accessory.patk DESC,m_accessory.id LIMIT $4`)).
+			WithArgs(80, 80, int64(2), 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "patk", "owner"}).AddRow(1, "Crown of Wisdom", 45, "Fiore").AddRow(3, "Old Bracelet", 10, nil))
+
+		res, ownerNames, hasMore, err := s.repo.GetPage(context.TODO(), filter, &cursor, 1)
+		assert.NoError(s.T(), err)
+		assert.True(s.T(), hasMore)
+		assert.Len(s.T(), res, 1)
+		assert.Equal(s.T(), map[int64]string{1: "Fiore"}, ownerNames)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("backward restores list order", func() {
+		s.SetupTest()
+		cursor, _ := helpers.NewCursor("id", true, int64(5))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT m_accessory.*, m_traveller.name as owner FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id AND m_traveller.status = 'published' WHERE ((m_accessory.id < $1)) AND "m_accessory"."deleted_at" IS NULL ORDER BY m_accessory.id DESC LIMIT $2`)).
+			WithArgs(int64(5), 11).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(4, "Ring of Power").AddRow(3, "Old Bracelet"))
+
+		res, _, hasMore, err := s.repo.GetPage(context.TODO(), domain.ListAccessoryRequest{}, &cursor, 10)
+		assert.NoError(s.T(), err)
+		assert.False(s.T(), hasMore)
+		assert.Equal(s.T(), []int64{3, 4}, []int64{res[0].ID, res[1].ID})
+	})
+
+	s.Run("database error", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(`SELECT`).WillReturnError(gorm.ErrInvalidDB)
+
+		_, _, _, err := s.repo.GetPage(context.TODO(), filter, nil, 10)
+		assert.Error(s.T(), err)
+	})
+}
+
+func (s *AccessoryRepositorySuite) TestAccessoryRepository_Count() {
+	s.SetupTest()
+	s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id AND m_traveller.status = 'published' WHERE LOWER(m_traveller.name) LIKE LOWER($1) AND "m_accessory"."deleted_at" IS NULL`)).
+		WithArgs("%Viola%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	total, err := s.repo.Count(context.TODO(), domain.ListAccessoryRequest{Owner: "Viola"})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(1), total)
+}
+
+func (s *AccessoryRepositorySuite) TestAccessoryRepository_Count_AsOf() {
+	s.SetupTest()
+	at := time.Date(2024, 10, 1, 9, 0, 0, 0, time.UTC)
+	s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM (SELECT ids.id, `)+`.+`+
+		regexp.QuoteMeta(`) AS m_accessory LEFT JOIN (SELECT ids.id, `)+`.+`+
+		regexp.QuoteMeta(`) AS m_traveller ON m_accessory.id = m_traveller.accessory_id AND m_traveller.status = 'published' WHERE LOWER(m_traveller.name) LIKE LOWER($15) AND "m_accessory"."deleted_at" IS NULL`)).
+		WithArgs(domain.TrashTypeAccessory, domain.TrashTypeAccessory, at, domain.TrashTypeAccessory, at, at, at,
+			domain.TrashTypeTraveller, domain.TrashTypeTraveller, at, domain.TrashTypeTraveller, at, at, at, "%Viola%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	total, err := s.repo.Count(context.TODO(), domain.ListAccessoryRequest{Owner: "Viola", AsOfTime: at})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(1), total)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
 }
 
 func (s *AccessoryRepositorySuite) TestAccessoryRepository_GetList() {
@@ -76,6 +341,7 @@ func (s *AccessoryRepositorySuite) TestAccessoryRepository_GetList() {
 		limit   int
 		mockSet func()
 		wantTot int64
+		wantMod time.Time
 		wantLen int
 	}{
 		{
@@ -84,16 +350,17 @@ func (s *AccessoryRepositorySuite) TestAccessoryRepository_GetList() {
 			offset: 0,
 			limit:  10,
 			mockSet: func() {
-				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id WHERE "m_accessory"."deleted_at" IS NULL`)).
-					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total, MAX(GREATEST(m_accessory.updated_at, m_traveller.updated_at)) AS last_modified FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id AND m_traveller.status = 'published' WHERE "m_accessory"."deleted_at" IS NULL`)).
+					WillReturnRows(sqlmock.NewRows([]string{"total", "last_modified"}).AddRow(2, time.Date(2026, 1, 27, 10, 0, 0, 0, time.UTC)))
 
-				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT m_accessory.*, m_traveller.name as owner FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id WHERE "m_accessory"."deleted_at" IS NULL LIMIT $1`)).
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT m_accessory.*, m_traveller.name as owner FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id AND m_traveller.status = 'published' WHERE "m_accessory"."deleted_at" IS NULL LIMIT $1`)).
 					WithArgs(10).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "hp", "sp", "patk", "pdef", "eatk", "edef", "spd", "crit", "effect", "owner"}).
 						AddRow(1, "Crown of Wisdom", 150, 80, 45, 30, 60, 25, 12, 8, "Increases elemental damage", "Fiore").
 						AddRow(2, "Ring of Power", 200, 100, 80, 50, 70, 40, 15, 10, "Increases physical damage", "Noctis"))
 			},
 			wantTot: 2,
+			wantMod: time.Date(2026, 1, 27, 10, 0, 0, 0, time.UTC),
 			wantLen: 2,
 		},
 		{
@@ -104,11 +371,11 @@ func (s *AccessoryRepositorySuite) TestAccessoryRepository_GetList() {
 			offset: 0,
 			limit:  10,
 			mockSet: func() {
-				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id WHERE LOWER(m_traveller.name) LIKE LOWER($1) AND "m_accessory"."deleted_at" IS NULL`)).
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total, MAX(GREATEST(m_accessory.updated_at, m_traveller.updated_at)) AS last_modified FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id AND m_traveller.status = 'published' WHERE LOWER(m_traveller.name) LIKE LOWER($1) AND "m_accessory"."deleted_at" IS NULL`)).
 					WithArgs("%Fiore%").
-					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+					WillReturnRows(sqlmock.NewRows([]string{"total", "last_modified"}).AddRow(1, nil))
 
-				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT m_accessory.*, m_traveller.name as owner FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id WHERE LOWER(m_traveller.name) LIKE LOWER($1) AND "m_accessory"."deleted_at" IS NULL LIMIT $2`)).
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT m_accessory.*, m_traveller.name as owner FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id AND m_traveller.status = 'published' WHERE LOWER(m_traveller.name) LIKE LOWER($1) AND "m_accessory"."deleted_at" IS NULL LIMIT $2`)).
 					WithArgs("%Fiore%", 10).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "hp", "sp", "patk", "pdef", "eatk", "edef", "spd", "crit", "effect", "owner"}).
 						AddRow(1, "Crown of Wisdom", 150, 80, 45, 30, 60, 25, 12, 8, "Increases elemental damage", "Fiore"))
@@ -126,11 +393,11 @@ func (s *AccessoryRepositorySuite) TestAccessoryRepository_GetList() {
 			offset: 0,
 			limit:  10,
 			mockSet: func() {
-				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id WHERE LOWER(m_accessory.effect) LIKE LOWER($1) AND "m_accessory"."deleted_at" IS NULL`)).
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total, MAX(GREATEST(m_accessory.updated_at, m_traveller.updated_at)) AS last_modified FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id AND m_traveller.status = 'published' WHERE LOWER(m_accessory.effect) LIKE LOWER($1) AND "m_accessory"."deleted_at" IS NULL`)).
 					WithArgs("%Elemental%").
-					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+					WillReturnRows(sqlmock.NewRows([]string{"total", "last_modified"}).AddRow(1, nil))
 
-				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT m_accessory.*, m_traveller.name as owner FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id WHERE LOWER(m_accessory.effect) LIKE LOWER($1) AND "m_accessory"."deleted_at" IS NULL ORDER BY m_accessory.hp DESC LIMIT $2`)).
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT m_accessory.*, m_traveller.name as owner FROM "m_accessory" LEFT JOIN m_traveller ON m_accessory.id = m_traveller.accessory_id AND m_traveller.status = 'published' WHERE LOWER(m_accessory.effect) LIKE LOWER($1) AND "m_accessory"."deleted_at" IS NULL ORDER BY m_accessory.hp DESC LIMIT $2`)).
 					WithArgs("%Elemental%", 10).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "hp", "sp", "patk", "pdef", "eatk", "edef", "spd", "crit", "effect", "owner"}).
 						AddRow(1, "Crown of Wisdom", 150, 80, 45, 30, 60, 25, 12, 8, "Increases elemental damage by 15%", "Fiore"))
@@ -145,9 +412,10 @@ func (s *AccessoryRepositorySuite) TestAccessoryRepository_GetList() {
 			s.SetupTest()
 			tt.mockSet()
 
-			result, ownerNames, total, err := s.repo.GetList(context.TODO(), tt.filter, tt.offset, tt.limit)
+			result, ownerNames, total, lastModified, err := s.repo.GetList(context.TODO(), tt.filter, tt.offset, tt.limit)
 			assert.NoError(s.T(), err)
 			assert.Equal(s.T(), tt.wantTot, total)
+			assert.True(s.T(), tt.wantMod.Equal(lastModified), "last modified %v, want %v", lastModified, tt.wantMod)
 			assert.Equal(s.T(), tt.wantLen, len(result))
 
 			if tt.wantLen > 0 {
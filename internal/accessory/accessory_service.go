@@ -2,29 +2,41 @@ package accessory
 
 import (
 	"context"
+	"errors"
 	"lizobly/ctc-db-api/pkg/domain"
 	"lizobly/ctc-db-api/pkg/helpers"
 	"lizobly/ctc-db-api/pkg/logging"
 	"lizobly/ctc-db-api/pkg/telemetry"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 )
 
 type AccessoryRepository interface {
-	GetList(ctx context.Context, filter domain.ListAccessoryRequest, offset, limit int) (result []*domain.Accessory, ownerNames map[int64]string, total int64, err error)
+	GetList(ctx context.Context, filter domain.ListAccessoryRequest, offset, limit int) (result []*domain.Accessory, ownerNames map[int64]string, total int64, lastModified time.Time, err error)
+	GetBySlug(ctx context.Context, slug string) (result *domain.Accessory, owner string, err error)
+	GetByID(ctx context.Context, id int) (result *domain.Accessory, err error)
+	GetByIDs(ctx context.Context, ids []int) (result []*domain.Accessory, ownerNames map[int64]string, err error)
+	GetPage(ctx context.Context, filter domain.ListAccessoryRequest, cursor *helpers.Cursor, limit int) (result []*domain.Accessory, ownerNames map[int64]string, hasMore bool, err error)
+	Count(ctx context.Context, filter domain.ListAccessoryRequest) (total int64, err error)
+	GetFacets(ctx context.Context, filter domain.ListAccessoryRequest, facets []string) (result map[string]map[bool]int64, err error)
 	Create(ctx context.Context, input *domain.Accessory) (err error)
 	Update(ctx context.Context, input *domain.Accessory) (err error)
 }
 
 type accessoryService struct {
 	accessoryRepo AccessoryRepository
+	cursors       *helpers.CursorCodec
 	logger        *logging.Logger
 }
 
-func NewAccessoryService(a AccessoryRepository, logger *logging.Logger) *accessoryService {
+// NewAccessoryService creates the accessory service. cursors signs the list cursors handed out
+// in keyset pagination mode.
+func NewAccessoryService(a AccessoryRepository, cursors *helpers.CursorCodec, logger *logging.Logger) *accessoryService {
 	return &accessoryService{
 		accessoryRepo: a,
+		cursors:       cursors,
 		logger:        logger.Named("service.accessory"),
 	}
 }
@@ -43,8 +55,29 @@ func (s *accessoryService) GetList(ctx context.Context, filter domain.ListAccess
 	if filter.OrderDir != "" {
 		filter.OrderDir = strings.ToUpper(filter.OrderDir)
 	}
+	filter.FacetFields = domain.ParseFacetFields(filter.Facets)
 
-	accessories, ownerNames, total, err := s.accessoryRepo.GetList(ctx, filter, params.Offset(), params.PageSize)
+	filter.AsOfTime, err = domain.ParseAsOf(filter.AsOf)
+	if err != nil {
+		return
+	}
+
+	if filter.Filter != "" {
+		filter.FilterCondition, err = accessoryFilterSchema.Compile(filter.Filter)
+		if err != nil {
+			err = domain.NewValidationError([]domain.FieldError{{Field: "filter", Message: err.Error()}})
+			return
+		}
+	}
+	for _, facet := range filter.FacetFields {
+		// A facet is counted without the filter= terms on its own field, which needs them apart
+		if _, ok := filter.FilterCondition.Without(facet); !ok {
+			err = domain.NewValidationError([]domain.FieldError{{Field: "facets", Message: "facet " + facet + " can't be counted while filter tests " + facet + " together with other fields"}})
+			return
+		}
+	}
+
+	accessories, ownerNames, total, lastModified, err := s.accessoryRepo.GetList(ctx, filter, params.Offset(), params.PageSize)
 	if err != nil {
 		return
 	}
@@ -56,6 +89,192 @@ func (s *accessoryService) GetList(ctx context.Context, filter domain.ListAccess
 	}
 
 	res = helpers.NewPaginatedResponse(items, params, total)
+	res.UpdatedAt = lastModified
+
+	if len(filter.FacetFields) > 0 {
+		var counts map[string]map[bool]int64
+		counts, err = s.accessoryRepo.GetFacets(ctx, filter, filter.FacetFields)
+		if err != nil {
+			return
+		}
+		// Presence facets always report both sides, present first
+		res.Facets = make(map[string][]helpers.FacetCount, len(filter.FacetFields))
+		for _, facet := range filter.FacetFields {
+			res.Facets[facet] = []helpers.FacetCount{
+				{Value: "true", Count: counts[facet][true]},
+				{Value: "false", Count: counts[facet][false]},
+			}
+		}
+	}
+
+	return
+}
+
+// GetPage returns a keyset-paginated page of the list. It takes the same filters as GetList;
+// the total is only counted when asked for.
+func (s *accessoryService) GetPage(ctx context.Context, filter domain.ListAccessoryRequest, params helpers.CursorParams) (res helpers.CursorResponse[domain.AccessoryListItemResponse], err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.accessory", "AccessoryService.GetPage",
+		attribute.Int("limit", params.Limit),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	params.Normalize()
+
+	filter.AsOfTime, err = domain.ParseAsOf(filter.AsOf)
+	if err != nil {
+		return
+	}
+
+	if filter.Filter != "" {
+		filter.FilterCondition, err = accessoryFilterSchema.Compile(filter.Filter)
+		if err != nil {
+			err = domain.NewValidationError([]domain.FieldError{{Field: "filter", Message: err.Error()}})
+			return
+		}
+	}
+
+	_, order := accessoryKeyset(filter)
+	var cursor *helpers.Cursor
+	if params.Cursor != "" {
+		decoded, decodeErr := s.cursors.Decode(params.Cursor, order)
+		if decodeErr != nil {
+			err = invalidCursorError()
+			return
+		}
+		cursor = &decoded
+	}
+
+	accessories, ownerNames, hasMore, err := s.accessoryRepo.GetPage(ctx, filter, cursor, params.Limit)
+	if err != nil {
+		if errors.Is(err, helpers.ErrInvalidCursor) {
+			err = invalidCursorError()
+		}
+		return
+	}
+
+	res.Data = make([]domain.AccessoryListItemResponse, len(accessories))
+	for i, acc := range accessories {
+		res.Data[i] = domain.ToAccessoryListItemResponse(acc, ownerNames)
+	}
+	res.Limit = params.Limit
+
+	res.NextCursor, res.PrevCursor, err = s.cursors.PageCursors(cursor, order, len(accessories), hasMore, func(i int) []interface{} {
+		return accessoryCursorKeys(accessories[i], filter)
+	})
+	if err != nil {
+		return
+	}
+
+	if params.IncludeTotal {
+		var total int64
+		total, err = s.accessoryRepo.Count(ctx, filter)
+		if err != nil {
+			return
+		}
+		res.Total = &total
+	}
 
 	return
 }
+
+func invalidCursorError() error {
+	return domain.NewValidationError([]domain.FieldError{{Field: "cursor", Message: "cursor is invalid or was issued for a different order_by"}})
+}
+
+func (s *accessoryService) GetByIDs(ctx context.Context, input domain.BatchGetRequest) (res helpers.BatchResponse[domain.AccessoryListItemResponse], err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.accessory", "AccessoryService.GetByIDs",
+		attribute.String("accessory.ids", input.IDs),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	err = domain.ParseBatchGetRequest(&input)
+	if err != nil {
+		return
+	}
+
+	accessories, ownerNames, err := s.accessoryRepo.GetByIDs(ctx, input.IDValues)
+	if err != nil {
+		return
+	}
+
+	// Return records in request order and report the ids that don't exist
+	byID := make(map[int64]*domain.Accessory, len(accessories))
+	for _, acc := range accessories {
+		byID[acc.ID] = acc
+	}
+	res.Data = make([]domain.AccessoryListItemResponse, 0, len(accessories))
+	res.NotFound = []int{}
+	for _, id := range input.IDValues {
+		acc, ok := byID[int64(id)]
+		if !ok {
+			res.NotFound = append(res.NotFound, id)
+			continue
+		}
+		res.Data = append(res.Data, domain.ToAccessoryListItemResponse(acc, ownerNames))
+	}
+
+	return
+}
+
+func (s *accessoryService) GetBySlug(ctx context.Context, slug string) (res domain.AccessoryListItemResponse, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.accessory", "AccessoryService.GetBySlug",
+		attribute.String("accessory.slug", slug),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	accessory, owner, err := s.accessoryRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return
+	}
+
+	res = domain.ToAccessoryListItemResponse(accessory, map[int64]string{accessory.ID: owner})
+
+	return
+}
+
+// GetByID returns an accessory as stored, whether or not a traveller owns it
+func (s *accessoryService) GetByID(ctx context.Context, id int) (res *domain.Accessory, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.accessory", "AccessoryService.GetByID",
+		attribute.Int("accessory.id", id),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	return s.accessoryRepo.GetByID(ctx, id)
+}
+
+// Patch writes an accessory on its own, so an accessory no traveller owns can be changed too.
+// A non-zero input.Version makes the write conditional on that version.
+func (s *accessoryService) Patch(ctx context.Context, id int, input domain.UpdateAccessoryRequest) (res *domain.Accessory, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.accessory", "AccessoryService.Patch",
+		attribute.Int("accessory.id", id),
+		attribute.String("accessory.name", input.Name),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	// Without an owner there is no release date to fall back on, so a scheduled accessory needs a publish time
+	publication, err := domain.NewPublication(input.Status, input.PublishAt, time.Time{})
+	if err != nil {
+		return
+	}
+
+	patched := &domain.Accessory{
+		CommonModel: domain.CommonModel{ID: int64(id), Version: input.Version},
+		Name:        input.Name,
+		HP:          input.HP,
+		SP:          input.SP,
+		PAtk:        input.PAtk,
+		PDef:        input.PDef,
+		EAtk:        input.EAtk,
+		EDef:        input.EDef,
+		Spd:         input.Spd,
+		Crit:        input.Crit,
+		Effect:      input.Effect,
+		Publication: publication,
+	}
+	err = s.accessoryRepo.Update(ctx, patched)
+	if err != nil {
+		return
+	}
+
+	return patched, nil
+}
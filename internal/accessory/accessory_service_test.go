@@ -2,11 +2,13 @@ package accessory
 
 import (
 	"context"
+	"errors"
 	"lizobly/ctc-db-api/internal/accessory/mocks"
 	"lizobly/ctc-db-api/pkg/domain"
 	"lizobly/ctc-db-api/pkg/helpers"
 	"lizobly/ctc-db-api/pkg/logging"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -28,7 +30,7 @@ func (s *AccessoryServiceSuite) SetupTest() {
 	logger, _ := logging.NewDevelopmentLogger()
 
 	s.accessoryRepo = new(mocks.MockAccessoryRepository)
-	s.svc = NewAccessoryService(s.accessoryRepo, logger)
+	s.svc = NewAccessoryService(s.accessoryRepo, helpers.NewCursorCodec("test-secret"), logger)
 }
 
 func (s *AccessoryServiceSuite) TearDownTest() {
@@ -39,7 +41,7 @@ func (s *AccessoryServiceSuite) TestAccessoryService_NewService() {
 	s.T().Run("success", func(t *testing.T) {
 		logger, _ := logging.NewDevelopmentLogger()
 		repo := new(mocks.MockAccessoryRepository)
-		svc := NewAccessoryService(repo, logger)
+		svc := NewAccessoryService(repo, helpers.NewCursorCodec("test-secret"), logger)
 		assert.NotNil(t, svc)
 	})
 }
@@ -52,6 +54,7 @@ func (s *AccessoryServiceSuite) TestAccessoryService_GetList() {
 	type want struct {
 		count         int
 		total         int64
+		updatedAt     time.Time
 		err           error
 		hasPagination bool
 	}
@@ -71,6 +74,7 @@ func (s *AccessoryServiceSuite) TestAccessoryService_GetList() {
 			want: want{
 				count:         2,
 				total:         2,
+				updatedAt:     time.Date(2026, 1, 27, 10, 0, 0, 0, time.UTC),
 				err:           nil,
 				hasPagination: true,
 			},
@@ -92,7 +96,7 @@ func (s *AccessoryServiceSuite) TestAccessoryService_GetList() {
 						Effect:      "DEF+10",
 					},
 				}
-				s.accessoryRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(accessories, ownerNames, want.total, want.err).Once()
+				s.accessoryRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(accessories, ownerNames, want.total, want.updatedAt, want.err).Once()
 			},
 		},
 		{
@@ -119,7 +123,7 @@ func (s *AccessoryServiceSuite) TestAccessoryService_GetList() {
 						Effect:      "ATK+10",
 					},
 				}
-				s.accessoryRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(accessories, ownerNames, want.total, want.err).Once()
+				s.accessoryRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(accessories, ownerNames, want.total, time.Time{}, want.err).Once()
 			},
 		},
 		{
@@ -146,7 +150,7 @@ func (s *AccessoryServiceSuite) TestAccessoryService_GetList() {
 						Effect:      "ATK+10",
 					},
 				}
-				s.accessoryRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(accessories, ownerNames, want.total, want.err).Once()
+				s.accessoryRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(accessories, ownerNames, want.total, time.Time{}, want.err).Once()
 			},
 		},
 		{
@@ -187,7 +191,7 @@ func (s *AccessoryServiceSuite) TestAccessoryService_GetList() {
 				// Note: service normalizes order direction to uppercase
 				normalizedFilter := args.filter
 				normalizedFilter.OrderDir = "ASC"
-				s.accessoryRepo.On("GetList", mock.Anything, normalizedFilter, 0, 10).Return(accessories, ownerNames, want.total, want.err).Once()
+				s.accessoryRepo.On("GetList", mock.Anything, normalizedFilter, 0, 10).Return(accessories, ownerNames, want.total, time.Time{}, want.err).Once()
 			},
 		},
 		{
@@ -228,7 +232,7 @@ func (s *AccessoryServiceSuite) TestAccessoryService_GetList() {
 				// Note: service normalizes order direction to uppercase
 				normalizedFilter := args.filter
 				normalizedFilter.OrderDir = "DESC"
-				s.accessoryRepo.On("GetList", mock.Anything, normalizedFilter, 0, 10).Return(accessories, ownerNames, want.total, want.err).Once()
+				s.accessoryRepo.On("GetList", mock.Anything, normalizedFilter, 0, 10).Return(accessories, ownerNames, want.total, time.Time{}, want.err).Once()
 			},
 		},
 		{
@@ -256,7 +260,7 @@ func (s *AccessoryServiceSuite) TestAccessoryService_GetList() {
 					ownerNames[int64(i+1)] = "Owner"
 				}
 				// Normalized params: page 1, page_size 10, offset 0
-				s.accessoryRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(accessories, ownerNames, want.total, want.err).Once()
+				s.accessoryRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(accessories, ownerNames, want.total, time.Time{}, want.err).Once()
 			},
 		},
 		{
@@ -284,7 +288,7 @@ func (s *AccessoryServiceSuite) TestAccessoryService_GetList() {
 					ownerNames[int64(i+11)] = "Owner"
 				}
 				// Page 2: offset = (2-1)*10 = 10
-				s.accessoryRepo.On("GetList", mock.Anything, args.filter, 10, 10).Return(accessories, ownerNames, want.total, want.err).Once()
+				s.accessoryRepo.On("GetList", mock.Anything, args.filter, 10, 10).Return(accessories, ownerNames, want.total, time.Time{}, want.err).Once()
 			},
 		},
 		{
@@ -303,7 +307,7 @@ func (s *AccessoryServiceSuite) TestAccessoryService_GetList() {
 			beforeTest: func(ctx context.Context, args args, want want) {
 				accessories := []*domain.Accessory{}
 				ownerNames := map[int64]string{}
-				s.accessoryRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(accessories, ownerNames, want.total, want.err).Once()
+				s.accessoryRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(accessories, ownerNames, want.total, time.Time{}, want.err).Once()
 			},
 		},
 		{
@@ -320,7 +324,7 @@ func (s *AccessoryServiceSuite) TestAccessoryService_GetList() {
 			},
 			wantErr: true,
 			beforeTest: func(ctx context.Context, args args, want want) {
-				s.accessoryRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(nil, nil, int64(0), want.err).Once()
+				s.accessoryRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(nil, nil, int64(0), time.Time{}, want.err).Once()
 			},
 		},
 		{
@@ -355,7 +359,7 @@ func (s *AccessoryServiceSuite) TestAccessoryService_GetList() {
 				}
 				normalizedFilter := args.filter
 				normalizedFilter.OrderDir = "ASC"
-				s.accessoryRepo.On("GetList", mock.Anything, normalizedFilter, 0, 10).Return(accessories, ownerNames, want.total, want.err).Once()
+				s.accessoryRepo.On("GetList", mock.Anything, normalizedFilter, 0, 10).Return(accessories, ownerNames, want.total, time.Time{}, want.err).Once()
 			},
 		},
 	}
@@ -377,6 +381,7 @@ func (s *AccessoryServiceSuite) TestAccessoryService_GetList() {
 			assert.Nil(s.T(), err)
 			assert.Equal(s.T(), tt.want.count, len(result.Data))
 			assert.Equal(s.T(), tt.want.total, result.Total)
+			assert.Equal(s.T(), tt.want.updatedAt, result.UpdatedAt)
 			if tt.want.hasPagination {
 				assert.Greater(s.T(), result.Page, 0)
 				assert.Greater(s.T(), result.PageSize, 0)
@@ -392,3 +397,181 @@ func (s *AccessoryServiceSuite) TestAccessoryService_GetList() {
 		})
 	}
 }
+
+func (s *AccessoryServiceSuite) TestAccessoryService_GetBySlug() {
+	s.Run("success with owner", func() {
+		acc := &domain.Accessory{Name: "Crown of Wisdom", Slug: "crown-of-wisdom", HP: 150, CommonModel: domain.CommonModel{ID: 1}}
+		s.accessoryRepo.On("GetBySlug", mock.Anything, "crown-of-wisdom").Return(acc, "Viola", nil).Once()
+
+		got, err := s.svc.GetBySlug(context.TODO(), "crown-of-wisdom")
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(1), got.ID)
+		assert.Equal(s.T(), "crown-of-wisdom", got.Slug)
+		assert.Equal(s.T(), "Viola", got.Owner)
+	})
+
+	s.Run("not found", func() {
+		wantErr := domain.NewNotFoundError("accessory", "unknown", nil)
+		s.accessoryRepo.On("GetBySlug", mock.Anything, "unknown").Return(nil, "", wantErr).Once()
+
+		_, err := s.svc.GetBySlug(context.TODO(), "unknown")
+		assert.Equal(s.T(), wantErr, err)
+	})
+}
+
+func (s *AccessoryServiceSuite) TestAccessoryService_Patch() {
+	s.Run("success", func() {
+		publishAt := time.Date(2024, 10, 1, 9, 0, 0, 0, time.UTC)
+		s.accessoryRepo.On("Update", mock.Anything, &domain.Accessory{
+			CommonModel: domain.CommonModel{ID: 9, Version: 2},
+			Name:        "Crimson Cloak",
+			HP:          150,
+			Publication: domain.Publication{Status: domain.PublishStatusScheduled, PublishAt: &publishAt},
+		}).Return(nil).Once()
+
+		got, err := s.svc.Patch(context.TODO(), 9, domain.UpdateAccessoryRequest{Name: "Crimson Cloak", HP: 150, PublishAt: "2024-10-01T09:00:00Z", Version: 2})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(9), got.ID)
+	})
+
+	s.Run("scheduled without a publish time", func() {
+		_, err := s.svc.Patch(context.TODO(), 9, domain.UpdateAccessoryRequest{Name: "Crimson Cloak", Status: domain.PublishStatusScheduled})
+		var ve *domain.ValidationError
+		assert.ErrorAs(s.T(), err, &ve)
+	})
+
+	s.Run("stale version", func() {
+		wantErr := domain.NewPreconditionFailedError("stale", nil)
+		s.accessoryRepo.On("Update", mock.Anything, mock.Anything).Return(wantErr).Once()
+
+		_, err := s.svc.Patch(context.TODO(), 9, domain.UpdateAccessoryRequest{Name: "Crimson Cloak", Version: 1})
+		assert.Equal(s.T(), wantErr, err)
+	})
+}
+
+func (s *AccessoryServiceSuite) TestAccessoryService_GetByIDs() {
+	s.Run("success in request order with missing ids", func() {
+		accessories := []*domain.Accessory{
+			{Name: "Crown of Wisdom", CommonModel: domain.CommonModel{ID: 1}},
+			{Name: "Ring of Power", CommonModel: domain.CommonModel{ID: 2}},
+		}
+		s.accessoryRepo.On("GetByIDs", mock.Anything, []int{2, 9, 1}).Return(accessories, map[int64]string{1: "Viola"}, nil).Once()
+
+		got, err := s.svc.GetByIDs(context.TODO(), domain.BatchGetRequest{IDs: "2,9,1,2"})
+		assert.NoError(s.T(), err)
+		assert.Len(s.T(), got.Data, 2)
+		assert.Equal(s.T(), "Ring of Power", got.Data[0].Name)
+		assert.Equal(s.T(), "Crown of Wisdom", got.Data[1].Name)
+		assert.Equal(s.T(), "Viola", got.Data[1].Owner)
+		assert.Equal(s.T(), []int{9}, got.NotFound)
+	})
+
+	s.Run("invalid ids", func() {
+		_, err := s.svc.GetByIDs(context.TODO(), domain.BatchGetRequest{IDs: "1,x"})
+		var ve *domain.ValidationError
+		assert.True(s.T(), errors.As(err, &ve), "expected ValidationError")
+	})
+
+	s.Run("repository error", func() {
+		s.accessoryRepo.On("GetByIDs", mock.Anything, []int{1}).Return(nil, nil, gorm.ErrInvalidDB).Once()
+
+		_, err := s.svc.GetByIDs(context.TODO(), domain.BatchGetRequest{IDs: "1"})
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+	})
+}
+
+func (s *AccessoryServiceSuite) TestAccessoryService_GetList_FilterExpression() {
+	s.Run("compiles the expression for the repository", func() {
+		s.accessoryRepo.On("GetList", mock.Anything, mock.MatchedBy(func(f domain.ListAccessoryRequest) bool {
+			return f.FilterCondition != nil &&
+				f.FilterCondition.SQL == "(m_accessory.patk > ? AND LOWER(COALESCE(m_accessory.effect, '')) LIKE LOWER(?))" &&
+				assert.ObjectsAreEqual([]interface{}{50, "%100\\%%"}, f.FilterCondition.Args)
+		}), 0, 10).Return([]*domain.Accessory{}, map[int64]string{}, int64(0), time.Time{}, nil).Once()
+
+		_, err := s.svc.GetList(context.TODO(), domain.ListAccessoryRequest{Filter: `patk>50 and effect~"100%"`}, helpers.PaginationParams{})
+		assert.NoError(s.T(), err)
+	})
+
+	s.Run("invalid expression", func() {
+		_, err := s.svc.GetList(context.TODO(), domain.ListAccessoryRequest{Filter: "patk~high"}, helpers.PaginationParams{})
+
+		var ve *domain.ValidationError
+		assert.True(s.T(), errors.As(err, &ve), "expected ValidationError")
+		assert.Equal(s.T(), "filter", ve.Errors[0].Field)
+	})
+}
+
+func (s *AccessoryServiceSuite) TestAccessoryService_GetList_Facets() {
+	s.Run("reports both sides of each presence facet", func() {
+		filter := domain.ListAccessoryRequest{Owner: "Viola", Facets: "owner,effect", FacetFields: []string{"owner", "effect"}}
+		s.accessoryRepo.On("GetList", mock.Anything, filter, 0, 10).Return([]*domain.Accessory{}, map[int64]string{}, int64(0), time.Time{}, nil).Once()
+		s.accessoryRepo.On("GetFacets", mock.Anything, filter, []string{"owner", "effect"}).Return(map[string]map[bool]int64{
+			"owner":  {true: 2, false: 5},
+			"effect": {true: 1},
+		}, nil).Once()
+
+		res, err := s.svc.GetList(context.TODO(), domain.ListAccessoryRequest{Owner: "Viola", Facets: "owner,effect"}, helpers.PaginationParams{})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), map[string][]helpers.FacetCount{
+			"owner":  {{Value: "true", Count: 2}, {Value: "false", Count: 5}},
+			"effect": {{Value: "true", Count: 1}, {Value: "false", Count: 0}},
+		}, res.Facets)
+	})
+
+	s.Run("facet tested together with other fields in filter", func() {
+		_, err := s.svc.GetList(context.TODO(), domain.ListAccessoryRequest{Filter: `not (owner~"Viola" and patk>50)`, Facets: "owner"}, helpers.PaginationParams{})
+
+		var ve *domain.ValidationError
+		assert.True(s.T(), errors.As(err, &ve), "expected ValidationError")
+		assert.Equal(s.T(), "facets", ve.Errors[0].Field)
+	})
+}
+
+func (s *AccessoryServiceSuite) TestAccessoryService_GetPage() {
+	cursors := helpers.NewCursorCodec("test-secret")
+	filter := domain.ListAccessoryRequest{OrderBy: "patk"}
+
+	s.Run("first page links to the next one", func() {
+		s.accessoryRepo.On("GetPage", mock.Anything, filter, (*helpers.Cursor)(nil), 2).Return([]*domain.Accessory{
+			{CommonModel: domain.CommonModel{ID: 2}, Name: "Ring of Power", PAtk: 80},
+			{CommonModel: domain.CommonModel{ID: 1}, Name: "Crown of Wisdom", PAtk: 45},
+		}, map[int64]string{1: "Fiore"}, true, nil).Once()
+
+		res, err := s.svc.GetPage(context.TODO(), filter, helpers.CursorParams{Limit: 2})
+		assert.NoError(s.T(), err)
+		assert.Len(s.T(), res.Data, 2)
+		assert.Equal(s.T(), "Fiore", res.Data[1].Owner)
+		assert.Empty(s.T(), res.PrevCursor)
+		assert.Nil(s.T(), res.Total)
+
+		next, err := cursors.Decode(res.NextCursor, "patk:desc,id")
+		assert.NoError(s.T(), err)
+		values, _ := next.Values(0, int64(0))
+		assert.Equal(s.T(), []interface{}{45, int64(1)}, values)
+	})
+
+	s.Run("counts the total when asked", func() {
+		s.accessoryRepo.On("GetPage", mock.Anything, filter, (*helpers.Cursor)(nil), 10).Return([]*domain.Accessory{}, map[int64]string{}, false, nil).Once()
+		s.accessoryRepo.On("Count", mock.Anything, filter).Return(int64(0), nil).Once()
+
+		res, err := s.svc.GetPage(context.TODO(), filter, helpers.CursorParams{IncludeTotal: true})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(0), *res.Total)
+	})
+
+	s.Run("cursor issued for another order", func() {
+		token, _ := cursors.Encode(helpers.Cursor{Order: "hp:desc,id"})
+
+		_, err := s.svc.GetPage(context.TODO(), filter, helpers.CursorParams{Cursor: token})
+		var ve *domain.ValidationError
+		assert.True(s.T(), errors.As(err, &ve), "expected ValidationError")
+		assert.Equal(s.T(), "cursor", ve.Errors[0].Field)
+	})
+
+	s.Run("repository error", func() {
+		s.accessoryRepo.On("GetPage", mock.Anything, filter, (*helpers.Cursor)(nil), 10).Return(nil, nil, false, gorm.ErrInvalidDB).Once()
+
+		_, err := s.svc.GetPage(context.TODO(), filter, helpers.CursorParams{})
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+	})
+}
@@ -7,6 +7,8 @@ package mocks
 import (
 	"context"
 	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -38,6 +40,72 @@ func (_m *MockAccessoryRepository) EXPECT() *MockAccessoryRepository_Expecter {
 	return &MockAccessoryRepository_Expecter{mock: &_m.Mock}
 }
 
+// Count provides a mock function for the type MockAccessoryRepository
+func (_mock *MockAccessoryRepository) Count(ctx context.Context, filter domain.ListAccessoryRequest) (int64, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListAccessoryRequest) (int64, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListAccessoryRequest) int64); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListAccessoryRequest) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccessoryRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type MockAccessoryRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ListAccessoryRequest
+func (_e *MockAccessoryRepository_Expecter) Count(ctx interface{}, filter interface{}) *MockAccessoryRepository_Count_Call {
+	return &MockAccessoryRepository_Count_Call{Call: _e.mock.On("Count", ctx, filter)}
+}
+
+func (_c *MockAccessoryRepository_Count_Call) Run(run func(ctx context.Context, filter domain.ListAccessoryRequest)) *MockAccessoryRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ListAccessoryRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.ListAccessoryRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccessoryRepository_Count_Call) Return(total int64, err error) *MockAccessoryRepository_Count_Call {
+	_c.Call.Return(total, err)
+	return _c
+}
+
+func (_c *MockAccessoryRepository_Count_Call) RunAndReturn(run func(ctx context.Context, filter domain.ListAccessoryRequest) (int64, error)) *MockAccessoryRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockAccessoryRepository
 func (_mock *MockAccessoryRepository) Create(ctx context.Context, input *domain.Accessory) error {
 	ret := _mock.Called(ctx, input)
@@ -95,8 +163,300 @@ func (_c *MockAccessoryRepository_Create_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// GetByID provides a mock function for the type MockAccessoryRepository
+func (_mock *MockAccessoryRepository) GetByID(ctx context.Context, id int) (*domain.Accessory, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.Accessory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*domain.Accessory, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *domain.Accessory); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Accessory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccessoryRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockAccessoryRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockAccessoryRepository_Expecter) GetByID(ctx interface{}, id interface{}) *MockAccessoryRepository_GetByID_Call {
+	return &MockAccessoryRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *MockAccessoryRepository_GetByID_Call) Run(run func(ctx context.Context, id int)) *MockAccessoryRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccessoryRepository_GetByID_Call) Return(result *domain.Accessory, err error) *MockAccessoryRepository_GetByID_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockAccessoryRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, id int) (*domain.Accessory, error)) *MockAccessoryRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIDs provides a mock function for the type MockAccessoryRepository
+func (_mock *MockAccessoryRepository) GetByIDs(ctx context.Context, ids []int) ([]*domain.Accessory, map[int64]string, error) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDs")
+	}
+
+	var r0 []*domain.Accessory
+	var r1 map[int64]string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int) ([]*domain.Accessory, map[int64]string, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int) []*domain.Accessory); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Accessory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []int) map[int64]string); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(map[int64]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, []int) error); ok {
+		r2 = returnFunc(ctx, ids)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockAccessoryRepository_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type MockAccessoryRepository_GetByIDs_Call struct {
+	*mock.Call
+}
+
+// GetByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int
+func (_e *MockAccessoryRepository_Expecter) GetByIDs(ctx interface{}, ids interface{}) *MockAccessoryRepository_GetByIDs_Call {
+	return &MockAccessoryRepository_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, ids)}
+}
+
+func (_c *MockAccessoryRepository_GetByIDs_Call) Run(run func(ctx context.Context, ids []int)) *MockAccessoryRepository_GetByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []int
+		if args[1] != nil {
+			arg1 = args[1].([]int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccessoryRepository_GetByIDs_Call) Return(result []*domain.Accessory, ownerNames map[int64]string, err error) *MockAccessoryRepository_GetByIDs_Call {
+	_c.Call.Return(result, ownerNames, err)
+	return _c
+}
+
+func (_c *MockAccessoryRepository_GetByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []int) ([]*domain.Accessory, map[int64]string, error)) *MockAccessoryRepository_GetByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBySlug provides a mock function for the type MockAccessoryRepository
+func (_mock *MockAccessoryRepository) GetBySlug(ctx context.Context, slug string) (*domain.Accessory, string, error) {
+	ret := _mock.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySlug")
+	}
+
+	var r0 *domain.Accessory
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Accessory, string, error)); ok {
+		return returnFunc(ctx, slug)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Accessory); ok {
+		r0 = returnFunc(ctx, slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Accessory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = returnFunc(ctx, slug)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, slug)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockAccessoryRepository_GetBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBySlug'
+type MockAccessoryRepository_GetBySlug_Call struct {
+	*mock.Call
+}
+
+// GetBySlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+func (_e *MockAccessoryRepository_Expecter) GetBySlug(ctx interface{}, slug interface{}) *MockAccessoryRepository_GetBySlug_Call {
+	return &MockAccessoryRepository_GetBySlug_Call{Call: _e.mock.On("GetBySlug", ctx, slug)}
+}
+
+func (_c *MockAccessoryRepository_GetBySlug_Call) Run(run func(ctx context.Context, slug string)) *MockAccessoryRepository_GetBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccessoryRepository_GetBySlug_Call) Return(result *domain.Accessory, owner string, err error) *MockAccessoryRepository_GetBySlug_Call {
+	_c.Call.Return(result, owner, err)
+	return _c
+}
+
+func (_c *MockAccessoryRepository_GetBySlug_Call) RunAndReturn(run func(ctx context.Context, slug string) (*domain.Accessory, string, error)) *MockAccessoryRepository_GetBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFacets provides a mock function for the type MockAccessoryRepository
+func (_mock *MockAccessoryRepository) GetFacets(ctx context.Context, filter domain.ListAccessoryRequest, facets []string) (map[string]map[bool]int64, error) {
+	ret := _mock.Called(ctx, filter, facets)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFacets")
+	}
+
+	var r0 map[string]map[bool]int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListAccessoryRequest, []string) (map[string]map[bool]int64, error)); ok {
+		return returnFunc(ctx, filter, facets)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListAccessoryRequest, []string) map[string]map[bool]int64); ok {
+		r0 = returnFunc(ctx, filter, facets)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]map[bool]int64)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListAccessoryRequest, []string) error); ok {
+		r1 = returnFunc(ctx, filter, facets)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccessoryRepository_GetFacets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFacets'
+type MockAccessoryRepository_GetFacets_Call struct {
+	*mock.Call
+}
+
+// GetFacets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ListAccessoryRequest
+//   - facets []string
+func (_e *MockAccessoryRepository_Expecter) GetFacets(ctx interface{}, filter interface{}, facets interface{}) *MockAccessoryRepository_GetFacets_Call {
+	return &MockAccessoryRepository_GetFacets_Call{Call: _e.mock.On("GetFacets", ctx, filter, facets)}
+}
+
+func (_c *MockAccessoryRepository_GetFacets_Call) Run(run func(ctx context.Context, filter domain.ListAccessoryRequest, facets []string)) *MockAccessoryRepository_GetFacets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ListAccessoryRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.ListAccessoryRequest)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccessoryRepository_GetFacets_Call) Return(result map[string]map[bool]int64, err error) *MockAccessoryRepository_GetFacets_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockAccessoryRepository_GetFacets_Call) RunAndReturn(run func(ctx context.Context, filter domain.ListAccessoryRequest, facets []string) (map[string]map[bool]int64, error)) *MockAccessoryRepository_GetFacets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetList provides a mock function for the type MockAccessoryRepository
-func (_mock *MockAccessoryRepository) GetList(ctx context.Context, filter domain.ListAccessoryRequest, offset int, limit int) ([]*domain.Accessory, map[int64]string, int64, error) {
+func (_mock *MockAccessoryRepository) GetList(ctx context.Context, filter domain.ListAccessoryRequest, offset int, limit int) ([]*domain.Accessory, map[int64]string, int64, time.Time, error) {
 	ret := _mock.Called(ctx, filter, offset, limit)
 
 	if len(ret) == 0 {
@@ -106,8 +466,9 @@ func (_mock *MockAccessoryRepository) GetList(ctx context.Context, filter domain
 	var r0 []*domain.Accessory
 	var r1 map[int64]string
 	var r2 int64
-	var r3 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListAccessoryRequest, int, int) ([]*domain.Accessory, map[int64]string, int64, error)); ok {
+	var r3 time.Time
+	var r4 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListAccessoryRequest, int, int) ([]*domain.Accessory, map[int64]string, int64, time.Time, error)); ok {
 		return returnFunc(ctx, filter, offset, limit)
 	}
 	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListAccessoryRequest, int, int) []*domain.Accessory); ok {
@@ -129,12 +490,17 @@ func (_mock *MockAccessoryRepository) GetList(ctx context.Context, filter domain
 	} else {
 		r2 = ret.Get(2).(int64)
 	}
-	if returnFunc, ok := ret.Get(3).(func(context.Context, domain.ListAccessoryRequest, int, int) error); ok {
+	if returnFunc, ok := ret.Get(3).(func(context.Context, domain.ListAccessoryRequest, int, int) time.Time); ok {
 		r3 = returnFunc(ctx, filter, offset, limit)
 	} else {
-		r3 = ret.Error(3)
+		r3 = ret.Get(3).(time.Time)
 	}
-	return r0, r1, r2, r3
+	if returnFunc, ok := ret.Get(4).(func(context.Context, domain.ListAccessoryRequest, int, int) error); ok {
+		r4 = returnFunc(ctx, filter, offset, limit)
+	} else {
+		r4 = ret.Error(4)
+	}
+	return r0, r1, r2, r3, r4
 }
 
 // MockAccessoryRepository_GetList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetList'
@@ -179,12 +545,106 @@ func (_c *MockAccessoryRepository_GetList_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *MockAccessoryRepository_GetList_Call) Return(result []*domain.Accessory, ownerNames map[int64]string, total int64, err error) *MockAccessoryRepository_GetList_Call {
-	_c.Call.Return(result, ownerNames, total, err)
+func (_c *MockAccessoryRepository_GetList_Call) Return(result []*domain.Accessory, ownerNames map[int64]string, total int64, lastModified time.Time, err error) *MockAccessoryRepository_GetList_Call {
+	_c.Call.Return(result, ownerNames, total, lastModified, err)
+	return _c
+}
+
+func (_c *MockAccessoryRepository_GetList_Call) RunAndReturn(run func(ctx context.Context, filter domain.ListAccessoryRequest, offset int, limit int) ([]*domain.Accessory, map[int64]string, int64, time.Time, error)) *MockAccessoryRepository_GetList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPage provides a mock function for the type MockAccessoryRepository
+func (_mock *MockAccessoryRepository) GetPage(ctx context.Context, filter domain.ListAccessoryRequest, cursor *helpers.Cursor, limit int) ([]*domain.Accessory, map[int64]string, bool, error) {
+	ret := _mock.Called(ctx, filter, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPage")
+	}
+
+	var r0 []*domain.Accessory
+	var r1 map[int64]string
+	var r2 bool
+	var r3 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListAccessoryRequest, *helpers.Cursor, int) ([]*domain.Accessory, map[int64]string, bool, error)); ok {
+		return returnFunc(ctx, filter, cursor, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListAccessoryRequest, *helpers.Cursor, int) []*domain.Accessory); ok {
+		r0 = returnFunc(ctx, filter, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Accessory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListAccessoryRequest, *helpers.Cursor, int) map[int64]string); ok {
+		r1 = returnFunc(ctx, filter, cursor, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(map[int64]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, domain.ListAccessoryRequest, *helpers.Cursor, int) bool); ok {
+		r2 = returnFunc(ctx, filter, cursor, limit)
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+	if returnFunc, ok := ret.Get(3).(func(context.Context, domain.ListAccessoryRequest, *helpers.Cursor, int) error); ok {
+		r3 = returnFunc(ctx, filter, cursor, limit)
+	} else {
+		r3 = ret.Error(3)
+	}
+	return r0, r1, r2, r3
+}
+
+// MockAccessoryRepository_GetPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPage'
+type MockAccessoryRepository_GetPage_Call struct {
+	*mock.Call
+}
+
+// GetPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ListAccessoryRequest
+//   - cursor *helpers.Cursor
+//   - limit int
+func (_e *MockAccessoryRepository_Expecter) GetPage(ctx interface{}, filter interface{}, cursor interface{}, limit interface{}) *MockAccessoryRepository_GetPage_Call {
+	return &MockAccessoryRepository_GetPage_Call{Call: _e.mock.On("GetPage", ctx, filter, cursor, limit)}
+}
+
+func (_c *MockAccessoryRepository_GetPage_Call) Run(run func(ctx context.Context, filter domain.ListAccessoryRequest, cursor *helpers.Cursor, limit int)) *MockAccessoryRepository_GetPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ListAccessoryRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.ListAccessoryRequest)
+		}
+		var arg2 *helpers.Cursor
+		if args[2] != nil {
+			arg2 = args[2].(*helpers.Cursor)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccessoryRepository_GetPage_Call) Return(result []*domain.Accessory, ownerNames map[int64]string, hasMore bool, err error) *MockAccessoryRepository_GetPage_Call {
+	_c.Call.Return(result, ownerNames, hasMore, err)
 	return _c
 }
 
-func (_c *MockAccessoryRepository_GetList_Call) RunAndReturn(run func(ctx context.Context, filter domain.ListAccessoryRequest, offset int, limit int) ([]*domain.Accessory, map[int64]string, int64, error)) *MockAccessoryRepository_GetList_Call {
+func (_c *MockAccessoryRepository_GetPage_Call) RunAndReturn(run func(ctx context.Context, filter domain.ListAccessoryRequest, cursor *helpers.Cursor, limit int) ([]*domain.Accessory, map[int64]string, bool, error)) *MockAccessoryRepository_GetPage_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -39,6 +39,138 @@ func (_m *MockAccessoryService) EXPECT() *MockAccessoryService_Expecter {
 	return &MockAccessoryService_Expecter{mock: &_m.Mock}
 }
 
+// GetByIDs provides a mock function for the type MockAccessoryService
+func (_mock *MockAccessoryService) GetByIDs(ctx context.Context, input domain.BatchGetRequest) (helpers.BatchResponse[domain.AccessoryListItemResponse], error) {
+	ret := _mock.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDs")
+	}
+
+	var r0 helpers.BatchResponse[domain.AccessoryListItemResponse]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.BatchGetRequest) (helpers.BatchResponse[domain.AccessoryListItemResponse], error)); ok {
+		return returnFunc(ctx, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.BatchGetRequest) helpers.BatchResponse[domain.AccessoryListItemResponse]); ok {
+		r0 = returnFunc(ctx, input)
+	} else {
+		r0 = ret.Get(0).(helpers.BatchResponse[domain.AccessoryListItemResponse])
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.BatchGetRequest) error); ok {
+		r1 = returnFunc(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccessoryService_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type MockAccessoryService_GetByIDs_Call struct {
+	*mock.Call
+}
+
+// GetByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input domain.BatchGetRequest
+func (_e *MockAccessoryService_Expecter) GetByIDs(ctx interface{}, input interface{}) *MockAccessoryService_GetByIDs_Call {
+	return &MockAccessoryService_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, input)}
+}
+
+func (_c *MockAccessoryService_GetByIDs_Call) Run(run func(ctx context.Context, input domain.BatchGetRequest)) *MockAccessoryService_GetByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.BatchGetRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.BatchGetRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccessoryService_GetByIDs_Call) Return(res helpers.BatchResponse[domain.AccessoryListItemResponse], err error) *MockAccessoryService_GetByIDs_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockAccessoryService_GetByIDs_Call) RunAndReturn(run func(ctx context.Context, input domain.BatchGetRequest) (helpers.BatchResponse[domain.AccessoryListItemResponse], error)) *MockAccessoryService_GetByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBySlug provides a mock function for the type MockAccessoryService
+func (_mock *MockAccessoryService) GetBySlug(ctx context.Context, slug string) (domain.AccessoryListItemResponse, error) {
+	ret := _mock.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySlug")
+	}
+
+	var r0 domain.AccessoryListItemResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.AccessoryListItemResponse, error)); ok {
+		return returnFunc(ctx, slug)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.AccessoryListItemResponse); ok {
+		r0 = returnFunc(ctx, slug)
+	} else {
+		r0 = ret.Get(0).(domain.AccessoryListItemResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccessoryService_GetBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBySlug'
+type MockAccessoryService_GetBySlug_Call struct {
+	*mock.Call
+}
+
+// GetBySlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+func (_e *MockAccessoryService_Expecter) GetBySlug(ctx interface{}, slug interface{}) *MockAccessoryService_GetBySlug_Call {
+	return &MockAccessoryService_GetBySlug_Call{Call: _e.mock.On("GetBySlug", ctx, slug)}
+}
+
+func (_c *MockAccessoryService_GetBySlug_Call) Run(run func(ctx context.Context, slug string)) *MockAccessoryService_GetBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccessoryService_GetBySlug_Call) Return(res domain.AccessoryListItemResponse, err error) *MockAccessoryService_GetBySlug_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockAccessoryService_GetBySlug_Call) RunAndReturn(run func(ctx context.Context, slug string) (domain.AccessoryListItemResponse, error)) *MockAccessoryService_GetBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetList provides a mock function for the type MockAccessoryService
 func (_mock *MockAccessoryService) GetList(ctx context.Context, filter domain.ListAccessoryRequest, params helpers.PaginationParams) (helpers.PaginatedResponse[domain.AccessoryListItemResponse], error) {
 	ret := _mock.Called(ctx, filter, params)
@@ -110,3 +242,75 @@ func (_c *MockAccessoryService_GetList_Call) RunAndReturn(run func(ctx context.C
 	_c.Call.Return(run)
 	return _c
 }
+
+// GetPage provides a mock function for the type MockAccessoryService
+func (_mock *MockAccessoryService) GetPage(ctx context.Context, filter domain.ListAccessoryRequest, params helpers.CursorParams) (helpers.CursorResponse[domain.AccessoryListItemResponse], error) {
+	ret := _mock.Called(ctx, filter, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPage")
+	}
+
+	var r0 helpers.CursorResponse[domain.AccessoryListItemResponse]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListAccessoryRequest, helpers.CursorParams) (helpers.CursorResponse[domain.AccessoryListItemResponse], error)); ok {
+		return returnFunc(ctx, filter, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListAccessoryRequest, helpers.CursorParams) helpers.CursorResponse[domain.AccessoryListItemResponse]); ok {
+		r0 = returnFunc(ctx, filter, params)
+	} else {
+		r0 = ret.Get(0).(helpers.CursorResponse[domain.AccessoryListItemResponse])
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListAccessoryRequest, helpers.CursorParams) error); ok {
+		r1 = returnFunc(ctx, filter, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccessoryService_GetPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPage'
+type MockAccessoryService_GetPage_Call struct {
+	*mock.Call
+}
+
+// GetPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ListAccessoryRequest
+//   - params helpers.CursorParams
+func (_e *MockAccessoryService_Expecter) GetPage(ctx interface{}, filter interface{}, params interface{}) *MockAccessoryService_GetPage_Call {
+	return &MockAccessoryService_GetPage_Call{Call: _e.mock.On("GetPage", ctx, filter, params)}
+}
+
+func (_c *MockAccessoryService_GetPage_Call) Run(run func(ctx context.Context, filter domain.ListAccessoryRequest, params helpers.CursorParams)) *MockAccessoryService_GetPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ListAccessoryRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.ListAccessoryRequest)
+		}
+		var arg2 helpers.CursorParams
+		if args[2] != nil {
+			arg2 = args[2].(helpers.CursorParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccessoryService_GetPage_Call) Return(res helpers.CursorResponse[domain.AccessoryListItemResponse], err error) *MockAccessoryService_GetPage_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockAccessoryService_GetPage_Call) RunAndReturn(run func(ctx context.Context, filter domain.ListAccessoryRequest, params helpers.CursorParams) (helpers.CursorResponse[domain.AccessoryListItemResponse], error)) *MockAccessoryService_GetPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,276 @@
+package changerequest
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/controller"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ChangeRequestService interface {
+	Create(ctx context.Context, input domain.CreateChangeRequestRequest) (res domain.ChangeRequestResponse, err error)
+	GetByID(ctx context.Context, id int) (res domain.ChangeRequestResponse, err error)
+	List(ctx context.Context, input domain.ListChangeRequestRequest, params helpers.PaginationParams) (res helpers.PaginatedResponse[domain.ChangeRequestListItemResponse], err error)
+	Comment(ctx context.Context, id int, input domain.CommentChangeRequestRequest) (res domain.ChangeRequestCommentResponse, err error)
+	Approve(ctx context.Context, id int, input domain.ReviewChangeRequestRequest) (res domain.ChangeRequestResponse, err error)
+	Reject(ctx context.Context, id int, input domain.ReviewChangeRequestRequest) (res domain.ChangeRequestResponse, err error)
+}
+
+type ChangeRequestHandler struct {
+	Service ChangeRequestService
+	logger  *logging.Logger
+}
+
+func NewChangeRequestHandler(e *echo.Group, svc ChangeRequestService, logger *logging.Logger) *ChangeRequestHandler {
+	handler := &ChangeRequestHandler{
+		Service: svc,
+		logger:  logger.Named("handler.change_request"),
+	}
+	group := e.Group("/change-requests")
+
+	group.GET("", handler.GetList)
+	group.POST("", handler.Create)
+	group.GET("/:id", handler.GetByID)
+	group.POST("/:id/comments", handler.Comment)
+	group.POST("/:id/approve", handler.Approve)
+	group.POST("/:id/reject", handler.Reject)
+
+	return handler
+}
+
+// GetList godoc
+//
+//	@Summary		List change requests
+//	@Description	list proposed changes to travellers and accessories, oldest first. List status=pending for the review queue.
+//	@Tags			change-requests
+//	@Accept			json
+//	@Produce		json
+//	@Param			status		query	string	false	"Status (pending, approved, rejected)"
+//	@Param			entity_type	query	string	false	"Entity type (traveller, accessory)"
+//	@Param			entity_id	query	int		false	"Entity ID"
+//	@Param			page		query	int		false	"Page number (default 1)"
+//	@Param			page_size	query	int		false	"Page size (default 10, max 100)"
+//	@Success		200	{object}	helpers.PaginatedResponse[domain.ChangeRequestListItemResponse]
+//	@Header			200	{string}	Link	"RFC 8288 links to the first, prev, next and last pages, keeping the request's filters"
+//	@Failure		400	{object}	controller.ErrorResponse
+//	@Failure		500	{object}	controller.ErrorResponse
+//	@Router			/change-requests [get]
+//	@Security		BearerAuth
+func (h *ChangeRequestHandler) GetList(ctx echo.Context) error {
+	var request domain.ListChangeRequestRequest
+	err := ctx.Bind(&request)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid query parameters")
+	}
+
+	err = ctx.Validate(&request)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	var params helpers.PaginationParams
+	err = ctx.Bind(&params)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid pagination parameters")
+	}
+
+	result, err := h.Service.List(ctx.Request().Context(), request, params)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "list change requests", h.logger)
+	}
+
+	helpers.SetPageLinks(ctx, &result)
+	return controller.Ok(ctx, result)
+}
+
+// Create godoc
+//
+//	@Summary		Propose a change
+//	@Description	propose a change to a traveller or accessory as a JSON merge patch (RFC 7396) against its update document, e.g. {"rarity": 5} or {"accessory": {"hp": 150}} for a traveller.
+//	@Description	The change is recorded against the record's current version and can only be approved while the record is still at it.
+//	@Tags			change-requests
+//	@Accept			json
+//	@Produce		json
+//	@Param			body		body	domain.CreateChangeRequestRequest	true	"Proposed change"
+//	@Param			If-Match	header	string								false	"ETag of the version the change is proposed against"
+//	@Success		201	{object}	domain.ChangeRequestResponse
+//	@Header			201	{string}	Location	"URI of the change request"
+//	@Failure		400	{object}	controller.ErrorResponse	"Invalid request, or a patch that leaves an invalid record or changes nothing"
+//	@Failure		404	{object}	controller.ErrorResponse
+//	@Failure		412	{object}	controller.ErrorResponse	"The record changed since the If-Match version"
+//	@Failure		500	{object}	controller.ErrorResponse
+//	@Router			/change-requests [post]
+//	@Security		BearerAuth
+func (h *ChangeRequestHandler) Create(ctx echo.Context) error {
+	var request domain.CreateChangeRequestRequest
+	err := ctx.Bind(&request)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid request body")
+	}
+
+	if ifMatch := ctx.Request().Header.Get("If-Match"); ifMatch != "" {
+		version, ok := domain.ParseETagVersion(ifMatch)
+		if !ok {
+			return helpers.RespondPreconditionFailed(ctx)
+		}
+		request.Version = version
+	}
+
+	err = ctx.Validate(&request)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	result, err := h.Service.Create(ctx.Request().Context(), request)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "create change request", h.logger)
+	}
+
+	return controller.Created(ctx, result, domain.ChangeRequestPath(result.ID))
+}
+
+// GetByID godoc
+//
+//	@Summary		Get change request
+//	@Description	get a change request with its comments. A pending one also lists the field-level changes it would make to the record as it is now, and stale is true once the record changed since the proposal.
+//	@Tags			change-requests
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Change request ID"
+//	@Success		200	{object}	domain.ChangeRequestResponse
+//	@Failure		400	{object}	controller.ErrorResponse
+//	@Failure		404	{object}	controller.ErrorResponse
+//	@Failure		500	{object}	controller.ErrorResponse
+//	@Router			/change-requests/{id} [get]
+//	@Security		BearerAuth
+func (h *ChangeRequestHandler) GetByID(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid id parameter")
+	}
+
+	result, err := h.Service.GetByID(ctx.Request().Context(), id)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "get change request", h.logger)
+	}
+
+	return controller.Ok(ctx, result)
+}
+
+// Comment godoc
+//
+//	@Summary		Comment on change request
+//	@Description	add a comment to the discussion of a change request
+//	@Tags			change-requests
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	int									true	"Change request ID"
+//	@Param			body	body	domain.CommentChangeRequestRequest	true	"Comment"
+//	@Success		201	{object}	domain.ChangeRequestCommentResponse
+//	@Failure		400	{object}	controller.ErrorResponse
+//	@Failure		404	{object}	controller.ErrorResponse
+//	@Failure		500	{object}	controller.ErrorResponse
+//	@Router			/change-requests/{id}/comments [post]
+//	@Security		BearerAuth
+func (h *ChangeRequestHandler) Comment(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid id parameter")
+	}
+
+	var request domain.CommentChangeRequestRequest
+	err = ctx.Bind(&request)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid request body")
+	}
+
+	err = ctx.Validate(&request)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	result, err := h.Service.Comment(ctx.Request().Context(), id, request)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "comment on change request", h.logger)
+	}
+
+	return controller.Created(ctx, result, "")
+}
+
+// Approve godoc
+//
+//	@Summary		Approve change request
+//	@Description	apply a pending change request to its record and close it, optionally with a comment. Only editors can review change requests.
+//	@Description	Fails with 409, leaving the change request pending, if the record changed since the change was proposed.
+//	@Tags			change-requests
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	int									true	"Change request ID"
+//	@Param			body	body	domain.ReviewChangeRequestRequest	false	"Review comment"
+//	@Success		200	{object}	domain.ChangeRequestResponse
+//	@Failure		400	{object}	controller.ErrorResponse
+//	@Failure		403	{object}	controller.ErrorResponse	"The caller is not an editor"
+//	@Failure		404	{object}	controller.ErrorResponse
+//	@Failure		409	{object}	controller.ErrorResponse	"Already reviewed, or the record changed since the proposal"
+//	@Failure		500	{object}	controller.ErrorResponse
+//	@Router			/change-requests/{id}/approve [post]
+//	@Security		BearerAuth
+func (h *ChangeRequestHandler) Approve(ctx echo.Context) error {
+	return h.review(ctx, "approve change request", h.Service.Approve)
+}
+
+// Reject godoc
+//
+//	@Summary		Reject change request
+//	@Description	close a pending change request without applying it, optionally with a comment. Only editors can review change requests.
+//	@Tags			change-requests
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	int									true	"Change request ID"
+//	@Param			body	body	domain.ReviewChangeRequestRequest	false	"Review comment"
+//	@Success		200	{object}	domain.ChangeRequestResponse
+//	@Failure		400	{object}	controller.ErrorResponse
+//	@Failure		403	{object}	controller.ErrorResponse	"The caller is not an editor"
+//	@Failure		404	{object}	controller.ErrorResponse
+//	@Failure		409	{object}	controller.ErrorResponse	"Already reviewed"
+//	@Failure		500	{object}	controller.ErrorResponse
+//	@Router			/change-requests/{id}/reject [post]
+//	@Security		BearerAuth
+func (h *ChangeRequestHandler) Reject(ctx echo.Context) error {
+	return h.review(ctx, "reject change request", h.Service.Reject)
+}
+
+// review runs an approval or rejection for an editor
+func (h *ChangeRequestHandler) review(ctx echo.Context, operation string, review func(ctx context.Context, id int, input domain.ReviewChangeRequestRequest) (domain.ChangeRequestResponse, error)) error {
+	if !logging.IsEditor(ctx.Request().Context()) {
+		return controller.ResponseError(ctx, http.StatusForbidden, "only editors can review change requests")
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid id parameter")
+	}
+
+	var request domain.ReviewChangeRequestRequest
+	err = ctx.Bind(&request)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid request body")
+	}
+
+	err = ctx.Validate(&request)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	result, err := review(ctx.Request().Context(), id, request)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, operation, h.logger)
+	}
+
+	return controller.Ok(ctx, result)
+}
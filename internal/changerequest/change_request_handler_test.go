@@ -0,0 +1,263 @@
+package changerequest
+
+import (
+	"encoding/json"
+	"lizobly/ctc-db-api/internal/changerequest/mocks"
+	"lizobly/ctc-db-api/pkg/controller"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type ChangeRequestHandlerSuite struct {
+	suite.Suite
+
+	e                    *echo.Echo
+	changeRequestService *mocks.MockChangeRequestService
+	handler              *ChangeRequestHandler
+}
+
+func TestChangeRequestHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ChangeRequestHandlerSuite))
+}
+
+func (s *ChangeRequestHandlerSuite) SetupTest() {
+	s.e = echo.New()
+	s.changeRequestService = new(mocks.MockChangeRequestService)
+	testLogger, _ := logging.NewDevelopmentLogger()
+	s.handler = NewChangeRequestHandler(s.e.Group(""), s.changeRequestService, testLogger)
+}
+
+func (s *ChangeRequestHandlerSuite) TearDownTest() {
+	s.changeRequestService.AssertExpectations(s.T())
+}
+
+func changeRequestResponse(status string) domain.ChangeRequestResponse {
+	return domain.ChangeRequestResponse{
+		ChangeRequestListItemResponse: domain.ChangeRequestListItemResponse{
+			ID:          7,
+			EntityType:  domain.ChangeRequestEntityTraveller,
+			EntityID:    4,
+			BaseVersion: 3,
+			Status:      status,
+		},
+		Patch: json.RawMessage(`{"rarity":4}`),
+	}
+}
+
+func (s *ChangeRequestHandlerSuite) TestChangeRequestHandler_GetList() {
+	page := helpers.NewPaginatedResponse([]domain.ChangeRequestListItemResponse{
+		changeRequestResponse(domain.ChangeRequestStatusPending).ChangeRequestListItemResponse,
+	}, helpers.PaginationParams{Page: 1, PageSize: 10}, 1)
+
+	tests := []struct {
+		name        string
+		queryParams map[string]string
+		statusCode  int
+		beforeTest  func()
+	}{
+		{
+			name:        "review queue",
+			queryParams: map[string]string{"status": "pending"},
+			statusCode:  http.StatusOK,
+			beforeTest: func() {
+				s.changeRequestService.On("List", mock.Anything, domain.ListChangeRequestRequest{Status: "pending"}, helpers.PaginationParams{}).
+					Return(page, nil).Once()
+			},
+		},
+		{
+			name:        "unknown status",
+			queryParams: map[string]string{"status": "merged"},
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "service error",
+			queryParams: map[string]string{},
+			statusCode:  http.StatusInternalServerError,
+			beforeTest: func() {
+				s.changeRequestService.On("List", mock.Anything, domain.ListChangeRequestRequest{}, helpers.PaginationParams{}).
+					Return(helpers.PaginatedResponse[domain.ChangeRequestListItemResponse]{}, gorm.ErrInvalidDB).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			queryValues := url.Values{}
+			for k, v := range tt.queryParams {
+				queryValues.Set(k, v)
+			}
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/change-requests", nil, queryValues, nil)
+
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+
+			err := s.handler.GetList(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.statusCode, ctx.Response().Status)
+
+			if tt.statusCode == http.StatusOK {
+				var got controller.DataResponse[helpers.PaginatedResponse[domain.ChangeRequestListItemResponse]]
+				assert.NoError(s.T(), json.Unmarshal(rec.Body.Bytes(), &got))
+				assert.Equal(s.T(), page.Data, got.Data.Data)
+			}
+		})
+	}
+}
+
+func (s *ChangeRequestHandlerSuite) TestChangeRequestHandler_Create() {
+	body := map[string]interface{}{"entity_type": "traveller", "entity_id": 4, "patch": map[string]interface{}{"rarity": 4}}
+
+	tests := []struct {
+		name        string
+		requestBody interface{}
+		ifMatch     string
+		statusCode  int
+		beforeTest  func()
+	}{
+		{
+			name:        "success",
+			requestBody: body,
+			ifMatch:     `"3"`,
+			statusCode:  http.StatusCreated,
+			beforeTest: func() {
+				s.changeRequestService.On("Create", mock.Anything, mock.MatchedBy(func(input domain.CreateChangeRequestRequest) bool {
+					return input.EntityType == "traveller" && input.EntityID == 4 && input.Version == 3 && string(input.Patch) == `{"rarity":4}`
+				})).Return(changeRequestResponse(domain.ChangeRequestStatusPending), nil).Once()
+			},
+		},
+		{
+			name:        "failed invalid If-Match",
+			requestBody: body,
+			ifMatch:     "*",
+			statusCode:  http.StatusPreconditionFailed,
+		},
+		{
+			name:        "failed unknown entity type",
+			requestBody: map[string]interface{}{"entity_type": "user", "entity_id": 4, "patch": map[string]interface{}{}},
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "failed changed since If-Match",
+			requestBody: body,
+			ifMatch:     `"2"`,
+			statusCode:  http.StatusPreconditionFailed,
+			beforeTest: func() {
+				s.changeRequestService.On("Create", mock.Anything, mock.Anything).
+					Return(domain.ChangeRequestResponse{}, domain.NewPreconditionFailedError("changed", nil)).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodPost, "/change-requests", tt.requestBody, nil, nil)
+			if tt.ifMatch != "" {
+				ctx.Request().Header.Set("If-Match", tt.ifMatch)
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+
+			err := s.handler.Create(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.statusCode, ctx.Response().Status)
+
+			if tt.statusCode == http.StatusCreated {
+				assert.Equal(s.T(), domain.ChangeRequestPath(7), rec.Header().Get(echo.HeaderLocation))
+			}
+		})
+	}
+}
+
+func (s *ChangeRequestHandlerSuite) TestChangeRequestHandler_Approve() {
+	tests := []struct {
+		name       string
+		editor     bool
+		id         string
+		statusCode int
+		beforeTest func()
+	}{
+		{
+			name:       "success",
+			editor:     true,
+			id:         "7",
+			statusCode: http.StatusOK,
+			beforeTest: func() {
+				s.changeRequestService.On("Approve", mock.Anything, 7, domain.ReviewChangeRequestRequest{Comment: "Matches the announcement"}).
+					Return(changeRequestResponse(domain.ChangeRequestStatusApproved), nil).Once()
+			},
+		},
+		{
+			name:       "failed not an editor",
+			id:         "7",
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "failed record changed",
+			editor:     true,
+			id:         "7",
+			statusCode: http.StatusConflict,
+			beforeTest: func() {
+				s.changeRequestService.On("Approve", mock.Anything, 7, mock.Anything).
+					Return(domain.ChangeRequestResponse{}, domain.NewConflictError("the traveller changed", nil)).Once()
+			},
+		},
+		{
+			name:       "failed invalid id",
+			editor:     true,
+			id:         "abc",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			_, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodPost, "/change-requests/:id/approve",
+				domain.ReviewChangeRequestRequest{Comment: "Matches the announcement"}, nil, map[string]string{"id": tt.id})
+			if tt.editor {
+				ctx.SetRequest(ctx.Request().WithContext(logging.WithEditor(ctx.Request().Context())))
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+
+			err := s.handler.Approve(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.statusCode, ctx.Response().Status)
+		})
+	}
+}
+
+func (s *ChangeRequestHandlerSuite) TestChangeRequestHandler_Reject() {
+	s.Run("failed not an editor", func() {
+		_, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodPost, "/change-requests/:id/reject", nil, nil, map[string]string{"id": "7"})
+
+		err := s.handler.Reject(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusForbidden, ctx.Response().Status)
+	})
+
+	s.Run("success", func() {
+		_, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodPost, "/change-requests/:id/reject", nil, nil, map[string]string{"id": "7"})
+		ctx.SetRequest(ctx.Request().WithContext(logging.WithEditor(ctx.Request().Context())))
+		s.changeRequestService.On("Reject", mock.Anything, 7, domain.ReviewChangeRequestRequest{}).
+			Return(changeRequestResponse(domain.ChangeRequestStatusRejected), nil).Once()
+
+		err := s.handler.Reject(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusOK, ctx.Response().Status)
+	})
+}
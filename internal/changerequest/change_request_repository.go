@@ -0,0 +1,188 @@
+package changerequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"lizobly/ctc-db-api/pkg/telemetry"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type changeRequestRepository struct {
+	db     *gorm.DB
+	logger *logging.Logger
+}
+
+func NewChangeRequestRepository(db *gorm.DB, logger *logging.Logger) *changeRequestRepository {
+	return &changeRequestRepository{
+		db:     db,
+		logger: logger.Named("repository.change_request"),
+	}
+}
+
+// orderedComments preloads a change request's comments oldest first
+func orderedComments(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at, id")
+}
+
+func (r *changeRequestRepository) Create(ctx context.Context, input *domain.ChangeRequest) (err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.change_request", "ChangeRequestRepository.Create", "insert", "m_change_request",
+		attribute.String("change_request.entity_type", input.EntityType),
+		attribute.Int64("change_request.entity_id", input.EntityID),
+	)
+	defer op.End(err)
+
+	err = r.db.WithContext(ctx).Create(input).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to create change request", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+func (r *changeRequestRepository) GetByID(ctx context.Context, id int) (result *domain.ChangeRequest, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.change_request", "ChangeRequestRepository.GetByID", "select", "m_change_request",
+		attribute.Int("change_request.id", id),
+	)
+	defer op.End(err)
+
+	result = &domain.ChangeRequest{}
+	err = r.db.WithContext(ctx).Preload("Comments", orderedComments).First(result, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("change request", id, nil)
+		}
+		// r.logger.WithContext(ctx).Error("failed to get change request", zap.Int("change_request.id", id), zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// GetList returns one page of the change requests matching the filter, oldest first so the
+// review queue is worked in order
+func (r *changeRequestRepository) GetList(ctx context.Context, filter domain.ListChangeRequestRequest, offset, limit int) (result []domain.ChangeRequest, total int64, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.change_request", "ChangeRequestRepository.GetList", "select", "m_change_request",
+		attribute.String("change_request.status", filter.Status),
+		attribute.String("change_request.entity_type", filter.EntityType),
+	)
+	defer op.End(err)
+
+	query := r.db.WithContext(ctx).Model(&domain.ChangeRequest{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+
+	err = query.Count(&total).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to count change requests", zap.Error(err))
+		return
+	}
+
+	err = query.Order("created_at, id").Offset(offset).Limit(limit).Find(&result).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to get change requests", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// AddComment adds a comment by the context's user to a change request
+func (r *changeRequestRepository) AddComment(ctx context.Context, comment *domain.ChangeRequestComment) (err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.change_request", "ChangeRequestRepository.AddComment", "insert", "m_change_request_comment",
+		attribute.Int64("change_request.id", comment.ChangeRequestID),
+	)
+	defer op.End(err)
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var changeRequest domain.ChangeRequest
+		err := tx.Select("id").First(&changeRequest, "id = ?", comment.ChangeRequestID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.NewNotFoundError("change request", comment.ChangeRequestID, nil)
+		}
+		if err != nil {
+			return err
+		}
+
+		comment.Author = logging.GetUserID(ctx)
+		return tx.Create(comment).Error
+	})
+
+	return
+}
+
+// Review closes a pending change request with the given status, adding the reviewer's comment
+// if there is one. apply, if given, runs first with the change request locked, so it is applied
+// at most once and never after a concurrent rejection. Its context carries the review's
+// transaction, so repository writes made through it commit or roll back with the review; an
+// error from it leaves the change request pending.
+func (r *changeRequestRepository) Review(ctx context.Context, id int, status, comment string, apply func(ctx context.Context, changeRequest *domain.ChangeRequest) error) (result *domain.ChangeRequest, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.change_request", "ChangeRequestRepository.Review", "update", "m_change_request",
+		attribute.Int("change_request.id", id),
+		attribute.String("change_request.status", status),
+	)
+	defer op.End(err)
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var changeRequest domain.ChangeRequest
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&changeRequest, "id = ?", id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.NewNotFoundError("change request", id, nil)
+		}
+		if err != nil {
+			return err
+		}
+		if changeRequest.Status != domain.ChangeRequestStatusPending {
+			return domain.NewConflictError(fmt.Sprintf("change request %d is already %s", id, changeRequest.Status), nil)
+		}
+
+		// The change is written in this transaction, so it only sticks if the review does
+		if apply != nil {
+			if err := apply(helpers.WithTransaction(ctx, tx), &changeRequest); err != nil {
+				return err
+			}
+		}
+
+		reviewer := logging.GetUserID(ctx)
+		err = tx.Model(&domain.ChangeRequest{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"status":      status,
+			"reviewed_by": reviewer,
+			"reviewed_at": time.Now(),
+			"version":     gorm.Expr("version + 1"),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if comment != "" {
+			err = tx.Create(&domain.ChangeRequestComment{ChangeRequestID: int64(id), Author: reviewer, Body: comment}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		result = &domain.ChangeRequest{}
+		return tx.Preload("Comments", orderedComments).First(result, "id = ?", id).Error
+	})
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to review change request", zap.Int("change_request.id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return
+}
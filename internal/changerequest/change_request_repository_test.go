@@ -0,0 +1,264 @@
+package changerequest
+
+import (
+	"context"
+	"errors"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type ChangeRequestRepositorySuite struct {
+	suite.Suite
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+	repo *changeRequestRepository
+}
+
+func TestChangeRequestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(ChangeRequestRepositorySuite))
+}
+
+func (s *ChangeRequestRepositorySuite) SetupTest() {
+	var err error
+	s.db, s.mock, err = helpers.NewMockDB()
+	if err != nil {
+		s.T().Fatal()
+	}
+
+	logger, _ := logging.NewDevelopmentLogger()
+	s.repo = NewChangeRequestRepository(s.db, logger)
+}
+
+var changeRequestColumns = []string{"id", "entity_type", "entity_id", "base_version", "patch", "status", "version"}
+
+const (
+	lockChangeRequest = `SELECT * FROM "m_change_request" WHERE id = $1 AND "m_change_request"."deleted_at" IS NULL ORDER BY "m_change_request"."id" LIMIT $2 FOR UPDATE`
+	selectComments    = `SELECT * FROM "m_change_request_comment" WHERE "m_change_request_comment"."change_request_id" = $1 ORDER BY created_at, id`
+)
+
+func (s *ChangeRequestRepositorySuite) TestChangeRequestRepository_Create() {
+	s.SetupTest()
+	changeRequest := &domain.ChangeRequest{
+		EntityType:  domain.ChangeRequestEntityTraveller,
+		EntityID:    4,
+		BaseVersion: 3,
+		Patch:       []byte(`{"rarity":4}`),
+		Status:      domain.ChangeRequestStatusPending,
+	}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_change_request" ("created_by","updated_by","deleted_by","created_at","updated_at","version","deleted_at","entity_type","entity_id","base_version","patch","summary","status","reviewed_by","reviewed_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15) RETURNING "id"`)).
+		WithArgs("", "", nil, helpers.AnyTime{}, helpers.AnyTime{}, int64(1), nil, domain.ChangeRequestEntityTraveller, int64(4), int64(3), []byte(`{"rarity":4}`), "", domain.ChangeRequestStatusPending, "", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	s.mock.ExpectCommit()
+
+	err := s.repo.Create(context.TODO(), changeRequest)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(7), changeRequest.ID)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *ChangeRequestRepositorySuite) TestChangeRequestRepository_GetByID() {
+	s.Run("with comments", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_change_request" WHERE id = $1 AND "m_change_request"."deleted_at" IS NULL ORDER BY "m_change_request"."id" LIMIT $2`)).
+			WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows(changeRequestColumns).AddRow(7, "traveller", 4, 3, []byte(`{"rarity":4}`), "pending", 1))
+		s.mock.ExpectQuery(regexp.QuoteMeta(selectComments)).
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "change_request_id", "author", "body"}).
+				AddRow(1, 7, "alfyn", "Source: the launch announcement").
+				AddRow(2, 7, "isla", "Thanks"))
+
+		res, err := s.repo.GetByID(context.TODO(), 7)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(4), res.EntityID)
+		assert.Len(s.T(), res.Comments, 2)
+		assert.Equal(s.T(), "alfyn", res.Comments[0].Author)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("not found", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_change_request"`)).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		res, err := s.repo.GetByID(context.TODO(), 7)
+		var nfe *domain.NotFoundError
+		assert.ErrorAs(s.T(), err, &nfe)
+		assert.Nil(s.T(), res)
+	})
+}
+
+func (s *ChangeRequestRepositorySuite) TestChangeRequestRepository_GetList() {
+	s.Run("filtered", func() {
+		s.SetupTest()
+		where := `WHERE status = $1 AND entity_type = $2 AND entity_id = $3 AND "m_change_request"."deleted_at" IS NULL`
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "m_change_request" `+where)).
+			WithArgs("pending", "traveller", int64(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_change_request" `+where+` ORDER BY created_at, id LIMIT $4 OFFSET $5`)).
+			WithArgs("pending", "traveller", int64(4), 2, 2).
+			WillReturnRows(sqlmock.NewRows(changeRequestColumns).AddRow(9, "traveller", 4, 3, []byte(`{}`), "pending", 1))
+
+		res, total, err := s.repo.GetList(context.TODO(), domain.ListChangeRequestRequest{Status: "pending", EntityType: "traveller", EntityID: 4}, 2, 2)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(3), total)
+		assert.Len(s.T(), res, 1)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("count failed", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "m_change_request" WHERE "m_change_request"."deleted_at" IS NULL`)).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, _, err := s.repo.GetList(context.TODO(), domain.ListChangeRequestRequest{}, 0, 10)
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+	})
+}
+
+func (s *ChangeRequestRepositorySuite) TestChangeRequestRepository_AddComment() {
+	s.Run("success", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "m_change_request" WHERE id = $1`)).
+			WithArgs(int64(7), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_change_request_comment" ("change_request_id","author","body","created_at") VALUES ($1,$2,$3,$4) RETURNING "id"`)).
+			WithArgs(int64(7), "alfyn", "Source: the launch announcement", helpers.AnyTime{}).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+		s.mock.ExpectCommit()
+
+		comment := &domain.ChangeRequestComment{ChangeRequestID: 7, Body: "Source: the launch announcement"}
+		err := s.repo.AddComment(logging.WithUserID(context.TODO(), "alfyn"), comment)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(3), comment.ID)
+		assert.Equal(s.T(), "alfyn", comment.Author)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("change request not found", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "m_change_request"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		s.mock.ExpectRollback()
+
+		err := s.repo.AddComment(context.TODO(), &domain.ChangeRequestComment{ChangeRequestID: 7, Body: "?"})
+		var nfe *domain.NotFoundError
+		assert.ErrorAs(s.T(), err, &nfe)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *ChangeRequestRepositorySuite) TestChangeRequestRepository_Review() {
+	ctx := logging.WithUserID(context.TODO(), "isla")
+
+	s.Run("approved with a comment", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(lockChangeRequest)).
+			WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows(changeRequestColumns).AddRow(7, "traveller", 4, 3, []byte(`{"rarity":4}`), "pending", 1))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_change_request" SET "reviewed_at"=$1,"reviewed_by"=$2,"status"=$3,"version"=version + 1,"updated_at"=$4 WHERE id = $5 AND "m_change_request"."deleted_at" IS NULL`)).
+			WithArgs(helpers.AnyTime{}, "isla", domain.ChangeRequestStatusApproved, helpers.AnyTime{}, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_change_request_comment"`)).
+			WithArgs(int64(7), "isla", "Matches the announcement", helpers.AnyTime{}).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_change_request" WHERE id = $1`)).
+			WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows(changeRequestColumns).AddRow(7, "traveller", 4, 3, []byte(`{"rarity":4}`), "approved", 2))
+		s.mock.ExpectQuery(regexp.QuoteMeta(selectComments)).
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "change_request_id", "author", "body"}).AddRow(4, 7, "isla", "Matches the announcement"))
+		s.mock.ExpectCommit()
+
+		var applied *domain.ChangeRequest
+		res, err := s.repo.Review(ctx, 7, domain.ChangeRequestStatusApproved, "Matches the announcement",
+			func(ctx context.Context, changeRequest *domain.ChangeRequest) error {
+				applied = changeRequest
+				return nil
+			})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(3), applied.BaseVersion)
+		assert.Equal(s.T(), domain.ChangeRequestStatusApproved, res.Status)
+		assert.Len(s.T(), res.Comments, 1)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("already reviewed", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(lockChangeRequest)).
+			WillReturnRows(sqlmock.NewRows(changeRequestColumns).AddRow(7, "traveller", 4, 3, []byte(`{}`), "rejected", 2))
+		s.mock.ExpectRollback()
+
+		res, err := s.repo.Review(ctx, 7, domain.ChangeRequestStatusApproved, "", func(context.Context, *domain.ChangeRequest) error {
+			s.T().Fatal("applied a reviewed change request")
+			return nil
+		})
+		assert.EqualError(s.T(), err, "change request 7 is already rejected")
+		assert.Nil(s.T(), res)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("apply failed leaves it pending", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(lockChangeRequest)).
+			WillReturnRows(sqlmock.NewRows(changeRequestColumns).AddRow(7, "traveller", 4, 3, []byte(`{}`), "pending", 1))
+		s.mock.ExpectRollback()
+
+		stale := errors.New("stale")
+		res, err := s.repo.Review(ctx, 7, domain.ChangeRequestStatusApproved, "", func(context.Context, *domain.ChangeRequest) error {
+			return stale
+		})
+		assert.ErrorIs(s.T(), err, stale)
+		assert.Nil(s.T(), res)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("status update failed rolls back the applied change", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(lockChangeRequest)).
+			WillReturnRows(sqlmock.NewRows(changeRequestColumns).AddRow(7, "traveller", 4, 3, []byte(`{"rarity":4}`), "pending", 1))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "rarity"=$1`)).
+			WithArgs(4, 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_change_request"`)).
+			WillReturnError(gorm.ErrInvalidDB)
+		s.mock.ExpectRollback()
+
+		res, err := s.repo.Review(ctx, 7, domain.ChangeRequestStatusApproved, "", func(ctx context.Context, changeRequest *domain.ChangeRequest) error {
+			// Written as a repository would, joining the review's transaction
+			return helpers.Conn(ctx, s.db).Table("m_traveller").Where("id = ?", changeRequest.EntityID).Update("rarity", 4).Error
+		})
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+		assert.Nil(s.T(), res)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("not found", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(lockChangeRequest)).
+			WillReturnRows(sqlmock.NewRows(changeRequestColumns))
+		s.mock.ExpectRollback()
+
+		_, err := s.repo.Review(ctx, 7, domain.ChangeRequestStatusRejected, "", nil)
+		var nfe *domain.NotFoundError
+		assert.ErrorAs(s.T(), err, &nfe)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
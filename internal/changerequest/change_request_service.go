@@ -0,0 +1,342 @@
+package changerequest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"lizobly/ctc-db-api/pkg/constants"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"lizobly/ctc-db-api/pkg/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ChangeRequestRepository interface {
+	Create(ctx context.Context, input *domain.ChangeRequest) (err error)
+	GetByID(ctx context.Context, id int) (result *domain.ChangeRequest, err error)
+	GetList(ctx context.Context, filter domain.ListChangeRequestRequest, offset, limit int) (result []domain.ChangeRequest, total int64, err error)
+	AddComment(ctx context.Context, comment *domain.ChangeRequestComment) (err error)
+	Review(ctx context.Context, id int, status, comment string, apply func(ctx context.Context, changeRequest *domain.ChangeRequest) error) (result *domain.ChangeRequest, err error)
+}
+
+// TravellerService reads and writes the travellers change requests are applied to
+type TravellerService interface {
+	GetByID(ctx context.Context, id int, include domain.TravellerInclude) (res *domain.Traveller, err error)
+	Patch(ctx context.Context, id int, input domain.UpdateTravellerRequest) (res *domain.Traveller, err error)
+}
+
+// AccessoryService reads and writes the accessories change requests are applied to. Accessories
+// are written on their own, so one no traveller owns can be changed too.
+type AccessoryService interface {
+	GetByID(ctx context.Context, id int) (res *domain.Accessory, err error)
+	Patch(ctx context.Context, id int, input domain.UpdateAccessoryRequest) (res *domain.Accessory, err error)
+}
+
+// Validator checks the documents change requests propose, as handlers check request bodies
+type Validator interface {
+	Validate(i interface{}) error
+}
+
+// changeRequestService takes proposed changes to travellers and accessories and lets editors
+// review them. Approved changes are applied with the traveller or accessory service's Patch, so
+// they go through the same validation, revisions and version checks as direct edits.
+type changeRequestService struct {
+	changeRequestRepo ChangeRequestRepository
+	travellerService  TravellerService
+	accessoryService  AccessoryService
+	validator         Validator
+	logger            *logging.Logger
+}
+
+func NewChangeRequestService(r ChangeRequestRepository, t TravellerService, a AccessoryService, v Validator, logger *logging.Logger) *changeRequestService {
+	return &changeRequestService{
+		changeRequestRepo: r,
+		travellerService:  t,
+		accessoryService:  a,
+		validator:         v,
+		logger:            logger.Named("service.change_request"),
+	}
+}
+
+// target is the record a change request is against, as it is now
+type target struct {
+	entityType string
+	id         int
+	version    int64
+	// document is the record's update document, the one the patch applies to
+	document []byte
+}
+
+func (s *changeRequestService) loadTarget(ctx context.Context, entityType string, id int) (t target, err error) {
+	t = target{entityType: entityType, id: id}
+	switch entityType {
+	case domain.ChangeRequestEntityTraveller:
+		traveller, getErr := s.travellerService.GetByID(ctx, id, domain.TravellerIncludeAll)
+		if getErr != nil {
+			return t, getErr
+		}
+		t.version = traveller.Version
+		t.document, err = json.Marshal(domain.ToUpdateTravellerRequest(traveller))
+		return
+	case domain.ChangeRequestEntityAccessory:
+		accessory, getErr := s.accessoryService.GetByID(ctx, id)
+		if getErr != nil {
+			return t, getErr
+		}
+		t.version = accessory.Version
+		t.document, err = json.Marshal(domain.ToUpdateAccessoryRequest(accessory))
+		return
+	default:
+		return t, fmt.Errorf("unknown change request entity type %q", entityType)
+	}
+}
+
+// propose applies a patch to the target's document and checks the result, returning the
+// proposed document and a write that applies it to the record
+func (s *changeRequestService) propose(t target, patch []byte) (proposed []byte, write func(ctx context.Context) error, err error) {
+	proposed, err = helpers.ApplyPatch(helpers.MIMEMergePatch, t.document, patch)
+	if err != nil {
+		return nil, nil, patchError(err.Error())
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(proposed))
+	decoder.DisallowUnknownFields()
+	// The change was checked against this version of the record, so only write if it is still current
+	switch t.entityType {
+	case domain.ChangeRequestEntityTraveller:
+		var update domain.UpdateTravellerRequest
+		if err = decoder.Decode(&update); err != nil {
+			return nil, nil, patchError("patched document is not a valid traveller")
+		}
+		if err = s.validator.Validate(&update); err != nil {
+			return nil, nil, err
+		}
+		update.Version = t.version
+		write = func(ctx context.Context) error {
+			_, err := s.travellerService.Patch(ctx, t.id, update)
+			return err
+		}
+	case domain.ChangeRequestEntityAccessory:
+		var update domain.UpdateAccessoryRequest
+		if err = decoder.Decode(&update); err != nil {
+			return nil, nil, patchError("patched document is not a valid accessory")
+		}
+		if err = s.validator.Validate(&update); err != nil {
+			return nil, nil, err
+		}
+		update.Version = t.version
+		write = func(ctx context.Context) error {
+			_, err := s.accessoryService.Patch(ctx, t.id, update)
+			return err
+		}
+	default:
+		return nil, nil, fmt.Errorf("unknown change request entity type %q", t.entityType)
+	}
+
+	return proposed, write, nil
+}
+
+func patchError(message string) error {
+	return domain.NewValidationError([]domain.FieldError{{Field: "patch", Message: message}})
+}
+
+// staleError reports that a change request's record changed since the change was proposed
+func staleError(changeRequest *domain.ChangeRequest) error {
+	return domain.NewConflictError(fmt.Sprintf("the %s changed since change request %d was made, so it can no longer be approved; propose the change again",
+		changeRequest.EntityType, changeRequest.ID), nil)
+}
+
+// Create proposes a change to the record as it is now. input.Version, taken from If-Match,
+// makes the proposal conditional on the version the proposer saw.
+func (s *changeRequestService) Create(ctx context.Context, input domain.CreateChangeRequestRequest) (res domain.ChangeRequestResponse, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.change_request", "ChangeRequestService.Create",
+		attribute.String("change_request.entity_type", input.EntityType),
+		attribute.Int64("change_request.entity_id", input.EntityID),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	t, err := s.loadTarget(ctx, input.EntityType, int(input.EntityID))
+	if err != nil {
+		return
+	}
+	if input.Version != 0 && input.Version != t.version {
+		err = domain.NewPreconditionFailedError(constants.MessagePreconditionFailed, nil)
+		return
+	}
+
+	proposed, _, err := s.propose(t, input.Patch)
+	if err != nil {
+		return
+	}
+	changes, err := domain.DocumentChanges(t.document, proposed)
+	if err != nil {
+		return
+	}
+	if len(changes) == 0 {
+		err = patchError(fmt.Sprintf("patch doesn't change the %s", input.EntityType))
+		return
+	}
+
+	changeRequest := &domain.ChangeRequest{
+		EntityType:  input.EntityType,
+		EntityID:    input.EntityID,
+		BaseVersion: t.version,
+		Patch:       input.Patch,
+		Summary:     input.Summary,
+		Status:      domain.ChangeRequestStatusPending,
+	}
+	err = s.changeRequestRepo.Create(ctx, changeRequest)
+	if err != nil {
+		return
+	}
+
+	res = domain.ToChangeRequestResponse(*changeRequest)
+	res.CurrentVersion = t.version
+	res.Changes = changes
+	return
+}
+
+// GetByID returns a change request with its discussion. A pending one also gets the changes it
+// would make to the record as it is now, and whether the record changed since the proposal.
+func (s *changeRequestService) GetByID(ctx context.Context, id int) (res domain.ChangeRequestResponse, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.change_request", "ChangeRequestService.GetByID",
+		attribute.Int("change_request.id", id),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	changeRequest, err := s.changeRequestRepo.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	res = domain.ToChangeRequestResponse(*changeRequest)
+	if changeRequest.Status != domain.ChangeRequestStatusPending {
+		return
+	}
+
+	t, err := s.loadTarget(ctx, changeRequest.EntityType, int(changeRequest.EntityID))
+	var nfe *domain.NotFoundError
+	if errors.As(err, &nfe) {
+		// The record is gone, so the change can't be applied any more
+		res.Stale = true
+		return res, nil
+	}
+	if err != nil {
+		return
+	}
+	res.CurrentVersion = t.version
+	res.Stale = t.version != changeRequest.BaseVersion
+
+	proposed, err := helpers.ApplyPatch(helpers.MIMEMergePatch, t.document, changeRequest.Patch)
+	if err != nil {
+		return
+	}
+	res.Changes, err = domain.DocumentChanges(t.document, proposed)
+	return
+}
+
+// List returns one page of change requests, oldest first
+func (s *changeRequestService) List(ctx context.Context, input domain.ListChangeRequestRequest, params helpers.PaginationParams) (res helpers.PaginatedResponse[domain.ChangeRequestListItemResponse], err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.change_request", "ChangeRequestService.List",
+		attribute.String("change_request.status", input.Status),
+		attribute.Int("page", params.Page),
+		attribute.Int("page_size", params.PageSize),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	params.Normalize()
+
+	changeRequests, total, err := s.changeRequestRepo.GetList(ctx, input, params.Offset(), params.PageSize)
+	if err != nil {
+		return
+	}
+
+	items := make([]domain.ChangeRequestListItemResponse, len(changeRequests))
+	for i, changeRequest := range changeRequests {
+		items[i] = domain.ToChangeRequestListItemResponse(changeRequest)
+	}
+
+	res = helpers.NewPaginatedResponse(items, params, total)
+	return
+}
+
+// Comment adds a comment to a change request's discussion
+func (s *changeRequestService) Comment(ctx context.Context, id int, input domain.CommentChangeRequestRequest) (res domain.ChangeRequestCommentResponse, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.change_request", "ChangeRequestService.Comment",
+		attribute.Int("change_request.id", id),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	comment := &domain.ChangeRequestComment{ChangeRequestID: int64(id), Body: input.Body}
+	err = s.changeRequestRepo.AddComment(ctx, comment)
+	if err != nil {
+		return
+	}
+
+	res = domain.ChangeRequestCommentResponse{
+		ID:        comment.ID,
+		Author:    comment.Author,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+	}
+	return
+}
+
+// Approve applies a pending change request and closes it in one transaction. It fails with a
+// conflict, leaving the change request pending, if the record changed since the change was
+// proposed.
+func (s *changeRequestService) Approve(ctx context.Context, id int, input domain.ReviewChangeRequestRequest) (res domain.ChangeRequestResponse, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.change_request", "ChangeRequestService.Approve",
+		attribute.Int("change_request.id", id),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	changeRequest, err := s.changeRequestRepo.Review(ctx, id, domain.ChangeRequestStatusApproved, input.Comment,
+		func(ctx context.Context, changeRequest *domain.ChangeRequest) error {
+			t, err := s.loadTarget(ctx, changeRequest.EntityType, int(changeRequest.EntityID))
+			if err != nil {
+				return err
+			}
+			if t.version != changeRequest.BaseVersion {
+				return staleError(changeRequest)
+			}
+
+			_, write, err := s.propose(t, changeRequest.Patch)
+			if err != nil {
+				return err
+			}
+
+			err = write(ctx)
+			var pfe *domain.PreconditionFailedError
+			if errors.As(err, &pfe) {
+				// Another write got in between reading the record and patching it
+				return staleError(changeRequest)
+			}
+			return err
+		})
+	if err != nil {
+		return
+	}
+
+	res = domain.ToChangeRequestResponse(*changeRequest)
+	return
+}
+
+// Reject closes a pending change request without applying it
+func (s *changeRequestService) Reject(ctx context.Context, id int, input domain.ReviewChangeRequestRequest) (res domain.ChangeRequestResponse, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.change_request", "ChangeRequestService.Reject",
+		attribute.Int("change_request.id", id),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	changeRequest, err := s.changeRequestRepo.Review(ctx, id, domain.ChangeRequestStatusRejected, input.Comment, nil)
+	if err != nil {
+		return
+	}
+
+	res = domain.ToChangeRequestResponse(*changeRequest)
+	return
+}
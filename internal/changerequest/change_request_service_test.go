@@ -0,0 +1,375 @@
+package changerequest
+
+import (
+	"context"
+	"encoding/json"
+	"lizobly/ctc-db-api/internal/changerequest/mocks"
+	"lizobly/ctc-db-api/pkg/constants"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"lizobly/ctc-db-api/pkg/validator"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type ChangeRequestServiceSuite struct {
+	suite.Suite
+	changeRequestRepo *mocks.MockChangeRequestRepository
+	travellerService  *mocks.MockTravellerService
+	accessoryService  *mocks.MockAccessoryService
+	svc               *changeRequestService
+}
+
+func TestChangeRequestServiceSuite(t *testing.T) {
+	suite.Run(t, new(ChangeRequestServiceSuite))
+}
+
+func (s *ChangeRequestServiceSuite) SetupTest() {
+	logger, _ := logging.NewDevelopmentLogger()
+	v, err := validator.NewValidator()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	s.changeRequestRepo = new(mocks.MockChangeRequestRepository)
+	s.travellerService = new(mocks.MockTravellerService)
+	s.accessoryService = new(mocks.MockAccessoryService)
+	s.svc = NewChangeRequestService(s.changeRequestRepo, s.travellerService, s.accessoryService, v, logger)
+}
+
+func (s *ChangeRequestServiceSuite) TearDownTest() {
+	s.changeRequestRepo.AssertExpectations(s.T())
+	s.travellerService.AssertExpectations(s.T())
+	s.accessoryService.AssertExpectations(s.T())
+}
+
+func viola(version int64) *domain.Traveller {
+	accessoryID := 9
+	return &domain.Traveller{
+		CommonModel: domain.CommonModel{ID: 4, Version: version},
+		Name:        "Viola",
+		Rarity:      5,
+		ReleaseDate: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+		InfluenceID: constants.InfluencePowerID,
+		JobID:       constants.JobMerchantID,
+		AccessoryID: &accessoryID,
+		Accessory: &domain.Accessory{
+			CommonModel: domain.CommonModel{ID: 9, Version: 2},
+			Name:        "Crimson Cloak",
+			HP:          100,
+			Publication: domain.Publication{Status: domain.PublishStatusPublished},
+		},
+		Publication: domain.Publication{Status: domain.PublishStatusPublished},
+	}
+}
+
+// crimsonCloak is an accessory no traveller owns
+func crimsonCloak(version int64) *domain.Accessory {
+	return &domain.Accessory{
+		CommonModel: domain.CommonModel{ID: 9, Version: version},
+		Name:        "Crimson Cloak",
+		HP:          100,
+		Publication: domain.Publication{Status: domain.PublishStatusPublished},
+	}
+}
+
+// pendingChangeRequest is a change request being reviewed by the apply callback
+func pendingChangeRequest(entityType string, entityID, baseVersion int64, patch string) *domain.ChangeRequest {
+	return &domain.ChangeRequest{
+		CommonModel: domain.CommonModel{ID: 7},
+		EntityType:  entityType,
+		EntityID:    entityID,
+		BaseVersion: baseVersion,
+		Patch:       []byte(patch),
+		Status:      domain.ChangeRequestStatusPending,
+	}
+}
+
+// runApply makes Review run its apply callback against the change request, returning the
+// reviewed change request if the callback succeeds
+func (s *ChangeRequestServiceSuite) runApply(changeRequest *domain.ChangeRequest, status string) {
+	s.changeRequestRepo.On("Review", mock.Anything, 7, status, "", mock.Anything).
+		Return(func(ctx context.Context, id int, status, comment string, apply func(context.Context, *domain.ChangeRequest) error) (*domain.ChangeRequest, error) {
+			if err := apply(ctx, changeRequest); err != nil {
+				return nil, err
+			}
+			reviewed := *changeRequest
+			reviewed.Status = status
+			return &reviewed, nil
+		}).Once()
+}
+
+func (s *ChangeRequestServiceSuite) TestChangeRequestService_Create() {
+	s.Run("traveller", func() {
+		s.SetupTest()
+		s.travellerService.On("GetByID", mock.Anything, 4, domain.TravellerIncludeAll).Return(viola(3), nil).Once()
+		s.changeRequestRepo.On("Create", mock.Anything, mock.MatchedBy(func(cr *domain.ChangeRequest) bool {
+			return cr.EntityType == domain.ChangeRequestEntityTraveller && cr.EntityID == 4 && cr.BaseVersion == 3 &&
+				cr.Status == domain.ChangeRequestStatusPending && string(cr.Patch) == `{"rarity":4}`
+		})).Return(nil).Once()
+
+		res, err := s.svc.Create(context.TODO(), domain.CreateChangeRequestRequest{
+			EntityType: domain.ChangeRequestEntityTraveller,
+			EntityID:   4,
+			Patch:      json.RawMessage(`{"rarity":4}`),
+			Version:    3,
+		})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(3), res.CurrentVersion)
+		assert.Equal(s.T(), []domain.FieldChange{{Field: "rarity", Before: float64(5), After: float64(4)}}, res.Changes)
+	})
+
+	s.Run("accessory", func() {
+		s.SetupTest()
+		s.accessoryService.On("GetByID", mock.Anything, 9).Return(crimsonCloak(2), nil).Once()
+		s.changeRequestRepo.On("Create", mock.Anything, mock.MatchedBy(func(cr *domain.ChangeRequest) bool {
+			return cr.EntityType == domain.ChangeRequestEntityAccessory && cr.EntityID == 9 && cr.BaseVersion == 2
+		})).Return(nil).Once()
+
+		res, err := s.svc.Create(context.TODO(), domain.CreateChangeRequestRequest{
+			EntityType: domain.ChangeRequestEntityAccessory,
+			EntityID:   9,
+			Patch:      json.RawMessage(`{"hp":150}`),
+		})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), []domain.FieldChange{{Field: "hp", Before: float64(100), After: float64(150)}}, res.Changes)
+	})
+
+	s.Run("changed since If-Match", func() {
+		s.SetupTest()
+		s.travellerService.On("GetByID", mock.Anything, 4, domain.TravellerIncludeAll).Return(viola(4), nil).Once()
+
+		_, err := s.svc.Create(context.TODO(), domain.CreateChangeRequestRequest{
+			EntityType: domain.ChangeRequestEntityTraveller,
+			EntityID:   4,
+			Patch:      json.RawMessage(`{"rarity":4}`),
+			Version:    3,
+		})
+		var pfe *domain.PreconditionFailedError
+		assert.ErrorAs(s.T(), err, &pfe)
+	})
+
+	s.Run("patch changes nothing", func() {
+		s.SetupTest()
+		s.travellerService.On("GetByID", mock.Anything, 4, domain.TravellerIncludeAll).Return(viola(3), nil).Once()
+
+		_, err := s.svc.Create(context.TODO(), domain.CreateChangeRequestRequest{
+			EntityType: domain.ChangeRequestEntityTraveller,
+			EntityID:   4,
+			Patch:      json.RawMessage(`{"rarity":5}`),
+		})
+		var ve *domain.ValidationError
+		assert.ErrorAs(s.T(), err, &ve)
+	})
+
+	s.Run("patch leaves an invalid traveller", func() {
+		s.SetupTest()
+		s.travellerService.On("GetByID", mock.Anything, 4, domain.TravellerIncludeAll).Return(viola(3), nil).Once()
+
+		_, err := s.svc.Create(context.TODO(), domain.CreateChangeRequestRequest{
+			EntityType: domain.ChangeRequestEntityTraveller,
+			EntityID:   4,
+			Patch:      json.RawMessage(`{"rarity":9}`),
+		})
+		assert.Error(s.T(), err)
+	})
+
+	s.Run("unknown field", func() {
+		s.SetupTest()
+		s.travellerService.On("GetByID", mock.Anything, 4, domain.TravellerIncludeAll).Return(viola(3), nil).Once()
+
+		_, err := s.svc.Create(context.TODO(), domain.CreateChangeRequestRequest{
+			EntityType: domain.ChangeRequestEntityTraveller,
+			EntityID:   4,
+			Patch:      json.RawMessage(`{"hidden":true}`),
+		})
+		var ve *domain.ValidationError
+		assert.ErrorAs(s.T(), err, &ve)
+	})
+
+	s.Run("traveller not found", func() {
+		s.SetupTest()
+		s.travellerService.On("GetByID", mock.Anything, 4, domain.TravellerIncludeAll).Return(nil, domain.NewNotFoundError("traveller", 4, nil)).Once()
+
+		_, err := s.svc.Create(context.TODO(), domain.CreateChangeRequestRequest{
+			EntityType: domain.ChangeRequestEntityTraveller,
+			EntityID:   4,
+			Patch:      json.RawMessage(`{"rarity":4}`),
+		})
+		var nfe *domain.NotFoundError
+		assert.ErrorAs(s.T(), err, &nfe)
+	})
+}
+
+func (s *ChangeRequestServiceSuite) TestChangeRequestService_GetByID() {
+	s.Run("pending, record unchanged", func() {
+		s.SetupTest()
+		s.changeRequestRepo.On("GetByID", mock.Anything, 7).Return(pendingChangeRequest(domain.ChangeRequestEntityTraveller, 4, 3, `{"rarity":4}`), nil).Once()
+		s.travellerService.On("GetByID", mock.Anything, 4, domain.TravellerIncludeAll).Return(viola(3), nil).Once()
+
+		res, err := s.svc.GetByID(context.TODO(), 7)
+		assert.NoError(s.T(), err)
+		assert.False(s.T(), res.Stale)
+		assert.Equal(s.T(), int64(3), res.CurrentVersion)
+		assert.Equal(s.T(), []domain.FieldChange{{Field: "rarity", Before: float64(5), After: float64(4)}}, res.Changes)
+	})
+
+	s.Run("pending, record changed since", func() {
+		s.SetupTest()
+		s.changeRequestRepo.On("GetByID", mock.Anything, 7).Return(pendingChangeRequest(domain.ChangeRequestEntityTraveller, 4, 3, `{"rarity":4}`), nil).Once()
+		s.travellerService.On("GetByID", mock.Anything, 4, domain.TravellerIncludeAll).Return(viola(5), nil).Once()
+
+		res, err := s.svc.GetByID(context.TODO(), 7)
+		assert.NoError(s.T(), err)
+		assert.True(s.T(), res.Stale)
+		assert.Equal(s.T(), int64(5), res.CurrentVersion)
+	})
+
+	s.Run("pending, record deleted", func() {
+		s.SetupTest()
+		s.changeRequestRepo.On("GetByID", mock.Anything, 7).Return(pendingChangeRequest(domain.ChangeRequestEntityTraveller, 4, 3, `{"rarity":4}`), nil).Once()
+		s.travellerService.On("GetByID", mock.Anything, 4, domain.TravellerIncludeAll).Return(nil, domain.NewNotFoundError("traveller", 4, nil)).Once()
+
+		res, err := s.svc.GetByID(context.TODO(), 7)
+		assert.NoError(s.T(), err)
+		assert.True(s.T(), res.Stale)
+		assert.Empty(s.T(), res.Changes)
+	})
+
+	s.Run("reviewed", func() {
+		s.SetupTest()
+		changeRequest := pendingChangeRequest(domain.ChangeRequestEntityTraveller, 4, 3, `{"rarity":4}`)
+		changeRequest.Status = domain.ChangeRequestStatusApproved
+		s.changeRequestRepo.On("GetByID", mock.Anything, 7).Return(changeRequest, nil).Once()
+
+		res, err := s.svc.GetByID(context.TODO(), 7)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), domain.ChangeRequestStatusApproved, res.Status)
+		assert.Empty(s.T(), res.Changes)
+	})
+}
+
+func (s *ChangeRequestServiceSuite) TestChangeRequestService_List() {
+	s.SetupTest()
+	filter := domain.ListChangeRequestRequest{Status: domain.ChangeRequestStatusPending}
+	s.changeRequestRepo.On("GetList", mock.Anything, filter, 5, 5).
+		Return([]domain.ChangeRequest{*pendingChangeRequest(domain.ChangeRequestEntityTraveller, 4, 3, `{}`)}, int64(6), nil).Once()
+
+	res, err := s.svc.List(context.TODO(), filter, helpers.PaginationParams{Page: 2, PageSize: 5})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(6), res.Total)
+	assert.Len(s.T(), res.Data, 1)
+	assert.Equal(s.T(), int64(7), res.Data[0].ID)
+}
+
+func (s *ChangeRequestServiceSuite) TestChangeRequestService_Approve() {
+	s.Run("traveller", func() {
+		s.SetupTest()
+		s.runApply(pendingChangeRequest(domain.ChangeRequestEntityTraveller, 4, 3, `{"rarity":4}`), domain.ChangeRequestStatusApproved)
+		s.travellerService.On("GetByID", mock.Anything, 4, domain.TravellerIncludeAll).Return(viola(3), nil).Once()
+		s.travellerService.On("Patch", mock.Anything, 4, mock.MatchedBy(func(update domain.UpdateTravellerRequest) bool {
+			return update.Rarity == 4 && update.Name == "Viola" && update.Version == 3
+		})).Return(viola(4), nil).Once()
+
+		res, err := s.svc.Approve(context.TODO(), 7, domain.ReviewChangeRequestRequest{})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), domain.ChangeRequestStatusApproved, res.Status)
+	})
+
+	s.Run("accessory is written on its own", func() {
+		s.SetupTest()
+		s.runApply(pendingChangeRequest(domain.ChangeRequestEntityAccessory, 9, 2, `{"hp":150}`), domain.ChangeRequestStatusApproved)
+		s.accessoryService.On("GetByID", mock.Anything, 9).Return(crimsonCloak(2), nil).Once()
+		s.accessoryService.On("Patch", mock.Anything, 9, mock.MatchedBy(func(update domain.UpdateAccessoryRequest) bool {
+			return update.HP == 150 && update.Name == "Crimson Cloak" && update.Version == 2
+		})).Return(crimsonCloak(3), nil).Once()
+
+		_, err := s.svc.Approve(context.TODO(), 7, domain.ReviewChangeRequestRequest{})
+		assert.NoError(s.T(), err)
+	})
+
+	s.Run("accessory changed while applying", func() {
+		s.SetupTest()
+		s.runApply(pendingChangeRequest(domain.ChangeRequestEntityAccessory, 9, 2, `{"hp":150}`), domain.ChangeRequestStatusApproved)
+		s.accessoryService.On("GetByID", mock.Anything, 9).Return(crimsonCloak(2), nil).Once()
+		s.accessoryService.On("Patch", mock.Anything, 9, mock.Anything).
+			Return(nil, domain.NewPreconditionFailedError(constants.MessagePreconditionFailed, nil)).Once()
+
+		_, err := s.svc.Approve(context.TODO(), 7, domain.ReviewChangeRequestRequest{})
+		var ce *domain.ConflictError
+		assert.ErrorAs(s.T(), err, &ce)
+	})
+
+	s.Run("record changed since the proposal", func() {
+		s.SetupTest()
+		s.runApply(pendingChangeRequest(domain.ChangeRequestEntityTraveller, 4, 3, `{"rarity":4}`), domain.ChangeRequestStatusApproved)
+		s.travellerService.On("GetByID", mock.Anything, 4, domain.TravellerIncludeAll).Return(viola(4), nil).Once()
+
+		_, err := s.svc.Approve(context.TODO(), 7, domain.ReviewChangeRequestRequest{})
+		var ce *domain.ConflictError
+		assert.ErrorAs(s.T(), err, &ce)
+	})
+
+	s.Run("record changed while applying", func() {
+		s.SetupTest()
+		s.runApply(pendingChangeRequest(domain.ChangeRequestEntityTraveller, 4, 3, `{"rarity":4}`), domain.ChangeRequestStatusApproved)
+		s.travellerService.On("GetByID", mock.Anything, 4, domain.TravellerIncludeAll).Return(viola(3), nil).Once()
+		s.travellerService.On("Patch", mock.Anything, 4, mock.Anything).
+			Return(nil, domain.NewPreconditionFailedError(constants.MessagePreconditionFailed, nil)).Once()
+
+		_, err := s.svc.Approve(context.TODO(), 7, domain.ReviewChangeRequestRequest{})
+		var ce *domain.ConflictError
+		assert.ErrorAs(s.T(), err, &ce)
+	})
+
+	s.Run("patch failed", func() {
+		s.SetupTest()
+		s.runApply(pendingChangeRequest(domain.ChangeRequestEntityTraveller, 4, 3, `{"rarity":4}`), domain.ChangeRequestStatusApproved)
+		s.travellerService.On("GetByID", mock.Anything, 4, domain.TravellerIncludeAll).Return(viola(3), nil).Once()
+		s.travellerService.On("Patch", mock.Anything, 4, mock.Anything).Return(nil, gorm.ErrInvalidDB).Once()
+
+		_, err := s.svc.Approve(context.TODO(), 7, domain.ReviewChangeRequestRequest{})
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+	})
+
+	s.Run("already reviewed", func() {
+		s.SetupTest()
+		s.changeRequestRepo.On("Review", mock.Anything, 7, domain.ChangeRequestStatusApproved, "LGTM", mock.Anything).
+			Return(nil, domain.NewConflictError("change request 7 is already rejected", nil)).Once()
+
+		_, err := s.svc.Approve(context.TODO(), 7, domain.ReviewChangeRequestRequest{Comment: "LGTM"})
+		var ce *domain.ConflictError
+		assert.ErrorAs(s.T(), err, &ce)
+	})
+}
+
+func (s *ChangeRequestServiceSuite) TestChangeRequestService_Reject() {
+	s.Run("success", func() {
+		s.SetupTest()
+		rejected := pendingChangeRequest(domain.ChangeRequestEntityTraveller, 4, 3, `{"rarity":4}`)
+		rejected.Status = domain.ChangeRequestStatusRejected
+		s.changeRequestRepo.On("Review", mock.Anything, 7, domain.ChangeRequestStatusRejected, "Not in the game files",
+			mock.MatchedBy(func(apply func(context.Context, *domain.ChangeRequest) error) bool { return apply == nil })).
+			Return(rejected, nil).Once()
+
+		res, err := s.svc.Reject(context.TODO(), 7, domain.ReviewChangeRequestRequest{Comment: "Not in the game files"})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), domain.ChangeRequestStatusRejected, res.Status)
+	})
+
+	s.Run("not found", func() {
+		s.SetupTest()
+		s.changeRequestRepo.On("Review", mock.Anything, 7, domain.ChangeRequestStatusRejected, "", mock.Anything).
+			Return(nil, domain.NewNotFoundError("change request", 7, nil)).Once()
+
+		_, err := s.svc.Reject(context.TODO(), 7, domain.ReviewChangeRequestRequest{})
+		var nfe *domain.NotFoundError
+		assert.ErrorAs(s.T(), err, &nfe)
+	})
+}
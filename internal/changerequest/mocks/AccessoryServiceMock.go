@@ -0,0 +1,181 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAccessoryService creates a new instance of MockAccessoryService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAccessoryService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAccessoryService {
+	mock := &MockAccessoryService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAccessoryService is an autogenerated mock type for the AccessoryService type
+type MockAccessoryService struct {
+	mock.Mock
+}
+
+type MockAccessoryService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAccessoryService) EXPECT() *MockAccessoryService_Expecter {
+	return &MockAccessoryService_Expecter{mock: &_m.Mock}
+}
+
+// GetByID provides a mock function for the type MockAccessoryService
+func (_mock *MockAccessoryService) GetByID(ctx context.Context, id int) (*domain.Accessory, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.Accessory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*domain.Accessory, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *domain.Accessory); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Accessory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccessoryService_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockAccessoryService_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockAccessoryService_Expecter) GetByID(ctx interface{}, id interface{}) *MockAccessoryService_GetByID_Call {
+	return &MockAccessoryService_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *MockAccessoryService_GetByID_Call) Run(run func(ctx context.Context, id int)) *MockAccessoryService_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccessoryService_GetByID_Call) Return(res *domain.Accessory, err error) *MockAccessoryService_GetByID_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockAccessoryService_GetByID_Call) RunAndReturn(run func(ctx context.Context, id int) (*domain.Accessory, error)) *MockAccessoryService_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Patch provides a mock function for the type MockAccessoryService
+func (_mock *MockAccessoryService) Patch(ctx context.Context, id int, input domain.UpdateAccessoryRequest) (*domain.Accessory, error) {
+	ret := _mock.Called(ctx, id, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 *domain.Accessory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.UpdateAccessoryRequest) (*domain.Accessory, error)); ok {
+		return returnFunc(ctx, id, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.UpdateAccessoryRequest) *domain.Accessory); ok {
+		r0 = returnFunc(ctx, id, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Accessory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, domain.UpdateAccessoryRequest) error); ok {
+		r1 = returnFunc(ctx, id, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccessoryService_Patch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Patch'
+type MockAccessoryService_Patch_Call struct {
+	*mock.Call
+}
+
+// Patch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - input domain.UpdateAccessoryRequest
+func (_e *MockAccessoryService_Expecter) Patch(ctx interface{}, id interface{}, input interface{}) *MockAccessoryService_Patch_Call {
+	return &MockAccessoryService_Patch_Call{Call: _e.mock.On("Patch", ctx, id, input)}
+}
+
+func (_c *MockAccessoryService_Patch_Call) Run(run func(ctx context.Context, id int, input domain.UpdateAccessoryRequest)) *MockAccessoryService_Patch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 domain.UpdateAccessoryRequest
+		if args[2] != nil {
+			arg2 = args[2].(domain.UpdateAccessoryRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccessoryService_Patch_Call) Return(res *domain.Accessory, err error) *MockAccessoryService_Patch_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockAccessoryService_Patch_Call) RunAndReturn(run func(ctx context.Context, id int, input domain.UpdateAccessoryRequest) (*domain.Accessory, error)) *MockAccessoryService_Patch_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,393 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockChangeRequestRepository creates a new instance of MockChangeRequestRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockChangeRequestRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockChangeRequestRepository {
+	mock := &MockChangeRequestRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockChangeRequestRepository is an autogenerated mock type for the ChangeRequestRepository type
+type MockChangeRequestRepository struct {
+	mock.Mock
+}
+
+type MockChangeRequestRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockChangeRequestRepository) EXPECT() *MockChangeRequestRepository_Expecter {
+	return &MockChangeRequestRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddComment provides a mock function for the type MockChangeRequestRepository
+func (_mock *MockChangeRequestRepository) AddComment(ctx context.Context, comment *domain.ChangeRequestComment) error {
+	ret := _mock.Called(ctx, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddComment")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.ChangeRequestComment) error); ok {
+		r0 = returnFunc(ctx, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChangeRequestRepository_AddComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddComment'
+type MockChangeRequestRepository_AddComment_Call struct {
+	*mock.Call
+}
+
+// AddComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - comment *domain.ChangeRequestComment
+func (_e *MockChangeRequestRepository_Expecter) AddComment(ctx interface{}, comment interface{}) *MockChangeRequestRepository_AddComment_Call {
+	return &MockChangeRequestRepository_AddComment_Call{Call: _e.mock.On("AddComment", ctx, comment)}
+}
+
+func (_c *MockChangeRequestRepository_AddComment_Call) Run(run func(ctx context.Context, comment *domain.ChangeRequestComment)) *MockChangeRequestRepository_AddComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.ChangeRequestComment
+		if args[1] != nil {
+			arg1 = args[1].(*domain.ChangeRequestComment)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChangeRequestRepository_AddComment_Call) Return(err error) *MockChangeRequestRepository_AddComment_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChangeRequestRepository_AddComment_Call) RunAndReturn(run func(ctx context.Context, comment *domain.ChangeRequestComment) error) *MockChangeRequestRepository_AddComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockChangeRequestRepository
+func (_mock *MockChangeRequestRepository) Create(ctx context.Context, input *domain.ChangeRequest) error {
+	ret := _mock.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.ChangeRequest) error); ok {
+		r0 = returnFunc(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChangeRequestRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockChangeRequestRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *domain.ChangeRequest
+func (_e *MockChangeRequestRepository_Expecter) Create(ctx interface{}, input interface{}) *MockChangeRequestRepository_Create_Call {
+	return &MockChangeRequestRepository_Create_Call{Call: _e.mock.On("Create", ctx, input)}
+}
+
+func (_c *MockChangeRequestRepository_Create_Call) Run(run func(ctx context.Context, input *domain.ChangeRequest)) *MockChangeRequestRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.ChangeRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.ChangeRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChangeRequestRepository_Create_Call) Return(err error) *MockChangeRequestRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChangeRequestRepository_Create_Call) RunAndReturn(run func(ctx context.Context, input *domain.ChangeRequest) error) *MockChangeRequestRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type MockChangeRequestRepository
+func (_mock *MockChangeRequestRepository) GetByID(ctx context.Context, id int) (*domain.ChangeRequest, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.ChangeRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*domain.ChangeRequest, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *domain.ChangeRequest); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ChangeRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChangeRequestRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockChangeRequestRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockChangeRequestRepository_Expecter) GetByID(ctx interface{}, id interface{}) *MockChangeRequestRepository_GetByID_Call {
+	return &MockChangeRequestRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *MockChangeRequestRepository_GetByID_Call) Run(run func(ctx context.Context, id int)) *MockChangeRequestRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChangeRequestRepository_GetByID_Call) Return(result *domain.ChangeRequest, err error) *MockChangeRequestRepository_GetByID_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockChangeRequestRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, id int) (*domain.ChangeRequest, error)) *MockChangeRequestRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetList provides a mock function for the type MockChangeRequestRepository
+func (_mock *MockChangeRequestRepository) GetList(ctx context.Context, filter domain.ListChangeRequestRequest, offset int, limit int) ([]domain.ChangeRequest, int64, error) {
+	ret := _mock.Called(ctx, filter, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetList")
+	}
+
+	var r0 []domain.ChangeRequest
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListChangeRequestRequest, int, int) ([]domain.ChangeRequest, int64, error)); ok {
+		return returnFunc(ctx, filter, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListChangeRequestRequest, int, int) []domain.ChangeRequest); ok {
+		r0 = returnFunc(ctx, filter, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChangeRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListChangeRequestRequest, int, int) int64); ok {
+		r1 = returnFunc(ctx, filter, offset, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, domain.ListChangeRequestRequest, int, int) error); ok {
+		r2 = returnFunc(ctx, filter, offset, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockChangeRequestRepository_GetList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetList'
+type MockChangeRequestRepository_GetList_Call struct {
+	*mock.Call
+}
+
+// GetList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ListChangeRequestRequest
+//   - offset int
+//   - limit int
+func (_e *MockChangeRequestRepository_Expecter) GetList(ctx interface{}, filter interface{}, offset interface{}, limit interface{}) *MockChangeRequestRepository_GetList_Call {
+	return &MockChangeRequestRepository_GetList_Call{Call: _e.mock.On("GetList", ctx, filter, offset, limit)}
+}
+
+func (_c *MockChangeRequestRepository_GetList_Call) Run(run func(ctx context.Context, filter domain.ListChangeRequestRequest, offset int, limit int)) *MockChangeRequestRepository_GetList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ListChangeRequestRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.ListChangeRequestRequest)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChangeRequestRepository_GetList_Call) Return(result []domain.ChangeRequest, total int64, err error) *MockChangeRequestRepository_GetList_Call {
+	_c.Call.Return(result, total, err)
+	return _c
+}
+
+func (_c *MockChangeRequestRepository_GetList_Call) RunAndReturn(run func(ctx context.Context, filter domain.ListChangeRequestRequest, offset int, limit int) ([]domain.ChangeRequest, int64, error)) *MockChangeRequestRepository_GetList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Review provides a mock function for the type MockChangeRequestRepository
+func (_mock *MockChangeRequestRepository) Review(ctx context.Context, id int, status string, comment string, apply func(ctx context.Context, changeRequest *domain.ChangeRequest) error) (*domain.ChangeRequest, error) {
+	ret := _mock.Called(ctx, id, status, comment, apply)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Review")
+	}
+
+	var r0 *domain.ChangeRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, string, func(ctx context.Context, changeRequest *domain.ChangeRequest) error) (*domain.ChangeRequest, error)); ok {
+		return returnFunc(ctx, id, status, comment, apply)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, string, func(ctx context.Context, changeRequest *domain.ChangeRequest) error) *domain.ChangeRequest); ok {
+		r0 = returnFunc(ctx, id, status, comment, apply)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ChangeRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string, string, func(ctx context.Context, changeRequest *domain.ChangeRequest) error) error); ok {
+		r1 = returnFunc(ctx, id, status, comment, apply)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChangeRequestRepository_Review_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Review'
+type MockChangeRequestRepository_Review_Call struct {
+	*mock.Call
+}
+
+// Review is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - status string
+//   - comment string
+//   - apply func(ctx context.Context, changeRequest *domain.ChangeRequest) error
+func (_e *MockChangeRequestRepository_Expecter) Review(ctx interface{}, id interface{}, status interface{}, comment interface{}, apply interface{}) *MockChangeRequestRepository_Review_Call {
+	return &MockChangeRequestRepository_Review_Call{Call: _e.mock.On("Review", ctx, id, status, comment, apply)}
+}
+
+func (_c *MockChangeRequestRepository_Review_Call) Run(run func(ctx context.Context, id int, status string, comment string, apply func(ctx context.Context, changeRequest *domain.ChangeRequest) error)) *MockChangeRequestRepository_Review_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 func(ctx context.Context, changeRequest *domain.ChangeRequest) error
+		if args[4] != nil {
+			arg4 = args[4].(func(ctx context.Context, changeRequest *domain.ChangeRequest) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChangeRequestRepository_Review_Call) Return(result *domain.ChangeRequest, err error) *MockChangeRequestRepository_Review_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockChangeRequestRepository_Review_Call) RunAndReturn(run func(ctx context.Context, id int, status string, comment string, apply func(ctx context.Context, changeRequest *domain.ChangeRequest) error) (*domain.ChangeRequest, error)) *MockChangeRequestRepository_Review_Call {
+	_c.Call.Return(run)
+	return _c
+}
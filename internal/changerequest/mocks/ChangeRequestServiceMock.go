@@ -0,0 +1,460 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockChangeRequestService creates a new instance of MockChangeRequestService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockChangeRequestService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockChangeRequestService {
+	mock := &MockChangeRequestService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockChangeRequestService is an autogenerated mock type for the ChangeRequestService type
+type MockChangeRequestService struct {
+	mock.Mock
+}
+
+type MockChangeRequestService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockChangeRequestService) EXPECT() *MockChangeRequestService_Expecter {
+	return &MockChangeRequestService_Expecter{mock: &_m.Mock}
+}
+
+// Approve provides a mock function for the type MockChangeRequestService
+func (_mock *MockChangeRequestService) Approve(ctx context.Context, id int, input domain.ReviewChangeRequestRequest) (domain.ChangeRequestResponse, error) {
+	ret := _mock.Called(ctx, id, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Approve")
+	}
+
+	var r0 domain.ChangeRequestResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.ReviewChangeRequestRequest) (domain.ChangeRequestResponse, error)); ok {
+		return returnFunc(ctx, id, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.ReviewChangeRequestRequest) domain.ChangeRequestResponse); ok {
+		r0 = returnFunc(ctx, id, input)
+	} else {
+		r0 = ret.Get(0).(domain.ChangeRequestResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, domain.ReviewChangeRequestRequest) error); ok {
+		r1 = returnFunc(ctx, id, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChangeRequestService_Approve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Approve'
+type MockChangeRequestService_Approve_Call struct {
+	*mock.Call
+}
+
+// Approve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - input domain.ReviewChangeRequestRequest
+func (_e *MockChangeRequestService_Expecter) Approve(ctx interface{}, id interface{}, input interface{}) *MockChangeRequestService_Approve_Call {
+	return &MockChangeRequestService_Approve_Call{Call: _e.mock.On("Approve", ctx, id, input)}
+}
+
+func (_c *MockChangeRequestService_Approve_Call) Run(run func(ctx context.Context, id int, input domain.ReviewChangeRequestRequest)) *MockChangeRequestService_Approve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 domain.ReviewChangeRequestRequest
+		if args[2] != nil {
+			arg2 = args[2].(domain.ReviewChangeRequestRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChangeRequestService_Approve_Call) Return(res domain.ChangeRequestResponse, err error) *MockChangeRequestService_Approve_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockChangeRequestService_Approve_Call) RunAndReturn(run func(ctx context.Context, id int, input domain.ReviewChangeRequestRequest) (domain.ChangeRequestResponse, error)) *MockChangeRequestService_Approve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Comment provides a mock function for the type MockChangeRequestService
+func (_mock *MockChangeRequestService) Comment(ctx context.Context, id int, input domain.CommentChangeRequestRequest) (domain.ChangeRequestCommentResponse, error) {
+	ret := _mock.Called(ctx, id, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Comment")
+	}
+
+	var r0 domain.ChangeRequestCommentResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.CommentChangeRequestRequest) (domain.ChangeRequestCommentResponse, error)); ok {
+		return returnFunc(ctx, id, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.CommentChangeRequestRequest) domain.ChangeRequestCommentResponse); ok {
+		r0 = returnFunc(ctx, id, input)
+	} else {
+		r0 = ret.Get(0).(domain.ChangeRequestCommentResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, domain.CommentChangeRequestRequest) error); ok {
+		r1 = returnFunc(ctx, id, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChangeRequestService_Comment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Comment'
+type MockChangeRequestService_Comment_Call struct {
+	*mock.Call
+}
+
+// Comment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - input domain.CommentChangeRequestRequest
+func (_e *MockChangeRequestService_Expecter) Comment(ctx interface{}, id interface{}, input interface{}) *MockChangeRequestService_Comment_Call {
+	return &MockChangeRequestService_Comment_Call{Call: _e.mock.On("Comment", ctx, id, input)}
+}
+
+func (_c *MockChangeRequestService_Comment_Call) Run(run func(ctx context.Context, id int, input domain.CommentChangeRequestRequest)) *MockChangeRequestService_Comment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 domain.CommentChangeRequestRequest
+		if args[2] != nil {
+			arg2 = args[2].(domain.CommentChangeRequestRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChangeRequestService_Comment_Call) Return(res domain.ChangeRequestCommentResponse, err error) *MockChangeRequestService_Comment_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockChangeRequestService_Comment_Call) RunAndReturn(run func(ctx context.Context, id int, input domain.CommentChangeRequestRequest) (domain.ChangeRequestCommentResponse, error)) *MockChangeRequestService_Comment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockChangeRequestService
+func (_mock *MockChangeRequestService) Create(ctx context.Context, input domain.CreateChangeRequestRequest) (domain.ChangeRequestResponse, error) {
+	ret := _mock.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.ChangeRequestResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CreateChangeRequestRequest) (domain.ChangeRequestResponse, error)); ok {
+		return returnFunc(ctx, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CreateChangeRequestRequest) domain.ChangeRequestResponse); ok {
+		r0 = returnFunc(ctx, input)
+	} else {
+		r0 = ret.Get(0).(domain.ChangeRequestResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CreateChangeRequestRequest) error); ok {
+		r1 = returnFunc(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChangeRequestService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockChangeRequestService_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input domain.CreateChangeRequestRequest
+func (_e *MockChangeRequestService_Expecter) Create(ctx interface{}, input interface{}) *MockChangeRequestService_Create_Call {
+	return &MockChangeRequestService_Create_Call{Call: _e.mock.On("Create", ctx, input)}
+}
+
+func (_c *MockChangeRequestService_Create_Call) Run(run func(ctx context.Context, input domain.CreateChangeRequestRequest)) *MockChangeRequestService_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.CreateChangeRequestRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.CreateChangeRequestRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChangeRequestService_Create_Call) Return(res domain.ChangeRequestResponse, err error) *MockChangeRequestService_Create_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockChangeRequestService_Create_Call) RunAndReturn(run func(ctx context.Context, input domain.CreateChangeRequestRequest) (domain.ChangeRequestResponse, error)) *MockChangeRequestService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type MockChangeRequestService
+func (_mock *MockChangeRequestService) GetByID(ctx context.Context, id int) (domain.ChangeRequestResponse, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.ChangeRequestResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (domain.ChangeRequestResponse, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) domain.ChangeRequestResponse); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.ChangeRequestResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChangeRequestService_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockChangeRequestService_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockChangeRequestService_Expecter) GetByID(ctx interface{}, id interface{}) *MockChangeRequestService_GetByID_Call {
+	return &MockChangeRequestService_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *MockChangeRequestService_GetByID_Call) Run(run func(ctx context.Context, id int)) *MockChangeRequestService_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChangeRequestService_GetByID_Call) Return(res domain.ChangeRequestResponse, err error) *MockChangeRequestService_GetByID_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockChangeRequestService_GetByID_Call) RunAndReturn(run func(ctx context.Context, id int) (domain.ChangeRequestResponse, error)) *MockChangeRequestService_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockChangeRequestService
+func (_mock *MockChangeRequestService) List(ctx context.Context, input domain.ListChangeRequestRequest, params helpers.PaginationParams) (helpers.PaginatedResponse[domain.ChangeRequestListItemResponse], error) {
+	ret := _mock.Called(ctx, input, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 helpers.PaginatedResponse[domain.ChangeRequestListItemResponse]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListChangeRequestRequest, helpers.PaginationParams) (helpers.PaginatedResponse[domain.ChangeRequestListItemResponse], error)); ok {
+		return returnFunc(ctx, input, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListChangeRequestRequest, helpers.PaginationParams) helpers.PaginatedResponse[domain.ChangeRequestListItemResponse]); ok {
+		r0 = returnFunc(ctx, input, params)
+	} else {
+		r0 = ret.Get(0).(helpers.PaginatedResponse[domain.ChangeRequestListItemResponse])
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListChangeRequestRequest, helpers.PaginationParams) error); ok {
+		r1 = returnFunc(ctx, input, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChangeRequestService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockChangeRequestService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input domain.ListChangeRequestRequest
+//   - params helpers.PaginationParams
+func (_e *MockChangeRequestService_Expecter) List(ctx interface{}, input interface{}, params interface{}) *MockChangeRequestService_List_Call {
+	return &MockChangeRequestService_List_Call{Call: _e.mock.On("List", ctx, input, params)}
+}
+
+func (_c *MockChangeRequestService_List_Call) Run(run func(ctx context.Context, input domain.ListChangeRequestRequest, params helpers.PaginationParams)) *MockChangeRequestService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ListChangeRequestRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.ListChangeRequestRequest)
+		}
+		var arg2 helpers.PaginationParams
+		if args[2] != nil {
+			arg2 = args[2].(helpers.PaginationParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChangeRequestService_List_Call) Return(res helpers.PaginatedResponse[domain.ChangeRequestListItemResponse], err error) *MockChangeRequestService_List_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockChangeRequestService_List_Call) RunAndReturn(run func(ctx context.Context, input domain.ListChangeRequestRequest, params helpers.PaginationParams) (helpers.PaginatedResponse[domain.ChangeRequestListItemResponse], error)) *MockChangeRequestService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reject provides a mock function for the type MockChangeRequestService
+func (_mock *MockChangeRequestService) Reject(ctx context.Context, id int, input domain.ReviewChangeRequestRequest) (domain.ChangeRequestResponse, error) {
+	ret := _mock.Called(ctx, id, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reject")
+	}
+
+	var r0 domain.ChangeRequestResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.ReviewChangeRequestRequest) (domain.ChangeRequestResponse, error)); ok {
+		return returnFunc(ctx, id, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.ReviewChangeRequestRequest) domain.ChangeRequestResponse); ok {
+		r0 = returnFunc(ctx, id, input)
+	} else {
+		r0 = ret.Get(0).(domain.ChangeRequestResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, domain.ReviewChangeRequestRequest) error); ok {
+		r1 = returnFunc(ctx, id, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChangeRequestService_Reject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reject'
+type MockChangeRequestService_Reject_Call struct {
+	*mock.Call
+}
+
+// Reject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - input domain.ReviewChangeRequestRequest
+func (_e *MockChangeRequestService_Expecter) Reject(ctx interface{}, id interface{}, input interface{}) *MockChangeRequestService_Reject_Call {
+	return &MockChangeRequestService_Reject_Call{Call: _e.mock.On("Reject", ctx, id, input)}
+}
+
+func (_c *MockChangeRequestService_Reject_Call) Run(run func(ctx context.Context, id int, input domain.ReviewChangeRequestRequest)) *MockChangeRequestService_Reject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 domain.ReviewChangeRequestRequest
+		if args[2] != nil {
+			arg2 = args[2].(domain.ReviewChangeRequestRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChangeRequestService_Reject_Call) Return(res domain.ChangeRequestResponse, err error) *MockChangeRequestService_Reject_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockChangeRequestService_Reject_Call) RunAndReturn(run func(ctx context.Context, id int, input domain.ReviewChangeRequestRequest) (domain.ChangeRequestResponse, error)) *MockChangeRequestService_Reject_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,187 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTravellerService creates a new instance of MockTravellerService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTravellerService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTravellerService {
+	mock := &MockTravellerService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTravellerService is an autogenerated mock type for the TravellerService type
+type MockTravellerService struct {
+	mock.Mock
+}
+
+type MockTravellerService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTravellerService) EXPECT() *MockTravellerService_Expecter {
+	return &MockTravellerService_Expecter{mock: &_m.Mock}
+}
+
+// GetByID provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) GetByID(ctx context.Context, id int, include domain.TravellerInclude) (*domain.Traveller, error) {
+	ret := _mock.Called(ctx, id, include)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.Traveller
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.TravellerInclude) (*domain.Traveller, error)); ok {
+		return returnFunc(ctx, id, include)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.TravellerInclude) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, id, include)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Traveller)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, domain.TravellerInclude) error); ok {
+		r1 = returnFunc(ctx, id, include)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerService_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockTravellerService_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - include domain.TravellerInclude
+func (_e *MockTravellerService_Expecter) GetByID(ctx interface{}, id interface{}, include interface{}) *MockTravellerService_GetByID_Call {
+	return &MockTravellerService_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id, include)}
+}
+
+func (_c *MockTravellerService_GetByID_Call) Run(run func(ctx context.Context, id int, include domain.TravellerInclude)) *MockTravellerService_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 domain.TravellerInclude
+		if args[2] != nil {
+			arg2 = args[2].(domain.TravellerInclude)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerService_GetByID_Call) Return(res *domain.Traveller, err error) *MockTravellerService_GetByID_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTravellerService_GetByID_Call) RunAndReturn(run func(ctx context.Context, id int, include domain.TravellerInclude) (*domain.Traveller, error)) *MockTravellerService_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Patch provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) Patch(ctx context.Context, id int, input domain.UpdateTravellerRequest) (*domain.Traveller, error) {
+	ret := _mock.Called(ctx, id, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 *domain.Traveller
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.UpdateTravellerRequest) (*domain.Traveller, error)); ok {
+		return returnFunc(ctx, id, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.UpdateTravellerRequest) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, id, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Traveller)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, domain.UpdateTravellerRequest) error); ok {
+		r1 = returnFunc(ctx, id, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerService_Patch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Patch'
+type MockTravellerService_Patch_Call struct {
+	*mock.Call
+}
+
+// Patch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - input domain.UpdateTravellerRequest
+func (_e *MockTravellerService_Expecter) Patch(ctx interface{}, id interface{}, input interface{}) *MockTravellerService_Patch_Call {
+	return &MockTravellerService_Patch_Call{Call: _e.mock.On("Patch", ctx, id, input)}
+}
+
+func (_c *MockTravellerService_Patch_Call) Run(run func(ctx context.Context, id int, input domain.UpdateTravellerRequest)) *MockTravellerService_Patch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 domain.UpdateTravellerRequest
+		if args[2] != nil {
+			arg2 = args[2].(domain.UpdateTravellerRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerService_Patch_Call) Return(res *domain.Traveller, err error) *MockTravellerService_Patch_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTravellerService_Patch_Call) RunAndReturn(run func(ctx context.Context, id int, input domain.UpdateTravellerRequest) (*domain.Traveller, error)) *MockTravellerService_Patch_Call {
+	_c.Call.Return(run)
+	return _c
+}
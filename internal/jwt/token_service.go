@@ -26,12 +26,13 @@ func NewTokenService(secretKey string, timeout time.Duration, logger *logging.Lo
 	}
 }
 
-// GenerateToken creates a new JWT token for the given username
-func (s *TokenService) GenerateToken(ctx context.Context, username string) (token string, expiresAt time.Time, err error) {
+// GenerateToken creates a new JWT token for the given username; editor tokens can read unpublished records
+func (s *TokenService) GenerateToken(ctx context.Context, username string, editor bool) (token string, expiresAt time.Time, err error) {
 	expiresAt = time.Now().Add(s.timeout)
 
 	claims := domain.JWTClaims{
 		Username: username,
+		Editor:   editor,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
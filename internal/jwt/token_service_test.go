@@ -51,7 +51,7 @@ func (s *TokenServiceSuite) TestTokenService_GenerateToken() {
 		ctx := context.Background()
 		username := "testuser"
 
-		token, expiresAt, err := s.service.GenerateToken(ctx, username)
+		token, expiresAt, err := s.service.GenerateToken(ctx, username, false)
 
 		// Verify no error
 		assert.NoError(t, err)
@@ -75,13 +75,25 @@ func (s *TokenServiceSuite) TestTokenService_GenerateToken() {
 		assert.NotNil(t, claims.ExpiresAt)
 	})
 
+	s.T().Run("carries the editor claim", func(t *testing.T) {
+		token, _, err := s.service.GenerateToken(context.Background(), "isla", true)
+		assert.NoError(t, err)
+
+		claims := &domain.JWTClaims{}
+		_, err = jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+			return []byte(s.secretKey), nil
+		})
+		assert.NoError(t, err)
+		assert.True(t, claims.Editor)
+	})
+
 	s.T().Run("generates different tokens for same username", func(t *testing.T) {
 		ctx := context.Background()
 		username := "testuser"
 
-		token1, _, err1 := s.service.GenerateToken(ctx, username)
+		token1, _, err1 := s.service.GenerateToken(ctx, username, false)
 		time.Sleep(1 * time.Second) // Ensure different expiration timestamps (JWT uses seconds)
-		token2, _, err2 := s.service.GenerateToken(ctx, username)
+		token2, _, err2 := s.service.GenerateToken(ctx, username, false)
 
 		assert.NoError(t, err1)
 		assert.NoError(t, err2)
@@ -94,7 +106,7 @@ func (s *TokenServiceSuite) TestTokenService_GenerateToken() {
 		shortTimeout := 1 * time.Second
 		shortService := NewTokenService(s.secretKey, shortTimeout, s.logger)
 
-		token, expiresAt, err := shortService.GenerateToken(ctx, username)
+		token, expiresAt, err := shortService.GenerateToken(ctx, username, false)
 
 		assert.NoError(t, err)
 
@@ -119,7 +131,7 @@ func (s *TokenServiceSuite) TestTokenService_GenerateToken() {
 		usernames := []string{"user1", "user2", "admin", "test@example.com"}
 
 		for _, username := range usernames {
-			token, _, err := s.service.GenerateToken(ctx, username)
+			token, _, err := s.service.GenerateToken(ctx, username, false)
 
 			assert.NoError(t, err)
 			assert.NotEmpty(t, token)
@@ -140,7 +152,7 @@ func (s *TokenServiceSuite) TestTokenService_GenerateToken_SigningMethod() {
 	s.T().Run("uses HS256 signing method", func(t *testing.T) {
 		ctx := context.Background()
 
-		token, _, err := s.service.GenerateToken(ctx, "testuser")
+		token, _, err := s.service.GenerateToken(ctx, "testuser", false)
 
 		assert.NoError(t, err)
 
@@ -0,0 +1,107 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockOperationService creates a new instance of MockOperationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOperationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOperationService {
+	mock := &MockOperationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOperationService is an autogenerated mock type for the OperationService type
+type MockOperationService struct {
+	mock.Mock
+}
+
+type MockOperationService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOperationService) EXPECT() *MockOperationService_Expecter {
+	return &MockOperationService_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function for the type MockOperationService
+func (_mock *MockOperationService) Get(ctx context.Context, id string) (*domain.Operation, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Operation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Operation, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Operation); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Operation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOperationService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockOperationService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockOperationService_Expecter) Get(ctx interface{}, id interface{}) *MockOperationService_Get_Call {
+	return &MockOperationService_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *MockOperationService_Get_Call) Run(run func(ctx context.Context, id string)) *MockOperationService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOperationService_Get_Call) Return(res *domain.Operation, err error) *MockOperationService_Get_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockOperationService_Get_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.Operation, error)) *MockOperationService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,63 @@
+package operation
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/controller"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/logging"
+
+	"github.com/labstack/echo/v4"
+)
+
+// retryAfterSeconds is how long clients are asked to wait before polling a running operation again
+const retryAfterSeconds = "1"
+
+type OperationService interface {
+	Get(ctx context.Context, id string) (res *domain.Operation, err error)
+}
+
+type OperationHandler struct {
+	Service OperationService
+	logger  *logging.Logger
+}
+
+func NewOperationHandler(e *echo.Group, svc OperationService, logger *logging.Logger) *OperationHandler {
+	handler := &OperationHandler{
+		Service: svc,
+		logger:  logger.Named("handler.operation"),
+	}
+	group := e.Group("/operations")
+
+	group.GET("/:id", handler.GetByID)
+
+	return handler
+}
+
+// GetByID godoc
+//
+//	@Summary		Get operation status
+//	@Description	get the status of a write accepted with Prefer: respond-async. Once it succeeded, location is the resource it wrote; once it failed, error is the response the request would have got had it run synchronously. Finished operations are kept for a limited time.
+//	@Tags			operations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Operation ID"
+//	@Success		200	{object}	domain.OperationResponse
+//	@Header			200	{string}	Retry-After	"Seconds to wait before polling again, while the operation is running"
+//	@Failure		404	{object}	controller.ErrorResponse
+//	@Failure		500	{object}	controller.ErrorResponse
+//	@Router			/operations/{id} [get]
+//	@Security		BearerAuth
+func (h *OperationHandler) GetByID(ctx echo.Context) error {
+	op, err := h.Service.Get(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "get operation", h.logger)
+	}
+
+	if op.Status == domain.OperationStatusRunning {
+		ctx.Response().Header().Set("Retry-After", retryAfterSeconds)
+	}
+
+	response := domain.ToOperationResponse(op)
+	response.Error = controller.OperationError(op.Err)
+	return controller.Ok(ctx, response)
+}
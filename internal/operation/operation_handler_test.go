@@ -0,0 +1,114 @@
+package operation
+
+import (
+	"encoding/json"
+	"lizobly/ctc-db-api/internal/operation/mocks"
+	"lizobly/ctc-db-api/pkg/controller"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type OperationHandlerSuite struct {
+	suite.Suite
+
+	e                *echo.Echo
+	operationService *mocks.MockOperationService
+	handler          *OperationHandler
+}
+
+func TestOperationHandlerSuite(t *testing.T) {
+	suite.Run(t, new(OperationHandlerSuite))
+}
+
+func (s *OperationHandlerSuite) SetupTest() {
+	s.e = echo.New()
+	s.operationService = new(mocks.MockOperationService)
+	testLogger, _ := logging.NewDevelopmentLogger()
+	s.handler = NewOperationHandler(s.e.Group(""), s.operationService, testLogger)
+}
+
+func (s *OperationHandlerSuite) TearDownTest() {
+	s.operationService.AssertExpectations(s.T())
+}
+
+func (s *OperationHandlerSuite) TestOperationHandler_GetByID() {
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	finishedAt := createdAt.Add(time.Second)
+
+	tests := []struct {
+		name           string
+		id             string
+		operation      *domain.Operation
+		err            error
+		wantStatusCode int
+		wantResponse   interface{}
+		wantRetryAfter string
+	}{
+		{
+			name:           "running",
+			id:             "op-1",
+			operation:      &domain.Operation{ID: "op-1", Name: "create traveller", Status: domain.OperationStatusRunning, CreatedAt: createdAt},
+			wantStatusCode: http.StatusOK,
+			wantRetryAfter: "1",
+			wantResponse: controller.DataResponse[domain.OperationResponse]{Data: domain.OperationResponse{
+				ID: "op-1", Name: "create traveller", Status: domain.OperationStatusRunning, CreatedAt: createdAt,
+				Links: domain.ResourceLinks{Self: "/api/v1/operations/op-1"},
+			}},
+		},
+		{
+			name: "succeeded",
+			id:   "op-2",
+			operation: &domain.Operation{ID: "op-2", Name: "create traveller", Status: domain.OperationStatusSucceeded,
+				Location: "/api/v1/travellers/7", CreatedAt: createdAt, FinishedAt: &finishedAt},
+			wantStatusCode: http.StatusOK,
+			wantResponse: controller.DataResponse[domain.OperationResponse]{Data: domain.OperationResponse{
+				ID: "op-2", Name: "create traveller", Status: domain.OperationStatusSucceeded, Location: "/api/v1/travellers/7",
+				CreatedAt: createdAt, FinishedAt: &finishedAt, Links: domain.ResourceLinks{Self: "/api/v1/operations/op-2"},
+			}},
+		},
+		{
+			name: "failed",
+			id:   "op-3",
+			operation: &domain.Operation{ID: "op-3", Name: "update traveller", Status: domain.OperationStatusFailed,
+				Err: domain.NewPreconditionFailedError("resource has been modified", nil), CreatedAt: createdAt, FinishedAt: &finishedAt},
+			wantStatusCode: http.StatusOK,
+			wantResponse: controller.DataResponse[domain.OperationResponse]{Data: domain.OperationResponse{
+				ID: "op-3", Name: "update traveller", Status: domain.OperationStatusFailed,
+				Error:     &domain.OperationError{Status: http.StatusPreconditionFailed, Message: "resource has been modified"},
+				CreatedAt: createdAt, FinishedAt: &finishedAt, Links: domain.ResourceLinks{Self: "/api/v1/operations/op-3"},
+			}},
+		},
+		{
+			name:           "not found",
+			id:             "missing",
+			err:            domain.NewNotFoundError("operation", "missing", nil),
+			wantStatusCode: http.StatusNotFound,
+			wantResponse:   controller.ErrorResponse{Message: "operation not found"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/operations/"+tt.id, nil, nil, map[string]string{"id": tt.id})
+			s.operationService.On("Get", ctx.Request().Context(), tt.id).Return(tt.operation, tt.err).Once()
+
+			err := s.handler.GetByID(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.wantStatusCode, ctx.Response().Status)
+			assert.Equal(s.T(), tt.wantRetryAfter, rec.Header().Get("Retry-After"))
+
+			wantRespBytes, err := json.Marshal(tt.wantResponse)
+			assert.NoError(s.T(), err)
+			assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
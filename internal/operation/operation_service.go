@@ -0,0 +1,135 @@
+package operation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/logging"
+	"lizobly/ctc-db-api/pkg/telemetry"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// operationService runs writes accepted with Prefer: respond-async in the background and keeps
+// their status in memory, so it is lost on restart and not shared between instances. Finished
+// operations are forgotten after the retention period.
+type operationService struct {
+	mu         sync.Mutex
+	operations map[string]*domain.Operation
+	timeout    time.Duration
+	retention  time.Duration
+	logger     *logging.Logger
+}
+
+func NewOperationService(timeout, retention time.Duration, logger *logging.Logger) *operationService {
+	return &operationService{
+		operations: make(map[string]*domain.Operation),
+		timeout:    timeout,
+		retention:  retention,
+		logger:     logger.Named("service.operation"),
+	}
+}
+
+// Start runs an operation in the background and returns it as started. The operation keeps the
+// request's trace and logging context but not its cancellation, and is limited by the timeout.
+func (s *operationService) Start(ctx context.Context, name string, run domain.OperationFunc) (res *domain.Operation) {
+	op := &domain.Operation{
+		ID:        uuid.NewString(),
+		Name:      name,
+		Status:    domain.OperationStatusRunning,
+		CreatedAt: time.Now(),
+	}
+
+	s.mu.Lock()
+	s.prune()
+	s.operations[op.ID] = op
+	started := *op
+	s.mu.Unlock()
+
+	runCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.timeout)
+	go func() {
+		defer cancel()
+		location, err := s.run(runCtx, op.ID, name, run)
+		s.finish(runCtx, op.ID, location, err)
+	}()
+
+	return &started
+}
+
+// Get returns a snapshot of an operation
+func (s *operationService) Get(ctx context.Context, id string) (res *domain.Operation, err error) {
+	_, span := telemetry.StartServiceSpan(ctx, "service.operation", "OperationService.Get",
+		attribute.String("operation.id", id),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune()
+	op, ok := s.operations[id]
+	if !ok {
+		return nil, domain.NewNotFoundError("operation", id, nil)
+	}
+	snapshot := *op
+	return &snapshot, nil
+}
+
+// run calls the operation's work, turning a panic into an error since the recovery middleware
+// does not cover background goroutines
+func (s *operationService) run(ctx context.Context, id, name string, run domain.OperationFunc) (location string, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.operation", "OperationService.Run",
+		attribute.String("operation.id", id),
+		attribute.String("operation.name", name),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("operation panicked: %v", r)
+		}
+	}()
+
+	location, err = run(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = domain.NewTimeoutError("operation timed out", err)
+	}
+	return
+}
+
+func (s *operationService) finish(ctx context.Context, id, location string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	op := s.operations[id]
+	finishedAt := time.Now()
+	op.FinishedAt = &finishedAt
+	if err != nil {
+		op.Status = domain.OperationStatusFailed
+		op.Err = err
+		s.logger.WithContext(ctx).Warn("operation failed",
+			zap.String("operation.id", id),
+			zap.String("operation.name", op.Name),
+			zap.Error(err),
+		)
+		return
+	}
+	op.Status = domain.OperationStatusSucceeded
+	op.Location = location
+}
+
+// prune forgets operations that finished longer ago than the retention period.
+// s.mu must be held.
+func (s *operationService) prune() {
+	cutoff := time.Now().Add(-s.retention)
+	for id, op := range s.operations {
+		if op.FinishedAt != nil && op.FinishedAt.Before(cutoff) {
+			delete(s.operations, id)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package operation
+
+import (
+	"context"
+	"errors"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/logging"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type OperationServiceSuite struct {
+	suite.Suite
+	svc *operationService
+}
+
+func TestOperationServiceSuite(t *testing.T) {
+	suite.Run(t, new(OperationServiceSuite))
+}
+
+func (s *OperationServiceSuite) SetupTest() {
+	logger, _ := logging.NewDevelopmentLogger()
+	s.svc = NewOperationService(time.Second, time.Hour, logger)
+}
+
+// waitFinished polls an operation until it is no longer running
+func (s *OperationServiceSuite) waitFinished(id string) *domain.Operation {
+	var op *domain.Operation
+	assert.Eventually(s.T(), func() bool {
+		var err error
+		op, err = s.svc.Get(context.TODO(), id)
+		return err == nil && op.Status != domain.OperationStatusRunning
+	}, time.Second, 5*time.Millisecond)
+	return op
+}
+
+func (s *OperationServiceSuite) TestOperationService_Start() {
+	s.Run("succeeds with the location of the written resource", func() {
+		s.SetupTest()
+		release := make(chan struct{})
+
+		started := s.svc.Start(context.TODO(), "create traveller", func(ctx context.Context) (string, error) {
+			<-release
+			return "/api/v1/travellers/1", nil
+		})
+		assert.Equal(s.T(), domain.OperationStatusRunning, started.Status)
+		assert.Equal(s.T(), "create traveller", started.Name)
+		assert.NotEmpty(s.T(), started.ID)
+
+		close(release)
+		op := s.waitFinished(started.ID)
+		assert.Equal(s.T(), domain.OperationStatusSucceeded, op.Status)
+		assert.Equal(s.T(), "/api/v1/travellers/1", op.Location)
+		assert.NotNil(s.T(), op.FinishedAt)
+		assert.Nil(s.T(), op.Err)
+	})
+
+	s.Run("outlives the request context", func() {
+		s.SetupTest()
+		ctx, cancel := context.WithCancel(context.Background())
+
+		started := s.svc.Start(ctx, "update traveller", func(ctx context.Context) (string, error) {
+			<-time.After(10 * time.Millisecond)
+			return "/api/v1/travellers/1", ctx.Err()
+		})
+		cancel()
+
+		op := s.waitFinished(started.ID)
+		assert.Equal(s.T(), domain.OperationStatusSucceeded, op.Status)
+	})
+
+	s.Run("records the error of a failed write", func() {
+		s.SetupTest()
+		wantErr := domain.NewPreconditionFailedError("resource has been modified", nil)
+
+		started := s.svc.Start(context.TODO(), "patch traveller", func(ctx context.Context) (string, error) {
+			return "", wantErr
+		})
+
+		op := s.waitFinished(started.ID)
+		assert.Equal(s.T(), domain.OperationStatusFailed, op.Status)
+		assert.Equal(s.T(), wantErr, op.Err)
+		assert.Empty(s.T(), op.Location)
+	})
+
+	s.Run("times out", func() {
+		logger, _ := logging.NewDevelopmentLogger()
+		s.svc = NewOperationService(10*time.Millisecond, time.Hour, logger)
+
+		started := s.svc.Start(context.TODO(), "create traveller", func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		})
+
+		op := s.waitFinished(started.ID)
+		var te *domain.TimeoutError
+		assert.True(s.T(), errors.As(op.Err, &te), "expected TimeoutError")
+	})
+
+	s.Run("recovers from a panic", func() {
+		s.SetupTest()
+
+		started := s.svc.Start(context.TODO(), "create traveller", func(ctx context.Context) (string, error) {
+			panic("boom")
+		})
+
+		op := s.waitFinished(started.ID)
+		assert.Equal(s.T(), domain.OperationStatusFailed, op.Status)
+		assert.EqualError(s.T(), op.Err, "operation panicked: boom")
+	})
+}
+
+func (s *OperationServiceSuite) TestOperationService_Get() {
+	s.Run("not found", func() {
+		s.SetupTest()
+
+		_, err := s.svc.Get(context.TODO(), "missing")
+		var nfe *domain.NotFoundError
+		assert.True(s.T(), errors.As(err, &nfe), "expected NotFoundError")
+	})
+
+	s.Run("finished operations are forgotten after the retention period", func() {
+		s.SetupTest()
+		finishedAt := time.Now().Add(-2 * time.Hour)
+		s.svc.operations["old"] = &domain.Operation{ID: "old", Status: domain.OperationStatusSucceeded, FinishedAt: &finishedAt}
+		s.svc.operations["running"] = &domain.Operation{ID: "running", Status: domain.OperationStatusRunning, CreatedAt: finishedAt}
+
+		_, err := s.svc.Get(context.TODO(), "old")
+		var nfe *domain.NotFoundError
+		assert.True(s.T(), errors.As(err, &nfe), "expected NotFoundError")
+
+		op, err := s.svc.Get(context.TODO(), "running")
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), domain.OperationStatusRunning, op.Status)
+	})
+}
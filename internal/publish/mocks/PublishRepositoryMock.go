@@ -0,0 +1,113 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockPublishRepository creates a new instance of MockPublishRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPublishRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPublishRepository {
+	mock := &MockPublishRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPublishRepository is an autogenerated mock type for the PublishRepository type
+type MockPublishRepository struct {
+	mock.Mock
+}
+
+type MockPublishRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPublishRepository) EXPECT() *MockPublishRepository_Expecter {
+	return &MockPublishRepository_Expecter{mock: &_m.Mock}
+}
+
+// PublishDue provides a mock function for the type MockPublishRepository
+func (_mock *MockPublishRepository) PublishDue(ctx context.Context, entityType string, now time.Time) ([]int64, error) {
+	ret := _mock.Called(ctx, entityType, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishDue")
+	}
+
+	var r0 []int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]int64, error)); ok {
+		return returnFunc(ctx, entityType, now)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) []int64); ok {
+		r0 = returnFunc(ctx, entityType, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, entityType, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPublishRepository_PublishDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishDue'
+type MockPublishRepository_PublishDue_Call struct {
+	*mock.Call
+}
+
+// PublishDue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityType string
+//   - now time.Time
+func (_e *MockPublishRepository_Expecter) PublishDue(ctx interface{}, entityType interface{}, now interface{}) *MockPublishRepository_PublishDue_Call {
+	return &MockPublishRepository_PublishDue_Call{Call: _e.mock.On("PublishDue", ctx, entityType, now)}
+}
+
+func (_c *MockPublishRepository_PublishDue_Call) Run(run func(ctx context.Context, entityType string, now time.Time)) *MockPublishRepository_PublishDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPublishRepository_PublishDue_Call) Return(ids []int64, err error) *MockPublishRepository_PublishDue_Call {
+	_c.Call.Return(ids, err)
+	return _c
+}
+
+func (_c *MockPublishRepository_PublishDue_Call) RunAndReturn(run func(ctx context.Context, entityType string, now time.Time) ([]int64, error)) *MockPublishRepository_PublishDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,102 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"lizobly/ctc-db-api/pkg/audit"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/logging"
+	"lizobly/ctc-db-api/pkg/telemetry"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// publishTarget describes how one entity type is published
+type publishTarget struct {
+	table string
+	model interface{}
+	// touchOwners bumps the version of the rows embedding the published ones, given their IDs,
+	// so their ETags change with them
+	touchOwners func(tx *gorm.DB, ids []int64) error
+}
+
+var publishTargets = map[string]publishTarget{
+	domain.TrashTypeTraveller: {
+		table: "m_traveller",
+		model: &domain.Traveller{},
+	},
+	domain.TrashTypeAccessory: {
+		table: "m_accessory",
+		model: &domain.Accessory{},
+		touchOwners: func(tx *gorm.DB, ids []int64) error {
+			return tx.Model(&domain.Traveller{}).
+				Where("accessory_id IN ?", ids).
+				UpdateColumn("version", gorm.Expr("version + 1")).Error
+		},
+	},
+}
+
+type publishRepository struct {
+	db     *gorm.DB
+	logger *logging.Logger
+}
+
+func NewPublishRepository(db *gorm.DB, logger *logging.Logger) *publishRepository {
+	return &publishRepository{
+		db:     db,
+		logger: logger.Named("repository.publish"),
+	}
+}
+
+// PublishDue publishes the scheduled records of entityType whose publish time is not after now
+// and returns their IDs. Each one gets a publish revision, which is its change event. Rows
+// another publisher is already publishing are skipped, so several instances can run at once.
+func (r *publishRepository) PublishDue(ctx context.Context, entityType string, now time.Time) (ids []int64, err error) {
+	target, ok := publishTargets[entityType]
+	if !ok {
+		return nil, fmt.Errorf("unknown publishable type %q", entityType)
+	}
+
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.publish", "PublishRepository.PublishDue", "update", target.table,
+		attribute.String("publish.type", entityType),
+		attribute.String("publish.now", now.Format(time.RFC3339)),
+	)
+	defer op.End(err)
+
+	// The publisher reads drafts and scheduled records like an editor
+	ctx = logging.WithEditor(audit.WithRevisionAction(ctx, domain.RevisionActionPublish))
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(target.model).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND publish_at <= ?", domain.PublishStatusScheduled, now).
+			Order("id").
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		err = tx.Model(target.model).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":  domain.PublishStatusPublished,
+			"version": gorm.Expr("version + 1"),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if target.touchOwners != nil {
+			return target.touchOwners(tx, ids)
+		}
+		return nil
+	})
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to publish", zap.String("publish.type", entityType), zap.Error(err))
+		ids = nil
+		return
+	}
+
+	return
+}
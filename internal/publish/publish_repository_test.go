@@ -0,0 +1,150 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"lizobly/ctc-db-api/pkg/audit"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PublishRepositorySuite struct {
+	suite.Suite
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+	repo *publishRepository
+}
+
+func TestPublishRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PublishRepositorySuite))
+}
+
+func (s *PublishRepositorySuite) SetupTest() {
+	var err error
+	s.db, s.mock, err = helpers.NewMockDB()
+	if err != nil {
+		s.T().Fatal()
+	}
+
+	logger, _ := logging.NewDevelopmentLogger()
+	s.repo = NewPublishRepository(s.db, logger)
+}
+
+func (s *PublishRepositorySuite) TestPublishRepository_PublishDue() {
+	now := time.Date(2024, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	s.Run("publishes due travellers", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "m_traveller" WHERE (status = $1 AND publish_at <= $2) AND "m_traveller"."deleted_at" IS NULL ORDER BY id FOR UPDATE SKIP LOCKED`)).
+			WithArgs(domain.PublishStatusScheduled, now).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4).AddRow(7))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "status"=$1,"version"=version + 1,"updated_at"=$2 WHERE id IN ($3,$4) AND "m_traveller"."deleted_at" IS NULL`)).
+			WithArgs(domain.PublishStatusPublished, helpers.AnyTime{}, 4, 7).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		s.mock.ExpectCommit()
+
+		ids, err := s.repo.PublishDue(context.TODO(), domain.TrashTypeTraveller, now)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), []int64{4, 7}, ids)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("publishing accessories touches their owners", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "m_accessory" WHERE (status = $1 AND publish_at <= $2)`)).
+			WithArgs(domain.PublishStatusScheduled, now).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_accessory" SET "status"=$1,"version"=version + 1,"updated_at"=$2 WHERE id IN ($3)`)).
+			WithArgs(domain.PublishStatusPublished, helpers.AnyTime{}, 9).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "version"=version + 1 WHERE accessory_id IN ($1)`)).
+			WithArgs(9).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		ids, err := s.repo.PublishDue(context.TODO(), domain.TrashTypeAccessory, now)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), []int64{9}, ids)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("each published record gets a publish revision, its change event", func() {
+		s.SetupTest()
+		if err := audit.RegisterRevisions(s.db); err != nil {
+			s.T().Fatal(err)
+		}
+		rows := func(status string) *sqlmock.Rows {
+			return sqlmock.NewRows([]string{"id", "name", "rarity", "status"}).AddRow(4, "Viola", 5, status)
+		}
+		state := func(status string) []byte {
+			state, _ := json.Marshal(domain.Traveller{Name: "Viola", Rarity: 5, Publication: domain.Publication{Status: status}}.RevisionState())
+			return state
+		}
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "m_traveller"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE id IN ($1) FOR UPDATE`)).
+			WithArgs(4).
+			WillReturnRows(rows(domain.PublishStatusScheduled))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "status"=$1`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."id" = $1`)).
+			WithArgs(4).
+			WillReturnRows(rows(domain.PublishStatusPublished))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_revision" ("entity_type","entity_id","action","before","after","actor","request_id","created_at")`)).
+			WithArgs(domain.TrashTypeTraveller, int64(4), domain.RevisionActionPublish, state(domain.PublishStatusScheduled), state(domain.PublishStatusPublished), sqlmock.AnyArg(), sqlmock.AnyArg(), helpers.AnyTime{}).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		s.mock.ExpectCommit()
+
+		ids, err := s.repo.PublishDue(context.TODO(), domain.TrashTypeTraveller, now)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), []int64{4}, ids)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("nothing due", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "m_traveller"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		s.mock.ExpectCommit()
+
+		ids, err := s.repo.PublishDue(context.TODO(), domain.TrashTypeTraveller, now)
+		assert.NoError(s.T(), err)
+		assert.Empty(s.T(), ids)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("failed rolls back", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "m_traveller"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller"`)).
+			WillReturnError(gorm.ErrInvalidDB)
+		s.mock.ExpectRollback()
+
+		ids, err := s.repo.PublishDue(context.TODO(), domain.TrashTypeTraveller, now)
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+		assert.Nil(s.T(), ids)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("unknown type", func() {
+		s.SetupTest()
+		_, err := s.repo.PublishDue(context.TODO(), "influence", now)
+		assert.ErrorContains(s.T(), err, `unknown publishable type "influence"`)
+	})
+}
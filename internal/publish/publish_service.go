@@ -0,0 +1,76 @@
+package publish
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/logging"
+	"lizobly/ctc-db-api/pkg/telemetry"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type PublishRepository interface {
+	PublishDue(ctx context.Context, entityType string, now time.Time) (ids []int64, err error)
+}
+
+// publishService publishes scheduled travellers and accessories once their publish time has come.
+// The change event for each publish is the revision recorded with the publish action, which
+// shows in the record's history like any other change.
+type publishService struct {
+	publishRepo PublishRepository
+	logger      *logging.Logger
+}
+
+func NewPublishService(r PublishRepository, logger *logging.Logger) *publishService {
+	return &publishService{
+		publishRepo: r,
+		logger:      logger.Named("service.publish"),
+	}
+}
+
+// PublishDue publishes every scheduled record whose publish time has passed and returns their
+// IDs by entity type, leaving out the types with none
+func (s *publishService) PublishDue(ctx context.Context) (published map[string][]int64, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.publish", "PublishService.PublishDue")
+	defer telemetry.EndSpanWithError(span, err)
+
+	now := time.Now()
+	published = make(map[string][]int64)
+	for _, t := range domain.PublishableTypes {
+		ids, publishErr := s.publishRepo.PublishDue(ctx, t, now)
+		if publishErr != nil {
+			err = publishErr
+			return
+		}
+		if len(ids) > 0 {
+			published[t] = ids
+		}
+	}
+
+	return
+}
+
+// RunPublisher publishes the records that are due right away and then every interval, until ctx
+// is done. Failures are logged and retried on the next tick.
+func (s *publishService) RunPublisher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		published, err := s.PublishDue(ctx)
+		if err != nil {
+			s.logger.WithContext(ctx).Error("failed to publish scheduled records", zap.Error(err))
+		} else if len(published) > 0 {
+			s.logger.WithContext(ctx).Info("published scheduled records",
+				zap.Any("publish.published", published),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package publish
+
+import (
+	"context"
+	"errors"
+	"lizobly/ctc-db-api/internal/publish/mocks"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/logging"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PublishServiceSuite struct {
+	suite.Suite
+	publishRepo *mocks.MockPublishRepository
+	svc         *publishService
+}
+
+func TestPublishServiceSuite(t *testing.T) {
+	suite.Run(t, new(PublishServiceSuite))
+}
+
+func (s *PublishServiceSuite) SetupTest() {
+	logger, _ := logging.NewDevelopmentLogger()
+
+	s.publishRepo = new(mocks.MockPublishRepository)
+	s.svc = NewPublishService(s.publishRepo, logger)
+}
+
+func (s *PublishServiceSuite) TearDownTest() {
+	s.publishRepo.AssertExpectations(s.T())
+}
+
+// dueNow matches a publish cutoff within a second of now
+var dueNow = mock.MatchedBy(func(now time.Time) bool {
+	return time.Since(now).Abs() < time.Second
+})
+
+func (s *PublishServiceSuite) TestPublishService_PublishDue() {
+	s.Run("every type", func() {
+		s.SetupTest()
+		s.publishRepo.On("PublishDue", mock.Anything, domain.TrashTypeTraveller, dueNow).Return([]int64{4, 7}, nil).Once()
+		s.publishRepo.On("PublishDue", mock.Anything, domain.TrashTypeAccessory, dueNow).Return([]int64{}, nil).Once()
+
+		res, err := s.svc.PublishDue(context.TODO())
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), map[string][]int64{domain.TrashTypeTraveller: {4, 7}}, res)
+	})
+
+	s.Run("failure stops the run", func() {
+		s.SetupTest()
+		s.publishRepo.On("PublishDue", mock.Anything, domain.TrashTypeTraveller, dueNow).Return(nil, errors.New("db down")).Once()
+
+		_, err := s.svc.PublishDue(context.TODO())
+		assert.EqualError(s.T(), err, "db down")
+	})
+}
+
+func (s *PublishServiceSuite) TestPublishService_RunPublisher() {
+	s.SetupTest()
+	ctx, cancel := context.WithCancel(context.Background())
+	s.publishRepo.On("PublishDue", mock.Anything, domain.TrashTypeTraveller, dueNow).Return([]int64{4}, nil)
+	s.publishRepo.On("PublishDue", mock.Anything, domain.TrashTypeAccessory, dueNow).Return(nil, nil).
+		Run(func(mock.Arguments) { cancel() })
+
+	done := make(chan struct{})
+	go func() {
+		s.svc.RunPublisher(ctx, time.Hour)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.T().Fatal("RunPublisher did not stop once its context was done")
+	}
+}
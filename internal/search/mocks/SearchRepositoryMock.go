@@ -0,0 +1,211 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSearchRepository creates a new instance of MockSearchRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSearchRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSearchRepository {
+	mock := &MockSearchRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSearchRepository is an autogenerated mock type for the SearchRepository type
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+type MockSearchRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSearchRepository) EXPECT() *MockSearchRepository_Expecter {
+	return &MockSearchRepository_Expecter{mock: &_m.Mock}
+}
+
+// Search provides a mock function for the type MockSearchRepository
+func (_mock *MockSearchRepository) Search(ctx context.Context, entityType string, query string, offset int, limit int) ([]domain.SearchHit, int64, error) {
+	ret := _mock.Called(ctx, entityType, query, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []domain.SearchHit
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, int) ([]domain.SearchHit, int64, error)); ok {
+		return returnFunc(ctx, entityType, query, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, int) []domain.SearchHit); ok {
+		r0 = returnFunc(ctx, entityType, query, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SearchHit)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int, int) int64); ok {
+		r1 = returnFunc(ctx, entityType, query, offset, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, int, int) error); ok {
+		r2 = returnFunc(ctx, entityType, query, offset, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockSearchRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockSearchRepository_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityType string
+//   - query string
+//   - offset int
+//   - limit int
+func (_e *MockSearchRepository_Expecter) Search(ctx interface{}, entityType interface{}, query interface{}, offset interface{}, limit interface{}) *MockSearchRepository_Search_Call {
+	return &MockSearchRepository_Search_Call{Call: _e.mock.On("Search", ctx, entityType, query, offset, limit)}
+}
+
+func (_c *MockSearchRepository_Search_Call) Run(run func(ctx context.Context, entityType string, query string, offset int, limit int)) *MockSearchRepository_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSearchRepository_Search_Call) Return(result []domain.SearchHit, total int64, err error) *MockSearchRepository_Search_Call {
+	_c.Call.Return(result, total, err)
+	return _c
+}
+
+func (_c *MockSearchRepository_Search_Call) RunAndReturn(run func(ctx context.Context, entityType string, query string, offset int, limit int) ([]domain.SearchHit, int64, error)) *MockSearchRepository_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Suggest provides a mock function for the type MockSearchRepository
+func (_mock *MockSearchRepository) Suggest(ctx context.Context, entityType string, query string, limit int) ([]domain.Suggestion, error) {
+	ret := _mock.Called(ctx, entityType, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Suggest")
+	}
+
+	var r0 []domain.Suggestion
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) ([]domain.Suggestion, error)); ok {
+		return returnFunc(ctx, entityType, query, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) []domain.Suggestion); ok {
+		r0 = returnFunc(ctx, entityType, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Suggestion)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = returnFunc(ctx, entityType, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSearchRepository_Suggest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Suggest'
+type MockSearchRepository_Suggest_Call struct {
+	*mock.Call
+}
+
+// Suggest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityType string
+//   - query string
+//   - limit int
+func (_e *MockSearchRepository_Expecter) Suggest(ctx interface{}, entityType interface{}, query interface{}, limit interface{}) *MockSearchRepository_Suggest_Call {
+	return &MockSearchRepository_Suggest_Call{Call: _e.mock.On("Suggest", ctx, entityType, query, limit)}
+}
+
+func (_c *MockSearchRepository_Suggest_Call) Run(run func(ctx context.Context, entityType string, query string, limit int)) *MockSearchRepository_Suggest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSearchRepository_Suggest_Call) Return(result []domain.Suggestion, err error) *MockSearchRepository_Suggest_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockSearchRepository_Suggest_Call) RunAndReturn(run func(ctx context.Context, entityType string, query string, limit int) ([]domain.Suggestion, error)) *MockSearchRepository_Suggest_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,178 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSearchService creates a new instance of MockSearchService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSearchService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSearchService {
+	mock := &MockSearchService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSearchService is an autogenerated mock type for the SearchService type
+type MockSearchService struct {
+	mock.Mock
+}
+
+type MockSearchService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSearchService) EXPECT() *MockSearchService_Expecter {
+	return &MockSearchService_Expecter{mock: &_m.Mock}
+}
+
+// Search provides a mock function for the type MockSearchService
+func (_mock *MockSearchService) Search(ctx context.Context, input domain.SearchRequest, params helpers.PaginationParams) (domain.SearchResponse, error) {
+	ret := _mock.Called(ctx, input, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 domain.SearchResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.SearchRequest, helpers.PaginationParams) (domain.SearchResponse, error)); ok {
+		return returnFunc(ctx, input, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.SearchRequest, helpers.PaginationParams) domain.SearchResponse); ok {
+		r0 = returnFunc(ctx, input, params)
+	} else {
+		r0 = ret.Get(0).(domain.SearchResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.SearchRequest, helpers.PaginationParams) error); ok {
+		r1 = returnFunc(ctx, input, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSearchService_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockSearchService_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input domain.SearchRequest
+//   - params helpers.PaginationParams
+func (_e *MockSearchService_Expecter) Search(ctx interface{}, input interface{}, params interface{}) *MockSearchService_Search_Call {
+	return &MockSearchService_Search_Call{Call: _e.mock.On("Search", ctx, input, params)}
+}
+
+func (_c *MockSearchService_Search_Call) Run(run func(ctx context.Context, input domain.SearchRequest, params helpers.PaginationParams)) *MockSearchService_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.SearchRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.SearchRequest)
+		}
+		var arg2 helpers.PaginationParams
+		if args[2] != nil {
+			arg2 = args[2].(helpers.PaginationParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSearchService_Search_Call) Return(res domain.SearchResponse, err error) *MockSearchService_Search_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockSearchService_Search_Call) RunAndReturn(run func(ctx context.Context, input domain.SearchRequest, params helpers.PaginationParams) (domain.SearchResponse, error)) *MockSearchService_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Suggest provides a mock function for the type MockSearchService
+func (_mock *MockSearchService) Suggest(ctx context.Context, input domain.SuggestRequest) (domain.SuggestResponse, error) {
+	ret := _mock.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Suggest")
+	}
+
+	var r0 domain.SuggestResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.SuggestRequest) (domain.SuggestResponse, error)); ok {
+		return returnFunc(ctx, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.SuggestRequest) domain.SuggestResponse); ok {
+		r0 = returnFunc(ctx, input)
+	} else {
+		r0 = ret.Get(0).(domain.SuggestResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.SuggestRequest) error); ok {
+		r1 = returnFunc(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSearchService_Suggest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Suggest'
+type MockSearchService_Suggest_Call struct {
+	*mock.Call
+}
+
+// Suggest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input domain.SuggestRequest
+func (_e *MockSearchService_Expecter) Suggest(ctx interface{}, input interface{}) *MockSearchService_Suggest_Call {
+	return &MockSearchService_Suggest_Call{Call: _e.mock.On("Suggest", ctx, input)}
+}
+
+func (_c *MockSearchService_Suggest_Call) Run(run func(ctx context.Context, input domain.SuggestRequest)) *MockSearchService_Suggest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.SuggestRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.SuggestRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSearchService_Suggest_Call) Return(res domain.SuggestResponse, err error) *MockSearchService_Suggest_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockSearchService_Suggest_Call) RunAndReturn(run func(ctx context.Context, input domain.SuggestRequest) (domain.SuggestResponse, error)) *MockSearchService_Suggest_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,109 @@
+package search
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/controller"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SearchService interface {
+	Search(ctx context.Context, input domain.SearchRequest, params helpers.PaginationParams) (res domain.SearchResponse, err error)
+	Suggest(ctx context.Context, input domain.SuggestRequest) (res domain.SuggestResponse, err error)
+}
+
+type SearchHandler struct {
+	Service SearchService
+	logger  *logging.Logger
+}
+
+func NewSearchHandler(e *echo.Group, svc SearchService, logger *logging.Logger) *SearchHandler {
+	handler := &SearchHandler{
+		Service: svc,
+		logger:  logger.Named("handler.search"),
+	}
+
+	e.GET("/search", handler.Search)
+	e.GET("/suggest", handler.Suggest)
+
+	return handler
+}
+
+// Search godoc
+//
+//	@Summary		Full-text search
+//	@Description	search traveller names and banners, and accessory names and effects. Words are matched by stem, so "elemental" also finds "element". Results are ranked and grouped by type, and each group is paginated on its own.
+//	@Tags			search
+//	@Accept			json
+//	@Produce		json
+//	@Param			q			query	string	true	"Search text; supports quoted phrases, or, and -word to exclude"
+//	@Param			type		query	string	false	"Comma-separated types to search (traveller, accessory); defaults to all"
+//	@Param			page		query	int		false	"Page number for every group (default 1)"
+//	@Param			page_size	query	int		false	"Page size for every group (default 10, max 100)"
+//	@Success		200	{object}	domain.SearchResponse
+//	@Failure		400	{object}	controller.ErrorResponse
+//	@Failure		500	{object}	controller.ErrorResponse
+//	@Router			/search [get]
+func (h *SearchHandler) Search(ctx echo.Context) error {
+	var request domain.SearchRequest
+	err := ctx.Bind(&request)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid query parameters")
+	}
+
+	err = ctx.Validate(&request)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	var params helpers.PaginationParams
+	err = ctx.Bind(&params)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid pagination parameters")
+	}
+
+	result, err := h.Service.Search(ctx.Request().Context(), request, params)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "search", h.logger)
+	}
+
+	return controller.Ok(ctx, result)
+}
+
+// Suggest godoc
+//
+//	@Summary		Name autocomplete
+//	@Description	complete a partially typed traveller or accessory name. Names starting with q come first, followed by names that closely resemble one of its words, so small typos still match.
+//	@Tags			search
+//	@Accept			json
+//	@Produce		json
+//	@Param			q		query	string	true	"Partial name"
+//	@Param			type	query	string	false	"Entity type (traveller, accessory); defaults to traveller"
+//	@Param			limit	query	int		false	"Maximum number of suggestions (default 5, max 20)"
+//	@Success		200	{object}	domain.SuggestResponse
+//	@Failure		400	{object}	controller.ErrorResponse
+//	@Failure		500	{object}	controller.ErrorResponse
+//	@Router			/suggest [get]
+func (h *SearchHandler) Suggest(ctx echo.Context) error {
+	var request domain.SuggestRequest
+	err := ctx.Bind(&request)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid query parameters")
+	}
+
+	err = ctx.Validate(&request)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	result, err := h.Service.Suggest(ctx.Request().Context(), request)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "suggest", h.logger)
+	}
+
+	return controller.Ok(ctx, result)
+}
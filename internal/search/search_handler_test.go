@@ -0,0 +1,181 @@
+package search
+
+import (
+	"encoding/json"
+	"lizobly/ctc-db-api/internal/search/mocks"
+	"lizobly/ctc-db-api/pkg/controller"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type SearchHandlerSuite struct {
+	suite.Suite
+
+	e             *echo.Echo
+	searchService *mocks.MockSearchService
+	handler       *SearchHandler
+}
+
+func TestSearchHandlerSuite(t *testing.T) {
+	suite.Run(t, new(SearchHandlerSuite))
+}
+
+func (s *SearchHandlerSuite) SetupTest() {
+	s.e = echo.New()
+	s.searchService = new(mocks.MockSearchService)
+	testLogger, _ := logging.NewDevelopmentLogger()
+	s.handler = NewSearchHandler(s.e.Group(""), s.searchService, testLogger)
+}
+
+func (s *SearchHandlerSuite) TearDownTest() {
+	s.searchService.AssertExpectations(s.T())
+}
+
+func (s *SearchHandlerSuite) TestSearchHandler_Search() {
+	response := domain.SearchResponse{
+		Query:    "element",
+		Page:     1,
+		PageSize: 10,
+		Groups: []domain.SearchGroup{
+			{Type: domain.SearchTypeAccessory, Data: []domain.SearchHit{{ID: 3, Type: domain.SearchTypeAccessory, Name: "Crown of Wisdom"}}, Total: 1, TotalPages: 1},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		queryParams  map[string]string
+		responseBody interface{}
+		statusCode   int
+		beforeTest   func()
+	}{
+		{
+			name:         "success",
+			queryParams:  map[string]string{"q": "element", "type": "accessory", "page": "1"},
+			responseBody: controller.DataResponse[domain.SearchResponse]{Data: response},
+			statusCode:   http.StatusOK,
+			beforeTest: func() {
+				s.searchService.On("Search", mock.Anything, domain.SearchRequest{Query: "element", Type: "accessory"}, helpers.PaginationParams{Page: 1}).
+					Return(response, nil).Once()
+			},
+		},
+		{
+			name:        "missing query",
+			queryParams: map[string]string{},
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "unknown type",
+			queryParams: map[string]string{"q": "isla", "type": "user"},
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "service error",
+			queryParams: map[string]string{"q": "element"},
+			statusCode:  http.StatusInternalServerError,
+			beforeTest: func() {
+				s.searchService.On("Search", mock.Anything, domain.SearchRequest{Query: "element"}, helpers.PaginationParams{}).
+					Return(domain.SearchResponse{}, gorm.ErrInvalidDB).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			queryValues := url.Values{}
+			for k, v := range tt.queryParams {
+				queryValues.Set(k, v)
+			}
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/search", nil, queryValues, nil)
+
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+
+			err := s.handler.Search(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.statusCode, ctx.Response().Status)
+
+			if tt.responseBody != nil {
+				wantRespBytes, err := json.Marshal(tt.responseBody)
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
+			}
+		})
+	}
+}
+
+func (s *SearchHandlerSuite) TestSearchHandler_Suggest() {
+	response := domain.SuggestResponse{
+		Query:       "fio",
+		Type:        domain.SearchTypeTraveller,
+		Suggestions: []domain.Suggestion{{ID: 1, Slug: "fiore", Name: "Fiore", Score: 0.4}},
+	}
+
+	tests := []struct {
+		name         string
+		queryParams  map[string]string
+		responseBody interface{}
+		statusCode   int
+		beforeTest   func()
+	}{
+		{
+			name:         "success",
+			queryParams:  map[string]string{"q": "fio", "limit": "3"},
+			responseBody: controller.DataResponse[domain.SuggestResponse]{Data: response},
+			statusCode:   http.StatusOK,
+			beforeTest: func() {
+				s.searchService.On("Suggest", mock.Anything, domain.SuggestRequest{Query: "fio", Limit: 3}).Return(response, nil).Once()
+			},
+		},
+		{
+			name:        "missing query",
+			queryParams: map[string]string{"type": "accessory"},
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "limit too large",
+			queryParams: map[string]string{"q": "fio", "limit": "50"},
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "unknown type",
+			queryParams: map[string]string{"q": "fio", "type": "traveller,accessory"},
+			statusCode:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			queryValues := url.Values{}
+			for k, v := range tt.queryParams {
+				queryValues.Set(k, v)
+			}
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/suggest", nil, queryValues, nil)
+
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+
+			err := s.handler.Suggest(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.statusCode, ctx.Response().Status)
+
+			if tt.responseBody != nil {
+				wantRespBytes, err := json.Marshal(tt.responseBody)
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"lizobly/ctc-db-api/pkg/domain"
+	filterexpr "lizobly/ctc-db-api/pkg/filter"
+	"lizobly/ctc-db-api/pkg/logging"
+	"lizobly/ctc-db-api/pkg/telemetry"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// searchConfig is the text search configuration the search_vector columns are built with;
+// queries must use the same one so words are stemmed the same way
+const searchConfig = "english"
+
+// Matched words are marked with private-use characters, removed from the document first, so
+// the snippet can be HTML-escaped as a whole before the markers become <mark> tags. Names,
+// banners and effects are user-entered, and only the highlighting may reach clients as markup.
+const (
+	highlightStart = "\uE000"
+	highlightStop  = "\uE001"
+)
+
+// headlineOptions marks matched words in snippets and keeps them short
+const headlineOptions = `StartSel="` + highlightStart + `", StopSel="` + highlightStop + `", MaxWords=25, MinWords=10, MaxFragments=2`
+
+var highlightReplacer = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// highlightSnippet HTML-escapes a ts_headline snippet and turns its markers into <mark> tags
+func highlightSnippet(snippet string) string {
+	return highlightReplacer.Replace(html.EscapeString(snippet))
+}
+
+// searchTarget describes how one entity type is searched
+type searchTarget struct {
+	table string
+	model interface{}
+	// document is the text snippets are cut from; it should cover the columns in search_vector
+	document string
+}
+
+var searchTargets = map[string]searchTarget{
+	domain.SearchTypeTraveller: {
+		table:    "m_traveller",
+		model:    &domain.Traveller{},
+		document: "concat_ws(' · ', m_traveller.name, m_traveller.banner)",
+	},
+	domain.SearchTypeAccessory: {
+		table:    "m_accessory",
+		model:    &domain.Accessory{},
+		document: "concat_ws(' · ', m_accessory.name, m_accessory.effect)",
+	},
+}
+
+type searchRepository struct {
+	db     *gorm.DB
+	logger *logging.Logger
+}
+
+func NewSearchRepository(db *gorm.DB, logger *logging.Logger) *searchRepository {
+	return &searchRepository{
+		db:     db,
+		logger: logger.Named("repository.search"),
+	}
+}
+
+// Search returns one page of the entityType rows whose search_vector matches query, best match first.
+// Query uses web search syntax: quoted phrases, "or", and a leading "-" to exclude a word.
+func (r *searchRepository) Search(ctx context.Context, entityType, query string, offset, limit int) (result []domain.SearchHit, total int64, err error) {
+	target, ok := searchTargets[entityType]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown search type %q", entityType)
+	}
+
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.search", "SearchRepository.Search", "select", target.table,
+		attribute.String("search.type", entityType),
+	)
+	defer op.End(err)
+
+	base := r.db.WithContext(ctx).Model(target.model).
+		Joins("CROSS JOIN websearch_to_tsquery(?, ?) AS tsq", searchConfig, query).
+		Where(target.table + ".search_vector @@ tsq")
+
+	err = base.Session(&gorm.Session{}).Count(&total).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to count search results", zap.String("search.type", entityType), zap.Error(err))
+		return
+	}
+
+	// Normalization 32 scales the rank into 0..1 so it reads the same across types
+	err = base.
+		Select(fmt.Sprintf(
+			"%[1]s.id, %[1]s.slug, %[1]s.name, ts_rank_cd(%[1]s.search_vector, tsq, 32) AS rank, ts_headline(?, translate(%[2]s, ?, ''), tsq, ?) AS snippet",
+			target.table, target.document,
+		), searchConfig, highlightStart+highlightStop, headlineOptions).
+		Order("rank DESC").
+		Order(target.table + ".id").
+		Offset(offset).
+		Limit(limit).
+		Scan(&result).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to search", zap.String("search.type", entityType), zap.Error(err))
+		return
+	}
+
+	for i := range result {
+		result[i].Type = entityType
+		result[i].Snippet = highlightSnippet(result[i].Snippet)
+	}
+
+	return
+}
+
+// Suggest returns up to limit names of entityType that start with query or closely resemble one of
+// its words, names starting with query first. Both conditions are served by a trigram index on name.
+func (r *searchRepository) Suggest(ctx context.Context, entityType, query string, limit int) (result []domain.Suggestion, err error) {
+	target, ok := searchTargets[entityType]
+	if !ok {
+		return nil, fmt.Errorf("unknown search type %q", entityType)
+	}
+
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.search", "SearchRepository.Suggest", "select", target.table,
+		attribute.String("search.type", entityType),
+	)
+	defer op.End(err)
+
+	name := target.table + ".name"
+	prefix := filterexpr.EscapeLike(query) + "%"
+	err = r.db.WithContext(ctx).Model(target.model).
+		Select(fmt.Sprintf("%[1]s.id, %[1]s.slug, %[2]s, word_similarity(?, %[2]s) AS score", target.table, name), query).
+		Where(fmt.Sprintf("%[1]s ILIKE ? OR ? <%% %[1]s", name), prefix, query).
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: name + " ILIKE ? DESC, score DESC, " + name, Vars: []interface{}{prefix}}}).
+		Limit(limit).
+		Scan(&result).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to suggest names", zap.String("search.type", entityType), zap.Error(err))
+		return
+	}
+
+	return
+}
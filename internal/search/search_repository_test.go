@@ -0,0 +1,159 @@
+package search
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"lizobly/ctc-db-api/pkg/publication"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type SearchRepositorySuite struct {
+	suite.Suite
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+	repo *searchRepository
+}
+
+func TestSearchRepositorySuite(t *testing.T) {
+	suite.Run(t, new(SearchRepositorySuite))
+}
+
+func (s *SearchRepositorySuite) SetupTest() {
+	var err error
+	s.db, s.mock, err = helpers.NewMockDB()
+	if err != nil {
+		s.T().Fatal()
+	}
+
+	logger, _ := logging.NewDevelopmentLogger()
+	s.repo = NewSearchRepository(s.db, logger)
+}
+
+func (s *SearchRepositorySuite) TestSearchRepository_Search() {
+	s.Run("accessories ranked with snippets", func() {
+		s.SetupTest()
+		from := `FROM "m_accessory" CROSS JOIN websearch_to_tsquery($1, $2) AS tsq WHERE m_accessory.search_vector @@ tsq AND "m_accessory"."deleted_at" IS NULL`
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) `+from)).
+			WithArgs("english", "elemental").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT m_accessory.id, m_accessory.slug, m_accessory.name, ts_rank_cd(m_accessory.search_vector, tsq, 32) AS rank, ts_headline($1, translate(concat_ws(' · ', m_accessory.name, m_accessory.effect), $2, ''), tsq, $3) AS snippet FROM "m_accessory" CROSS JOIN websearch_to_tsquery($4, $5) AS tsq WHERE m_accessory.search_vector @@ tsq AND "m_accessory"."deleted_at" IS NULL ORDER BY rank DESC,m_accessory.id LIMIT $6 OFFSET $7`)).
+			WithArgs("english", highlightStart+highlightStop, headlineOptions, "english", "elemental", 10, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug", "name", "rank", "snippet"}).
+				AddRow(3, "crown-of-wisdom", "Crown of Wisdom", 0.5, "Crown of Wisdom · Increases "+highlightStart+"elemental"+highlightStop+" damage"))
+
+		res, total, err := s.repo.Search(context.TODO(), domain.SearchTypeAccessory, "elemental", 10, 10)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(11), total)
+		assert.Equal(s.T(), []domain.SearchHit{{
+			ID: 3, Type: domain.SearchTypeAccessory, Slug: "crown-of-wisdom", Name: "Crown of Wisdom", Rank: 0.5,
+			Snippet: "Crown of Wisdom · Increases <mark>elemental</mark> damage",
+		}}, res)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("travellers", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "m_traveller" CROSS JOIN websearch_to_tsquery($1, $2) AS tsq WHERE m_traveller.search_vector @@ tsq`)).
+			WithArgs("english", "fiore").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`ts_headline($1, translate(concat_ws(' · ', m_traveller.name, m_traveller.banner), $2, ''), tsq, $3) AS snippet FROM "m_traveller"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug", "name", "rank", "snippet"}))
+
+		res, total, err := s.repo.Search(context.TODO(), domain.SearchTypeTraveller, "fiore", 0, 10)
+		assert.NoError(s.T(), err)
+		assert.Zero(s.T(), total)
+		assert.Empty(s.T(), res)
+	})
+
+	s.Run("user-entered markup is escaped in snippets", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "m_accessory"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`ts_headline(`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug", "name", "rank", "snippet"}).
+				AddRow(9, "cursed-ring", "Cursed Ring", 0.3, `Cursed Ring · <script>alert("x")</script> `+highlightStart+"poison"+highlightStop+" & <mark>"))
+
+		res, _, err := s.repo.Search(context.TODO(), domain.SearchTypeAccessory, "poison", 0, 10)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), `Cursed Ring · &lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; <mark>poison</mark> &amp; &lt;mark&gt;`, res[0].Snippet)
+	})
+
+	s.Run("unknown type", func() {
+		s.SetupTest()
+		_, _, err := s.repo.Search(context.TODO(), "user", "isla", 0, 10)
+		assert.EqualError(s.T(), err, `unknown search type "user"`)
+	})
+
+	s.Run("database error", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*)`)).WillReturnError(gorm.ErrInvalidDB)
+
+		_, _, err := s.repo.Search(context.TODO(), domain.SearchTypeTraveller, "fiore", 0, 10)
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+	})
+}
+
+func (s *SearchRepositorySuite) TestSearchRepository_HidesDrafts() {
+	s.SetupTest()
+	s.Require().NoError(publication.Register(s.db))
+	published := `"m_traveller"."status" = $3 AND "m_traveller"."deleted_at" IS NULL`
+
+	s.Run("search counts and returns only published rows", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "m_traveller" CROSS JOIN websearch_to_tsquery($1, $2) AS tsq WHERE m_traveller.search_vector @@ tsq AND `+published)).
+			WithArgs("english", "fiore", domain.PublishStatusPublished).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`WHERE m_traveller.search_vector @@ tsq AND "m_traveller"."status" = $6 AND "m_traveller"."deleted_at" IS NULL`)).
+			WithArgs("english", highlightStart+highlightStop, headlineOptions, "english", "fiore", domain.PublishStatusPublished, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug", "name", "rank", "snippet"}))
+
+		_, _, err := s.repo.Search(context.TODO(), domain.SearchTypeTraveller, "fiore", 0, 10)
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("suggest only names published rows", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`WHERE (m_traveller.name ILIKE $2 OR $3 <% m_traveller.name) AND "m_traveller"."status" = $4 AND "m_traveller"."deleted_at" IS NULL`)).
+			WithArgs("fi", "fi%", "fi", domain.PublishStatusPublished, "fi%", 5).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug", "name", "score"}))
+
+		_, err := s.repo.Suggest(context.TODO(), domain.SearchTypeTraveller, "fi", 5)
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *SearchRepositorySuite) TestSearchRepository_Suggest() {
+	s.Run("prefix matches first then similar names", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT m_traveller.id, m_traveller.slug, m_traveller.name, word_similarity($1, m_traveller.name) AS score FROM "m_traveller" WHERE (m_traveller.name ILIKE $2 OR $3 <% m_traveller.name) AND "m_traveller"."deleted_at" IS NULL ORDER BY m_traveller.name ILIKE $4 DESC, score DESC, m_traveller.name LIMIT $5`)).
+			WithArgs("fi_", `fi\_%`, "fi_", `fi\_%`, 5).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug", "name", "score"}).AddRow(1, "fiore", "Fiore", 0.4))
+
+		res, err := s.repo.Suggest(context.TODO(), domain.SearchTypeTraveller, "fi_", 5)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), []domain.Suggestion{{ID: 1, Slug: "fiore", Name: "Fiore", Score: 0.4}}, res)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("unknown type", func() {
+		s.SetupTest()
+		_, err := s.repo.Suggest(context.TODO(), "user", "isla", 5)
+		assert.EqualError(s.T(), err, `unknown search type "user"`)
+	})
+
+	s.Run("database error", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`FROM "m_accessory"`)).WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := s.repo.Suggest(context.TODO(), domain.SearchTypeAccessory, "crown", 5)
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+	})
+}
@@ -0,0 +1,122 @@
+package search
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"lizobly/ctc-db-api/pkg/telemetry"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type SearchRepository interface {
+	Search(ctx context.Context, entityType, query string, offset, limit int) (result []domain.SearchHit, total int64, err error)
+	Suggest(ctx context.Context, entityType, query string, limit int) (result []domain.Suggestion, err error)
+}
+
+type searchService struct {
+	searchRepo SearchRepository
+	logger     *logging.Logger
+}
+
+func NewSearchService(r SearchRepository, logger *logging.Logger) *searchService {
+	return &searchService{
+		searchRepo: r,
+		logger:     logger.Named("service.search"),
+	}
+}
+
+// Search runs the query against every requested entity type and returns the same page of each
+func (s *searchService) Search(ctx context.Context, input domain.SearchRequest, params helpers.PaginationParams) (res domain.SearchResponse, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.search", "SearchService.Search",
+		attribute.String("search.type", input.Type),
+		attribute.Int("page", params.Page),
+		attribute.Int("page_size", params.PageSize),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	params.Normalize()
+
+	input.Query = strings.TrimSpace(input.Query)
+	if input.Query == "" {
+		err = domain.NewValidationError([]domain.FieldError{{Field: "q", Message: "q must not be blank"}})
+		return
+	}
+
+	// Keep the canonical group order whatever order the types were requested in
+	input.Types = domain.SearchTypes
+	if input.Type != "" {
+		requested := make(map[string]bool)
+		for _, t := range strings.Split(input.Type, ",") {
+			requested[strings.TrimSpace(t)] = true
+		}
+		input.Types = nil
+		for _, t := range domain.SearchTypes {
+			if requested[t] {
+				input.Types = append(input.Types, t)
+			}
+		}
+	}
+
+	res = domain.SearchResponse{
+		Query:    input.Query,
+		Page:     params.Page,
+		PageSize: params.PageSize,
+		Groups:   make([]domain.SearchGroup, 0, len(input.Types)),
+	}
+	for _, t := range input.Types {
+		hits, total, searchErr := s.searchRepo.Search(ctx, t, input.Query, params.Offset(), params.PageSize)
+		if searchErr != nil {
+			err = searchErr
+			return
+		}
+		if hits == nil {
+			hits = []domain.SearchHit{}
+		}
+		res.Groups = append(res.Groups, domain.SearchGroup{
+			Type:       t,
+			Data:       hits,
+			Total:      total,
+			TotalPages: helpers.CalculateTotalPages(total, params.PageSize),
+		})
+	}
+
+	return
+}
+
+// Suggest returns name completions for search-as-you-type; type defaults to traveller
+func (s *searchService) Suggest(ctx context.Context, input domain.SuggestRequest) (res domain.SuggestResponse, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.search", "SearchService.Suggest",
+		attribute.String("search.type", input.Type),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	input.Query = strings.TrimSpace(input.Query)
+	if input.Query == "" {
+		err = domain.NewValidationError([]domain.FieldError{{Field: "q", Message: "q must not be blank"}})
+		return
+	}
+	if input.Type == "" {
+		input.Type = domain.SearchTypeTraveller
+	}
+	if input.Limit < 1 {
+		input.Limit = domain.DefaultSuggestLimit
+	}
+
+	suggestions, err := s.searchRepo.Suggest(ctx, input.Type, input.Query, input.Limit)
+	if err != nil {
+		return
+	}
+	if suggestions == nil {
+		suggestions = []domain.Suggestion{}
+	}
+
+	res = domain.SuggestResponse{
+		Query:       input.Query,
+		Type:        input.Type,
+		Suggestions: suggestions,
+	}
+	return
+}
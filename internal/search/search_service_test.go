@@ -0,0 +1,126 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"lizobly/ctc-db-api/internal/search/mocks"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type SearchServiceSuite struct {
+	suite.Suite
+	searchRepo *mocks.MockSearchRepository
+	svc        *searchService
+}
+
+func TestSearchServiceSuite(t *testing.T) {
+	suite.Run(t, new(SearchServiceSuite))
+}
+
+func (s *SearchServiceSuite) SetupTest() {
+	logger, _ := logging.NewDevelopmentLogger()
+
+	s.searchRepo = new(mocks.MockSearchRepository)
+	s.svc = NewSearchService(s.searchRepo, logger)
+}
+
+func (s *SearchServiceSuite) TearDownTest() {
+	s.searchRepo.AssertExpectations(s.T())
+}
+
+func (s *SearchServiceSuite) TestSearchService_Search() {
+	s.Run("groups every type in order", func() {
+		s.SetupTest()
+		hit := domain.SearchHit{ID: 3, Type: domain.SearchTypeAccessory, Name: "Crown of Wisdom", Rank: 0.5}
+		s.searchRepo.On("Search", mock.Anything, domain.SearchTypeTraveller, "element", 10, 5).Return(nil, int64(0), nil).Once()
+		s.searchRepo.On("Search", mock.Anything, domain.SearchTypeAccessory, "element", 10, 5).Return([]domain.SearchHit{hit}, int64(11), nil).Once()
+
+		res, err := s.svc.Search(context.TODO(), domain.SearchRequest{Query: "  element "}, helpers.PaginationParams{Page: 3, PageSize: 5})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), domain.SearchResponse{
+			Query:    "element",
+			Page:     3,
+			PageSize: 5,
+			Groups: []domain.SearchGroup{
+				{Type: domain.SearchTypeTraveller, Data: []domain.SearchHit{}},
+				{Type: domain.SearchTypeAccessory, Data: []domain.SearchHit{hit}, Total: 11, TotalPages: 3},
+			},
+		}, res)
+	})
+
+	s.Run("restricted to requested types", func() {
+		s.SetupTest()
+		s.searchRepo.On("Search", mock.Anything, domain.SearchTypeAccessory, "ring", 0, 10).Return([]domain.SearchHit{}, int64(0), nil).Once()
+
+		res, err := s.svc.Search(context.TODO(), domain.SearchRequest{Query: "ring", Type: " accessory"}, helpers.PaginationParams{})
+		assert.NoError(s.T(), err)
+		assert.Len(s.T(), res.Groups, 1)
+		assert.Equal(s.T(), domain.SearchTypeAccessory, res.Groups[0].Type)
+	})
+
+	s.Run("blank query", func() {
+		s.SetupTest()
+		_, err := s.svc.Search(context.TODO(), domain.SearchRequest{Query: "   "}, helpers.PaginationParams{})
+
+		var ve *domain.ValidationError
+		assert.True(s.T(), errors.As(err, &ve), "expected ValidationError")
+		assert.Equal(s.T(), "q", ve.Errors[0].Field)
+	})
+
+	s.Run("repository error", func() {
+		s.SetupTest()
+		s.searchRepo.On("Search", mock.Anything, domain.SearchTypeTraveller, "fiore", 0, 10).Return(nil, int64(0), gorm.ErrInvalidDB).Once()
+
+		_, err := s.svc.Search(context.TODO(), domain.SearchRequest{Query: "fiore"}, helpers.PaginationParams{})
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+	})
+}
+
+func (s *SearchServiceSuite) TestSearchService_Suggest() {
+	s.Run("defaults to travellers", func() {
+		s.SetupTest()
+		s.searchRepo.On("Suggest", mock.Anything, domain.SearchTypeTraveller, "fio", domain.DefaultSuggestLimit).
+			Return([]domain.Suggestion{{ID: 1, Name: "Fiore", Score: 0.4}}, nil).Once()
+
+		res, err := s.svc.Suggest(context.TODO(), domain.SuggestRequest{Query: "fio "})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), domain.SuggestResponse{
+			Query:       "fio",
+			Type:        domain.SearchTypeTraveller,
+			Suggestions: []domain.Suggestion{{ID: 1, Name: "Fiore", Score: 0.4}},
+		}, res)
+	})
+
+	s.Run("accessories with limit and no matches", func() {
+		s.SetupTest()
+		s.searchRepo.On("Suggest", mock.Anything, domain.SearchTypeAccessory, "qzx", 2).Return(nil, nil).Once()
+
+		res, err := s.svc.Suggest(context.TODO(), domain.SuggestRequest{Query: "qzx", Type: domain.SearchTypeAccessory, Limit: 2})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), []domain.Suggestion{}, res.Suggestions)
+	})
+
+	s.Run("blank query", func() {
+		s.SetupTest()
+		_, err := s.svc.Suggest(context.TODO(), domain.SuggestRequest{Query: " "})
+
+		var ve *domain.ValidationError
+		assert.True(s.T(), errors.As(err, &ve), "expected ValidationError")
+	})
+
+	s.Run("repository error", func() {
+		s.SetupTest()
+		s.searchRepo.On("Suggest", mock.Anything, domain.SearchTypeTraveller, "fio", domain.DefaultSuggestLimit).Return(nil, gorm.ErrInvalidDB).Once()
+
+		_, err := s.svc.Suggest(context.TODO(), domain.SuggestRequest{Query: "fio"})
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+	})
+}
@@ -0,0 +1,261 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTrashRepository creates a new instance of MockTrashRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTrashRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTrashRepository {
+	mock := &MockTrashRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTrashRepository is an autogenerated mock type for the TrashRepository type
+type MockTrashRepository struct {
+	mock.Mock
+}
+
+type MockTrashRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTrashRepository) EXPECT() *MockTrashRepository_Expecter {
+	return &MockTrashRepository_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function for the type MockTrashRepository
+func (_mock *MockTrashRepository) List(ctx context.Context, entityTypes []string, offset int, limit int) ([]domain.TrashItem, int64, error) {
+	ret := _mock.Called(ctx, entityTypes, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.TrashItem
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, int, int) ([]domain.TrashItem, int64, error)); ok {
+		return returnFunc(ctx, entityTypes, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, int, int) []domain.TrashItem); ok {
+		r0 = returnFunc(ctx, entityTypes, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TrashItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, int, int) int64); ok {
+		r1 = returnFunc(ctx, entityTypes, offset, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, []string, int, int) error); ok {
+		r2 = returnFunc(ctx, entityTypes, offset, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockTrashRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockTrashRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityTypes []string
+//   - offset int
+//   - limit int
+func (_e *MockTrashRepository_Expecter) List(ctx interface{}, entityTypes interface{}, offset interface{}, limit interface{}) *MockTrashRepository_List_Call {
+	return &MockTrashRepository_List_Call{Call: _e.mock.On("List", ctx, entityTypes, offset, limit)}
+}
+
+func (_c *MockTrashRepository_List_Call) Run(run func(ctx context.Context, entityTypes []string, offset int, limit int)) *MockTrashRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrashRepository_List_Call) Return(result []domain.TrashItem, total int64, err error) *MockTrashRepository_List_Call {
+	_c.Call.Return(result, total, err)
+	return _c
+}
+
+func (_c *MockTrashRepository_List_Call) RunAndReturn(run func(ctx context.Context, entityTypes []string, offset int, limit int) ([]domain.TrashItem, int64, error)) *MockTrashRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Purge provides a mock function for the type MockTrashRepository
+func (_mock *MockTrashRepository) Purge(ctx context.Context, entityType string, id int) error {
+	ret := _mock.Called(ctx, entityType, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = returnFunc(ctx, entityType, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTrashRepository_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type MockTrashRepository_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityType string
+//   - id int
+func (_e *MockTrashRepository_Expecter) Purge(ctx interface{}, entityType interface{}, id interface{}) *MockTrashRepository_Purge_Call {
+	return &MockTrashRepository_Purge_Call{Call: _e.mock.On("Purge", ctx, entityType, id)}
+}
+
+func (_c *MockTrashRepository_Purge_Call) Run(run func(ctx context.Context, entityType string, id int)) *MockTrashRepository_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrashRepository_Purge_Call) Return(err error) *MockTrashRepository_Purge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTrashRepository_Purge_Call) RunAndReturn(run func(ctx context.Context, entityType string, id int) error) *MockTrashRepository_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDeletedBefore provides a mock function for the type MockTrashRepository
+func (_mock *MockTrashRepository) PurgeDeletedBefore(ctx context.Context, entityType string, before time.Time) (int64, error) {
+	ret := _mock.Called(ctx, entityType, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedBefore")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, entityType, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) int64); ok {
+		r0 = returnFunc(ctx, entityType, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, entityType, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrashRepository_PurgeDeletedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeletedBefore'
+type MockTrashRepository_PurgeDeletedBefore_Call struct {
+	*mock.Call
+}
+
+// PurgeDeletedBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityType string
+//   - before time.Time
+func (_e *MockTrashRepository_Expecter) PurgeDeletedBefore(ctx interface{}, entityType interface{}, before interface{}) *MockTrashRepository_PurgeDeletedBefore_Call {
+	return &MockTrashRepository_PurgeDeletedBefore_Call{Call: _e.mock.On("PurgeDeletedBefore", ctx, entityType, before)}
+}
+
+func (_c *MockTrashRepository_PurgeDeletedBefore_Call) Run(run func(ctx context.Context, entityType string, before time.Time)) *MockTrashRepository_PurgeDeletedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrashRepository_PurgeDeletedBefore_Call) Return(purged int64, err error) *MockTrashRepository_PurgeDeletedBefore_Call {
+	_c.Call.Return(purged, err)
+	return _c
+}
+
+func (_c *MockTrashRepository_PurgeDeletedBefore_Call) RunAndReturn(run func(ctx context.Context, entityType string, before time.Time) (int64, error)) *MockTrashRepository_PurgeDeletedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
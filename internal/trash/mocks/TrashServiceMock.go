@@ -0,0 +1,241 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTrashService creates a new instance of MockTrashService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTrashService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTrashService {
+	mock := &MockTrashService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTrashService is an autogenerated mock type for the TrashService type
+type MockTrashService struct {
+	mock.Mock
+}
+
+type MockTrashService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTrashService) EXPECT() *MockTrashService_Expecter {
+	return &MockTrashService_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function for the type MockTrashService
+func (_mock *MockTrashService) List(ctx context.Context, input domain.ListTrashRequest, params helpers.PaginationParams) (helpers.PaginatedResponse[domain.TrashItem], error) {
+	ret := _mock.Called(ctx, input, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 helpers.PaginatedResponse[domain.TrashItem]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTrashRequest, helpers.PaginationParams) (helpers.PaginatedResponse[domain.TrashItem], error)); ok {
+		return returnFunc(ctx, input, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTrashRequest, helpers.PaginationParams) helpers.PaginatedResponse[domain.TrashItem]); ok {
+		r0 = returnFunc(ctx, input, params)
+	} else {
+		r0 = ret.Get(0).(helpers.PaginatedResponse[domain.TrashItem])
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListTrashRequest, helpers.PaginationParams) error); ok {
+		r1 = returnFunc(ctx, input, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrashService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockTrashService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input domain.ListTrashRequest
+//   - params helpers.PaginationParams
+func (_e *MockTrashService_Expecter) List(ctx interface{}, input interface{}, params interface{}) *MockTrashService_List_Call {
+	return &MockTrashService_List_Call{Call: _e.mock.On("List", ctx, input, params)}
+}
+
+func (_c *MockTrashService_List_Call) Run(run func(ctx context.Context, input domain.ListTrashRequest, params helpers.PaginationParams)) *MockTrashService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ListTrashRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.ListTrashRequest)
+		}
+		var arg2 helpers.PaginationParams
+		if args[2] != nil {
+			arg2 = args[2].(helpers.PaginationParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrashService_List_Call) Return(res helpers.PaginatedResponse[domain.TrashItem], err error) *MockTrashService_List_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTrashService_List_Call) RunAndReturn(run func(ctx context.Context, input domain.ListTrashRequest, params helpers.PaginationParams) (helpers.PaginatedResponse[domain.TrashItem], error)) *MockTrashService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Purge provides a mock function for the type MockTrashService
+func (_mock *MockTrashService) Purge(ctx context.Context, entityType string, id int) error {
+	ret := _mock.Called(ctx, entityType, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = returnFunc(ctx, entityType, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTrashService_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type MockTrashService_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityType string
+//   - id int
+func (_e *MockTrashService_Expecter) Purge(ctx interface{}, entityType interface{}, id interface{}) *MockTrashService_Purge_Call {
+	return &MockTrashService_Purge_Call{Call: _e.mock.On("Purge", ctx, entityType, id)}
+}
+
+func (_c *MockTrashService_Purge_Call) Run(run func(ctx context.Context, entityType string, id int)) *MockTrashService_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrashService_Purge_Call) Return(err error) *MockTrashService_Purge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTrashService_Purge_Call) RunAndReturn(run func(ctx context.Context, entityType string, id int) error) *MockTrashService_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDeletedBefore provides a mock function for the type MockTrashService
+func (_mock *MockTrashService) PurgeDeletedBefore(ctx context.Context, input domain.PurgeTrashRequest) (domain.PurgeTrashResponse, error) {
+	ret := _mock.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedBefore")
+	}
+
+	var r0 domain.PurgeTrashResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.PurgeTrashRequest) (domain.PurgeTrashResponse, error)); ok {
+		return returnFunc(ctx, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.PurgeTrashRequest) domain.PurgeTrashResponse); ok {
+		r0 = returnFunc(ctx, input)
+	} else {
+		r0 = ret.Get(0).(domain.PurgeTrashResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.PurgeTrashRequest) error); ok {
+		r1 = returnFunc(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrashService_PurgeDeletedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeletedBefore'
+type MockTrashService_PurgeDeletedBefore_Call struct {
+	*mock.Call
+}
+
+// PurgeDeletedBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input domain.PurgeTrashRequest
+func (_e *MockTrashService_Expecter) PurgeDeletedBefore(ctx interface{}, input interface{}) *MockTrashService_PurgeDeletedBefore_Call {
+	return &MockTrashService_PurgeDeletedBefore_Call{Call: _e.mock.On("PurgeDeletedBefore", ctx, input)}
+}
+
+func (_c *MockTrashService_PurgeDeletedBefore_Call) Run(run func(ctx context.Context, input domain.PurgeTrashRequest)) *MockTrashService_PurgeDeletedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.PurgeTrashRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.PurgeTrashRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrashService_PurgeDeletedBefore_Call) Return(res domain.PurgeTrashResponse, err error) *MockTrashService_PurgeDeletedBefore_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTrashService_PurgeDeletedBefore_Call) RunAndReturn(run func(ctx context.Context, input domain.PurgeTrashRequest) (domain.PurgeTrashResponse, error)) *MockTrashService_PurgeDeletedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,149 @@
+package trash
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/controller"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TrashService interface {
+	List(ctx context.Context, input domain.ListTrashRequest, params helpers.PaginationParams) (res helpers.PaginatedResponse[domain.TrashItem], err error)
+	Purge(ctx context.Context, entityType string, id int) (err error)
+	PurgeDeletedBefore(ctx context.Context, input domain.PurgeTrashRequest) (res domain.PurgeTrashResponse, err error)
+}
+
+type TrashHandler struct {
+	Service TrashService
+	logger  *logging.Logger
+}
+
+func NewTrashHandler(e *echo.Group, svc TrashService, logger *logging.Logger) *TrashHandler {
+	handler := &TrashHandler{
+		Service: svc,
+		logger:  logger.Named("handler.trash"),
+	}
+	group := e.Group("/trash")
+
+	group.GET("", handler.GetList)
+	group.DELETE("", handler.PurgeDeletedBefore)
+	group.DELETE("/:type/:id", handler.Purge)
+
+	return handler
+}
+
+// GetList godoc
+//
+//	@Summary		List deleted records
+//	@Description	list deleted travellers and accessories, most recently deleted first. Travellers can be restored with POST /travellers/{id}/restore until they are purged.
+//	@Tags			trash
+//	@Accept			json
+//	@Produce		json
+//	@Param			type		query	string	false	"Entity type (traveller, accessory); defaults to both"
+//	@Param			page		query	int		false	"Page number (default 1)"
+//	@Param			page_size	query	int		false	"Page size (default 10, max 100)"
+//	@Success		200	{object}	helpers.PaginatedResponse[domain.TrashItem]
+//	@Header			200	{string}	Link	"RFC 8288 links to the first, prev, next and last pages, keeping the request's filters"
+//	@Failure		400	{object}	controller.ErrorResponse
+//	@Failure		500	{object}	controller.ErrorResponse
+//	@Router			/trash [get]
+func (h *TrashHandler) GetList(ctx echo.Context) error {
+	var request domain.ListTrashRequest
+	err := ctx.Bind(&request)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid query parameters")
+	}
+
+	err = ctx.Validate(&request)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	var params helpers.PaginationParams
+	err = ctx.Bind(&params)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid pagination parameters")
+	}
+
+	result, err := h.Service.List(ctx.Request().Context(), request, params)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "list trash", h.logger)
+	}
+
+	helpers.SetPageLinks(ctx, &result)
+	return controller.Ok(ctx, result)
+}
+
+// PurgeDeletedBefore godoc
+//
+//	@Summary		Empty the trash
+//	@Description	permanently delete the records deleted longer ago than older_than, or the configured retention period. This cannot be undone.
+//	@Tags			trash
+//	@Accept			json
+//	@Produce		json
+//	@Param			type		query	string	false	"Entity type (traveller, accessory); defaults to both"
+//	@Param			older_than	query	string	false	"Minimum time since deletion, e.g. 720h or 0s for everything; defaults to the retention period"
+//	@Success		200	{object}	domain.PurgeTrashResponse
+//	@Failure		400	{object}	controller.ErrorResponse
+//	@Failure		500	{object}	controller.ErrorResponse
+//	@Router			/trash [delete]
+//	@Security		BearerAuth
+func (h *TrashHandler) PurgeDeletedBefore(ctx echo.Context) error {
+	var request domain.PurgeTrashRequest
+	err := ctx.Bind(&request)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid query parameters")
+	}
+
+	err = ctx.Validate(&request)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	result, err := h.Service.PurgeDeletedBefore(ctx.Request().Context(), request)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "purge trash", h.logger)
+	}
+
+	return controller.Ok(ctx, result)
+}
+
+// Purge godoc
+//
+//	@Summary		Purge deleted record
+//	@Description	permanently delete one deleted traveller or accessory. Live records can't be purged; delete them first. This cannot be undone.
+//	@Tags			trash
+//	@Accept			json
+//	@Produce		json
+//	@Param			type	path	string	true	"Entity type (traveller, accessory)"
+//	@Param			id		path	int		true	"Record ID"
+//	@Success		204	"No Content"
+//	@Failure		400	{object}	controller.ErrorResponse
+//	@Failure		404	{object}	controller.ErrorResponse	"No deleted record of the type has the ID"
+//	@Failure		500	{object}	controller.ErrorResponse
+//	@Router			/trash/{type}/{id} [delete]
+//	@Security		BearerAuth
+func (h *TrashHandler) Purge(ctx echo.Context) error {
+	entityType := ctx.Param("type")
+	if !slices.Contains(domain.TrashTypes, entityType) {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid type parameter")
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid id parameter")
+	}
+
+	err = h.Service.Purge(ctx.Request().Context(), entityType, id)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "purge", h.logger)
+	}
+
+	return controller.NoContent(ctx)
+}
@@ -0,0 +1,228 @@
+package trash
+
+import (
+	"encoding/json"
+	"lizobly/ctc-db-api/internal/trash/mocks"
+	"lizobly/ctc-db-api/pkg/controller"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type TrashHandlerSuite struct {
+	suite.Suite
+
+	e            *echo.Echo
+	trashService *mocks.MockTrashService
+	handler      *TrashHandler
+}
+
+func TestTrashHandlerSuite(t *testing.T) {
+	suite.Run(t, new(TrashHandlerSuite))
+}
+
+func (s *TrashHandlerSuite) SetupTest() {
+	s.e = echo.New()
+	s.trashService = new(mocks.MockTrashService)
+	testLogger, _ := logging.NewDevelopmentLogger()
+	s.handler = NewTrashHandler(s.e.Group(""), s.trashService, testLogger)
+}
+
+func (s *TrashHandlerSuite) TearDownTest() {
+	s.trashService.AssertExpectations(s.T())
+}
+
+func (s *TrashHandlerSuite) TestTrashHandler_GetList() {
+	deletedBy := "isla"
+	page := helpers.NewPaginatedResponse([]domain.TrashItem{
+		{ID: 4, Type: domain.TrashTypeTraveller, Slug: "viola", Name: "Viola", DeletedAt: time.Date(2024, 10, 1, 9, 30, 0, 0, time.UTC), DeletedBy: &deletedBy},
+	}, helpers.PaginationParams{Page: 1, PageSize: 10}, 1)
+
+	tests := []struct {
+		name        string
+		queryParams map[string]string
+		statusCode  int
+		beforeTest  func()
+	}{
+		{
+			name:        "success",
+			queryParams: map[string]string{"type": "traveller", "page": "1"},
+			statusCode:  http.StatusOK,
+			beforeTest: func() {
+				s.trashService.On("List", mock.Anything, domain.ListTrashRequest{Type: "traveller"}, helpers.PaginationParams{Page: 1}).
+					Return(page, nil).Once()
+			},
+		},
+		{
+			name:        "unknown type",
+			queryParams: map[string]string{"type": "user"},
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "service error",
+			queryParams: map[string]string{},
+			statusCode:  http.StatusInternalServerError,
+			beforeTest: func() {
+				s.trashService.On("List", mock.Anything, domain.ListTrashRequest{}, helpers.PaginationParams{}).
+					Return(helpers.PaginatedResponse[domain.TrashItem]{}, gorm.ErrInvalidDB).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			queryValues := url.Values{}
+			for k, v := range tt.queryParams {
+				queryValues.Set(k, v)
+			}
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/trash", nil, queryValues, nil)
+
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+
+			err := s.handler.GetList(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.statusCode, ctx.Response().Status)
+
+			if tt.statusCode == http.StatusOK {
+				var got controller.DataResponse[helpers.PaginatedResponse[domain.TrashItem]]
+				assert.NoError(s.T(), json.Unmarshal(rec.Body.Bytes(), &got))
+				assert.Equal(s.T(), page.Data, got.Data.Data)
+				assert.NotNil(s.T(), got.Data.Links)
+				assert.NotEmpty(s.T(), rec.Header().Get("Link"))
+			}
+		})
+	}
+}
+
+func (s *TrashHandlerSuite) TestTrashHandler_PurgeDeletedBefore() {
+	response := domain.PurgeTrashResponse{
+		DeletedBefore: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+		Purged:        map[string]int64{domain.TrashTypeTraveller: 3, domain.TrashTypeAccessory: 1},
+	}
+
+	tests := []struct {
+		name         string
+		queryParams  map[string]string
+		responseBody interface{}
+		statusCode   int
+		beforeTest   func()
+	}{
+		{
+			name:         "success",
+			queryParams:  map[string]string{"older_than": "720h"},
+			responseBody: controller.DataResponse[domain.PurgeTrashResponse]{Data: response},
+			statusCode:   http.StatusOK,
+			beforeTest: func() {
+				s.trashService.On("PurgeDeletedBefore", mock.Anything, domain.PurgeTrashRequest{OlderThan: "720h"}).Return(response, nil).Once()
+			},
+		},
+		{
+			name:        "failed invalid age",
+			queryParams: map[string]string{"older_than": "a month"},
+			statusCode:  http.StatusBadRequest,
+			beforeTest: func() {
+				s.trashService.On("PurgeDeletedBefore", mock.Anything, domain.PurgeTrashRequest{OlderThan: "a month"}).
+					Return(domain.PurgeTrashResponse{}, domain.NewValidationError([]domain.FieldError{{Field: "older_than", Message: "invalid"}})).Once()
+			},
+		},
+		{
+			name:        "failed unknown type",
+			queryParams: map[string]string{"type": "user"},
+			statusCode:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			queryValues := url.Values{}
+			for k, v := range tt.queryParams {
+				queryValues.Set(k, v)
+			}
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodDelete, "/trash", nil, queryValues, nil)
+
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+
+			err := s.handler.PurgeDeletedBefore(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.statusCode, ctx.Response().Status)
+
+			if tt.responseBody != nil {
+				wantRespBytes, err := json.Marshal(tt.responseBody)
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
+			}
+		})
+	}
+}
+
+func (s *TrashHandlerSuite) TestTrashHandler_Purge() {
+	tests := []struct {
+		name       string
+		entityType string
+		id         string
+		statusCode int
+		beforeTest func()
+	}{
+		{
+			name:       "success",
+			entityType: "accessory",
+			id:         "9",
+			statusCode: http.StatusNoContent,
+			beforeTest: func() {
+				s.trashService.On("Purge", mock.Anything, domain.TrashTypeAccessory, 9).Return(nil).Once()
+			},
+		},
+		{
+			name:       "failed unknown type",
+			entityType: "user",
+			id:         "9",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "failed invalid id",
+			entityType: "traveller",
+			id:         "abc",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "failed not in trash",
+			entityType: "traveller",
+			id:         "4",
+			statusCode: http.StatusNotFound,
+			beforeTest: func() {
+				s.trashService.On("Purge", mock.Anything, domain.TrashTypeTraveller, 4).
+					Return(domain.NewNotFoundError("deleted traveller", 4, nil)).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			_, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodDelete, "/trash/"+tt.entityType+"/"+tt.id, nil, nil,
+				map[string]string{"type": tt.entityType, "id": tt.id})
+
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+
+			err := s.handler.Purge(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.statusCode, ctx.Response().Status)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package trash
+
+import (
+	"context"
+	"fmt"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/logging"
+	"lizobly/ctc-db-api/pkg/telemetry"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// trashTarget describes how one entity type is listed and purged
+type trashTarget struct {
+	table string
+	model interface{}
+	// clearReferences lets go of rows about to be purged, given a subquery of their IDs,
+	// so nothing points at them once they are gone
+	clearReferences func(tx *gorm.DB, ids *gorm.DB) error
+}
+
+var trashTargets = map[string]trashTarget{
+	domain.TrashTypeTraveller: {
+		table: "m_traveller",
+		model: &domain.Traveller{},
+	},
+	domain.TrashTypeAccessory: {
+		table: "m_accessory",
+		model: &domain.Accessory{},
+		// Travellers, deleted ones included, drop a purged accessory instead of pointing at nothing
+		clearReferences: func(tx *gorm.DB, ids *gorm.DB) error {
+			return tx.Unscoped().Model(&domain.Traveller{}).
+				Where("accessory_id IN (?)", ids).
+				UpdateColumn("accessory_id", nil).Error
+		},
+	},
+}
+
+type trashRepository struct {
+	db     *gorm.DB
+	logger *logging.Logger
+}
+
+func NewTrashRepository(db *gorm.DB, logger *logging.Logger) *trashRepository {
+	return &trashRepository{
+		db:     db,
+		logger: logger.Named("repository.trash"),
+	}
+}
+
+// List returns one page of the deleted records of the given types, most recently deleted first
+func (r *trashRepository) List(ctx context.Context, entityTypes []string, offset, limit int) (result []domain.TrashItem, total int64, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.trash", "TrashRepository.List", "select", "trash",
+		attribute.StringSlice("trash.types", entityTypes),
+	)
+	defer op.End(err)
+
+	parts := make([]string, 0, len(entityTypes))
+	vars := make([]interface{}, 0, len(entityTypes))
+	for _, entityType := range entityTypes {
+		target, ok := trashTargets[entityType]
+		if !ok {
+			return nil, 0, fmt.Errorf("unknown trash type %q", entityType)
+		}
+		// entityType is one of the trashTargets keys, so it is safe to inline
+		parts = append(parts, "?")
+		vars = append(vars, r.db.Unscoped().Model(target.model).
+			Select(fmt.Sprintf("id, '%s' AS type, slug, name, deleted_at, deleted_by", entityType)).
+			Where("deleted_at IS NOT NULL"))
+	}
+	trashed := r.db.Raw(strings.Join(parts, " UNION ALL "), vars...)
+
+	err = r.db.WithContext(ctx).Table("(?) AS trash", trashed).Count(&total).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to count trash", zap.Error(err))
+		return
+	}
+
+	err = r.db.WithContext(ctx).Table("(?) AS trash", trashed).
+		Order("deleted_at DESC, type, id").
+		Offset(offset).
+		Limit(limit).
+		Scan(&result).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to list trash", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// Purge permanently deletes one deleted record. Live records are never purged.
+func (r *trashRepository) Purge(ctx context.Context, entityType string, id int) (err error) {
+	target, ok := trashTargets[entityType]
+	if !ok {
+		return fmt.Errorf("unknown trash type %q", entityType)
+	}
+
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.trash", "TrashRepository.Purge", "delete", target.table,
+		attribute.String("trash.type", entityType),
+		attribute.Int("trash.id", id),
+	)
+	defer op.End(err)
+
+	purged, err := r.purge(ctx, entityType, target, "id = ?", id)
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to purge", zap.String("trash.type", entityType), zap.Int("trash.id", id), zap.Error(err))
+		return
+	}
+	if purged == 0 {
+		return domain.NewNotFoundError("deleted "+entityType, id, nil)
+	}
+
+	return
+}
+
+// PurgeDeletedBefore permanently deletes the records of entityType deleted before the given time
+// and returns how many there were
+func (r *trashRepository) PurgeDeletedBefore(ctx context.Context, entityType string, before time.Time) (purged int64, err error) {
+	target, ok := trashTargets[entityType]
+	if !ok {
+		return 0, fmt.Errorf("unknown trash type %q", entityType)
+	}
+
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.trash", "TrashRepository.PurgeDeletedBefore", "delete", target.table,
+		attribute.String("trash.type", entityType),
+		attribute.String("trash.deleted_before", before.Format(time.RFC3339)),
+	)
+	defer op.End(err)
+
+	purged, err = r.purge(ctx, entityType, target, "deleted_at < ?", before)
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to purge", zap.String("trash.type", entityType), zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// purge hard-deletes the deleted rows of target matching the condition in a single transaction,
+// clearing references to them and dropping their external ID mappings first
+func (r *trashRepository) purge(ctx context.Context, entityType string, target trashTarget, query string, args ...interface{}) (purged int64, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		trashedIDs := func() *gorm.DB {
+			return tx.Unscoped().Model(target.model).Select("id").
+				Where("deleted_at IS NOT NULL").Where(query, args...)
+		}
+
+		if target.clearReferences != nil {
+			if err := target.clearReferences(tx, trashedIDs()); err != nil {
+				return err
+			}
+		}
+
+		err := tx.Where("entity_type = ? AND entity_id IN (?)", entityType, trashedIDs()).
+			Delete(&domain.ExternalID{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("deleted_at IS NOT NULL").Where(query, args...).Delete(target.model)
+		purged = result.RowsAffected
+		return result.Error
+	})
+
+	return
+}
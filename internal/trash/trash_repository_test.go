@@ -0,0 +1,175 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type TrashRepositorySuite struct {
+	suite.Suite
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+	repo *trashRepository
+}
+
+func TestTrashRepositorySuite(t *testing.T) {
+	suite.Run(t, new(TrashRepositorySuite))
+}
+
+func (s *TrashRepositorySuite) SetupTest() {
+	var err error
+	s.db, s.mock, err = helpers.NewMockDB()
+	if err != nil {
+		s.T().Fatal()
+	}
+
+	logger, _ := logging.NewDevelopmentLogger()
+	s.repo = NewTrashRepository(s.db, logger)
+}
+
+func (s *TrashRepositorySuite) TestTrashRepository_List() {
+	trashedTravellers := `SELECT id, 'traveller' AS type, slug, name, deleted_at, deleted_by FROM "m_traveller" WHERE deleted_at IS NOT NULL`
+	trashedAccessories := `SELECT id, 'accessory' AS type, slug, name, deleted_at, deleted_by FROM "m_accessory" WHERE deleted_at IS NOT NULL`
+	deletedAt := time.Date(2024, 10, 1, 9, 30, 0, 0, time.UTC)
+	deletedBy := "isla"
+
+	s.Run("every type, most recently deleted first", func() {
+		s.SetupTest()
+		from := `FROM (` + trashedTravellers + ` UNION ALL ` + trashedAccessories + `) AS trash`
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) ` + from)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * `+from+` ORDER BY deleted_at DESC, type, id LIMIT $1 OFFSET $2`)).
+			WithArgs(10, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "type", "slug", "name", "deleted_at", "deleted_by"}).
+				AddRow(4, "traveller", "viola", "Viola", deletedAt, deletedBy).
+				AddRow(9, "accessory", "crown-of-wisdom", "Crown of Wisdom", deletedAt, nil))
+
+		res, total, err := s.repo.List(context.TODO(), domain.TrashTypes, 10, 10)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(12), total)
+		assert.Equal(s.T(), []domain.TrashItem{
+			{ID: 4, Type: domain.TrashTypeTraveller, Slug: "viola", Name: "Viola", DeletedAt: deletedAt, DeletedBy: &deletedBy},
+			{ID: 9, Type: domain.TrashTypeAccessory, Slug: "crown-of-wisdom", Name: "Crown of Wisdom", DeletedAt: deletedAt},
+		}, res)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("one type", func() {
+		s.SetupTest()
+		from := `FROM (` + trashedAccessories + `) AS trash`
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) ` + from)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * ` + from)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "type", "slug", "name", "deleted_at", "deleted_by"}))
+
+		res, total, err := s.repo.List(context.TODO(), []string{domain.TrashTypeAccessory}, 0, 10)
+		assert.NoError(s.T(), err)
+		assert.Zero(s.T(), total)
+		assert.Empty(s.T(), res)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("unknown type", func() {
+		s.SetupTest()
+		_, _, err := s.repo.List(context.TODO(), []string{"user"}, 0, 10)
+		assert.EqualError(s.T(), err, `unknown trash type "user"`)
+	})
+}
+
+func (s *TrashRepositorySuite) TestTrashRepository_Purge() {
+	s.Run("traveller and its external IDs", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "m_external_id" WHERE entity_type = $1 AND entity_id IN (SELECT "id" FROM "m_traveller" WHERE deleted_at IS NOT NULL AND id = $2)`)).
+			WithArgs(domain.ExternalEntityTraveller, 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "m_traveller" WHERE deleted_at IS NOT NULL AND id = $1`)).
+			WithArgs(4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.Purge(context.TODO(), domain.TrashTypeTraveller, 4)
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("accessory is dropped by travellers first", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "accessory_id"=$1 WHERE accessory_id IN (SELECT "id" FROM "m_accessory" WHERE deleted_at IS NOT NULL AND id = $2)`)).
+			WithArgs(nil, 9).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "m_external_id"`)).
+			WithArgs(domain.ExternalEntityAccessory, 9).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "m_accessory" WHERE deleted_at IS NOT NULL AND id = $1`)).
+			WithArgs(9).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.Purge(context.TODO(), domain.TrashTypeAccessory, 9)
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("failed not in trash", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "m_external_id"`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "m_traveller"`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectCommit()
+
+		err := s.repo.Purge(context.TODO(), domain.TrashTypeTraveller, 4)
+		var nfe *domain.NotFoundError
+		assert.True(s.T(), errors.As(err, &nfe), "expected NotFoundError")
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *TrashRepositorySuite) TestTrashRepository_PurgeDeletedBefore() {
+	before := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	s.Run("success", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "m_external_id" WHERE entity_type = $1 AND entity_id IN (SELECT "id" FROM "m_traveller" WHERE deleted_at IS NOT NULL AND deleted_at < $2)`)).
+			WithArgs(domain.ExternalEntityTraveller, before).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "m_traveller" WHERE deleted_at IS NOT NULL AND deleted_at < $1`)).
+			WithArgs(before).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		s.mock.ExpectCommit()
+
+		purged, err := s.repo.PurgeDeletedBefore(context.TODO(), domain.TrashTypeTraveller, before)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(3), purged)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("failed rolls back", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "m_external_id"`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "m_traveller"`)).
+			WillReturnError(gorm.ErrInvalidDB)
+		s.mock.ExpectRollback()
+
+		_, err := s.repo.PurgeDeletedBefore(context.TODO(), domain.TrashTypeTraveller, before)
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
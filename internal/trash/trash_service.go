@@ -0,0 +1,132 @@
+package trash
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"lizobly/ctc-db-api/pkg/telemetry"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+type TrashRepository interface {
+	List(ctx context.Context, entityTypes []string, offset, limit int) (result []domain.TrashItem, total int64, err error)
+	Purge(ctx context.Context, entityType string, id int) (err error)
+	PurgeDeletedBefore(ctx context.Context, entityType string, before time.Time) (purged int64, err error)
+}
+
+// trashService lists deleted records and purges them for good, either on request or once they
+// have been deleted longer than the retention period
+type trashService struct {
+	trashRepo TrashRepository
+	retention time.Duration
+	logger    *logging.Logger
+}
+
+func NewTrashService(r TrashRepository, retention time.Duration, logger *logging.Logger) *trashService {
+	return &trashService{
+		trashRepo: r,
+		retention: retention,
+		logger:    logger.Named("service.trash"),
+	}
+}
+
+// List returns one page of deleted records, most recently deleted first
+func (s *trashService) List(ctx context.Context, input domain.ListTrashRequest, params helpers.PaginationParams) (res helpers.PaginatedResponse[domain.TrashItem], err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.trash", "TrashService.List",
+		attribute.String("trash.type", input.Type),
+		attribute.Int("page", params.Page),
+		attribute.Int("page_size", params.PageSize),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	params.Normalize()
+
+	items, total, err := s.trashRepo.List(ctx, trashTypes(input.Type), params.Offset(), params.PageSize)
+	if err != nil {
+		return
+	}
+	if items == nil {
+		items = []domain.TrashItem{}
+	}
+
+	res = helpers.NewPaginatedResponse(items, params, total)
+	return
+}
+
+// Purge permanently deletes one deleted record
+func (s *trashService) Purge(ctx context.Context, entityType string, id int) (err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.trash", "TrashService.Purge",
+		attribute.String("trash.type", entityType),
+		attribute.Int("trash.id", id),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	return s.trashRepo.Purge(ctx, entityType, id)
+}
+
+// PurgeDeletedBefore permanently deletes the records deleted longer ago than input.OlderThan,
+// or the retention period if it is not given
+func (s *trashService) PurgeDeletedBefore(ctx context.Context, input domain.PurgeTrashRequest) (res domain.PurgeTrashResponse, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.trash", "TrashService.PurgeDeletedBefore",
+		attribute.String("trash.type", input.Type),
+		attribute.String("trash.older_than", input.OlderThan),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	err = input.ParseOlderThan(time.Now(), s.retention)
+	if err != nil {
+		return
+	}
+
+	res = domain.PurgeTrashResponse{
+		DeletedBefore: input.DeletedBefore,
+		Purged:        make(map[string]int64),
+	}
+	for _, t := range trashTypes(input.Type) {
+		purged, purgeErr := s.trashRepo.PurgeDeletedBefore(ctx, t, input.DeletedBefore)
+		if purgeErr != nil {
+			err = purgeErr
+			return
+		}
+		res.Purged[t] = purged
+	}
+
+	return
+}
+
+// RunRetention purges the records deleted longer ago than the retention period right away and
+// then every interval, until ctx is done. Failures are logged and retried on the next tick.
+func (s *trashService) RunRetention(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := s.PurgeDeletedBefore(ctx, domain.PurgeTrashRequest{})
+		if err != nil {
+			s.logger.WithContext(ctx).Error("failed to purge expired trash", zap.Error(err))
+		} else {
+			s.logger.WithContext(ctx).Info("purged expired trash",
+				zap.Time("trash.deleted_before", res.DeletedBefore),
+				zap.Any("trash.purged", res.Purged),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// trashTypes returns the requested type, or every type in purge order if none was requested
+func trashTypes(entityType string) []string {
+	if entityType == "" {
+		return domain.TrashTypes
+	}
+	return []string{entityType}
+}
@@ -0,0 +1,132 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"lizobly/ctc-db-api/internal/trash/mocks"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type TrashServiceSuite struct {
+	suite.Suite
+	trashRepo *mocks.MockTrashRepository
+	svc       *trashService
+}
+
+func TestTrashServiceSuite(t *testing.T) {
+	suite.Run(t, new(TrashServiceSuite))
+}
+
+func (s *TrashServiceSuite) SetupTest() {
+	logger, _ := logging.NewDevelopmentLogger()
+
+	s.trashRepo = new(mocks.MockTrashRepository)
+	s.svc = NewTrashService(s.trashRepo, 24*time.Hour, logger)
+}
+
+func (s *TrashServiceSuite) TearDownTest() {
+	s.trashRepo.AssertExpectations(s.T())
+}
+
+// deletedAround matches a cutoff within a second of now minus age
+func deletedAround(age time.Duration) interface{} {
+	return mock.MatchedBy(func(before time.Time) bool {
+		return before.Sub(time.Now().Add(-age)).Abs() < time.Second
+	})
+}
+
+func (s *TrashServiceSuite) TestTrashService_List() {
+	s.Run("every type", func() {
+		s.SetupTest()
+		item := domain.TrashItem{ID: 4, Type: domain.TrashTypeTraveller, Name: "Viola"}
+		s.trashRepo.On("List", mock.Anything, domain.TrashTypes, 10, 5).Return([]domain.TrashItem{item}, int64(11), nil).Once()
+
+		res, err := s.svc.List(context.TODO(), domain.ListTrashRequest{}, helpers.PaginationParams{Page: 3, PageSize: 5})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), helpers.NewPaginatedResponse([]domain.TrashItem{item}, helpers.PaginationParams{Page: 3, PageSize: 5}, 11), res)
+	})
+
+	s.Run("one type, empty", func() {
+		s.SetupTest()
+		s.trashRepo.On("List", mock.Anything, []string{domain.TrashTypeAccessory}, 0, helpers.DefaultPageSize).Return(nil, int64(0), nil).Once()
+
+		res, err := s.svc.List(context.TODO(), domain.ListTrashRequest{Type: domain.TrashTypeAccessory}, helpers.PaginationParams{})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), []domain.TrashItem{}, res.Data)
+	})
+}
+
+func (s *TrashServiceSuite) TestTrashService_Purge() {
+	s.SetupTest()
+	s.trashRepo.On("Purge", mock.Anything, domain.TrashTypeAccessory, 9).Return(nil).Once()
+
+	err := s.svc.Purge(context.TODO(), domain.TrashTypeAccessory, 9)
+	assert.NoError(s.T(), err)
+}
+
+func (s *TrashServiceSuite) TestTrashService_PurgeDeletedBefore() {
+	s.Run("travellers then accessories past the retention period", func() {
+		s.SetupTest()
+		purgeTravellers := s.trashRepo.On("PurgeDeletedBefore", mock.Anything, domain.TrashTypeTraveller, deletedAround(24*time.Hour)).Return(int64(3), nil).Once()
+		s.trashRepo.On("PurgeDeletedBefore", mock.Anything, domain.TrashTypeAccessory, deletedAround(24*time.Hour)).Return(int64(1), nil).Once().
+			NotBefore(purgeTravellers)
+
+		res, err := s.svc.PurgeDeletedBefore(context.TODO(), domain.PurgeTrashRequest{})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), map[string]int64{domain.TrashTypeTraveller: 3, domain.TrashTypeAccessory: 1}, res.Purged)
+		assert.WithinDuration(s.T(), time.Now().Add(-24*time.Hour), res.DeletedBefore, time.Second)
+	})
+
+	s.Run("requested age and type", func() {
+		s.SetupTest()
+		s.trashRepo.On("PurgeDeletedBefore", mock.Anything, domain.TrashTypeAccessory, deletedAround(0)).Return(int64(2), nil).Once()
+
+		res, err := s.svc.PurgeDeletedBefore(context.TODO(), domain.PurgeTrashRequest{Type: domain.TrashTypeAccessory, OlderThan: "0s"})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), map[string]int64{domain.TrashTypeAccessory: 2}, res.Purged)
+	})
+
+	s.Run("failed invalid age", func() {
+		s.SetupTest()
+		_, err := s.svc.PurgeDeletedBefore(context.TODO(), domain.PurgeTrashRequest{OlderThan: "a month"})
+		var ve *domain.ValidationError
+		assert.True(s.T(), errors.As(err, &ve))
+	})
+
+	s.Run("failed repository error", func() {
+		s.SetupTest()
+		s.trashRepo.On("PurgeDeletedBefore", mock.Anything, domain.TrashTypeTraveller, mock.Anything).Return(int64(0), gorm.ErrInvalidDB).Once()
+
+		_, err := s.svc.PurgeDeletedBefore(context.TODO(), domain.PurgeTrashRequest{})
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+	})
+}
+
+func (s *TrashServiceSuite) TestTrashService_RunRetention() {
+	s.SetupTest()
+	ctx, cancel := context.WithCancel(context.Background())
+	s.trashRepo.On("PurgeDeletedBefore", mock.Anything, domain.TrashTypeTraveller, deletedAround(24*time.Hour)).Return(int64(0), nil)
+	s.trashRepo.On("PurgeDeletedBefore", mock.Anything, domain.TrashTypeAccessory, deletedAround(24*time.Hour)).Return(int64(0), nil).
+		Run(func(mock.Arguments) { cancel() })
+
+	done := make(chan struct{})
+	go func() {
+		s.svc.RunRetention(ctx, time.Hour)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.T().Fatal("RunRetention did not stop once its context was done")
+	}
+}
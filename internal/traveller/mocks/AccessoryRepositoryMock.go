@@ -0,0 +1,140 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAccessoryRepository creates a new instance of MockAccessoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAccessoryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAccessoryRepository {
+	mock := &MockAccessoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAccessoryRepository is an autogenerated mock type for the AccessoryRepository type
+type MockAccessoryRepository struct {
+	mock.Mock
+}
+
+type MockAccessoryRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAccessoryRepository) EXPECT() *MockAccessoryRepository_Expecter {
+	return &MockAccessoryRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetList provides a mock function for the type MockAccessoryRepository
+func (_mock *MockAccessoryRepository) GetList(ctx context.Context, filter domain.ListAccessoryRequest, offset int, limit int) ([]*domain.Accessory, map[int64]string, int64, time.Time, error) {
+	ret := _mock.Called(ctx, filter, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetList")
+	}
+
+	var r0 []*domain.Accessory
+	var r1 map[int64]string
+	var r2 int64
+	var r3 time.Time
+	var r4 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListAccessoryRequest, int, int) ([]*domain.Accessory, map[int64]string, int64, time.Time, error)); ok {
+		return returnFunc(ctx, filter, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListAccessoryRequest, int, int) []*domain.Accessory); ok {
+		r0 = returnFunc(ctx, filter, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Accessory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListAccessoryRequest, int, int) map[int64]string); ok {
+		r1 = returnFunc(ctx, filter, offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(map[int64]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, domain.ListAccessoryRequest, int, int) int64); ok {
+		r2 = returnFunc(ctx, filter, offset, limit)
+	} else {
+		r2 = ret.Get(2).(int64)
+	}
+	if returnFunc, ok := ret.Get(3).(func(context.Context, domain.ListAccessoryRequest, int, int) time.Time); ok {
+		r3 = returnFunc(ctx, filter, offset, limit)
+	} else {
+		r3 = ret.Get(3).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(4).(func(context.Context, domain.ListAccessoryRequest, int, int) error); ok {
+		r4 = returnFunc(ctx, filter, offset, limit)
+	} else {
+		r4 = ret.Error(4)
+	}
+	return r0, r1, r2, r3, r4
+}
+
+// MockAccessoryRepository_GetList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetList'
+type MockAccessoryRepository_GetList_Call struct {
+	*mock.Call
+}
+
+// GetList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ListAccessoryRequest
+//   - offset int
+//   - limit int
+func (_e *MockAccessoryRepository_Expecter) GetList(ctx interface{}, filter interface{}, offset interface{}, limit interface{}) *MockAccessoryRepository_GetList_Call {
+	return &MockAccessoryRepository_GetList_Call{Call: _e.mock.On("GetList", ctx, filter, offset, limit)}
+}
+
+func (_c *MockAccessoryRepository_GetList_Call) Run(run func(ctx context.Context, filter domain.ListAccessoryRequest, offset int, limit int)) *MockAccessoryRepository_GetList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ListAccessoryRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.ListAccessoryRequest)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccessoryRepository_GetList_Call) Return(result []*domain.Accessory, ownerNames map[int64]string, total int64, lastModified time.Time, err error) *MockAccessoryRepository_GetList_Call {
+	_c.Call.Return(result, ownerNames, total, lastModified, err)
+	return _c
+}
+
+func (_c *MockAccessoryRepository_GetList_Call) RunAndReturn(run func(ctx context.Context, filter domain.ListAccessoryRequest, offset int, limit int) ([]*domain.Accessory, map[int64]string, int64, time.Time, error)) *MockAccessoryRepository_GetList_Call {
+	_c.Call.Return(run)
+	return _c
+}
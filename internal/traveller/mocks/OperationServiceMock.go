@@ -0,0 +1,104 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockOperationService creates a new instance of MockOperationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOperationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOperationService {
+	mock := &MockOperationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOperationService is an autogenerated mock type for the OperationService type
+type MockOperationService struct {
+	mock.Mock
+}
+
+type MockOperationService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOperationService) EXPECT() *MockOperationService_Expecter {
+	return &MockOperationService_Expecter{mock: &_m.Mock}
+}
+
+// Start provides a mock function for the type MockOperationService
+func (_mock *MockOperationService) Start(ctx context.Context, name string, run domain.OperationFunc) *domain.Operation {
+	ret := _mock.Called(ctx, name, run)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 *domain.Operation
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.OperationFunc) *domain.Operation); ok {
+		r0 = returnFunc(ctx, name, run)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Operation)
+		}
+	}
+	return r0
+}
+
+// MockOperationService_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockOperationService_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - run domain.OperationFunc
+func (_e *MockOperationService_Expecter) Start(ctx interface{}, name interface{}, run interface{}) *MockOperationService_Start_Call {
+	return &MockOperationService_Start_Call{Call: _e.mock.On("Start", ctx, name, run)}
+}
+
+func (_c *MockOperationService_Start_Call) Run(run func(ctx context.Context, name string, run domain.OperationFunc)) *MockOperationService_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.OperationFunc
+		if args[2] != nil {
+			arg2 = args[2].(domain.OperationFunc)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOperationService_Start_Call) Return(res *domain.Operation) *MockOperationService_Start_Call {
+	_c.Call.Return(res)
+	return _c
+}
+
+func (_c *MockOperationService_Start_Call) RunAndReturn(run func(ctx context.Context, name string, run domain.OperationFunc) *domain.Operation) *MockOperationService_Start_Call {
+	_c.Call.Return(run)
+	return _c
+}
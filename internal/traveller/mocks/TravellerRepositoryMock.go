@@ -7,6 +7,8 @@ package mocks
 import (
 	"context"
 	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -38,6 +40,72 @@ func (_m *MockTravellerRepository) EXPECT() *MockTravellerRepository_Expecter {
 	return &MockTravellerRepository_Expecter{mock: &_m.Mock}
 }
 
+// Count provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) Count(ctx context.Context, filter domain.ListTravellerRequest) (int64, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTravellerRequest) (int64, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTravellerRequest) int64); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListTravellerRequest) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type MockTravellerRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ListTravellerRequest
+func (_e *MockTravellerRepository_Expecter) Count(ctx interface{}, filter interface{}) *MockTravellerRepository_Count_Call {
+	return &MockTravellerRepository_Count_Call{Call: _e.mock.On("Count", ctx, filter)}
+}
+
+func (_c *MockTravellerRepository_Count_Call) Run(run func(ctx context.Context, filter domain.ListTravellerRequest)) *MockTravellerRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ListTravellerRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.ListTravellerRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerRepository_Count_Call) Return(total int64, err error) *MockTravellerRepository_Count_Call {
+	_c.Call.Return(total, err)
+	return _c
+}
+
+func (_c *MockTravellerRepository_Count_Call) RunAndReturn(run func(ctx context.Context, filter domain.ListTravellerRequest) (int64, error)) *MockTravellerRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockTravellerRepository
 func (_mock *MockTravellerRepository) Create(ctx context.Context, input *domain.Traveller) error {
 	ret := _mock.Called(ctx, input)
@@ -215,9 +283,89 @@ func (_c *MockTravellerRepository_Delete_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// FindSimilarPairs provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) FindSimilarPairs(ctx context.Context, threshold float64, minScore float64, limit int) ([]domain.SimilarPair, error) {
+	ret := _mock.Called(ctx, threshold, minScore, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindSimilarPairs")
+	}
+
+	var r0 []domain.SimilarPair
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, float64, float64, int) ([]domain.SimilarPair, error)); ok {
+		return returnFunc(ctx, threshold, minScore, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, float64, float64, int) []domain.SimilarPair); ok {
+		r0 = returnFunc(ctx, threshold, minScore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SimilarPair)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, float64, float64, int) error); ok {
+		r1 = returnFunc(ctx, threshold, minScore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerRepository_FindSimilarPairs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindSimilarPairs'
+type MockTravellerRepository_FindSimilarPairs_Call struct {
+	*mock.Call
+}
+
+// FindSimilarPairs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - threshold float64
+//   - minScore float64
+//   - limit int
+func (_e *MockTravellerRepository_Expecter) FindSimilarPairs(ctx interface{}, threshold interface{}, minScore interface{}, limit interface{}) *MockTravellerRepository_FindSimilarPairs_Call {
+	return &MockTravellerRepository_FindSimilarPairs_Call{Call: _e.mock.On("FindSimilarPairs", ctx, threshold, minScore, limit)}
+}
+
+func (_c *MockTravellerRepository_FindSimilarPairs_Call) Run(run func(ctx context.Context, threshold float64, minScore float64, limit int)) *MockTravellerRepository_FindSimilarPairs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 float64
+		if args[1] != nil {
+			arg1 = args[1].(float64)
+		}
+		var arg2 float64
+		if args[2] != nil {
+			arg2 = args[2].(float64)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerRepository_FindSimilarPairs_Call) Return(result []domain.SimilarPair, err error) *MockTravellerRepository_FindSimilarPairs_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockTravellerRepository_FindSimilarPairs_Call) RunAndReturn(run func(ctx context.Context, threshold float64, minScore float64, limit int) ([]domain.SimilarPair, error)) *MockTravellerRepository_FindSimilarPairs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByID provides a mock function for the type MockTravellerRepository
-func (_mock *MockTravellerRepository) GetByID(ctx context.Context, id int) (*domain.Traveller, error) {
-	ret := _mock.Called(ctx, id)
+func (_mock *MockTravellerRepository) GetByID(ctx context.Context, id int, include domain.TravellerInclude) (*domain.Traveller, error) {
+	ret := _mock.Called(ctx, id, include)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByID")
@@ -225,18 +373,18 @@ func (_mock *MockTravellerRepository) GetByID(ctx context.Context, id int) (*dom
 
 	var r0 *domain.Traveller
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*domain.Traveller, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.TravellerInclude) (*domain.Traveller, error)); ok {
+		return returnFunc(ctx, id, include)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *domain.Traveller); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.TravellerInclude) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, id, include)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Traveller)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, domain.TravellerInclude) error); ok {
+		r1 = returnFunc(ctx, id, include)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -251,11 +399,12 @@ type MockTravellerRepository_GetByID_Call struct {
 // GetByID is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id int
-func (_e *MockTravellerRepository_Expecter) GetByID(ctx interface{}, id interface{}) *MockTravellerRepository_GetByID_Call {
-	return &MockTravellerRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+//   - include domain.TravellerInclude
+func (_e *MockTravellerRepository_Expecter) GetByID(ctx interface{}, id interface{}, include interface{}) *MockTravellerRepository_GetByID_Call {
+	return &MockTravellerRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id, include)}
 }
 
-func (_c *MockTravellerRepository_GetByID_Call) Run(run func(ctx context.Context, id int)) *MockTravellerRepository_GetByID_Call {
+func (_c *MockTravellerRepository_GetByID_Call) Run(run func(ctx context.Context, id int, include domain.TravellerInclude)) *MockTravellerRepository_GetByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -265,9 +414,14 @@ func (_c *MockTravellerRepository_GetByID_Call) Run(run func(ctx context.Context
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
+		var arg2 domain.TravellerInclude
+		if args[2] != nil {
+			arg2 = args[2].(domain.TravellerInclude)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -278,219 +432,1142 @@ func (_c *MockTravellerRepository_GetByID_Call) Return(result *domain.Traveller,
 	return _c
 }
 
-func (_c *MockTravellerRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, id int) (*domain.Traveller, error)) *MockTravellerRepository_GetByID_Call {
+func (_c *MockTravellerRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, id int, include domain.TravellerInclude) (*domain.Traveller, error)) *MockTravellerRepository_GetByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetList provides a mock function for the type MockTravellerRepository
-func (_mock *MockTravellerRepository) GetList(ctx context.Context, filter domain.ListTravellerRequest, offset int, limit int) ([]*domain.Traveller, int64, error) {
-	ret := _mock.Called(ctx, filter, offset, limit)
+// GetByIDAsOf provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) GetByIDAsOf(ctx context.Context, id int, at time.Time) (*domain.Traveller, error) {
+	ret := _mock.Called(ctx, id, at)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetList")
+		panic("no return value specified for GetByIDAsOf")
 	}
 
-	var r0 []*domain.Traveller
-	var r1 int64
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTravellerRequest, int, int) ([]*domain.Traveller, int64, error)); ok {
-		return returnFunc(ctx, filter, offset, limit)
+	var r0 *domain.Traveller
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time) (*domain.Traveller, error)); ok {
+		return returnFunc(ctx, id, at)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTravellerRequest, int, int) []*domain.Traveller); ok {
-		r0 = returnFunc(ctx, filter, offset, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, id, at)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*domain.Traveller)
+			r0 = ret.Get(0).(*domain.Traveller)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListTravellerRequest, int, int) int64); ok {
-		r1 = returnFunc(ctx, filter, offset, limit)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, domain.ListTravellerRequest, int, int) error); ok {
-		r2 = returnFunc(ctx, filter, offset, limit)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = returnFunc(ctx, id, at)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockTravellerRepository_GetList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetList'
-type MockTravellerRepository_GetList_Call struct {
+// MockTravellerRepository_GetByIDAsOf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDAsOf'
+type MockTravellerRepository_GetByIDAsOf_Call struct {
 	*mock.Call
 }
 
-// GetList is a helper method to define mock.On call
+// GetByIDAsOf is a helper method to define mock.On call
 //   - ctx context.Context
-//   - filter domain.ListTravellerRequest
-//   - offset int
-//   - limit int
-func (_e *MockTravellerRepository_Expecter) GetList(ctx interface{}, filter interface{}, offset interface{}, limit interface{}) *MockTravellerRepository_GetList_Call {
-	return &MockTravellerRepository_GetList_Call{Call: _e.mock.On("GetList", ctx, filter, offset, limit)}
+//   - id int
+//   - at time.Time
+func (_e *MockTravellerRepository_Expecter) GetByIDAsOf(ctx interface{}, id interface{}, at interface{}) *MockTravellerRepository_GetByIDAsOf_Call {
+	return &MockTravellerRepository_GetByIDAsOf_Call{Call: _e.mock.On("GetByIDAsOf", ctx, id, at)}
 }
 
-func (_c *MockTravellerRepository_GetList_Call) Run(run func(ctx context.Context, filter domain.ListTravellerRequest, offset int, limit int)) *MockTravellerRepository_GetList_Call {
+func (_c *MockTravellerRepository_GetByIDAsOf_Call) Run(run func(ctx context.Context, id int, at time.Time)) *MockTravellerRepository_GetByIDAsOf_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 domain.ListTravellerRequest
+		var arg1 int
 		if args[1] != nil {
-			arg1 = args[1].(domain.ListTravellerRequest)
+			arg1 = args[1].(int)
 		}
-		var arg2 int
+		var arg2 time.Time
 		if args[2] != nil {
-			arg2 = args[2].(int)
-		}
-		var arg3 int
-		if args[3] != nil {
-			arg3 = args[3].(int)
+			arg2 = args[2].(time.Time)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTravellerRepository_GetList_Call) Return(result []*domain.Traveller, total int64, err error) *MockTravellerRepository_GetList_Call {
-	_c.Call.Return(result, total, err)
+func (_c *MockTravellerRepository_GetByIDAsOf_Call) Return(result *domain.Traveller, err error) *MockTravellerRepository_GetByIDAsOf_Call {
+	_c.Call.Return(result, err)
 	return _c
 }
 
-func (_c *MockTravellerRepository_GetList_Call) RunAndReturn(run func(ctx context.Context, filter domain.ListTravellerRequest, offset int, limit int) ([]*domain.Traveller, int64, error)) *MockTravellerRepository_GetList_Call {
+func (_c *MockTravellerRepository_GetByIDAsOf_Call) RunAndReturn(run func(ctx context.Context, id int, at time.Time) (*domain.Traveller, error)) *MockTravellerRepository_GetByIDAsOf_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function for the type MockTravellerRepository
-func (_mock *MockTravellerRepository) Update(ctx context.Context, input *domain.Traveller) error {
-	ret := _mock.Called(ctx, input)
+// GetByIDs provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) GetByIDs(ctx context.Context, ids []int, include domain.TravellerInclude) ([]*domain.Traveller, error) {
+	ret := _mock.Called(ctx, ids, include)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for GetByIDs")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Traveller) error); ok {
-		r0 = returnFunc(ctx, input)
+	var r0 []*domain.Traveller
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int, domain.TravellerInclude) ([]*domain.Traveller, error)); ok {
+		return returnFunc(ctx, ids, include)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int, domain.TravellerInclude) []*domain.Traveller); ok {
+		r0 = returnFunc(ctx, ids, include)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Traveller)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []int, domain.TravellerInclude) error); ok {
+		r1 = returnFunc(ctx, ids, include)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockTravellerRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockTravellerRepository_Update_Call struct {
+// MockTravellerRepository_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type MockTravellerRepository_GetByIDs_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// GetByIDs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - input *domain.Traveller
-func (_e *MockTravellerRepository_Expecter) Update(ctx interface{}, input interface{}) *MockTravellerRepository_Update_Call {
-	return &MockTravellerRepository_Update_Call{Call: _e.mock.On("Update", ctx, input)}
+//   - ids []int
+//   - include domain.TravellerInclude
+func (_e *MockTravellerRepository_Expecter) GetByIDs(ctx interface{}, ids interface{}, include interface{}) *MockTravellerRepository_GetByIDs_Call {
+	return &MockTravellerRepository_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, ids, include)}
 }
 
-func (_c *MockTravellerRepository_Update_Call) Run(run func(ctx context.Context, input *domain.Traveller)) *MockTravellerRepository_Update_Call {
+func (_c *MockTravellerRepository_GetByIDs_Call) Run(run func(ctx context.Context, ids []int, include domain.TravellerInclude)) *MockTravellerRepository_GetByIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.Traveller
+		var arg1 []int
 		if args[1] != nil {
-			arg1 = args[1].(*domain.Traveller)
+			arg1 = args[1].([]int)
+		}
+		var arg2 domain.TravellerInclude
+		if args[2] != nil {
+			arg2 = args[2].(domain.TravellerInclude)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTravellerRepository_Update_Call) Return(err error) *MockTravellerRepository_Update_Call {
-	_c.Call.Return(err)
+func (_c *MockTravellerRepository_GetByIDs_Call) Return(result []*domain.Traveller, err error) *MockTravellerRepository_GetByIDs_Call {
+	_c.Call.Return(result, err)
 	return _c
 }
 
-func (_c *MockTravellerRepository_Update_Call) RunAndReturn(run func(ctx context.Context, input *domain.Traveller) error) *MockTravellerRepository_Update_Call {
+func (_c *MockTravellerRepository_GetByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []int, include domain.TravellerInclude) ([]*domain.Traveller, error)) *MockTravellerRepository_GetByIDs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateTravellerWithAccessory provides a mock function for the type MockTravellerRepository
-func (_mock *MockTravellerRepository) UpdateTravellerWithAccessory(ctx context.Context, id int, traveller *domain.Traveller, accessory *domain.Accessory) error {
-	ret := _mock.Called(ctx, id, traveller, accessory)
+// GetBySlug provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) GetBySlug(ctx context.Context, slug string, include domain.TravellerInclude) (*domain.Traveller, error) {
+	ret := _mock.Called(ctx, slug, include)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateTravellerWithAccessory")
+		panic("no return value specified for GetBySlug")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, *domain.Traveller, *domain.Accessory) error); ok {
-		r0 = returnFunc(ctx, id, traveller, accessory)
+	var r0 *domain.Traveller
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TravellerInclude) (*domain.Traveller, error)); ok {
+		return returnFunc(ctx, slug, include)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TravellerInclude) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, slug, include)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Traveller)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.TravellerInclude) error); ok {
+		r1 = returnFunc(ctx, slug, include)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockTravellerRepository_UpdateTravellerWithAccessory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTravellerWithAccessory'
-type MockTravellerRepository_UpdateTravellerWithAccessory_Call struct {
+// MockTravellerRepository_GetBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBySlug'
+type MockTravellerRepository_GetBySlug_Call struct {
 	*mock.Call
 }
 
-// UpdateTravellerWithAccessory is a helper method to define mock.On call
+// GetBySlug is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int
-//   - traveller *domain.Traveller
-//   - accessory *domain.Accessory
-func (_e *MockTravellerRepository_Expecter) UpdateTravellerWithAccessory(ctx interface{}, id interface{}, traveller interface{}, accessory interface{}) *MockTravellerRepository_UpdateTravellerWithAccessory_Call {
-	return &MockTravellerRepository_UpdateTravellerWithAccessory_Call{Call: _e.mock.On("UpdateTravellerWithAccessory", ctx, id, traveller, accessory)}
+//   - slug string
+//   - include domain.TravellerInclude
+func (_e *MockTravellerRepository_Expecter) GetBySlug(ctx interface{}, slug interface{}, include interface{}) *MockTravellerRepository_GetBySlug_Call {
+	return &MockTravellerRepository_GetBySlug_Call{Call: _e.mock.On("GetBySlug", ctx, slug, include)}
 }
 
-func (_c *MockTravellerRepository_UpdateTravellerWithAccessory_Call) Run(run func(ctx context.Context, id int, traveller *domain.Traveller, accessory *domain.Accessory)) *MockTravellerRepository_UpdateTravellerWithAccessory_Call {
+func (_c *MockTravellerRepository_GetBySlug_Call) Run(run func(ctx context.Context, slug string, include domain.TravellerInclude)) *MockTravellerRepository_GetBySlug_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(int)
+			arg1 = args[1].(string)
 		}
-		var arg2 *domain.Traveller
+		var arg2 domain.TravellerInclude
 		if args[2] != nil {
-			arg2 = args[2].(*domain.Traveller)
-		}
-		var arg3 *domain.Accessory
-		if args[3] != nil {
-			arg3 = args[3].(*domain.Accessory)
+			arg2 = args[2].(domain.TravellerInclude)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTravellerRepository_UpdateTravellerWithAccessory_Call) Return(err error) *MockTravellerRepository_UpdateTravellerWithAccessory_Call {
-	_c.Call.Return(err)
+func (_c *MockTravellerRepository_GetBySlug_Call) Return(result *domain.Traveller, err error) *MockTravellerRepository_GetBySlug_Call {
+	_c.Call.Return(result, err)
 	return _c
 }
 
-func (_c *MockTravellerRepository_UpdateTravellerWithAccessory_Call) RunAndReturn(run func(ctx context.Context, id int, traveller *domain.Traveller, accessory *domain.Accessory) error) *MockTravellerRepository_UpdateTravellerWithAccessory_Call {
+func (_c *MockTravellerRepository_GetBySlug_Call) RunAndReturn(run func(ctx context.Context, slug string, include domain.TravellerInclude) (*domain.Traveller, error)) *MockTravellerRepository_GetBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFacets provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) GetFacets(ctx context.Context, filter domain.ListTravellerRequest, facets []string) (map[string]map[int]int64, error) {
+	ret := _mock.Called(ctx, filter, facets)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFacets")
+	}
+
+	var r0 map[string]map[int]int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTravellerRequest, []string) (map[string]map[int]int64, error)); ok {
+		return returnFunc(ctx, filter, facets)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTravellerRequest, []string) map[string]map[int]int64); ok {
+		r0 = returnFunc(ctx, filter, facets)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]map[int]int64)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListTravellerRequest, []string) error); ok {
+		r1 = returnFunc(ctx, filter, facets)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerRepository_GetFacets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFacets'
+type MockTravellerRepository_GetFacets_Call struct {
+	*mock.Call
+}
+
+// GetFacets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ListTravellerRequest
+//   - facets []string
+func (_e *MockTravellerRepository_Expecter) GetFacets(ctx interface{}, filter interface{}, facets interface{}) *MockTravellerRepository_GetFacets_Call {
+	return &MockTravellerRepository_GetFacets_Call{Call: _e.mock.On("GetFacets", ctx, filter, facets)}
+}
+
+func (_c *MockTravellerRepository_GetFacets_Call) Run(run func(ctx context.Context, filter domain.ListTravellerRequest, facets []string)) *MockTravellerRepository_GetFacets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ListTravellerRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.ListTravellerRequest)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerRepository_GetFacets_Call) Return(result map[string]map[int]int64, err error) *MockTravellerRepository_GetFacets_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockTravellerRepository_GetFacets_Call) RunAndReturn(run func(ctx context.Context, filter domain.ListTravellerRequest, facets []string) (map[string]map[int]int64, error)) *MockTravellerRepository_GetFacets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHistory provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) GetHistory(ctx context.Context, id int, offset int, limit int) ([]domain.Revision, int64, error) {
+	ret := _mock.Called(ctx, id, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
+	}
+
+	var r0 []domain.Revision
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, int) ([]domain.Revision, int64, error)); ok {
+		return returnFunc(ctx, id, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, int) []domain.Revision); ok {
+		r0 = returnFunc(ctx, id, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Revision)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, int) int64); ok {
+		r1 = returnFunc(ctx, id, offset, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, int, int, int) error); ok {
+		r2 = returnFunc(ctx, id, offset, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockTravellerRepository_GetHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHistory'
+type MockTravellerRepository_GetHistory_Call struct {
+	*mock.Call
+}
+
+// GetHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - offset int
+//   - limit int
+func (_e *MockTravellerRepository_Expecter) GetHistory(ctx interface{}, id interface{}, offset interface{}, limit interface{}) *MockTravellerRepository_GetHistory_Call {
+	return &MockTravellerRepository_GetHistory_Call{Call: _e.mock.On("GetHistory", ctx, id, offset, limit)}
+}
+
+func (_c *MockTravellerRepository_GetHistory_Call) Run(run func(ctx context.Context, id int, offset int, limit int)) *MockTravellerRepository_GetHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerRepository_GetHistory_Call) Return(result []domain.Revision, total int64, err error) *MockTravellerRepository_GetHistory_Call {
+	_c.Call.Return(result, total, err)
+	return _c
+}
+
+func (_c *MockTravellerRepository_GetHistory_Call) RunAndReturn(run func(ctx context.Context, id int, offset int, limit int) ([]domain.Revision, int64, error)) *MockTravellerRepository_GetHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetList provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) GetList(ctx context.Context, filter domain.ListTravellerRequest, offset int, limit int) ([]*domain.Traveller, int64, time.Time, error) {
+	ret := _mock.Called(ctx, filter, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetList")
+	}
+
+	var r0 []*domain.Traveller
+	var r1 int64
+	var r2 time.Time
+	var r3 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTravellerRequest, int, int) ([]*domain.Traveller, int64, time.Time, error)); ok {
+		return returnFunc(ctx, filter, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTravellerRequest, int, int) []*domain.Traveller); ok {
+		r0 = returnFunc(ctx, filter, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Traveller)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListTravellerRequest, int, int) int64); ok {
+		r1 = returnFunc(ctx, filter, offset, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, domain.ListTravellerRequest, int, int) time.Time); ok {
+		r2 = returnFunc(ctx, filter, offset, limit)
+	} else {
+		r2 = ret.Get(2).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(3).(func(context.Context, domain.ListTravellerRequest, int, int) error); ok {
+		r3 = returnFunc(ctx, filter, offset, limit)
+	} else {
+		r3 = ret.Error(3)
+	}
+	return r0, r1, r2, r3
+}
+
+// MockTravellerRepository_GetList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetList'
+type MockTravellerRepository_GetList_Call struct {
+	*mock.Call
+}
+
+// GetList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ListTravellerRequest
+//   - offset int
+//   - limit int
+func (_e *MockTravellerRepository_Expecter) GetList(ctx interface{}, filter interface{}, offset interface{}, limit interface{}) *MockTravellerRepository_GetList_Call {
+	return &MockTravellerRepository_GetList_Call{Call: _e.mock.On("GetList", ctx, filter, offset, limit)}
+}
+
+func (_c *MockTravellerRepository_GetList_Call) Run(run func(ctx context.Context, filter domain.ListTravellerRequest, offset int, limit int)) *MockTravellerRepository_GetList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ListTravellerRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.ListTravellerRequest)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerRepository_GetList_Call) Return(result []*domain.Traveller, total int64, lastModified time.Time, err error) *MockTravellerRepository_GetList_Call {
+	_c.Call.Return(result, total, lastModified, err)
+	return _c
+}
+
+func (_c *MockTravellerRepository_GetList_Call) RunAndReturn(run func(ctx context.Context, filter domain.ListTravellerRequest, offset int, limit int) ([]*domain.Traveller, int64, time.Time, error)) *MockTravellerRepository_GetList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPage provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) GetPage(ctx context.Context, filter domain.ListTravellerRequest, cursor *helpers.Cursor, limit int) ([]*domain.Traveller, bool, error) {
+	ret := _mock.Called(ctx, filter, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPage")
+	}
+
+	var r0 []*domain.Traveller
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTravellerRequest, *helpers.Cursor, int) ([]*domain.Traveller, bool, error)); ok {
+		return returnFunc(ctx, filter, cursor, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTravellerRequest, *helpers.Cursor, int) []*domain.Traveller); ok {
+		r0 = returnFunc(ctx, filter, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Traveller)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListTravellerRequest, *helpers.Cursor, int) bool); ok {
+		r1 = returnFunc(ctx, filter, cursor, limit)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, domain.ListTravellerRequest, *helpers.Cursor, int) error); ok {
+		r2 = returnFunc(ctx, filter, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockTravellerRepository_GetPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPage'
+type MockTravellerRepository_GetPage_Call struct {
+	*mock.Call
+}
+
+// GetPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ListTravellerRequest
+//   - cursor *helpers.Cursor
+//   - limit int
+func (_e *MockTravellerRepository_Expecter) GetPage(ctx interface{}, filter interface{}, cursor interface{}, limit interface{}) *MockTravellerRepository_GetPage_Call {
+	return &MockTravellerRepository_GetPage_Call{Call: _e.mock.On("GetPage", ctx, filter, cursor, limit)}
+}
+
+func (_c *MockTravellerRepository_GetPage_Call) Run(run func(ctx context.Context, filter domain.ListTravellerRequest, cursor *helpers.Cursor, limit int)) *MockTravellerRepository_GetPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ListTravellerRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.ListTravellerRequest)
+		}
+		var arg2 *helpers.Cursor
+		if args[2] != nil {
+			arg2 = args[2].(*helpers.Cursor)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerRepository_GetPage_Call) Return(result []*domain.Traveller, hasMore bool, err error) *MockTravellerRepository_GetPage_Call {
+	_c.Call.Return(result, hasMore, err)
+	return _c
+}
+
+func (_c *MockTravellerRepository_GetPage_Call) RunAndReturn(run func(ctx context.Context, filter domain.ListTravellerRequest, cursor *helpers.Cursor, limit int) ([]*domain.Traveller, bool, error)) *MockTravellerRepository_GetPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeTravellers provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) MergeTravellers(ctx context.Context, targetID int, sourceID int, keepAccessory string, expectedVersion int64) (*domain.Traveller, error) {
+	ret := _mock.Called(ctx, targetID, sourceID, keepAccessory, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeTravellers")
+	}
+
+	var r0 *domain.Traveller
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, string, int64) (*domain.Traveller, error)); ok {
+		return returnFunc(ctx, targetID, sourceID, keepAccessory, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, string, int64) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, targetID, sourceID, keepAccessory, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Traveller)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, string, int64) error); ok {
+		r1 = returnFunc(ctx, targetID, sourceID, keepAccessory, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerRepository_MergeTravellers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeTravellers'
+type MockTravellerRepository_MergeTravellers_Call struct {
+	*mock.Call
+}
+
+// MergeTravellers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - targetID int
+//   - sourceID int
+//   - keepAccessory string
+//   - expectedVersion int64
+func (_e *MockTravellerRepository_Expecter) MergeTravellers(ctx interface{}, targetID interface{}, sourceID interface{}, keepAccessory interface{}, expectedVersion interface{}) *MockTravellerRepository_MergeTravellers_Call {
+	return &MockTravellerRepository_MergeTravellers_Call{Call: _e.mock.On("MergeTravellers", ctx, targetID, sourceID, keepAccessory, expectedVersion)}
+}
+
+func (_c *MockTravellerRepository_MergeTravellers_Call) Run(run func(ctx context.Context, targetID int, sourceID int, keepAccessory string, expectedVersion int64)) *MockTravellerRepository_MergeTravellers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 int64
+		if args[4] != nil {
+			arg4 = args[4].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerRepository_MergeTravellers_Call) Return(result *domain.Traveller, err error) *MockTravellerRepository_MergeTravellers_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockTravellerRepository_MergeTravellers_Call) RunAndReturn(run func(ctx context.Context, targetID int, sourceID int, keepAccessory string, expectedVersion int64) (*domain.Traveller, error)) *MockTravellerRepository_MergeTravellers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchTravellerWithAccessory provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) PatchTravellerWithAccessory(ctx context.Context, id int, traveller *domain.Traveller, accessory *domain.Accessory) error {
+	ret := _mock.Called(ctx, id, traveller, accessory)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchTravellerWithAccessory")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, *domain.Traveller, *domain.Accessory) error); ok {
+		r0 = returnFunc(ctx, id, traveller, accessory)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTravellerRepository_PatchTravellerWithAccessory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchTravellerWithAccessory'
+type MockTravellerRepository_PatchTravellerWithAccessory_Call struct {
+	*mock.Call
+}
+
+// PatchTravellerWithAccessory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - traveller *domain.Traveller
+//   - accessory *domain.Accessory
+func (_e *MockTravellerRepository_Expecter) PatchTravellerWithAccessory(ctx interface{}, id interface{}, traveller interface{}, accessory interface{}) *MockTravellerRepository_PatchTravellerWithAccessory_Call {
+	return &MockTravellerRepository_PatchTravellerWithAccessory_Call{Call: _e.mock.On("PatchTravellerWithAccessory", ctx, id, traveller, accessory)}
+}
+
+func (_c *MockTravellerRepository_PatchTravellerWithAccessory_Call) Run(run func(ctx context.Context, id int, traveller *domain.Traveller, accessory *domain.Accessory)) *MockTravellerRepository_PatchTravellerWithAccessory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 *domain.Traveller
+		if args[2] != nil {
+			arg2 = args[2].(*domain.Traveller)
+		}
+		var arg3 *domain.Accessory
+		if args[3] != nil {
+			arg3 = args[3].(*domain.Accessory)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerRepository_PatchTravellerWithAccessory_Call) Return(err error) *MockTravellerRepository_PatchTravellerWithAccessory_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTravellerRepository_PatchTravellerWithAccessory_Call) RunAndReturn(run func(ctx context.Context, id int, traveller *domain.Traveller, accessory *domain.Accessory) error) *MockTravellerRepository_PatchTravellerWithAccessory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreTraveller provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) RestoreTraveller(ctx context.Context, id int) (*domain.Traveller, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreTraveller")
+	}
+
+	var r0 *domain.Traveller
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*domain.Traveller, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Traveller)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerRepository_RestoreTraveller_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreTraveller'
+type MockTravellerRepository_RestoreTraveller_Call struct {
+	*mock.Call
+}
+
+// RestoreTraveller is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockTravellerRepository_Expecter) RestoreTraveller(ctx interface{}, id interface{}) *MockTravellerRepository_RestoreTraveller_Call {
+	return &MockTravellerRepository_RestoreTraveller_Call{Call: _e.mock.On("RestoreTraveller", ctx, id)}
+}
+
+func (_c *MockTravellerRepository_RestoreTraveller_Call) Run(run func(ctx context.Context, id int)) *MockTravellerRepository_RestoreTraveller_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerRepository_RestoreTraveller_Call) Return(result *domain.Traveller, err error) *MockTravellerRepository_RestoreTraveller_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockTravellerRepository_RestoreTraveller_Call) RunAndReturn(run func(ctx context.Context, id int) (*domain.Traveller, error)) *MockTravellerRepository_RestoreTraveller_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevertRevision provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) RevertRevision(ctx context.Context, id int, revisionID int64) (*domain.Traveller, error) {
+	ret := _mock.Called(ctx, id, revisionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertRevision")
+	}
+
+	var r0 *domain.Traveller
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int64) (*domain.Traveller, error)); ok {
+		return returnFunc(ctx, id, revisionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int64) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, id, revisionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Traveller)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int64) error); ok {
+		r1 = returnFunc(ctx, id, revisionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerRepository_RevertRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevertRevision'
+type MockTravellerRepository_RevertRevision_Call struct {
+	*mock.Call
+}
+
+// RevertRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - revisionID int64
+func (_e *MockTravellerRepository_Expecter) RevertRevision(ctx interface{}, id interface{}, revisionID interface{}) *MockTravellerRepository_RevertRevision_Call {
+	return &MockTravellerRepository_RevertRevision_Call{Call: _e.mock.On("RevertRevision", ctx, id, revisionID)}
+}
+
+func (_c *MockTravellerRepository_RevertRevision_Call) Run(run func(ctx context.Context, id int, revisionID int64)) *MockTravellerRepository_RevertRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerRepository_RevertRevision_Call) Return(result *domain.Traveller, err error) *MockTravellerRepository_RevertRevision_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockTravellerRepository_RevertRevision_Call) RunAndReturn(run func(ctx context.Context, id int, revisionID int64) (*domain.Traveller, error)) *MockTravellerRepository_RevertRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SuggestNames provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) SuggestNames(ctx context.Context, name string, limit int) ([]string, error) {
+	ret := _mock.Called(ctx, name, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuggestNames")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]string, error)); ok {
+		return returnFunc(ctx, name, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []string); ok {
+		r0 = returnFunc(ctx, name, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, name, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerRepository_SuggestNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuggestNames'
+type MockTravellerRepository_SuggestNames_Call struct {
+	*mock.Call
+}
+
+// SuggestNames is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - limit int
+func (_e *MockTravellerRepository_Expecter) SuggestNames(ctx interface{}, name interface{}, limit interface{}) *MockTravellerRepository_SuggestNames_Call {
+	return &MockTravellerRepository_SuggestNames_Call{Call: _e.mock.On("SuggestNames", ctx, name, limit)}
+}
+
+func (_c *MockTravellerRepository_SuggestNames_Call) Run(run func(ctx context.Context, name string, limit int)) *MockTravellerRepository_SuggestNames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerRepository_SuggestNames_Call) Return(result []string, err error) *MockTravellerRepository_SuggestNames_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockTravellerRepository_SuggestNames_Call) RunAndReturn(run func(ctx context.Context, name string, limit int) ([]string, error)) *MockTravellerRepository_SuggestNames_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) Update(ctx context.Context, input *domain.Traveller) error {
+	ret := _mock.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Traveller) error); ok {
+		r0 = returnFunc(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTravellerRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockTravellerRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *domain.Traveller
+func (_e *MockTravellerRepository_Expecter) Update(ctx interface{}, input interface{}) *MockTravellerRepository_Update_Call {
+	return &MockTravellerRepository_Update_Call{Call: _e.mock.On("Update", ctx, input)}
+}
+
+func (_c *MockTravellerRepository_Update_Call) Run(run func(ctx context.Context, input *domain.Traveller)) *MockTravellerRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Traveller
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Traveller)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerRepository_Update_Call) Return(err error) *MockTravellerRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTravellerRepository_Update_Call) RunAndReturn(run func(ctx context.Context, input *domain.Traveller) error) *MockTravellerRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTravellerWithAccessory provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) UpdateTravellerWithAccessory(ctx context.Context, id int, traveller *domain.Traveller, accessory *domain.Accessory) error {
+	ret := _mock.Called(ctx, id, traveller, accessory)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTravellerWithAccessory")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, *domain.Traveller, *domain.Accessory) error); ok {
+		r0 = returnFunc(ctx, id, traveller, accessory)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTravellerRepository_UpdateTravellerWithAccessory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTravellerWithAccessory'
+type MockTravellerRepository_UpdateTravellerWithAccessory_Call struct {
+	*mock.Call
+}
+
+// UpdateTravellerWithAccessory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - traveller *domain.Traveller
+//   - accessory *domain.Accessory
+func (_e *MockTravellerRepository_Expecter) UpdateTravellerWithAccessory(ctx interface{}, id interface{}, traveller interface{}, accessory interface{}) *MockTravellerRepository_UpdateTravellerWithAccessory_Call {
+	return &MockTravellerRepository_UpdateTravellerWithAccessory_Call{Call: _e.mock.On("UpdateTravellerWithAccessory", ctx, id, traveller, accessory)}
+}
+
+func (_c *MockTravellerRepository_UpdateTravellerWithAccessory_Call) Run(run func(ctx context.Context, id int, traveller *domain.Traveller, accessory *domain.Accessory)) *MockTravellerRepository_UpdateTravellerWithAccessory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 *domain.Traveller
+		if args[2] != nil {
+			arg2 = args[2].(*domain.Traveller)
+		}
+		var arg3 *domain.Accessory
+		if args[3] != nil {
+			arg3 = args[3].(*domain.Accessory)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerRepository_UpdateTravellerWithAccessory_Call) Return(err error) *MockTravellerRepository_UpdateTravellerWithAccessory_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTravellerRepository_UpdateTravellerWithAccessory_Call) RunAndReturn(run func(ctx context.Context, id int, traveller *domain.Traveller, accessory *domain.Accessory) error) *MockTravellerRepository_UpdateTravellerWithAccessory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertTravellerWithAccessory provides a mock function for the type MockTravellerRepository
+func (_mock *MockTravellerRepository) UpsertTravellerWithAccessory(ctx context.Context, key domain.ExternalKey, traveller *domain.Traveller, accessory *domain.Accessory) (bool, error) {
+	ret := _mock.Called(ctx, key, traveller, accessory)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertTravellerWithAccessory")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ExternalKey, *domain.Traveller, *domain.Accessory) (bool, error)); ok {
+		return returnFunc(ctx, key, traveller, accessory)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ExternalKey, *domain.Traveller, *domain.Accessory) bool); ok {
+		r0 = returnFunc(ctx, key, traveller, accessory)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ExternalKey, *domain.Traveller, *domain.Accessory) error); ok {
+		r1 = returnFunc(ctx, key, traveller, accessory)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerRepository_UpsertTravellerWithAccessory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertTravellerWithAccessory'
+type MockTravellerRepository_UpsertTravellerWithAccessory_Call struct {
+	*mock.Call
+}
+
+// UpsertTravellerWithAccessory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key domain.ExternalKey
+//   - traveller *domain.Traveller
+//   - accessory *domain.Accessory
+func (_e *MockTravellerRepository_Expecter) UpsertTravellerWithAccessory(ctx interface{}, key interface{}, traveller interface{}, accessory interface{}) *MockTravellerRepository_UpsertTravellerWithAccessory_Call {
+	return &MockTravellerRepository_UpsertTravellerWithAccessory_Call{Call: _e.mock.On("UpsertTravellerWithAccessory", ctx, key, traveller, accessory)}
+}
+
+func (_c *MockTravellerRepository_UpsertTravellerWithAccessory_Call) Run(run func(ctx context.Context, key domain.ExternalKey, traveller *domain.Traveller, accessory *domain.Accessory)) *MockTravellerRepository_UpsertTravellerWithAccessory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ExternalKey
+		if args[1] != nil {
+			arg1 = args[1].(domain.ExternalKey)
+		}
+		var arg2 *domain.Traveller
+		if args[2] != nil {
+			arg2 = args[2].(*domain.Traveller)
+		}
+		var arg3 *domain.Accessory
+		if args[3] != nil {
+			arg3 = args[3].(*domain.Accessory)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerRepository_UpsertTravellerWithAccessory_Call) Return(created bool, err error) *MockTravellerRepository_UpsertTravellerWithAccessory_Call {
+	_c.Call.Return(created, err)
+	return _c
+}
+
+func (_c *MockTravellerRepository_UpsertTravellerWithAccessory_Call) RunAndReturn(run func(ctx context.Context, key domain.ExternalKey, traveller *domain.Traveller, accessory *domain.Accessory) (bool, error)) *MockTravellerRepository_UpsertTravellerWithAccessory_Call {
 	_c.Call.Return(run)
 	return _c
 }
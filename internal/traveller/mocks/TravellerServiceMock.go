@@ -8,6 +8,7 @@ import (
 	"context"
 	"lizobly/ctc-db-api/pkg/domain"
 	"lizobly/ctc-db-api/pkg/helpers"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -39,23 +40,91 @@ func (_m *MockTravellerService) EXPECT() *MockTravellerService_Expecter {
 	return &MockTravellerService_Expecter{mock: &_m.Mock}
 }
 
+// Compare provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) Compare(ctx context.Context, input domain.CompareTravellerRequest) (domain.TravellerComparisonResponse, error) {
+	ret := _mock.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Compare")
+	}
+
+	var r0 domain.TravellerComparisonResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CompareTravellerRequest) (domain.TravellerComparisonResponse, error)); ok {
+		return returnFunc(ctx, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CompareTravellerRequest) domain.TravellerComparisonResponse); ok {
+		r0 = returnFunc(ctx, input)
+	} else {
+		r0 = ret.Get(0).(domain.TravellerComparisonResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CompareTravellerRequest) error); ok {
+		r1 = returnFunc(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerService_Compare_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Compare'
+type MockTravellerService_Compare_Call struct {
+	*mock.Call
+}
+
+// Compare is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input domain.CompareTravellerRequest
+func (_e *MockTravellerService_Expecter) Compare(ctx interface{}, input interface{}) *MockTravellerService_Compare_Call {
+	return &MockTravellerService_Compare_Call{Call: _e.mock.On("Compare", ctx, input)}
+}
+
+func (_c *MockTravellerService_Compare_Call) Run(run func(ctx context.Context, input domain.CompareTravellerRequest)) *MockTravellerService_Compare_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.CompareTravellerRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.CompareTravellerRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerService_Compare_Call) Return(res domain.TravellerComparisonResponse, err error) *MockTravellerService_Compare_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTravellerService_Compare_Call) RunAndReturn(run func(ctx context.Context, input domain.CompareTravellerRequest) (domain.TravellerComparisonResponse, error)) *MockTravellerService_Compare_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockTravellerService
-func (_mock *MockTravellerService) Create(ctx context.Context, input domain.CreateTravellerRequest) (int64, error) {
+func (_mock *MockTravellerService) Create(ctx context.Context, input domain.CreateTravellerRequest) (*domain.Traveller, error) {
 	ret := _mock.Called(ctx, input)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
-	var r0 int64
+	var r0 *domain.Traveller
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CreateTravellerRequest) (int64, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CreateTravellerRequest) (*domain.Traveller, error)); ok {
 		return returnFunc(ctx, input)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CreateTravellerRequest) int64); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CreateTravellerRequest) *domain.Traveller); ok {
 		r0 = returnFunc(ctx, input)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Traveller)
+		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CreateTravellerRequest) error); ok {
 		r1 = returnFunc(ctx, input)
@@ -95,12 +164,12 @@ func (_c *MockTravellerService_Create_Call) Run(run func(ctx context.Context, in
 	return _c
 }
 
-func (_c *MockTravellerService_Create_Call) Return(id int64, err error) *MockTravellerService_Create_Call {
-	_c.Call.Return(id, err)
+func (_c *MockTravellerService_Create_Call) Return(res *domain.Traveller, err error) *MockTravellerService_Create_Call {
+	_c.Call.Return(res, err)
 	return _c
 }
 
-func (_c *MockTravellerService_Create_Call) RunAndReturn(run func(ctx context.Context, input domain.CreateTravellerRequest) (int64, error)) *MockTravellerService_Create_Call {
+func (_c *MockTravellerService_Create_Call) RunAndReturn(run func(ctx context.Context, input domain.CreateTravellerRequest) (*domain.Traveller, error)) *MockTravellerService_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -162,9 +231,75 @@ func (_c *MockTravellerService_Delete_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// FindDuplicates provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) FindDuplicates(ctx context.Context, input domain.DuplicateCandidateRequest) (domain.DuplicateReportResponse, error) {
+	ret := _mock.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDuplicates")
+	}
+
+	var r0 domain.DuplicateReportResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.DuplicateCandidateRequest) (domain.DuplicateReportResponse, error)); ok {
+		return returnFunc(ctx, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.DuplicateCandidateRequest) domain.DuplicateReportResponse); ok {
+		r0 = returnFunc(ctx, input)
+	} else {
+		r0 = ret.Get(0).(domain.DuplicateReportResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.DuplicateCandidateRequest) error); ok {
+		r1 = returnFunc(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerService_FindDuplicates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindDuplicates'
+type MockTravellerService_FindDuplicates_Call struct {
+	*mock.Call
+}
+
+// FindDuplicates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input domain.DuplicateCandidateRequest
+func (_e *MockTravellerService_Expecter) FindDuplicates(ctx interface{}, input interface{}) *MockTravellerService_FindDuplicates_Call {
+	return &MockTravellerService_FindDuplicates_Call{Call: _e.mock.On("FindDuplicates", ctx, input)}
+}
+
+func (_c *MockTravellerService_FindDuplicates_Call) Run(run func(ctx context.Context, input domain.DuplicateCandidateRequest)) *MockTravellerService_FindDuplicates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.DuplicateCandidateRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.DuplicateCandidateRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerService_FindDuplicates_Call) Return(res domain.DuplicateReportResponse, err error) *MockTravellerService_FindDuplicates_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTravellerService_FindDuplicates_Call) RunAndReturn(run func(ctx context.Context, input domain.DuplicateCandidateRequest) (domain.DuplicateReportResponse, error)) *MockTravellerService_FindDuplicates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByID provides a mock function for the type MockTravellerService
-func (_mock *MockTravellerService) GetByID(ctx context.Context, id int) (*domain.Traveller, error) {
-	ret := _mock.Called(ctx, id)
+func (_mock *MockTravellerService) GetByID(ctx context.Context, id int, include domain.TravellerInclude) (*domain.Traveller, error) {
+	ret := _mock.Called(ctx, id, include)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByID")
@@ -172,18 +307,18 @@ func (_mock *MockTravellerService) GetByID(ctx context.Context, id int) (*domain
 
 	var r0 *domain.Traveller
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*domain.Traveller, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.TravellerInclude) (*domain.Traveller, error)); ok {
+		return returnFunc(ctx, id, include)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *domain.Traveller); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.TravellerInclude) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, id, include)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Traveller)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, domain.TravellerInclude) error); ok {
+		r1 = returnFunc(ctx, id, include)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -198,11 +333,12 @@ type MockTravellerService_GetByID_Call struct {
 // GetByID is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id int
-func (_e *MockTravellerService_Expecter) GetByID(ctx interface{}, id interface{}) *MockTravellerService_GetByID_Call {
-	return &MockTravellerService_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+//   - include domain.TravellerInclude
+func (_e *MockTravellerService_Expecter) GetByID(ctx interface{}, id interface{}, include interface{}) *MockTravellerService_GetByID_Call {
+	return &MockTravellerService_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id, include)}
 }
 
-func (_c *MockTravellerService_GetByID_Call) Run(run func(ctx context.Context, id int)) *MockTravellerService_GetByID_Call {
+func (_c *MockTravellerService_GetByID_Call) Run(run func(ctx context.Context, id int, include domain.TravellerInclude)) *MockTravellerService_GetByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -212,9 +348,14 @@ func (_c *MockTravellerService_GetByID_Call) Run(run func(ctx context.Context, i
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
+		var arg2 domain.TravellerInclude
+		if args[2] != nil {
+			arg2 = args[2].(domain.TravellerInclude)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -225,63 +366,65 @@ func (_c *MockTravellerService_GetByID_Call) Return(res *domain.Traveller, err e
 	return _c
 }
 
-func (_c *MockTravellerService_GetByID_Call) RunAndReturn(run func(ctx context.Context, id int) (*domain.Traveller, error)) *MockTravellerService_GetByID_Call {
+func (_c *MockTravellerService_GetByID_Call) RunAndReturn(run func(ctx context.Context, id int, include domain.TravellerInclude) (*domain.Traveller, error)) *MockTravellerService_GetByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetList provides a mock function for the type MockTravellerService
-func (_mock *MockTravellerService) GetList(ctx context.Context, filter domain.ListTravellerRequest, params helpers.PaginationParams) (helpers.PaginatedResponse[domain.TravellerListItemResponse], error) {
-	ret := _mock.Called(ctx, filter, params)
+// GetByIDAsOf provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) GetByIDAsOf(ctx context.Context, id int, at time.Time) (*domain.Traveller, error) {
+	ret := _mock.Called(ctx, id, at)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetList")
+		panic("no return value specified for GetByIDAsOf")
 	}
 
-	var r0 helpers.PaginatedResponse[domain.TravellerListItemResponse]
+	var r0 *domain.Traveller
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTravellerRequest, helpers.PaginationParams) (helpers.PaginatedResponse[domain.TravellerListItemResponse], error)); ok {
-		return returnFunc(ctx, filter, params)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time) (*domain.Traveller, error)); ok {
+		return returnFunc(ctx, id, at)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTravellerRequest, helpers.PaginationParams) helpers.PaginatedResponse[domain.TravellerListItemResponse]); ok {
-		r0 = returnFunc(ctx, filter, params)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, id, at)
 	} else {
-		r0 = ret.Get(0).(helpers.PaginatedResponse[domain.TravellerListItemResponse])
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Traveller)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListTravellerRequest, helpers.PaginationParams) error); ok {
-		r1 = returnFunc(ctx, filter, params)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = returnFunc(ctx, id, at)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTravellerService_GetList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetList'
-type MockTravellerService_GetList_Call struct {
+// MockTravellerService_GetByIDAsOf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDAsOf'
+type MockTravellerService_GetByIDAsOf_Call struct {
 	*mock.Call
 }
 
-// GetList is a helper method to define mock.On call
+// GetByIDAsOf is a helper method to define mock.On call
 //   - ctx context.Context
-//   - filter domain.ListTravellerRequest
-//   - params helpers.PaginationParams
-func (_e *MockTravellerService_Expecter) GetList(ctx interface{}, filter interface{}, params interface{}) *MockTravellerService_GetList_Call {
-	return &MockTravellerService_GetList_Call{Call: _e.mock.On("GetList", ctx, filter, params)}
+//   - id int
+//   - at time.Time
+func (_e *MockTravellerService_Expecter) GetByIDAsOf(ctx interface{}, id interface{}, at interface{}) *MockTravellerService_GetByIDAsOf_Call {
+	return &MockTravellerService_GetByIDAsOf_Call{Call: _e.mock.On("GetByIDAsOf", ctx, id, at)}
 }
 
-func (_c *MockTravellerService_GetList_Call) Run(run func(ctx context.Context, filter domain.ListTravellerRequest, params helpers.PaginationParams)) *MockTravellerService_GetList_Call {
+func (_c *MockTravellerService_GetByIDAsOf_Call) Run(run func(ctx context.Context, id int, at time.Time)) *MockTravellerService_GetByIDAsOf_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 domain.ListTravellerRequest
+		var arg1 int
 		if args[1] != nil {
-			arg1 = args[1].(domain.ListTravellerRequest)
+			arg1 = args[1].(int)
 		}
-		var arg2 helpers.PaginationParams
+		var arg2 time.Time
 		if args[2] != nil {
-			arg2 = args[2].(helpers.PaginationParams)
+			arg2 = args[2].(time.Time)
 		}
 		run(
 			arg0,
@@ -292,59 +435,68 @@ func (_c *MockTravellerService_GetList_Call) Run(run func(ctx context.Context, f
 	return _c
 }
 
-func (_c *MockTravellerService_GetList_Call) Return(res helpers.PaginatedResponse[domain.TravellerListItemResponse], err error) *MockTravellerService_GetList_Call {
+func (_c *MockTravellerService_GetByIDAsOf_Call) Return(res *domain.Traveller, err error) *MockTravellerService_GetByIDAsOf_Call {
 	_c.Call.Return(res, err)
 	return _c
 }
 
-func (_c *MockTravellerService_GetList_Call) RunAndReturn(run func(ctx context.Context, filter domain.ListTravellerRequest, params helpers.PaginationParams) (helpers.PaginatedResponse[domain.TravellerListItemResponse], error)) *MockTravellerService_GetList_Call {
+func (_c *MockTravellerService_GetByIDAsOf_Call) RunAndReturn(run func(ctx context.Context, id int, at time.Time) (*domain.Traveller, error)) *MockTravellerService_GetByIDAsOf_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function for the type MockTravellerService
-func (_mock *MockTravellerService) Update(ctx context.Context, id int, input domain.UpdateTravellerRequest) error {
-	ret := _mock.Called(ctx, id, input)
+// GetByIDs provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) GetByIDs(ctx context.Context, input domain.BatchGetRequest, include domain.TravellerInclude) (helpers.BatchResponse[domain.TravellerResponse], error) {
+	ret := _mock.Called(ctx, input, include)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for GetByIDs")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.UpdateTravellerRequest) error); ok {
-		r0 = returnFunc(ctx, id, input)
+	var r0 helpers.BatchResponse[domain.TravellerResponse]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.BatchGetRequest, domain.TravellerInclude) (helpers.BatchResponse[domain.TravellerResponse], error)); ok {
+		return returnFunc(ctx, input, include)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.BatchGetRequest, domain.TravellerInclude) helpers.BatchResponse[domain.TravellerResponse]); ok {
+		r0 = returnFunc(ctx, input, include)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(helpers.BatchResponse[domain.TravellerResponse])
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.BatchGetRequest, domain.TravellerInclude) error); ok {
+		r1 = returnFunc(ctx, input, include)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockTravellerService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockTravellerService_Update_Call struct {
+// MockTravellerService_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type MockTravellerService_GetByIDs_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// GetByIDs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int
-//   - input domain.UpdateTravellerRequest
-func (_e *MockTravellerService_Expecter) Update(ctx interface{}, id interface{}, input interface{}) *MockTravellerService_Update_Call {
-	return &MockTravellerService_Update_Call{Call: _e.mock.On("Update", ctx, id, input)}
+//   - input domain.BatchGetRequest
+//   - include domain.TravellerInclude
+func (_e *MockTravellerService_Expecter) GetByIDs(ctx interface{}, input interface{}, include interface{}) *MockTravellerService_GetByIDs_Call {
+	return &MockTravellerService_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, input, include)}
 }
 
-func (_c *MockTravellerService_Update_Call) Run(run func(ctx context.Context, id int, input domain.UpdateTravellerRequest)) *MockTravellerService_Update_Call {
+func (_c *MockTravellerService_GetByIDs_Call) Run(run func(ctx context.Context, input domain.BatchGetRequest, include domain.TravellerInclude)) *MockTravellerService_GetByIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 domain.BatchGetRequest
 		if args[1] != nil {
-			arg1 = args[1].(int)
+			arg1 = args[1].(domain.BatchGetRequest)
 		}
-		var arg2 domain.UpdateTravellerRequest
+		var arg2 domain.TravellerInclude
 		if args[2] != nil {
-			arg2 = args[2].(domain.UpdateTravellerRequest)
+			arg2 = args[2].(domain.TravellerInclude)
 		}
 		run(
 			arg0,
@@ -355,12 +507,818 @@ func (_c *MockTravellerService_Update_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *MockTravellerService_Update_Call) Return(err error) *MockTravellerService_Update_Call {
-	_c.Call.Return(err)
+func (_c *MockTravellerService_GetByIDs_Call) Return(res helpers.BatchResponse[domain.TravellerResponse], err error) *MockTravellerService_GetByIDs_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTravellerService_GetByIDs_Call) RunAndReturn(run func(ctx context.Context, input domain.BatchGetRequest, include domain.TravellerInclude) (helpers.BatchResponse[domain.TravellerResponse], error)) *MockTravellerService_GetByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBySlug provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) GetBySlug(ctx context.Context, slug string, include domain.TravellerInclude) (*domain.Traveller, error) {
+	ret := _mock.Called(ctx, slug, include)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySlug")
+	}
+
+	var r0 *domain.Traveller
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TravellerInclude) (*domain.Traveller, error)); ok {
+		return returnFunc(ctx, slug, include)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TravellerInclude) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, slug, include)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Traveller)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.TravellerInclude) error); ok {
+		r1 = returnFunc(ctx, slug, include)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerService_GetBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBySlug'
+type MockTravellerService_GetBySlug_Call struct {
+	*mock.Call
+}
+
+// GetBySlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+//   - include domain.TravellerInclude
+func (_e *MockTravellerService_Expecter) GetBySlug(ctx interface{}, slug interface{}, include interface{}) *MockTravellerService_GetBySlug_Call {
+	return &MockTravellerService_GetBySlug_Call{Call: _e.mock.On("GetBySlug", ctx, slug, include)}
+}
+
+func (_c *MockTravellerService_GetBySlug_Call) Run(run func(ctx context.Context, slug string, include domain.TravellerInclude)) *MockTravellerService_GetBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.TravellerInclude
+		if args[2] != nil {
+			arg2 = args[2].(domain.TravellerInclude)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerService_GetBySlug_Call) Return(res *domain.Traveller, err error) *MockTravellerService_GetBySlug_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTravellerService_GetBySlug_Call) RunAndReturn(run func(ctx context.Context, slug string, include domain.TravellerInclude) (*domain.Traveller, error)) *MockTravellerService_GetBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetList provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) GetList(ctx context.Context, filter domain.ListTravellerRequest, params helpers.PaginationParams) (helpers.PaginatedResponse[domain.TravellerListItemResponse], error) {
+	ret := _mock.Called(ctx, filter, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetList")
+	}
+
+	var r0 helpers.PaginatedResponse[domain.TravellerListItemResponse]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTravellerRequest, helpers.PaginationParams) (helpers.PaginatedResponse[domain.TravellerListItemResponse], error)); ok {
+		return returnFunc(ctx, filter, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTravellerRequest, helpers.PaginationParams) helpers.PaginatedResponse[domain.TravellerListItemResponse]); ok {
+		r0 = returnFunc(ctx, filter, params)
+	} else {
+		r0 = ret.Get(0).(helpers.PaginatedResponse[domain.TravellerListItemResponse])
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListTravellerRequest, helpers.PaginationParams) error); ok {
+		r1 = returnFunc(ctx, filter, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerService_GetList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetList'
+type MockTravellerService_GetList_Call struct {
+	*mock.Call
+}
+
+// GetList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ListTravellerRequest
+//   - params helpers.PaginationParams
+func (_e *MockTravellerService_Expecter) GetList(ctx interface{}, filter interface{}, params interface{}) *MockTravellerService_GetList_Call {
+	return &MockTravellerService_GetList_Call{Call: _e.mock.On("GetList", ctx, filter, params)}
+}
+
+func (_c *MockTravellerService_GetList_Call) Run(run func(ctx context.Context, filter domain.ListTravellerRequest, params helpers.PaginationParams)) *MockTravellerService_GetList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ListTravellerRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.ListTravellerRequest)
+		}
+		var arg2 helpers.PaginationParams
+		if args[2] != nil {
+			arg2 = args[2].(helpers.PaginationParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerService_GetList_Call) Return(res helpers.PaginatedResponse[domain.TravellerListItemResponse], err error) *MockTravellerService_GetList_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTravellerService_GetList_Call) RunAndReturn(run func(ctx context.Context, filter domain.ListTravellerRequest, params helpers.PaginationParams) (helpers.PaginatedResponse[domain.TravellerListItemResponse], error)) *MockTravellerService_GetList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPage provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) GetPage(ctx context.Context, filter domain.ListTravellerRequest, params helpers.CursorParams) (helpers.CursorResponse[domain.TravellerListItemResponse], error) {
+	ret := _mock.Called(ctx, filter, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPage")
+	}
+
+	var r0 helpers.CursorResponse[domain.TravellerListItemResponse]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTravellerRequest, helpers.CursorParams) (helpers.CursorResponse[domain.TravellerListItemResponse], error)); ok {
+		return returnFunc(ctx, filter, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListTravellerRequest, helpers.CursorParams) helpers.CursorResponse[domain.TravellerListItemResponse]); ok {
+		r0 = returnFunc(ctx, filter, params)
+	} else {
+		r0 = ret.Get(0).(helpers.CursorResponse[domain.TravellerListItemResponse])
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListTravellerRequest, helpers.CursorParams) error); ok {
+		r1 = returnFunc(ctx, filter, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerService_GetPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPage'
+type MockTravellerService_GetPage_Call struct {
+	*mock.Call
+}
+
+// GetPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ListTravellerRequest
+//   - params helpers.CursorParams
+func (_e *MockTravellerService_Expecter) GetPage(ctx interface{}, filter interface{}, params interface{}) *MockTravellerService_GetPage_Call {
+	return &MockTravellerService_GetPage_Call{Call: _e.mock.On("GetPage", ctx, filter, params)}
+}
+
+func (_c *MockTravellerService_GetPage_Call) Run(run func(ctx context.Context, filter domain.ListTravellerRequest, params helpers.CursorParams)) *MockTravellerService_GetPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ListTravellerRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.ListTravellerRequest)
+		}
+		var arg2 helpers.CursorParams
+		if args[2] != nil {
+			arg2 = args[2].(helpers.CursorParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerService_GetPage_Call) Return(res helpers.CursorResponse[domain.TravellerListItemResponse], err error) *MockTravellerService_GetPage_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTravellerService_GetPage_Call) RunAndReturn(run func(ctx context.Context, filter domain.ListTravellerRequest, params helpers.CursorParams) (helpers.CursorResponse[domain.TravellerListItemResponse], error)) *MockTravellerService_GetPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRecommendedAccessories provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) GetRecommendedAccessories(ctx context.Context, id int, input domain.RecommendAccessoryRequest) (domain.AccessoryRecommendationResponse, error) {
+	ret := _mock.Called(ctx, id, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecommendedAccessories")
+	}
+
+	var r0 domain.AccessoryRecommendationResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.RecommendAccessoryRequest) (domain.AccessoryRecommendationResponse, error)); ok {
+		return returnFunc(ctx, id, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.RecommendAccessoryRequest) domain.AccessoryRecommendationResponse); ok {
+		r0 = returnFunc(ctx, id, input)
+	} else {
+		r0 = ret.Get(0).(domain.AccessoryRecommendationResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, domain.RecommendAccessoryRequest) error); ok {
+		r1 = returnFunc(ctx, id, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerService_GetRecommendedAccessories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecommendedAccessories'
+type MockTravellerService_GetRecommendedAccessories_Call struct {
+	*mock.Call
+}
+
+// GetRecommendedAccessories is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - input domain.RecommendAccessoryRequest
+func (_e *MockTravellerService_Expecter) GetRecommendedAccessories(ctx interface{}, id interface{}, input interface{}) *MockTravellerService_GetRecommendedAccessories_Call {
+	return &MockTravellerService_GetRecommendedAccessories_Call{Call: _e.mock.On("GetRecommendedAccessories", ctx, id, input)}
+}
+
+func (_c *MockTravellerService_GetRecommendedAccessories_Call) Run(run func(ctx context.Context, id int, input domain.RecommendAccessoryRequest)) *MockTravellerService_GetRecommendedAccessories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 domain.RecommendAccessoryRequest
+		if args[2] != nil {
+			arg2 = args[2].(domain.RecommendAccessoryRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerService_GetRecommendedAccessories_Call) Return(res domain.AccessoryRecommendationResponse, err error) *MockTravellerService_GetRecommendedAccessories_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTravellerService_GetRecommendedAccessories_Call) RunAndReturn(run func(ctx context.Context, id int, input domain.RecommendAccessoryRequest) (domain.AccessoryRecommendationResponse, error)) *MockTravellerService_GetRecommendedAccessories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// History provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) History(ctx context.Context, id int, params helpers.PaginationParams) (helpers.PaginatedResponse[domain.RevisionResponse], error) {
+	ret := _mock.Called(ctx, id, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 helpers.PaginatedResponse[domain.RevisionResponse]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, helpers.PaginationParams) (helpers.PaginatedResponse[domain.RevisionResponse], error)); ok {
+		return returnFunc(ctx, id, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, helpers.PaginationParams) helpers.PaginatedResponse[domain.RevisionResponse]); ok {
+		r0 = returnFunc(ctx, id, params)
+	} else {
+		r0 = ret.Get(0).(helpers.PaginatedResponse[domain.RevisionResponse])
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, helpers.PaginationParams) error); ok {
+		r1 = returnFunc(ctx, id, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerService_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type MockTravellerService_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - params helpers.PaginationParams
+func (_e *MockTravellerService_Expecter) History(ctx interface{}, id interface{}, params interface{}) *MockTravellerService_History_Call {
+	return &MockTravellerService_History_Call{Call: _e.mock.On("History", ctx, id, params)}
+}
+
+func (_c *MockTravellerService_History_Call) Run(run func(ctx context.Context, id int, params helpers.PaginationParams)) *MockTravellerService_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 helpers.PaginationParams
+		if args[2] != nil {
+			arg2 = args[2].(helpers.PaginationParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerService_History_Call) Return(res helpers.PaginatedResponse[domain.RevisionResponse], err error) *MockTravellerService_History_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTravellerService_History_Call) RunAndReturn(run func(ctx context.Context, id int, params helpers.PaginationParams) (helpers.PaginatedResponse[domain.RevisionResponse], error)) *MockTravellerService_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Merge provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) Merge(ctx context.Context, id int, input domain.MergeTravellerRequest) (*domain.Traveller, error) {
+	ret := _mock.Called(ctx, id, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 *domain.Traveller
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.MergeTravellerRequest) (*domain.Traveller, error)); ok {
+		return returnFunc(ctx, id, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.MergeTravellerRequest) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, id, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Traveller)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, domain.MergeTravellerRequest) error); ok {
+		r1 = returnFunc(ctx, id, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerService_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type MockTravellerService_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - input domain.MergeTravellerRequest
+func (_e *MockTravellerService_Expecter) Merge(ctx interface{}, id interface{}, input interface{}) *MockTravellerService_Merge_Call {
+	return &MockTravellerService_Merge_Call{Call: _e.mock.On("Merge", ctx, id, input)}
+}
+
+func (_c *MockTravellerService_Merge_Call) Run(run func(ctx context.Context, id int, input domain.MergeTravellerRequest)) *MockTravellerService_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 domain.MergeTravellerRequest
+		if args[2] != nil {
+			arg2 = args[2].(domain.MergeTravellerRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerService_Merge_Call) Return(res *domain.Traveller, err error) *MockTravellerService_Merge_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTravellerService_Merge_Call) RunAndReturn(run func(ctx context.Context, id int, input domain.MergeTravellerRequest) (*domain.Traveller, error)) *MockTravellerService_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Patch provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) Patch(ctx context.Context, id int, input domain.UpdateTravellerRequest) (*domain.Traveller, error) {
+	ret := _mock.Called(ctx, id, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 *domain.Traveller
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.UpdateTravellerRequest) (*domain.Traveller, error)); ok {
+		return returnFunc(ctx, id, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.UpdateTravellerRequest) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, id, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Traveller)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, domain.UpdateTravellerRequest) error); ok {
+		r1 = returnFunc(ctx, id, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerService_Patch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Patch'
+type MockTravellerService_Patch_Call struct {
+	*mock.Call
+}
+
+// Patch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - input domain.UpdateTravellerRequest
+func (_e *MockTravellerService_Expecter) Patch(ctx interface{}, id interface{}, input interface{}) *MockTravellerService_Patch_Call {
+	return &MockTravellerService_Patch_Call{Call: _e.mock.On("Patch", ctx, id, input)}
+}
+
+func (_c *MockTravellerService_Patch_Call) Run(run func(ctx context.Context, id int, input domain.UpdateTravellerRequest)) *MockTravellerService_Patch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 domain.UpdateTravellerRequest
+		if args[2] != nil {
+			arg2 = args[2].(domain.UpdateTravellerRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerService_Patch_Call) Return(res *domain.Traveller, err error) *MockTravellerService_Patch_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTravellerService_Patch_Call) RunAndReturn(run func(ctx context.Context, id int, input domain.UpdateTravellerRequest) (*domain.Traveller, error)) *MockTravellerService_Patch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) Restore(ctx context.Context, id int) (*domain.Traveller, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *domain.Traveller
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*domain.Traveller, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Traveller)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerService_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockTravellerService_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockTravellerService_Expecter) Restore(ctx interface{}, id interface{}) *MockTravellerService_Restore_Call {
+	return &MockTravellerService_Restore_Call{Call: _e.mock.On("Restore", ctx, id)}
+}
+
+func (_c *MockTravellerService_Restore_Call) Run(run func(ctx context.Context, id int)) *MockTravellerService_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerService_Restore_Call) Return(res *domain.Traveller, err error) *MockTravellerService_Restore_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTravellerService_Restore_Call) RunAndReturn(run func(ctx context.Context, id int) (*domain.Traveller, error)) *MockTravellerService_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revert provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) Revert(ctx context.Context, id int, input domain.RevertRevisionRequest) (*domain.Traveller, error) {
+	ret := _mock.Called(ctx, id, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revert")
+	}
+
+	var r0 *domain.Traveller
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.RevertRevisionRequest) (*domain.Traveller, error)); ok {
+		return returnFunc(ctx, id, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.RevertRevisionRequest) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, id, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Traveller)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, domain.RevertRevisionRequest) error); ok {
+		r1 = returnFunc(ctx, id, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerService_Revert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revert'
+type MockTravellerService_Revert_Call struct {
+	*mock.Call
+}
+
+// Revert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - input domain.RevertRevisionRequest
+func (_e *MockTravellerService_Expecter) Revert(ctx interface{}, id interface{}, input interface{}) *MockTravellerService_Revert_Call {
+	return &MockTravellerService_Revert_Call{Call: _e.mock.On("Revert", ctx, id, input)}
+}
+
+func (_c *MockTravellerService_Revert_Call) Run(run func(ctx context.Context, id int, input domain.RevertRevisionRequest)) *MockTravellerService_Revert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 domain.RevertRevisionRequest
+		if args[2] != nil {
+			arg2 = args[2].(domain.RevertRevisionRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerService_Revert_Call) Return(res *domain.Traveller, err error) *MockTravellerService_Revert_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTravellerService_Revert_Call) RunAndReturn(run func(ctx context.Context, id int, input domain.RevertRevisionRequest) (*domain.Traveller, error)) *MockTravellerService_Revert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) Update(ctx context.Context, id int, input domain.UpdateTravellerRequest) (*domain.Traveller, error) {
+	ret := _mock.Called(ctx, id, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *domain.Traveller
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.UpdateTravellerRequest) (*domain.Traveller, error)); ok {
+		return returnFunc(ctx, id, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, domain.UpdateTravellerRequest) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, id, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Traveller)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, domain.UpdateTravellerRequest) error); ok {
+		r1 = returnFunc(ctx, id, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTravellerService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockTravellerService_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - input domain.UpdateTravellerRequest
+func (_e *MockTravellerService_Expecter) Update(ctx interface{}, id interface{}, input interface{}) *MockTravellerService_Update_Call {
+	return &MockTravellerService_Update_Call{Call: _e.mock.On("Update", ctx, id, input)}
+}
+
+func (_c *MockTravellerService_Update_Call) Run(run func(ctx context.Context, id int, input domain.UpdateTravellerRequest)) *MockTravellerService_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 domain.UpdateTravellerRequest
+		if args[2] != nil {
+			arg2 = args[2].(domain.UpdateTravellerRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerService_Update_Call) Return(res *domain.Traveller, err error) *MockTravellerService_Update_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockTravellerService_Update_Call) RunAndReturn(run func(ctx context.Context, id int, input domain.UpdateTravellerRequest) (*domain.Traveller, error)) *MockTravellerService_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function for the type MockTravellerService
+func (_mock *MockTravellerService) Upsert(ctx context.Context, key domain.ExternalKey, input domain.UpdateTravellerRequest) (*domain.Traveller, bool, error) {
+	ret := _mock.Called(ctx, key, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 *domain.Traveller
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ExternalKey, domain.UpdateTravellerRequest) (*domain.Traveller, bool, error)); ok {
+		return returnFunc(ctx, key, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ExternalKey, domain.UpdateTravellerRequest) *domain.Traveller); ok {
+		r0 = returnFunc(ctx, key, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Traveller)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ExternalKey, domain.UpdateTravellerRequest) bool); ok {
+		r1 = returnFunc(ctx, key, input)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, domain.ExternalKey, domain.UpdateTravellerRequest) error); ok {
+		r2 = returnFunc(ctx, key, input)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockTravellerService_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockTravellerService_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key domain.ExternalKey
+//   - input domain.UpdateTravellerRequest
+func (_e *MockTravellerService_Expecter) Upsert(ctx interface{}, key interface{}, input interface{}) *MockTravellerService_Upsert_Call {
+	return &MockTravellerService_Upsert_Call{Call: _e.mock.On("Upsert", ctx, key, input)}
+}
+
+func (_c *MockTravellerService_Upsert_Call) Run(run func(ctx context.Context, key domain.ExternalKey, input domain.UpdateTravellerRequest)) *MockTravellerService_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ExternalKey
+		if args[1] != nil {
+			arg1 = args[1].(domain.ExternalKey)
+		}
+		var arg2 domain.UpdateTravellerRequest
+		if args[2] != nil {
+			arg2 = args[2].(domain.UpdateTravellerRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTravellerService_Upsert_Call) Return(res *domain.Traveller, created bool, err error) *MockTravellerService_Upsert_Call {
+	_c.Call.Return(res, created, err)
 	return _c
 }
 
-func (_c *MockTravellerService_Update_Call) RunAndReturn(run func(ctx context.Context, id int, input domain.UpdateTravellerRequest) error) *MockTravellerService_Update_Call {
+func (_c *MockTravellerService_Upsert_Call) RunAndReturn(run func(ctx context.Context, key domain.ExternalKey, input domain.UpdateTravellerRequest) (*domain.Traveller, bool, error)) *MockTravellerService_Upsert_Call {
 	_c.Call.Return(run)
 	return _c
 }
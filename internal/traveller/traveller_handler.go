@@ -1,7 +1,11 @@
 package traveller
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"lizobly/ctc-db-api/pkg/constants"
 	"lizobly/ctc-db-api/pkg/controller"
 	"lizobly/ctc-db-api/pkg/domain"
@@ -9,35 +13,67 @@ import (
 	"lizobly/ctc-db-api/pkg/logging"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
 type TravellerService interface {
-	GetByID(ctx context.Context, id int) (res *domain.Traveller, err error)
+	GetByID(ctx context.Context, id int, include domain.TravellerInclude) (res *domain.Traveller, err error)
+	GetByIDAsOf(ctx context.Context, id int, at time.Time) (res *domain.Traveller, err error)
+	GetBySlug(ctx context.Context, slug string, include domain.TravellerInclude) (res *domain.Traveller, err error)
 	GetList(ctx context.Context, filter domain.ListTravellerRequest, params helpers.PaginationParams) (res helpers.PaginatedResponse[domain.TravellerListItemResponse], err error)
-	Create(ctx context.Context, input domain.CreateTravellerRequest) (id int64, err error)
-	Update(ctx context.Context, id int, input domain.UpdateTravellerRequest) (err error)
+	GetPage(ctx context.Context, filter domain.ListTravellerRequest, params helpers.CursorParams) (res helpers.CursorResponse[domain.TravellerListItemResponse], err error)
+	Create(ctx context.Context, input domain.CreateTravellerRequest) (res *domain.Traveller, err error)
+	Update(ctx context.Context, id int, input domain.UpdateTravellerRequest) (res *domain.Traveller, err error)
+	Patch(ctx context.Context, id int, input domain.UpdateTravellerRequest) (res *domain.Traveller, err error)
+	Upsert(ctx context.Context, key domain.ExternalKey, input domain.UpdateTravellerRequest) (res *domain.Traveller, created bool, err error)
 	Delete(ctx context.Context, id int) (err error)
+	GetRecommendedAccessories(ctx context.Context, id int, input domain.RecommendAccessoryRequest) (res domain.AccessoryRecommendationResponse, err error)
+	Compare(ctx context.Context, input domain.CompareTravellerRequest) (res domain.TravellerComparisonResponse, err error)
+	GetByIDs(ctx context.Context, input domain.BatchGetRequest, include domain.TravellerInclude) (res helpers.BatchResponse[domain.TravellerResponse], err error)
+	FindDuplicates(ctx context.Context, input domain.DuplicateCandidateRequest) (res domain.DuplicateReportResponse, err error)
+	Merge(ctx context.Context, id int, input domain.MergeTravellerRequest) (res *domain.Traveller, err error)
+	Restore(ctx context.Context, id int) (res *domain.Traveller, err error)
+	History(ctx context.Context, id int, params helpers.PaginationParams) (res helpers.PaginatedResponse[domain.RevisionResponse], err error)
+	Revert(ctx context.Context, id int, input domain.RevertRevisionRequest) (res *domain.Traveller, err error)
+}
+
+// OperationService runs writes in the background for Prefer: respond-async
+type OperationService interface {
+	Start(ctx context.Context, name string, run domain.OperationFunc) (res *domain.Operation)
 }
 
 type TravellerHandler struct {
-	Service TravellerService
-	logger  *logging.Logger
+	Service    TravellerService
+	Operations OperationService
+	logger     *logging.Logger
 }
 
-func NewTravellerHandler(e *echo.Group, svc TravellerService, logger *logging.Logger) *TravellerHandler {
+func NewTravellerHandler(e *echo.Group, svc TravellerService, operations OperationService, logger *logging.Logger) *TravellerHandler {
 	handler := &TravellerHandler{
-		Service: svc,
-		logger:  logger.Named("handler.traveller"),
+		Service:    svc,
+		Operations: operations,
+		logger:     logger.Named("handler.traveller"),
 	}
 	group := e.Group("/travellers")
 
 	group.GET("", handler.GetList)
 	group.GET("/:id", handler.GetByID)
+	group.GET("/by-slug/:slug", handler.GetBySlug)
+	group.GET("/compare", handler.Compare)
+	group.GET("/duplicates", handler.GetDuplicates)
 	group.POST("", handler.Create)
 	group.PUT("/:id", handler.Update)
+	group.PATCH("/:id", handler.Patch)
+	group.PUT("/by-external/:source/:id", handler.Upsert)
+	group.POST("/:id/merge", handler.Merge)
 	group.DELETE("/:id", handler.Delete)
+	group.POST("/:id/restore", handler.Restore)
+	group.GET("/:id/history", handler.GetHistory)
+	group.POST("/:id/revert", handler.Revert)
+	group.GET("/:id/recommended-accessories", handler.GetRecommendedAccessories)
 
 	return handler
 }
@@ -45,32 +81,73 @@ func NewTravellerHandler(e *echo.Group, svc TravellerService, logger *logging.Lo
 // GetList godoc
 //
 //	@Summary		Get list
-//	@Description	get traveller list with optional filters and pagination
+//	@Description	get traveller list with optional filters, ordering, and pagination
 //	@Tags			travellers
 //	@Accept			json
 //	@Produce		json
-//	@Param			name		query	string	false	"Filter by name (case insensitive)"
-//	@Param			influence	query	string	false	"Filter by influence name"
-//	@Param			job			query	string	false	"Filter by job name"
+//	@Param			name			query	string	false	"Filter by name (case insensitive)"
+//	@Param			influence		query	string	false	"Filter by comma-separated influence names"
+//	@Param			job				query	string	false	"Filter by comma-separated job names"
+//	@Param			rarity_min		query	int		false	"Minimum rarity (1-5)"
+//	@Param			rarity_max		query	int		false	"Maximum rarity (1-5)"
+//	@Param			released_after	query	string	false	"Released on or after date (DD-MM-YYYY)"
+//	@Param			released_before	query	string	false	"Released on or before date (DD-MM-YYYY)"
+//	@Param			has_accessory	query	bool	false	"Filter by whether the traveller has an accessory"
+//	@Param			banner			query	string	false	"Filter by banner (case insensitive)"
+//	@Param			order_by	query	string	false	"Comma-separated sort keys (name, rarity, release_date, created_at, updated_at, influence, job); influence and job sort by name"
+//	@Param			order_dir	query	string	false	"Comma-separated directions (asc, desc in any case), one per key or one for all"
+//	@Param			facets		query	string	false	"Comma-separated facets to count (job, influence, rarity); each respects every other filter except its own"
+//	@Param			filter		query	string	false	"Filter expression, e.g. rarity>=4 and job in (Warrior,Dancer) and name~\"vi\""
 //	@Param			page		query	int		false	"Page number (default 1)"
 //	@Param			page_size	query	int		false	"Page size (default 10, max 100)"
+//	@Param			cursor		query	string	false	"Keyset pagination cursor from next_cursor or prev_cursor; send it empty for the first page. Setting cursor or limit switches the response to a helpers.CursorResponse"
+//	@Param			limit		query	int		false	"Keyset page size (default 10, max 100)"
+//	@Param			include_total	query	bool	false	"Count the matching rows in keyset mode"
+//	@Param			fields		query	string	false	"Comma-separated fields to return for each item, e.g. name,rarity,job; id is always returned"
+//	@Param			include		query	string	false	"Comma-separated relations to embed in each item (accessory)"
+//	@Param			as_of		query	string	false	"List the travellers, and included accessories, as they were at this RFC 3339 time"
+//	@Param			ids			query	string	false	"Comma-separated IDs to fetch in one request (max 100); the response is then a helpers.BatchResponse listing missing IDs under not_found, and other parameters except fields and include are ignored"
 //	@Success		200	{object}	helpers.PaginatedResponse[domain.TravellerListItemResponse]
+//	@Header			200	{string}	ETag	"Entity tag for the page, derived from the filter, page, and result set"
+//	@Header			200	{string}	Last-Modified	"Newest modification among matching items; If-Modified-Since is ignored, use If-None-Match"
+//	@Header			200	{string}	Link	"RFC 8288 links to the first, prev, next and last pages, keeping the request's filters"
 //	@Failure		400	{object}	controller.ErrorResponse
 //	@Failure		500	{object}	controller.ErrorResponse
 //	@Router			/travellers [get]
 //	@Security		BearerAuth
 func (h *TravellerHandler) GetList(ctx echo.Context) error {
+	// A list of ids turns the request into a multi-get; other filters and pagination don't apply
+	if ctx.QueryParams().Has("ids") {
+		return h.getByIDs(ctx)
+	}
+
 	var filter domain.ListTravellerRequest
 	err := ctx.Bind(&filter)
 	if err != nil {
 		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid request body")
 	}
+	// Sort directions are case insensitive
+	filter.OrderDir = strings.ToLower(filter.OrderDir)
 
 	err = ctx.Validate(&filter)
 	if err != nil {
 		return controller.ResponseErrorValidation(ctx, err)
 	}
 
+	fields, err := controller.ParseFieldset(ctx, domain.TravellerListItemResponse{})
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+	// An included relation is always returned, whatever fields lists
+	if fields != nil && filter.Include != "" {
+		fields["accessory"] = true
+	}
+
+	// A cursor or limit switches to keyset pagination
+	if ctx.QueryParams().Has("cursor") || ctx.QueryParams().Has("limit") {
+		return h.getPage(ctx, filter, fields)
+	}
+
 	var params helpers.PaginationParams
 	err = ctx.Bind(&params)
 	if err != nil {
@@ -82,10 +159,76 @@ func (h *TravellerHandler) GetList(ctx echo.Context) error {
 		return controller.HandleServiceError(ctx, err, "get traveller list", h.logger)
 	}
 
-	// Set cache headers for list responses
-	helpers.SetListCacheHeaders(ctx)
+	// Set cache headers and check if client has valid cached page
+	if helpers.SetListCacheHeaders(ctx, result.ETag(ctx.QueryParams()), result.LastModified()) {
+		return helpers.RespondNotModified(ctx)
+	}
 
-	return controller.Ok(ctx, result)
+	helpers.SetPageLinks(ctx, &result)
+	return controller.Ok(ctx, result.Select(fields))
+}
+
+// getPage serves GET /travellers?cursor=...&limit=..., a keyset-paginated page of the filtered list
+func (h *TravellerHandler) getPage(ctx echo.Context, filter domain.ListTravellerRequest, fields helpers.Fieldset) error {
+	var params helpers.CursorParams
+	err := ctx.Bind(&params)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid pagination parameters")
+	}
+
+	result, err := h.Service.GetPage(ctx.Request().Context(), filter, params)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "get traveller page", h.logger)
+	}
+
+	helpers.SetCursorLinks(ctx, &result)
+	return controller.Ok(ctx, result.Select(fields))
+}
+
+// getByIDs serves GET /travellers?ids=..., returning the records in request order with per-ID not-found reporting
+func (h *TravellerHandler) getByIDs(ctx echo.Context) error {
+	var request domain.BatchGetRequest
+	err := ctx.Bind(&request)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid query parameters")
+	}
+
+	fields, err := controller.ParseFieldset(ctx, domain.TravellerResponse{})
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	include, err := travellerInclude(ctx, fields)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	result, err := h.Service.GetByIDs(ctx.Request().Context(), request, include)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "get travellers by ids", h.logger)
+	}
+
+	return controller.Ok(ctx, result.Select(fields))
+}
+
+// travellerInclude returns the relations a traveller read loads: every relation when fields
+// selects the whole response, otherwise the selected ones and those named in include. Included
+// relations are added to fields so they are returned.
+func travellerInclude(ctx echo.Context, fields helpers.Fieldset) (domain.TravellerInclude, error) {
+	request := domain.TravellerIncludeRequest{Include: ctx.QueryParam("include")}
+	if err := ctx.Validate(&request); err != nil {
+		return domain.TravellerInclude{}, err
+	}
+	if fields == nil {
+		return domain.TravellerIncludeAll, nil
+	}
+
+	include := domain.ParseTravellerInclude(request.Include)
+	if include.Accessory {
+		fields["accessory"] = true
+	}
+	include.Accessory = fields["accessory"]
+	return include, nil
 }
 
 // GetByID godoc
@@ -95,7 +238,10 @@ func (h *TravellerHandler) GetList(ctx echo.Context) error {
 //	@Tags			travellers
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		int	true	"Traveller ID"
+//	@Param			id		path		int		true	"Traveller ID"
+//	@Param			fields	query		string	false	"Comma-separated fields to return, e.g. name,rarity,job; id is always returned"
+//	@Param			include	query		string	false	"Comma-separated relations to return even when fields leaves them out (accessory)"
+//	@Param			as_of	query		string	false	"Return the traveller as it was at this RFC 3339 time, e.g. 2024-10-01T09:00:00Z"
 //	@Success		200	{object}	domain.TravellerResponse
 //	@Header			200	{string}	ETag	"Entity tag for caching"
 //	@Header			200	{string}	Last-Modified	"Last modified timestamp"
@@ -110,7 +256,30 @@ func (h *TravellerHandler) GetByID(ctx echo.Context) error {
 		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid id parameter")
 	}
 
-	traveller, err := h.Service.GetByID(ctx.Request().Context(), id)
+	fields, err := controller.ParseFieldset(ctx, domain.TravellerResponse{})
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	include, err := travellerInclude(ctx, fields)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	asOf, err := domain.ParseAsOf(ctx.QueryParam("as_of"))
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+	if !asOf.IsZero() {
+		// The version and timestamps of a past state don't identify it, so it gets no cache headers
+		traveller, err := h.Service.GetByIDAsOf(ctx.Request().Context(), id, asOf)
+		if err != nil {
+			return controller.HandleServiceError(ctx, err, "get traveller as of", h.logger)
+		}
+		return controller.Ok(ctx, fields.Select(domain.ToTravellerResponse(traveller)))
+	}
+
+	traveller, err := h.Service.GetByID(ctx.Request().Context(), id, include)
 	if err != nil {
 		return controller.HandleServiceError(ctx, err, "get traveller by id", h.logger)
 	}
@@ -121,7 +290,49 @@ func (h *TravellerHandler) GetByID(ctx echo.Context) error {
 	}
 
 	response := domain.ToTravellerResponse(traveller)
-	return controller.Ok(ctx, response)
+	return controller.Ok(ctx, fields.Select(response))
+}
+
+// GetBySlug godoc
+//
+//	@Summary		Get by slug
+//	@Description	get traveller information by its URL-safe slug
+//	@Tags			travellers
+//	@Accept			json
+//	@Produce		json
+//	@Param			slug	path		string	true	"Traveller slug"
+//	@Param			fields	query		string	false	"Comma-separated fields to return, e.g. name,rarity,job; id is always returned"
+//	@Param			include	query		string	false	"Comma-separated relations to return even when fields leaves them out (accessory)"
+//	@Success		200		{object}	domain.TravellerResponse
+//	@Header			200		{string}	ETag	"Entity tag for caching"
+//	@Header			200		{string}	Last-Modified	"Last modified timestamp"
+//	@Failure		404		{object}	controller.ErrorResponse
+//	@Failure		500		{object}	controller.ErrorResponse
+//	@Router			/travellers/by-slug/{slug} [get]
+//	@Security		BearerAuth
+func (h *TravellerHandler) GetBySlug(ctx echo.Context) error {
+	fields, err := controller.ParseFieldset(ctx, domain.TravellerResponse{})
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	include, err := travellerInclude(ctx, fields)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	traveller, err := h.Service.GetBySlug(ctx.Request().Context(), ctx.Param("slug"), include)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "get traveller by slug", h.logger)
+	}
+
+	// Set cache headers and check if client has valid cached version
+	if helpers.SetCacheHeaders(ctx, traveller.ETag(), traveller.LastModified(), constants.CacheMaxAgeResource) {
+		return helpers.RespondNotModified(ctx)
+	}
+
+	response := domain.ToTravellerResponse(traveller)
+	return controller.Ok(ctx, fields.Select(response))
 }
 
 // Create godoc
@@ -132,10 +343,14 @@ func (h *TravellerHandler) GetByID(ctx echo.Context) error {
 //	@Accept			json
 //	@Produce		json
 //	@Param			body	body		domain.CreateTravellerRequest	true	"Traveller data"
+//	@Param			Prefer	header	string	false	"return=minimal for an empty 204, return=representation (default) for the traveller, respond-async to run in the background"
 //	@Success		201	{object}	domain.TravellerResponse
 //	@Header			201	{string}	Location	"URI of the created resource"
 //	@Header			201	{string}	ETag	"Entity tag for caching"
 //	@Header			201	{string}	Last-Modified	"Last modified timestamp"
+//	@Success		202	{object}	domain.OperationResponse	"Accepted with Prefer: respond-async; poll the Location for the result"
+//	@Header			202	{string}	Location	"URI of the operation status"
+//	@Success		204	"Created with Prefer: return=minimal; Location, ETag and Last-Modified are set"
 //	@Failure		400	{object}	controller.ErrorResponse
 //	@Failure		409	{object}	controller.ErrorResponse
 //	@Failure		500	{object}	controller.ErrorResponse
@@ -154,23 +369,19 @@ func (h *TravellerHandler) Create(ctx echo.Context) error {
 		return controller.ResponseErrorValidation(ctx, err)
 	}
 
-	id, err := h.Service.Create(ctx.Request().Context(), newTraveller)
-	if err != nil {
-		return controller.HandleServiceError(ctx, err, "create traveller", h.logger)
+	prefer := helpers.ParsePrefer(ctx)
+	if prefer.RespondAsync {
+		return h.startAsync(ctx, "create traveller", func(ctx context.Context) (*domain.Traveller, error) {
+			return h.Service.Create(ctx, newTraveller)
+		})
 	}
 
-	traveller, err := h.Service.GetByID(ctx.Request().Context(), int(id))
+	traveller, err := h.Service.Create(ctx.Request().Context(), newTraveller)
 	if err != nil {
-		return controller.HandleServiceError(ctx, err, "get created traveller", h.logger)
+		return controller.HandleServiceError(ctx, err, "create traveller", h.logger)
 	}
 
-	// Set ETag and Last-Modified for created resource
-	ctx.Response().Header().Set("ETag", traveller.ETag())
-	ctx.Response().Header().Set("Last-Modified", traveller.LastModified())
-
-	location := "/api/v1/travellers/" + strconv.FormatInt(id, 10)
-	response := domain.ToTravellerResponse(traveller)
-	return controller.Created(ctx, response, location)
+	return respondWritten(ctx, prefer, traveller, http.StatusCreated)
 }
 
 // Update godoc
@@ -183,9 +394,13 @@ func (h *TravellerHandler) Create(ctx echo.Context) error {
 //	@Param			id	path		int	true	"Traveller ID"
 //	@Param			body	body		domain.UpdateTravellerRequest	true	"Updated traveller data"
 //	@Param			If-Match	header	string	false	"ETag for optimistic locking"
+//	@Param			Prefer	header	string	false	"return=minimal for an empty 204, return=representation (default) for the traveller, respond-async to run in the background"
 //	@Success		200	{object}	domain.TravellerResponse
 //	@Header			200	{string}	ETag	"Updated entity tag"
 //	@Header			200	{string}	Last-Modified	"Updated timestamp"
+//	@Success		202	{object}	domain.OperationResponse	"Accepted with Prefer: respond-async; poll the Location for the result"
+//	@Header			202	{string}	Location	"URI of the operation status"
+//	@Success		204	"Updated with Prefer: return=minimal; Location, ETag and Last-Modified are set"
 //	@Failure		400	{object}	controller.ErrorResponse
 //	@Failure		404	{object}	controller.ErrorResponse
 //	@Failure		412	{object}	controller.ErrorResponse	"Precondition Failed - resource was modified"
@@ -198,49 +413,317 @@ func (h *TravellerHandler) Update(ctx echo.Context) error {
 		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid id parameter")
 	}
 
-	// Check for optimistic locking with If-Match header
-	if ctx.Request().Header.Get("If-Match") != "" {
-		// Get current state to verify ETag
-		currentTraveller, err := h.Service.GetByID(ctx.Request().Context(), id)
-		if err != nil {
-			return controller.HandleServiceError(ctx, err, "get traveller for etag check", h.logger)
-		}
+	var updateRequest domain.UpdateTravellerRequest
+	err = ctx.Bind(&updateRequest)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid request body")
+	}
 
-		// Prevent lost updates - resource was modified
-		if !helpers.CheckETagMatch(ctx, currentTraveller.ETag()) {
+	// Optimistic locking - the repository only writes if the version from If-Match is still current
+	if ifMatch := ctx.Request().Header.Get("If-Match"); ifMatch != "" {
+		version, ok := domain.ParseETagVersion(ifMatch)
+		if !ok {
 			return helpers.RespondPreconditionFailed(ctx)
 		}
+		updateRequest.Version = version
 	}
 
-	var updateRequest domain.UpdateTravellerRequest
-	err = ctx.Bind(&updateRequest)
+	err = ctx.Validate(&updateRequest)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	prefer := helpers.ParsePrefer(ctx)
+	if prefer.RespondAsync {
+		return h.startAsync(ctx, "update traveller", func(ctx context.Context) (*domain.Traveller, error) {
+			return h.Service.Update(ctx, id, updateRequest)
+		})
+	}
+
+	traveller, err := h.Service.Update(ctx.Request().Context(), id, updateRequest)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "update traveller", h.logger)
+	}
+
+	return respondWritten(ctx, prefer, traveller, http.StatusOK)
+}
+
+// Patch godoc
+//
+//	@Summary		Patch traveller
+//	@Description	partially update a traveller with a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) document.
+//	@Description	The patch is applied to the traveller in update-request form and the result is validated like a PUT.
+//	@Description	banner, release_date and accessory can be cleared with null (merge patch) or remove (JSON patch).
+//	@Tags			travellers
+//	@Accept			application/merge-patch+json,application/json-patch+json
+//	@Produce		json
+//	@Param			id			path		int		true	"Traveller ID"
+//	@Param			If-Match	header		string	false	"ETag for optimistic locking"
+//	@Param			body		body		domain.UpdateTravellerRequest	true	"Patch document"
+//	@Param			Prefer		header		string	false	"return=minimal for an empty 204, return=representation (default) for the traveller, respond-async to run in the background"
+//	@Success		200			{object}	domain.TravellerResponse
+//	@Header			200			{string}	ETag	"Updated entity tag"
+//	@Header			200			{string}	Last-Modified	"Updated timestamp"
+//	@Success		202			{object}	domain.OperationResponse	"Accepted with Prefer: respond-async; poll the Location for the result"
+//	@Header			202			{string}	Location	"URI of the operation status"
+//	@Success		204			"Patched with Prefer: return=minimal; Location, ETag and Last-Modified are set"
+//	@Failure		400			{object}	controller.ErrorResponse
+//	@Failure		404			{object}	controller.ErrorResponse
+//	@Failure		409			{object}	controller.ErrorResponse
+//	@Failure		412			{object}	controller.ErrorResponse	"Precondition Failed - resource was modified"
+//	@Failure		415			{object}	controller.ErrorResponse
+//	@Failure		500			{object}	controller.ErrorResponse
+//	@Router			/travellers/{id} [patch]
+//	@Security		BearerAuth
+func (h *TravellerHandler) Patch(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid id parameter")
+	}
+
+	mediaType, err := helpers.PatchMediaType(ctx.Request().Header.Get(echo.HeaderContentType))
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusUnsupportedMediaType, "content type must be application/merge-patch+json or application/json-patch+json")
+	}
+
+	patch, err := io.ReadAll(ctx.Request().Body)
 	if err != nil {
 		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid request body")
 	}
 
-	err = ctx.Validate(&updateRequest)
+	currentTraveller, err := h.Service.GetByID(ctx.Request().Context(), id, domain.TravellerIncludeAll)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "get traveller for patch", h.logger)
+	}
+
+	// Prevent lost updates - resource was modified
+	if !helpers.CheckETagMatch(ctx, currentTraveller.ETag()) {
+		return helpers.RespondPreconditionFailed(ctx)
+	}
+
+	original, err := json.Marshal(domain.ToUpdateTravellerRequest(currentTraveller))
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "encode traveller for patch", h.logger)
+	}
+
+	patched, err := helpers.ApplyPatch(mediaType, original, patch)
+	if err != nil {
+		if errors.Is(err, helpers.ErrPatchNotApplicable) {
+			return controller.ResponseError(ctx, http.StatusConflict, err.Error())
+		}
+		return controller.ResponseError(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	// Reject documents that no longer fit the traveller shape, e.g. unknown fields or wrong types
+	var patchRequest domain.UpdateTravellerRequest
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patchRequest); err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "patched document is not a valid traveller")
+	}
+
+	err = ctx.Validate(&patchRequest)
 	if err != nil {
 		return controller.ResponseErrorValidation(ctx, err)
 	}
 
-	err = h.Service.Update(ctx.Request().Context(), id, updateRequest)
+	// The patch was applied to this version, so only write if it is still current
+	patchRequest.Version = currentTraveller.Version
+
+	prefer := helpers.ParsePrefer(ctx)
+	if prefer.RespondAsync {
+		return h.startAsync(ctx, "patch traveller", func(ctx context.Context) (*domain.Traveller, error) {
+			return h.Service.Patch(ctx, id, patchRequest)
+		})
+	}
+
+	traveller, err := h.Service.Patch(ctx.Request().Context(), id, patchRequest)
 	if err != nil {
-		return controller.HandleServiceError(ctx, err, "update traveller", h.logger)
+		return controller.HandleServiceError(ctx, err, "patch traveller", h.logger)
+	}
+
+	return respondWritten(ctx, prefer, traveller, http.StatusOK)
+}
+
+// Upsert godoc
+//
+//	@Summary		Upsert traveller by external ID
+//	@Description	create or update the traveller synced from an external source under the source's own ID, in one atomic write.
+//	@Description	A mapped ID updates its traveller like PUT /travellers/{id}; an unmapped ID adopts the traveller with the same name, or creates one.
+//	@Description	The accessory is mapped under the same external ID.
+//	@Tags			travellers
+//	@Accept			json
+//	@Produce		json
+//	@Param			source		path		string	true	"External source, e.g. cotc-wiki"
+//	@Param			id			path		string	true	"ID of the traveller in the source"
+//	@Param			body		body		domain.UpdateTravellerRequest	true	"Traveller data"
+//	@Param			If-Match	header		string	false	"ETag for optimistic locking; fails if the traveller would be created"
+//	@Param			Prefer		header		string	false	"return=minimal for an empty 204, return=representation (default) for the traveller, respond-async to run in the background"
+//	@Success		200			{object}	domain.TravellerResponse	"Updated"
+//	@Header			200			{string}	ETag	"Updated entity tag"
+//	@Header			200			{string}	Last-Modified	"Updated timestamp"
+//	@Success		201			{object}	domain.TravellerResponse	"Created"
+//	@Header			201			{string}	Location	"URI of the created resource"
+//	@Success		202			{object}	domain.OperationResponse	"Accepted with Prefer: respond-async; poll the Location for the result"
+//	@Success		204			"Written with Prefer: return=minimal; Location, ETag and Last-Modified are set"
+//	@Failure		400			{object}	controller.ErrorResponse
+//	@Failure		409			{object}	controller.ErrorResponse
+//	@Failure		412			{object}	controller.ErrorResponse	"Precondition Failed - resource was modified"
+//	@Failure		500			{object}	controller.ErrorResponse
+//	@Router			/travellers/by-external/{source}/{id} [put]
+//	@Security		BearerAuth
+func (h *TravellerHandler) Upsert(ctx echo.Context) error {
+	key := domain.ExternalKey{Source: ctx.Param("source"), ExternalID: ctx.Param("id")}
+	err := ctx.Validate(&key)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
 	}
 
-	traveller, err := h.Service.GetByID(ctx.Request().Context(), id)
+	var upsertRequest domain.UpdateTravellerRequest
+	err = ctx.Bind(&upsertRequest)
 	if err != nil {
-		return controller.HandleServiceError(ctx, err, "get updated traveller", h.logger)
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid request body")
 	}
 
-	// Set new ETag and Last-Modified for updated resource
+	// Optimistic locking - the repository only writes if the version from If-Match is still current
+	if ifMatch := ctx.Request().Header.Get("If-Match"); ifMatch != "" {
+		version, ok := domain.ParseETagVersion(ifMatch)
+		if !ok {
+			return helpers.RespondPreconditionFailed(ctx)
+		}
+		upsertRequest.Version = version
+	}
+
+	err = ctx.Validate(&upsertRequest)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	prefer := helpers.ParsePrefer(ctx)
+	if prefer.RespondAsync {
+		return h.startAsync(ctx, "upsert traveller", func(ctx context.Context) (*domain.Traveller, error) {
+			traveller, _, err := h.Service.Upsert(ctx, key, upsertRequest)
+			return traveller, err
+		})
+	}
+
+	traveller, created, err := h.Service.Upsert(ctx.Request().Context(), key, upsertRequest)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "upsert traveller", h.logger)
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	return respondWritten(ctx, prefer, traveller, status)
+}
+
+// Merge godoc
+//
+//	@Summary		Merge travellers
+//	@Description	merge a duplicate traveller into this one in one transaction. Attributes this traveller leaves empty are taken from the duplicate,
+//	@Description	its accessory moves over if this traveller has none, and its external IDs are re-pointed here. When both have an accessory,
+//	@Description	keep_accessory picks the one kept; the other stays without a traveller. Pending change requests for the duplicate are closed,
+//	@Description	and the duplicate is then deleted.
+//	@Tags			travellers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"ID of the traveller to keep"
+//	@Param			body		body		domain.MergeTravellerRequest	true	"Traveller to merge in"
+//	@Param			If-Match	header		string	false	"ETag of the kept traveller for optimistic locking"
+//	@Param			Prefer		header		string	false	"return=minimal for an empty 204, return=representation (default) for the traveller, respond-async to run in the background"
+//	@Success		200			{object}	domain.TravellerResponse
+//	@Header			200			{string}	ETag	"Updated entity tag"
+//	@Header			200			{string}	Last-Modified	"Updated timestamp"
+//	@Success		202			{object}	domain.OperationResponse	"Accepted with Prefer: respond-async; poll the Location for the result"
+//	@Header			202			{string}	Location	"URI of the operation status"
+//	@Success		204			"Merged with Prefer: return=minimal; Location, ETag and Last-Modified are set"
+//	@Failure		400			{object}	controller.ErrorResponse
+//	@Failure		404			{object}	controller.ErrorResponse
+//	@Failure		412			{object}	controller.ErrorResponse	"Precondition Failed - resource was modified"
+//	@Failure		500			{object}	controller.ErrorResponse
+//	@Router			/travellers/{id}/merge [post]
+//	@Security		BearerAuth
+func (h *TravellerHandler) Merge(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid id parameter")
+	}
+
+	var mergeRequest domain.MergeTravellerRequest
+	err = ctx.Bind(&mergeRequest)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid request body")
+	}
+
+	// Optimistic locking - the repository only merges if the version from If-Match is still current
+	if ifMatch := ctx.Request().Header.Get("If-Match"); ifMatch != "" {
+		version, ok := domain.ParseETagVersion(ifMatch)
+		if !ok {
+			return helpers.RespondPreconditionFailed(ctx)
+		}
+		mergeRequest.Version = version
+	}
+
+	err = ctx.Validate(&mergeRequest)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	prefer := helpers.ParsePrefer(ctx)
+	if prefer.RespondAsync {
+		return h.startAsync(ctx, "merge travellers", func(ctx context.Context) (*domain.Traveller, error) {
+			return h.Service.Merge(ctx, id, mergeRequest)
+		})
+	}
+
+	traveller, err := h.Service.Merge(ctx.Request().Context(), id, mergeRequest)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "merge travellers", h.logger)
+	}
+
+	return respondWritten(ctx, prefer, traveller, http.StatusOK)
+}
+
+// respondWritten sends a written traveller as the client prefers: the traveller by default, or
+// with return=minimal an empty 204 that keeps only Location and the cache headers
+func respondWritten(ctx echo.Context, prefer helpers.Preferences, traveller *domain.Traveller, status int) error {
 	ctx.Response().Header().Set("ETag", traveller.ETag())
 	ctx.Response().Header().Set("Last-Modified", traveller.LastModified())
+	location := domain.TravellerPath(traveller.ID)
+
+	if prefer.Return != "" {
+		helpers.SetPreferenceApplied(ctx, "return="+prefer.Return)
+	}
+	if prefer.Return == helpers.PreferReturnMinimal {
+		ctx.Response().Header().Set("Location", location)
+		return controller.NoContent(ctx)
+	}
 
 	response := domain.ToTravellerResponse(traveller)
+	if status == http.StatusCreated {
+		return controller.Created(ctx, response, location)
+	}
 	return controller.Ok(ctx, response)
 }
 
+// startAsync runs a write in the background for Prefer: respond-async and responds 202 with the
+// operation, whose location is the written traveller once it succeeds. The request was already
+// validated, so only failures of the write itself end up on the operation.
+func (h *TravellerHandler) startAsync(ctx echo.Context, name string, write func(ctx context.Context) (*domain.Traveller, error)) error {
+	op := h.Operations.Start(ctx.Request().Context(), name, func(ctx context.Context) (string, error) {
+		traveller, err := write(ctx)
+		if err != nil {
+			return "", err
+		}
+		return domain.TravellerPath(traveller.ID), nil
+	})
+
+	helpers.SetPreferenceApplied(ctx, "respond-async")
+	return controller.Accepted(ctx, domain.ToOperationResponse(op), domain.OperationPath(op.ID))
+}
+
 // Delete godoc
 //
 //	@Summary		Delete traveller
@@ -268,3 +751,234 @@ func (h *TravellerHandler) Delete(ctx echo.Context) error {
 
 	return controller.NoContent(ctx)
 }
+
+// Restore godoc
+//
+//	@Summary		Restore traveller
+//	@Description	bring a deleted traveller back from the trash. It fails with 409 if a live traveller has taken its name since;
+//	@Description	if its accessory has gone to another traveller, it comes back without one.
+//	@Tags			travellers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"Traveller ID"
+//	@Param			Prefer	header		string	false	"return=minimal for an empty 204, return=representation (default) for the traveller"
+//	@Success		200		{object}	domain.TravellerResponse
+//	@Header			200		{string}	ETag	"Updated entity tag"
+//	@Header			200		{string}	Last-Modified	"Updated timestamp"
+//	@Success		204		"Restored with Prefer: return=minimal; Location, ETag and Last-Modified are set"
+//	@Failure		400		{object}	controller.ErrorResponse
+//	@Failure		404		{object}	controller.ErrorResponse	"No deleted traveller has the ID"
+//	@Failure		409		{object}	controller.ErrorResponse	"A live traveller has the same name"
+//	@Failure		500		{object}	controller.ErrorResponse
+//	@Router			/travellers/{id}/restore [post]
+//	@Security		BearerAuth
+func (h *TravellerHandler) Restore(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid id parameter")
+	}
+
+	traveller, err := h.Service.Restore(ctx.Request().Context(), id)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "restore traveller", h.logger)
+	}
+
+	return respondWritten(ctx, helpers.ParsePrefer(ctx), traveller, http.StatusOK)
+}
+
+// GetHistory godoc
+//
+//	@Summary		Get traveller history
+//	@Description	list the revisions of a traveller and of its current accessory, most recent first, with who made each one,
+//	@Description	in which request, and the fields it changed. A revision's ID can be passed to POST /travellers/{id}/revert.
+//	@Description	The scheduled publisher records a revision with the publish action when it publishes the traveller or accessory.
+//	@Tags			travellers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path	int	true	"Traveller ID"
+//	@Param			page		query	int	false	"Page number (default 1)"
+//	@Param			page_size	query	int	false	"Page size (default 10, max 100)"
+//	@Success		200	{object}	helpers.PaginatedResponse[domain.RevisionResponse]
+//	@Header			200	{string}	Link	"RFC 8288 links to the first, prev, next and last pages"
+//	@Failure		400	{object}	controller.ErrorResponse
+//	@Failure		404	{object}	controller.ErrorResponse
+//	@Failure		500	{object}	controller.ErrorResponse
+//	@Router			/travellers/{id}/history [get]
+//	@Security		BearerAuth
+func (h *TravellerHandler) GetHistory(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid id parameter")
+	}
+
+	var params helpers.PaginationParams
+	err = ctx.Bind(&params)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid pagination parameters")
+	}
+
+	result, err := h.Service.History(ctx.Request().Context(), id, params)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "get traveller history", h.logger)
+	}
+
+	helpers.SetPageLinks(ctx, &result)
+	return controller.Ok(ctx, result)
+}
+
+// Revert godoc
+//
+//	@Summary		Revert traveller revision
+//	@Description	set the fields one revision of a traveller or its current accessory changed back to their values before it.
+//	@Description	It fails with 409 if any of those fields has changed again since; only revisions that changed fields can be reverted.
+//	@Tags			travellers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"Traveller ID"
+//	@Param			revision	query		int		true	"Revision ID, from GET /travellers/{id}/history"
+//	@Param			Prefer		header		string	false	"return=minimal for an empty 204, return=representation (default) for the traveller"
+//	@Success		200			{object}	domain.TravellerResponse
+//	@Header			200			{string}	ETag	"Updated entity tag"
+//	@Header			200			{string}	Last-Modified	"Updated timestamp"
+//	@Success		204			"Reverted with Prefer: return=minimal; Location, ETag and Last-Modified are set"
+//	@Failure		400			{object}	controller.ErrorResponse
+//	@Failure		404			{object}	controller.ErrorResponse	"No traveller, or no revision of it or its accessory, has the ID"
+//	@Failure		409			{object}	controller.ErrorResponse	"A reverted field has changed since the revision"
+//	@Failure		500			{object}	controller.ErrorResponse
+//	@Router			/travellers/{id}/revert [post]
+//	@Security		BearerAuth
+func (h *TravellerHandler) Revert(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid id parameter")
+	}
+
+	// Bind only reads the query string of GET and DELETE requests
+	var request domain.RevertRevisionRequest
+	err = (&echo.DefaultBinder{}).BindQueryParams(ctx, &request)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid query parameters")
+	}
+
+	err = ctx.Validate(&request)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	traveller, err := h.Service.Revert(ctx.Request().Context(), id, request)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "revert traveller revision", h.logger)
+	}
+
+	return respondWritten(ctx, helpers.ParsePrefer(ctx), traveller, http.StatusOK)
+}
+
+// GetRecommendedAccessories godoc
+//
+//	@Summary		Get recommended accessories
+//	@Description	get the top accessory candidates for a traveller, scored against the stat-weight profile of its job
+//	@Tags			travellers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path	int		true	"Traveller ID"
+//	@Param			limit			query	int		false	"Number of candidates (default 5, max 50)"
+//	@Param			include_owned	query	bool	false	"Include accessories equipped by other travellers"
+//	@Success		200	{object}	domain.AccessoryRecommendationResponse
+//	@Failure		400	{object}	controller.ErrorResponse
+//	@Failure		404	{object}	controller.ErrorResponse
+//	@Failure		500	{object}	controller.ErrorResponse
+//	@Router			/travellers/{id}/recommended-accessories [get]
+//	@Security		BearerAuth
+func (h *TravellerHandler) GetRecommendedAccessories(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid id parameter")
+	}
+
+	var request domain.RecommendAccessoryRequest
+	err = ctx.Bind(&request)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid query parameters")
+	}
+
+	err = ctx.Validate(&request)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	result, err := h.Service.GetRecommendedAccessories(ctx.Request().Context(), id, request)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "get recommended accessories", h.logger)
+	}
+
+	return controller.Ok(ctx, result)
+}
+
+// Compare godoc
+//
+//	@Summary		Compare travellers
+//	@Description	compare travellers side by side, with accessory stats aligned per field, best and worst markers per stat, and the differences in job, influence, rarity and release date
+//	@Tags			travellers
+//	@Accept			json
+//	@Produce		json
+//	@Param			ids	query		string	true	"Comma-separated traveller IDs, in display order (at least 2, at most TRAVELLER_COMPARE_LIMIT)"
+//	@Success		200	{object}	domain.TravellerComparisonResponse
+//	@Failure		400	{object}	controller.ErrorResponse
+//	@Failure		404	{object}	controller.ErrorResponse
+//	@Failure		500	{object}	controller.ErrorResponse
+//	@Router			/travellers/compare [get]
+//	@Security		BearerAuth
+func (h *TravellerHandler) Compare(ctx echo.Context) error {
+	var request domain.CompareTravellerRequest
+	err := ctx.Bind(&request)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid query parameters")
+	}
+
+	err = ctx.Validate(&request)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	result, err := h.Service.Compare(ctx.Request().Context(), request)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "compare travellers", h.logger)
+	}
+
+	return controller.Ok(ctx, result)
+}
+
+// GetDuplicates godoc
+//
+//	@Summary		Get duplicate candidates
+//	@Description	report pairs of travellers that may be the same one entered twice, most likely first.
+//	@Description	Pairs with similar names, compared ignoring case, spacing and Unicode form, are scored from 0 to 100 on their name similarity and on sharing job, influence, rarity and release date.
+//	@Tags			travellers
+//	@Accept			json
+//	@Produce		json
+//	@Param			min_score	query		number	false	"Lowest score to report (default 60)"
+//	@Param			limit		query		int		false	"Number of candidates (default 20, max 100)"
+//	@Success		200			{object}	domain.DuplicateReportResponse
+//	@Failure		400			{object}	controller.ErrorResponse
+//	@Failure		500			{object}	controller.ErrorResponse
+//	@Router			/travellers/duplicates [get]
+//	@Security		BearerAuth
+func (h *TravellerHandler) GetDuplicates(ctx echo.Context) error {
+	var request domain.DuplicateCandidateRequest
+	err := ctx.Bind(&request)
+	if err != nil {
+		return controller.ResponseError(ctx, http.StatusBadRequest, "invalid query parameters")
+	}
+
+	err = ctx.Validate(&request)
+	if err != nil {
+		return controller.ResponseErrorValidation(ctx, err)
+	}
+
+	result, err := h.Service.FindDuplicates(ctx.Request().Context(), request)
+	if err != nil {
+		return controller.HandleServiceError(ctx, err, "find duplicate travellers", h.logger)
+	}
+
+	return controller.Ok(ctx, result)
+}
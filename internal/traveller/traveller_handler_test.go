@@ -1,6 +1,7 @@
 package traveller
 
 import (
+	"context"
 	"encoding/json"
 	"lizobly/ctc-db-api/internal/traveller/mocks"
 	"lizobly/ctc-db-api/pkg/constants"
@@ -8,8 +9,10 @@ import (
 	"lizobly/ctc-db-api/pkg/domain"
 	"lizobly/ctc-db-api/pkg/helpers"
 	"lizobly/ctc-db-api/pkg/logging"
+	"maps"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -27,6 +30,7 @@ type TravellerHandlerSuite struct {
 
 	e                *echo.Echo
 	travellerService *mocks.MockTravellerService
+	operations       *mocks.MockOperationService
 	handler          *TravellerHandler
 }
 
@@ -37,18 +41,21 @@ func TestTravellerHandlerSuite(t *testing.T) {
 func (s *TravellerHandlerSuite) SetupTest() {
 	s.e = echo.New()
 	s.travellerService = new(mocks.MockTravellerService)
+	s.operations = new(mocks.MockOperationService)
 	testLogger, _ := logging.NewDevelopmentLogger()
-	s.handler = NewTravellerHandler(s.e.Group(""), s.travellerService, testLogger)
+	s.handler = NewTravellerHandler(s.e.Group(""), s.travellerService, s.operations, testLogger)
 }
 
 func (s *TravellerHandlerSuite) TearDownTest() {
 	s.travellerService.AssertExpectations(s.T())
+	s.operations.AssertExpectations(s.T())
 }
 
 func (s *TravellerHandlerSuite) TestTravellerHandler_NewHandler() {
 	testLogger, _ := logging.NewDevelopmentLogger()
-	got := NewTravellerHandler(s.e.Group(""), s.travellerService, testLogger)
+	got := NewTravellerHandler(s.e.Group(""), s.travellerService, s.operations, testLogger)
 	assert.Equal(s.T(), s.travellerService, got.Service)
+	assert.Equal(s.T(), s.operations, got.Operations)
 	assert.NotNil(s.T(), got.logger)
 
 }
@@ -85,7 +92,7 @@ func (s *TravellerHandlerSuite) TestTravellerHandler_GetByID() {
 			beforeTest: func(ctx echo.Context, param args, want want) {
 				id, err := strconv.Atoi(ctx.Param("id"))
 				assert.Nil(s.T(), err)
-				s.travellerService.On("GetByID", ctx.Request().Context(), id).Return(traveller, nil).Once()
+				s.travellerService.On("GetByID", ctx.Request().Context(), id, domain.TravellerIncludeAll).Return(traveller, nil).Once()
 			},
 		},
 		{
@@ -109,7 +116,7 @@ func (s *TravellerHandlerSuite) TestTravellerHandler_GetByID() {
 			beforeTest: func(ctx echo.Context, param args, want want) {
 				id, err := strconv.Atoi(ctx.Param("id"))
 				assert.Nil(s.T(), err)
-				s.travellerService.On("GetByID", ctx.Request().Context(), id).Return(traveller, domain.NewNotFoundError("traveller", id, nil)).Once()
+				s.travellerService.On("GetByID", ctx.Request().Context(), id, domain.TravellerIncludeAll).Return(traveller, domain.NewNotFoundError("traveller", id, nil)).Once()
 			},
 		},
 	}
@@ -142,6 +149,107 @@ func (s *TravellerHandlerSuite) TestTravellerHandler_GetByID() {
 
 }
 
+func (s *TravellerHandlerSuite) TestTravellerHandler_GetByID_AsOf() {
+	at := time.Date(2024, 10, 1, 9, 0, 0, 0, time.UTC)
+	past := &domain.Traveller{CommonModel: domain.CommonModel{ID: 1, Version: 5}, Name: "Viola", Rarity: 4}
+
+	tests := []struct {
+		name       string
+		asOf       string
+		beforeTest func(ctx echo.Context)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			asOf: "2024-10-01T09:00:00Z",
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("GetByIDAsOf", ctx.Request().Context(), 1, at).Return(past, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "failed invalid as_of",
+			asOf:       "01-10-2024",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "failed not found then",
+			asOf: "2024-10-01T09:00:00Z",
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("GetByIDAsOf", ctx.Request().Context(), 1, at).Return(nil, domain.NewNotFoundError("traveller", 1, nil)).Once()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers/1", nil, url.Values{"as_of": []string{tt.asOf}}, map[string]string{"id": "1"})
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(ctx)
+			}
+
+			err := s.handler.GetByID(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.wantStatus, ctx.Response().Status)
+			// A past state isn't cacheable by the current version
+			assert.Empty(s.T(), rec.Header().Get("ETag"))
+		})
+	}
+}
+
+func (s *TravellerHandlerSuite) TestTravellerHandler_GetBySlug() {
+	traveller := &domain.Traveller{Name: "Fiore", Slug: "fiore", CommonModel: domain.CommonModel{ID: 1}}
+
+	tests := []struct {
+		name         string
+		slug         string
+		responseBody interface{}
+		statusCode   int
+		beforeTest   func(ctx echo.Context)
+	}{
+		{
+			name: "success get traveller",
+			slug: "fiore",
+			responseBody: controller.DataResponse[domain.TravellerResponse]{
+				Data: domain.ToTravellerResponse(traveller),
+			},
+			statusCode: http.StatusOK,
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("GetBySlug", ctx.Request().Context(), "fiore", domain.TravellerIncludeAll).Return(traveller, nil).Once()
+			},
+		},
+		{
+			name:       "failed traveller not found",
+			slug:       "unknown",
+			statusCode: http.StatusNotFound,
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("GetBySlug", ctx.Request().Context(), "unknown", domain.TravellerIncludeAll).Return(nil, domain.NewNotFoundError("traveller", "unknown", nil)).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pathParam := map[string]string{"slug": tt.slug}
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers/by-slug/"+tt.slug, nil, nil, pathParam)
+
+			tt.beforeTest(ctx)
+
+			err := s.handler.GetBySlug(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.statusCode, ctx.Response().Status)
+
+			if tt.responseBody != nil {
+				wantRespBytes, err := json.Marshal(tt.responseBody)
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
+			}
+		})
+	}
+}
+
 func (s *TravellerHandlerSuite) TestTravellerHandler_Create() {
 
 	type args struct {
@@ -185,8 +293,7 @@ func (s *TravellerHandlerSuite) TestTravellerHandler_Create() {
 				statusCode: http.StatusCreated,
 			},
 			beforeTest: func(ctx echo.Context, param args, want want) {
-				s.travellerService.On("Create", ctx.Request().Context(), param.requestBody).Return(int64(1), nil).Once()
-				s.travellerService.On("GetByID", ctx.Request().Context(), 1).Return(createdTraveller, nil).Once()
+				s.travellerService.On("Create", ctx.Request().Context(), param.requestBody).Return(createdTraveller, nil).Once()
 			},
 		},
 		{
@@ -213,7 +320,7 @@ func (s *TravellerHandlerSuite) TestTravellerHandler_Create() {
 				statusCode: http.StatusInternalServerError,
 			},
 			beforeTest: func(ctx echo.Context, param args, want want) {
-				s.travellerService.On("Create", ctx.Request().Context(), param.requestBody).Return(int64(0), gorm.ErrInvalidDB).Once()
+				s.travellerService.On("Create", ctx.Request().Context(), param.requestBody).Return(nil, gorm.ErrInvalidDB).Once()
 			},
 		},
 	}
@@ -288,22 +395,42 @@ func (s *TravellerHandlerSuite) TestTravellerHandler_Update() {
 				statusCode: http.StatusOK,
 			},
 			beforeTest: func(ctx echo.Context, param args, want want) {
-				s.travellerService.On("Update", ctx.Request().Context(), 1, updateRequest).Return(nil).Once()
-				s.travellerService.On("GetByID", ctx.Request().Context(), 1).Return(updatedTraveller, nil).Once()
+				s.travellerService.On("Update", ctx.Request().Context(), 1, updateRequest).Return(updatedTraveller, nil).Once()
+			},
+		},
+		{
+			name: "success conditional update with If-Match",
+			args: args{"1", updateRequest, map[string]string{"If-Match": `"3"`}},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				conditionalRequest := updateRequest
+				conditionalRequest.Version = 3
+				s.travellerService.On("Update", ctx.Request().Context(), 1, conditionalRequest).Return(updatedTraveller, nil).Once()
 			},
 		},
 		{
-			name: "failed precondition - ETag mismatch",
-			args: args{"1", updateRequest, map[string]string{"If-Match": `"9999999999"`}},
+			name: "failed precondition - version mismatch",
+			args: args{"1", updateRequest, map[string]string{"If-Match": `"3"`}},
 			want: want{
+				responseBody: controller.ErrorResponse{
+					Message: constants.MessagePreconditionFailed,
+				},
 				statusCode: http.StatusPreconditionFailed,
 			},
 			beforeTest: func(ctx echo.Context, param args, want want) {
-				currentTraveller := updatedTraveller
-				// Unix timestamp 1704067230 (different from If-Match 9999999999)
-				t, _ := time.Parse(time.RFC3339, "2024-01-01T00:20:30Z")
-				currentTraveller.UpdatedAt = t
-				s.travellerService.On("GetByID", ctx.Request().Context(), 1).Return(currentTraveller, nil).Once()
+				conditionalRequest := updateRequest
+				conditionalRequest.Version = 3
+				s.travellerService.On("Update", ctx.Request().Context(), 1, conditionalRequest).
+					Return(nil, domain.NewPreconditionFailedError(constants.MessagePreconditionFailed, nil)).Once()
+			},
+		},
+		{
+			name: "failed precondition - weak ETag",
+			args: args{"1", updateRequest, map[string]string{"If-Match": `W/"3"`}},
+			want: want{
+				statusCode: http.StatusPreconditionFailed,
 			},
 		},
 		{
@@ -333,7 +460,7 @@ func (s *TravellerHandlerSuite) TestTravellerHandler_Update() {
 				statusCode: http.StatusInternalServerError,
 			},
 			beforeTest: func(ctx echo.Context, param args, want want) {
-				s.travellerService.On("Update", ctx.Request().Context(), 1, updateRequest).Return(gorm.ErrInvalidDB).Once()
+				s.travellerService.On("Update", ctx.Request().Context(), 1, updateRequest).Return(nil, gorm.ErrInvalidDB).Once()
 			},
 		},
 	}
@@ -373,16 +500,45 @@ func (s *TravellerHandlerSuite) TestTravellerHandler_Update() {
 
 }
 
-func (s *TravellerHandlerSuite) TestTravellerHandler_Delete() {
+func (s *TravellerHandlerSuite) TestTravellerHandler_Patch() {
 
 	type args struct {
-		pathID string
+		pathID      string
+		contentType string
+		patch       string
 	}
 	type want struct {
 		responseBody interface{}
 		statusCode   int
 	}
 
+	releaseDate := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	currentTraveller := &domain.Traveller{
+		CommonModel: domain.CommonModel{ID: 1},
+		Name:        "Fiore",
+		Rarity:      4,
+		Banner:      "Standard Banner",
+		ReleaseDate: releaseDate,
+		InfluenceID: constants.InfluencePowerID,
+		JobID:       constants.JobMerchantID,
+		Accessory:   &domain.Accessory{Name: "Crown of Wisdom", HP: 150},
+	}
+
+	// Current state with banner, release date and accessory cleared
+	clearedRequest := domain.UpdateTravellerRequest{
+		Name:      "Fiore",
+		Rarity:    4,
+		Influence: constants.InfluencePower,
+		Job:       constants.JobMerchant,
+	}
+	clearedTraveller := &domain.Traveller{
+		CommonModel: domain.CommonModel{ID: 1},
+		Name:        "Fiore",
+		Rarity:      4,
+		InfluenceID: constants.InfluencePowerID,
+		JobID:       constants.JobMerchantID,
+	}
+
 	tests := []struct {
 		name       string
 		args       args
@@ -390,18 +546,47 @@ func (s *TravellerHandlerSuite) TestTravellerHandler_Delete() {
 		beforeTest func(ctx echo.Context, param args, want want)
 	}{
 		{
-			name: "success delete traveller",
-			args: args{"1"},
+			name: "success merge patch clears fields with null",
+			args: args{"1", helpers.MIMEMergePatch, `{"banner":null,"release_date":null,"accessory":null}`},
 			want: want{
-				statusCode: http.StatusNoContent,
+				responseBody: controller.DataResponse[domain.TravellerResponse]{
+					Data: domain.ToTravellerResponse(clearedTraveller),
+				},
+				statusCode: http.StatusOK,
 			},
 			beforeTest: func(ctx echo.Context, param args, want want) {
-				s.travellerService.On("Delete", ctx.Request().Context(), 1).Return(nil).Once()
+				s.travellerService.On("GetByID", ctx.Request().Context(), 1, domain.TravellerIncludeAll).Return(currentTraveller, nil).Once()
+				s.travellerService.On("Patch", ctx.Request().Context(), 1, clearedRequest).Return(clearedTraveller, nil).Once()
+			},
+		},
+		{
+			name: "success merge patch updates nested accessory",
+			args: args{"1", helpers.MIMEMergePatch + "; charset=utf-8", `{"rarity":5,"accessory":{"hp":200}}`},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				expected := domain.ToUpdateTravellerRequest(currentTraveller)
+				expected.Rarity = 5
+				expected.Accessory.HP = 200
+				s.travellerService.On("GetByID", ctx.Request().Context(), 1, domain.TravellerIncludeAll).Return(currentTraveller, nil).Once()
+				s.travellerService.On("Patch", ctx.Request().Context(), 1, expected).Return(currentTraveller, nil).Once()
+			},
+		},
+		{
+			name: "success json patch",
+			args: args{"1", helpers.MIMEJSONPatch, `[{"op":"test","path":"/name","value":"Fiore"},{"op":"remove","path":"/banner"},{"op":"remove","path":"/release_date"},{"op":"remove","path":"/accessory"}]`},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				s.travellerService.On("GetByID", ctx.Request().Context(), 1, domain.TravellerIncludeAll).Return(currentTraveller, nil).Once()
+				s.travellerService.On("Patch", ctx.Request().Context(), 1, clearedRequest).Return(clearedTraveller, nil).Once()
 			},
 		},
 		{
 			name: "failed invalid id",
-			args: args{""},
+			args: args{"", helpers.MIMEMergePatch, `{}`},
 			want: want{
 				responseBody: controller.ErrorResponse{
 					Message: "invalid id parameter",
@@ -410,16 +595,94 @@ func (s *TravellerHandlerSuite) TestTravellerHandler_Delete() {
 			},
 		},
 		{
-			name: "failed delete",
-			args: args{"1"},
+			name: "failed unsupported content type",
+			args: args{"1", echo.MIMEApplicationJSON, `{"rarity":5}`},
 			want: want{
 				responseBody: controller.ErrorResponse{
-					Message: "internal server error",
+					Message: "content type must be application/merge-patch+json or application/json-patch+json",
 				},
-				statusCode: http.StatusInternalServerError,
+				statusCode: http.StatusUnsupportedMediaType,
+			},
+		},
+		{
+			name: "failed malformed json patch",
+			args: args{"1", helpers.MIMEJSONPatch, `{"op":"remove"}`},
+			want: want{
+				statusCode: http.StatusBadRequest,
 			},
 			beforeTest: func(ctx echo.Context, param args, want want) {
-				s.travellerService.On("Delete", ctx.Request().Context(), 1).Return(gorm.ErrInvalidDB)
+				s.travellerService.On("GetByID", ctx.Request().Context(), 1, domain.TravellerIncludeAll).Return(currentTraveller, nil).Once()
+			},
+		},
+		{
+			name: "failed json patch test operation",
+			args: args{"1", helpers.MIMEJSONPatch, `[{"op":"test","path":"/name","value":"Viola"}]`},
+			want: want{
+				statusCode: http.StatusConflict,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				s.travellerService.On("GetByID", ctx.Request().Context(), 1, domain.TravellerIncludeAll).Return(currentTraveller, nil).Once()
+			},
+		},
+		{
+			name: "failed unknown field",
+			args: args{"1", helpers.MIMEMergePatch, `{"nickname":"Fi"}`},
+			want: want{
+				responseBody: controller.ErrorResponse{
+					Message: "patched document is not a valid traveller",
+				},
+				statusCode: http.StatusBadRequest,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				s.travellerService.On("GetByID", ctx.Request().Context(), 1, domain.TravellerIncludeAll).Return(currentTraveller, nil).Once()
+			},
+		},
+		{
+			name: "failed validation on required field null",
+			args: args{"1", helpers.MIMEMergePatch, `{"name":null}`},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				s.travellerService.On("GetByID", ctx.Request().Context(), 1, domain.TravellerIncludeAll).Return(currentTraveller, nil).Once()
+			},
+		},
+		{
+			name: "failed stale If-Match",
+			args: args{"1", helpers.MIMEMergePatch, `{"rarity":5}`},
+			want: want{
+				statusCode: http.StatusPreconditionFailed,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				ctx.Request().Header.Set("If-Match", `"5"`)
+				s.travellerService.On("GetByID", ctx.Request().Context(), 1, domain.TravellerIncludeAll).Return(currentTraveller, nil).Once()
+			},
+		},
+		{
+			name: "success patch is conditional on the version it was applied to",
+			args: args{"1", helpers.MIMEMergePatch, `{"rarity":5}`},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				versioned := *currentTraveller
+				versioned.Version = 4
+				ctx.Request().Header.Set("If-Match", `"4"`)
+				expected := domain.ToUpdateTravellerRequest(&versioned)
+				expected.Rarity = 5
+				expected.Version = 4
+				s.travellerService.On("GetByID", ctx.Request().Context(), 1, domain.TravellerIncludeAll).Return(&versioned, nil).Once()
+				s.travellerService.On("Patch", ctx.Request().Context(), 1, expected).Return(&versioned, nil).Once()
+			},
+		},
+		{
+			name: "failed traveller not found",
+			args: args{"2", helpers.MIMEMergePatch, `{"rarity":5}`},
+			want: want{
+				statusCode: http.StatusNotFound,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				s.travellerService.On("GetByID", ctx.Request().Context(), 2, domain.TravellerIncludeAll).Return(nil, domain.NewNotFoundError("traveller", 2, nil)).Once()
 			},
 		},
 	}
@@ -428,96 +691,582 @@ func (s *TravellerHandlerSuite) TestTravellerHandler_Delete() {
 		s.Run(tt.name, func() {
 
 			pathParam := map[string]string{"id": tt.args.pathID}
-			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodDelete, "/travellers/1", nil, nil, pathParam)
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodPatch, "/travellers/1", json.RawMessage(tt.args.patch), nil, pathParam)
+			ctx.Request().Header.Set(echo.HeaderContentType, tt.args.contentType)
 
 			if tt.beforeTest != nil {
 				tt.beforeTest(ctx, tt.args, tt.want)
 			}
 
-			err := s.handler.Delete(ctx)
+			err := s.handler.Patch(ctx)
 			assert.Nil(s.T(), err)
 			assert.Equal(s.T(), tt.want.statusCode, ctx.Response().Status)
 
 			if tt.want.responseBody != nil {
-
 				wantRespBytes, err := json.Marshal(tt.want.responseBody)
 				assert.NoError(s.T(), err)
-
 				assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
-
 			}
-
 		})
 	}
-
 }
 
-func (s *TravellerHandlerSuite) TestTravellerHandler_GetList() {
-
-	type args struct {
-		queryParams map[string]string
+func (s *TravellerHandlerSuite) TestTravellerHandler_Upsert() {
+	key := domain.ExternalKey{Source: "cotc-wiki", ExternalID: "fiore-4"}
+	upsertRequest := domain.UpdateTravellerRequest{
+		Name:      "Fiore",
+		Rarity:    4,
+		Influence: constants.InfluencePower,
+		Job:       constants.JobMerchant,
 	}
-	type want struct {
-		responseBody interface{}
-		statusCode   int
+	traveller := &domain.Traveller{
+		CommonModel: domain.CommonModel{ID: 7, Version: 1},
+		Name:        "Fiore",
+		Rarity:      4,
+		InfluenceID: constants.InfluencePowerID,
+		JobID:       constants.JobMerchantID,
 	}
 
 	tests := []struct {
-		name       string
-		args       args
-		want       want
-		beforeTest func(ctx echo.Context, param args, want want)
+		name         string
+		pathParams   map[string]string
+		headers      map[string]string
+		requestBody  interface{}
+		beforeTest   func(ctx echo.Context)
+		wantStatus   int
+		wantLocation string
 	}{
 		{
-			name: "success get list without filters",
-			args: args{
-				queryParams: map[string]string{},
+			name:        "created",
+			pathParams:  map[string]string{"source": "cotc-wiki", "id": "fiore-4"},
+			requestBody: upsertRequest,
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("Upsert", ctx.Request().Context(), key, upsertRequest).Return(traveller, true, nil).Once()
 			},
-			want: want{
-				statusCode: http.StatusOK,
-			},
-			beforeTest: func(ctx echo.Context, param args, want want) {
-				filter := domain.ListTravellerRequest{}
-				response := helpers.PaginatedResponse[domain.TravellerListItemResponse]{
-					Data:       []domain.TravellerListItemResponse{},
-					Page:       1,
-					PageSize:   10,
-					Total:      0,
-					TotalPages: 0,
-				}
-				s.travellerService.On("GetList", mock.Anything, filter, mock.MatchedBy(func(p helpers.PaginationParams) bool {
-					return true
-				})).Return(response, nil).Once()
+			wantStatus:   http.StatusCreated,
+			wantLocation: "/api/v1/travellers/7",
+		},
+		{
+			name:        "updated with If-Match",
+			pathParams:  map[string]string{"source": "cotc-wiki", "id": "fiore-4"},
+			headers:     map[string]string{"If-Match": `"3"`},
+			requestBody: upsertRequest,
+			beforeTest: func(ctx echo.Context) {
+				conditionalRequest := upsertRequest
+				conditionalRequest.Version = 3
+				s.travellerService.On("Upsert", ctx.Request().Context(), key, conditionalRequest).Return(traveller, false, nil).Once()
 			},
+			wantStatus: http.StatusOK,
 		},
 		{
-			name: "success get list with name filter",
-			args: args{
-				queryParams: map[string]string{"name": "Fiore"},
+			name:        "failed source too long",
+			pathParams:  map[string]string{"source": strings.Repeat("a", 51), "id": "fiore-4"},
+			requestBody: upsertRequest,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "failed validation",
+			pathParams:  map[string]string{"source": "cotc-wiki", "id": "fiore-4"},
+			requestBody: domain.UpdateTravellerRequest{Name: "Fiore"},
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "failed precondition",
+			pathParams:  map[string]string{"source": "cotc-wiki", "id": "fiore-4"},
+			headers:     map[string]string{"If-Match": `"3"`},
+			requestBody: upsertRequest,
+			beforeTest: func(ctx echo.Context) {
+				conditionalRequest := upsertRequest
+				conditionalRequest.Version = 3
+				s.travellerService.On("Upsert", ctx.Request().Context(), key, conditionalRequest).
+					Return(nil, false, domain.NewPreconditionFailedError(constants.MessagePreconditionFailed, nil)).Once()
 			},
-			want: want{
-				statusCode: http.StatusOK,
+			wantStatus: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodPut, "/travellers/by-external/cotc-wiki/fiore-4", tt.requestBody, nil, tt.pathParams)
+			for key, value := range tt.headers {
+				ctx.Request().Header.Set(key, value)
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(ctx)
+			}
+
+			err := s.handler.Upsert(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.wantStatus, ctx.Response().Status)
+			assert.Equal(s.T(), tt.wantLocation, rec.Header().Get("Location"))
+		})
+	}
+}
+
+func (s *TravellerHandlerSuite) TestTravellerHandler_Merge() {
+	merged := &domain.Traveller{
+		CommonModel: domain.CommonModel{ID: 1, Version: 3},
+		Name:        "Viola",
+		Rarity:      5,
+		InfluenceID: constants.InfluenceFameID,
+		JobID:       constants.JobDancerID,
+	}
+
+	tests := []struct {
+		name        string
+		id          string
+		headers     map[string]string
+		requestBody interface{}
+		beforeTest  func(ctx echo.Context)
+		wantStatus  int
+		wantETag    string
+	}{
+		{
+			name:        "success",
+			id:          "1",
+			requestBody: domain.MergeTravellerRequest{SourceID: 4},
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("Merge", ctx.Request().Context(), 1, domain.MergeTravellerRequest{SourceID: 4}).Return(merged, nil).Once()
 			},
-			beforeTest: func(ctx echo.Context, param args, want want) {
-				filter := domain.ListTravellerRequest{Name: "Fiore"}
-				response := helpers.PaginatedResponse[domain.TravellerListItemResponse]{
-					Data: []domain.TravellerListItemResponse{
-						{Name: "Fiore", Rarity: 5},
-					},
-					Page:       1,
-					PageSize:   10,
-					Total:      1,
-					TotalPages: 1,
-				}
-				s.travellerService.On("GetList", mock.Anything, filter, mock.MatchedBy(func(p helpers.PaginationParams) bool {
-					return true
-				})).Return(response, nil).Once()
+			wantStatus: http.StatusOK,
+			wantETag:   `"3"`,
+		},
+		{
+			name:        "success with If-Match",
+			id:          "1",
+			headers:     map[string]string{"If-Match": `"2"`},
+			requestBody: domain.MergeTravellerRequest{SourceID: 4},
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("Merge", ctx.Request().Context(), 1, domain.MergeTravellerRequest{SourceID: 4, Version: 2}).Return(merged, nil).Once()
 			},
+			wantStatus: http.StatusOK,
+			wantETag:   `"3"`,
 		},
 		{
-			name: "success get list with multiple filters",
-			args: args{
-				queryParams: map[string]string{
+			name:        "failed invalid id",
+			id:          "abc",
+			requestBody: domain.MergeTravellerRequest{SourceID: 4},
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "failed missing source",
+			id:          "1",
+			requestBody: map[string]interface{}{},
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "failed unknown keep_accessory",
+			id:          "1",
+			requestBody: domain.MergeTravellerRequest{SourceID: 4, KeepAccessory: "both"},
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "failed both have an accessory",
+			id:          "1",
+			requestBody: domain.MergeTravellerRequest{SourceID: 4},
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("Merge", ctx.Request().Context(), 1, domain.MergeTravellerRequest{SourceID: 4}).
+					Return(nil, domain.NewValidationError([]domain.FieldError{{Field: "keep_accessory", Message: "both travellers have an accessory"}})).Once()
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "failed source not found",
+			id:          "1",
+			requestBody: domain.MergeTravellerRequest{SourceID: 4},
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("Merge", ctx.Request().Context(), 1, domain.MergeTravellerRequest{SourceID: 4}).
+					Return(nil, domain.NewNotFoundError("traveller", 4, nil)).Once()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodPost, "/travellers/"+tt.id+"/merge", tt.requestBody, nil, map[string]string{"id": tt.id})
+			for key, value := range tt.headers {
+				ctx.Request().Header.Set(key, value)
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(ctx)
+			}
+
+			err := s.handler.Merge(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.wantStatus, ctx.Response().Status)
+			assert.Equal(s.T(), tt.wantETag, rec.Header().Get("ETag"))
+		})
+	}
+}
+
+func (s *TravellerHandlerSuite) TestTravellerHandler_Restore() {
+	restored := &domain.Traveller{CommonModel: domain.CommonModel{ID: 1, Version: 4}, Name: "Viola"}
+
+	tests := []struct {
+		name       string
+		id         string
+		headers    map[string]string
+		beforeTest func(ctx echo.Context)
+		wantStatus int
+		wantETag   string
+	}{
+		{
+			name: "success",
+			id:   "1",
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("Restore", ctx.Request().Context(), 1).Return(restored, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantETag:   `"4"`,
+		},
+		{
+			name:    "success with return=minimal",
+			id:      "1",
+			headers: map[string]string{"Prefer": "return=minimal"},
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("Restore", ctx.Request().Context(), 1).Return(restored, nil).Once()
+			},
+			wantStatus: http.StatusNoContent,
+			wantETag:   `"4"`,
+		},
+		{
+			name:       "failed invalid id",
+			id:         "abc",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "failed not in trash",
+			id:   "1",
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("Restore", ctx.Request().Context(), 1).
+					Return(nil, domain.NewNotFoundError("deleted traveller", 1, nil)).Once()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "failed name taken",
+			id:   "1",
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("Restore", ctx.Request().Context(), 1).
+					Return(nil, domain.NewConflictError("traveller with this name already exists", nil)).Once()
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodPost, "/travellers/"+tt.id+"/restore", nil, nil, map[string]string{"id": tt.id})
+			for key, value := range tt.headers {
+				ctx.Request().Header.Set(key, value)
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(ctx)
+			}
+
+			err := s.handler.Restore(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.wantStatus, ctx.Response().Status)
+			assert.Equal(s.T(), tt.wantETag, rec.Header().Get("ETag"))
+		})
+	}
+}
+
+func (s *TravellerHandlerSuite) TestTravellerHandler_GetHistory() {
+	page := helpers.NewPaginatedResponse([]domain.RevisionResponse{
+		{ID: 12, EntityType: domain.TrashTypeTraveller, EntityID: 1, Action: domain.RevisionActionUpdate, Actor: "isla",
+			Changes: []domain.FieldChange{{Field: "rarity", Before: 4, After: 5}}},
+	}, helpers.PaginationParams{Page: 1, PageSize: 10}, 1)
+
+	tests := []struct {
+		name       string
+		id         string
+		query      url.Values
+		beforeTest func(ctx echo.Context)
+		wantStatus int
+	}{
+		{
+			name:  "success",
+			id:    "1",
+			query: url.Values{"page": []string{"1"}},
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("History", ctx.Request().Context(), 1, helpers.PaginationParams{Page: 1}).Return(page, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "failed invalid id",
+			id:         "abc",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "failed not found",
+			id:   "1",
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("History", ctx.Request().Context(), 1, helpers.PaginationParams{}).
+					Return(helpers.PaginatedResponse[domain.RevisionResponse]{}, domain.NewNotFoundError("traveller", 1, nil)).Once()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			_, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers/"+tt.id+"/history", nil, tt.query, map[string]string{"id": tt.id})
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(ctx)
+			}
+
+			err := s.handler.GetHistory(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.wantStatus, ctx.Response().Status)
+		})
+	}
+}
+
+func (s *TravellerHandlerSuite) TestTravellerHandler_Revert() {
+	reverted := &domain.Traveller{CommonModel: domain.CommonModel{ID: 1, Version: 5}, Name: "Viola", Rarity: 4}
+
+	tests := []struct {
+		name       string
+		id         string
+		query      url.Values
+		beforeTest func(ctx echo.Context)
+		wantStatus int
+		wantETag   string
+	}{
+		{
+			name:  "success",
+			id:    "1",
+			query: url.Values{"revision": []string{"12"}},
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("Revert", ctx.Request().Context(), 1, domain.RevertRevisionRequest{Revision: 12}).Return(reverted, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantETag:   `"5"`,
+		},
+		{
+			name:       "failed invalid id",
+			id:         "abc",
+			query:      url.Values{"revision": []string{"12"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "failed missing revision",
+			id:         "1",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "failed invalid revision",
+			id:         "1",
+			query:      url.Values{"revision": []string{"latest"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "failed changed since",
+			id:    "1",
+			query: url.Values{"revision": []string{"12"}},
+			beforeTest: func(ctx echo.Context) {
+				s.travellerService.On("Revert", ctx.Request().Context(), 1, domain.RevertRevisionRequest{Revision: 12}).
+					Return(nil, domain.NewConflictError("rarity changed since revision 12", nil)).Once()
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodPost, "/travellers/"+tt.id+"/revert", nil, tt.query, map[string]string{"id": tt.id})
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(ctx)
+			}
+
+			err := s.handler.Revert(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.wantStatus, ctx.Response().Status)
+			assert.Equal(s.T(), tt.wantETag, rec.Header().Get("ETag"))
+		})
+	}
+}
+
+func (s *TravellerHandlerSuite) TestTravellerHandler_Delete() {
+
+	type args struct {
+		pathID string
+	}
+	type want struct {
+		responseBody interface{}
+		statusCode   int
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		want       want
+		beforeTest func(ctx echo.Context, param args, want want)
+	}{
+		{
+			name: "success delete traveller",
+			args: args{"1"},
+			want: want{
+				statusCode: http.StatusNoContent,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				s.travellerService.On("Delete", ctx.Request().Context(), 1).Return(nil).Once()
+			},
+		},
+		{
+			name: "failed invalid id",
+			args: args{""},
+			want: want{
+				responseBody: controller.ErrorResponse{
+					Message: "invalid id parameter",
+				},
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "failed delete",
+			args: args{"1"},
+			want: want{
+				responseBody: controller.ErrorResponse{
+					Message: "internal server error",
+				},
+				statusCode: http.StatusInternalServerError,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				s.travellerService.On("Delete", ctx.Request().Context(), 1).Return(gorm.ErrInvalidDB)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+
+			pathParam := map[string]string{"id": tt.args.pathID}
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodDelete, "/travellers/1", nil, nil, pathParam)
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(ctx, tt.args, tt.want)
+			}
+
+			err := s.handler.Delete(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.want.statusCode, ctx.Response().Status)
+
+			if tt.want.responseBody != nil {
+
+				wantRespBytes, err := json.Marshal(tt.want.responseBody)
+				assert.NoError(s.T(), err)
+
+				assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
+
+			}
+
+		})
+	}
+
+}
+
+func (s *TravellerHandlerSuite) TestTravellerHandler_GetList() {
+
+	type args struct {
+		queryParams map[string]string
+	}
+	type want struct {
+		responseBody interface{}
+		statusCode   int
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		want       want
+		beforeTest func(ctx echo.Context, param args, want want)
+	}{
+		{
+			name: "success get list without filters",
+			args: args{
+				queryParams: map[string]string{},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				filter := domain.ListTravellerRequest{}
+				response := helpers.PaginatedResponse[domain.TravellerListItemResponse]{
+					Data:       []domain.TravellerListItemResponse{},
+					Page:       1,
+					PageSize:   10,
+					Total:      0,
+					TotalPages: 0,
+				}
+				s.travellerService.On("GetList", mock.Anything, filter, mock.MatchedBy(func(p helpers.PaginationParams) bool {
+					return true
+				})).Return(response, nil).Once()
+			},
+		},
+		{
+			name: "success get list with name filter",
+			args: args{
+				queryParams: map[string]string{"name": "Fiore"},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				filter := domain.ListTravellerRequest{Name: "Fiore"}
+				response := helpers.PaginatedResponse[domain.TravellerListItemResponse]{
+					Data: []domain.TravellerListItemResponse{
+						{Name: "Fiore", Rarity: 5},
+					},
+					Page:       1,
+					PageSize:   10,
+					Total:      1,
+					TotalPages: 1,
+				}
+				s.travellerService.On("GetList", mock.Anything, filter, mock.MatchedBy(func(p helpers.PaginationParams) bool {
+					return true
+				})).Return(response, nil).Once()
+			},
+		},
+		{
+			name: "success get list with filter expression",
+			args: args{
+				queryParams: map[string]string{"filter": `rarity>=4 and name~"vi"`},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				filter := domain.ListTravellerRequest{Filter: `rarity>=4 and name~"vi"`}
+				s.travellerService.On("GetList", mock.Anything, filter, mock.Anything).Return(helpers.PaginatedResponse[domain.TravellerListItemResponse]{}, nil).Once()
+			},
+		},
+		{
+			name: "invalid filter expression",
+			args: args{
+				queryParams: map[string]string{"filter": "rarity>>4"},
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				filter := domain.ListTravellerRequest{Filter: "rarity>>4"}
+				s.travellerService.On("GetList", mock.Anything, filter, mock.Anything).
+					Return(helpers.PaginatedResponse[domain.TravellerListItemResponse]{}, domain.NewValidationError([]domain.FieldError{{Field: "filter", Message: `unknown operator ">>" at position 6`}})).Once()
+			},
+		},
+		{
+			name: "success get list with multiple filters",
+			args: args{
+				queryParams: map[string]string{
 					"name":      "Fiore",
 					"influence": "Fame",
 					"job":       "Warrior",
@@ -529,25 +1278,89 @@ func (s *TravellerHandlerSuite) TestTravellerHandler_GetList() {
 				statusCode: http.StatusOK,
 			},
 			beforeTest: func(ctx echo.Context, param args, want want) {
-				filter := domain.ListTravellerRequest{
-					Name:      "Fiore",
-					Influence: "Fame",
-					Job:       "Warrior",
-				}
-				paginationParams := helpers.PaginationParams{
-					Page:     2,
-					PageSize: 20,
-				}
+				filter := domain.ListTravellerRequest{
+					Name:      "Fiore",
+					Influence: "Fame",
+					Job:       "Warrior",
+				}
+				paginationParams := helpers.PaginationParams{
+					Page:     2,
+					PageSize: 20,
+				}
+				response := helpers.PaginatedResponse[domain.TravellerListItemResponse]{
+					Data: []domain.TravellerListItemResponse{
+						{Name: "Fiore", Rarity: 5},
+					},
+					Page:       2,
+					PageSize:   20,
+					Total:      1,
+					TotalPages: 1,
+				}
+				s.travellerService.On("GetList", mock.Anything, filter, paginationParams).Return(response, nil).Once()
+			},
+		},
+		{
+			name: "not modified when If-None-Match matches the page",
+			args: args{
+				queryParams: map[string]string{"name": "Fiore"},
+			},
+			want: want{
+				statusCode: http.StatusNotModified,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				filter := domain.ListTravellerRequest{Name: "Fiore"}
+				response := helpers.PaginatedResponse[domain.TravellerListItemResponse]{
+					Data:       []domain.TravellerListItemResponse{{Name: "Fiore", Rarity: 5}},
+					Page:       1,
+					PageSize:   10,
+					Total:      1,
+					TotalPages: 1,
+					UpdatedAt:  time.Date(2026, 1, 27, 10, 0, 0, 0, time.UTC),
+				}
+				ctx.Request().Header.Set("If-None-Match", response.ETag(ctx.QueryParams()))
+				s.travellerService.On("GetList", mock.Anything, filter, mock.Anything).Return(response, nil).Once()
+			},
+		},
+		{
+			name: "success when the page changed since If-Modified-Since",
+			args: args{
+				queryParams: map[string]string{},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				response := helpers.PaginatedResponse[domain.TravellerListItemResponse]{
+					Data:       []domain.TravellerListItemResponse{{Name: "Fiore", Rarity: 5}},
+					Page:       1,
+					PageSize:   10,
+					Total:      1,
+					TotalPages: 1,
+					UpdatedAt:  time.Date(2026, 1, 27, 10, 0, 0, 0, time.UTC),
+				}
+				ctx.Request().Header.Set("If-Modified-Since", "Mon, 26 Jan 2026 10:00:00 GMT")
+				s.travellerService.On("GetList", mock.Anything, domain.ListTravellerRequest{}, mock.Anything).Return(response, nil).Once()
+			},
+		},
+		{
+			name: "success although not modified since If-Modified-Since, as a list ignores it",
+			args: args{
+				queryParams: map[string]string{},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
 				response := helpers.PaginatedResponse[domain.TravellerListItemResponse]{
-					Data: []domain.TravellerListItemResponse{
-						{Name: "Fiore", Rarity: 5},
-					},
-					Page:       2,
-					PageSize:   20,
+					Data:       []domain.TravellerListItemResponse{{Name: "Fiore", Rarity: 5}},
+					Page:       1,
+					PageSize:   10,
 					Total:      1,
 					TotalPages: 1,
+					UpdatedAt:  time.Date(2026, 1, 27, 10, 0, 0, 0, time.UTC),
 				}
-				s.travellerService.On("GetList", mock.Anything, filter, paginationParams).Return(response, nil).Once()
+				ctx.Request().Header.Set("If-Modified-Since", "Tue, 27 Jan 2026 10:00:00 GMT")
+				s.travellerService.On("GetList", mock.Anything, domain.ListTravellerRequest{}, mock.Anything).Return(response, nil).Once()
 			},
 		},
 		{
@@ -568,6 +1381,112 @@ func (s *TravellerHandlerSuite) TestTravellerHandler_GetList() {
 				})).Return(helpers.PaginatedResponse[domain.TravellerListItemResponse]{}, gorm.ErrInvalidDB).Once()
 			},
 		},
+		{
+			name: "success get list with sort keys",
+			args: args{
+				queryParams: map[string]string{
+					"order_by":  "rarity,name",
+					"order_dir": "desc",
+				},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				filter := domain.ListTravellerRequest{
+					OrderBy:  "rarity,name",
+					OrderDir: "desc",
+				}
+				s.travellerService.On("GetList", mock.Anything, filter, mock.Anything).Return(helpers.PaginatedResponse[domain.TravellerListItemResponse]{}, nil).Once()
+			},
+		},
+		{
+			name: "success get list with sort directions in any case",
+			args: args{
+				queryParams: map[string]string{
+					"order_by":  "rarity,name",
+					"order_dir": "DESC,Asc",
+				},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				filter := domain.ListTravellerRequest{
+					OrderBy:  "rarity,name",
+					OrderDir: "desc,asc",
+				}
+				s.travellerService.On("GetList", mock.Anything, filter, mock.Anything).Return(helpers.PaginatedResponse[domain.TravellerListItemResponse]{}, nil).Once()
+			},
+		},
+		{
+			name: "success get list with range and presence filters",
+			args: args{
+				queryParams: map[string]string{
+					"influence":       "Fame,Power",
+					"rarity_min":      "4",
+					"released_after":  "01-01-2023",
+					"released_before": "31-12-2023",
+					"has_accessory":   "true",
+					"banner":          "Standard",
+				},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				filter := domain.ListTravellerRequest{
+					Influence:      "Fame,Power",
+					RarityMin:      "4",
+					ReleasedAfter:  "01-01-2023",
+					ReleasedBefore: "31-12-2023",
+					HasAccessory:   "true",
+					Banner:         "Standard",
+				}
+				s.travellerService.On("GetList", mock.Anything, filter, mock.Anything).Return(helpers.PaginatedResponse[domain.TravellerListItemResponse]{}, nil).Once()
+			},
+		},
+		{
+			name: "failed range and presence validation",
+			args: args{
+				queryParams: map[string]string{
+					"job":            "Warrior,Pirate",
+					"rarity_max":     "9",
+					"released_after": "2023-01-01",
+					"has_accessory":  "maybe",
+				},
+			},
+			want: want{
+				responseBody: controller.ErrorResponse{
+					Message: "validation failed",
+					Errors: []controller.FieldError{
+						{Field: "job", Message: "Job must be a comma-separated list of valid job types."},
+						{Field: "rarity_max", Message: "RarityMax must be one of [1 2 3 4 5]"},
+						{Field: "released_after", Message: "ReleasedAfter does not match the 02-01-2006 format"},
+						{Field: "has_accessory", Message: "HasAccessory must be a valid boolean value"},
+					},
+				},
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "failed sort validation",
+			args: args{
+				queryParams: map[string]string{"order_by": "rarity,power"},
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "failed facet validation",
+			args: args{
+				queryParams: map[string]string{"facets": "job,banner"},
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
 		{
 			name: "failed filter validation",
 			args: args{
@@ -606,3 +1525,465 @@ func (s *TravellerHandlerSuite) TestTravellerHandler_GetList() {
 		})
 	}
 }
+
+func (s *TravellerHandlerSuite) TestTravellerHandler_GetRecommendedAccessories() {
+
+	type args struct {
+		pathID      string
+		queryParams map[string]string
+	}
+	type want struct {
+		responseBody interface{}
+		statusCode   int
+	}
+
+	recommendations := domain.AccessoryRecommendationResponse{
+		Traveller: "Fiore",
+		Job:       constants.JobWarrior,
+		Weights:   domain.GetJobStatWeights(constants.JobWarriorID),
+		Recommendations: []domain.AccessoryRecommendation{
+			{Accessory: domain.AccessoryListItemResponse{Name: "Iron Ring", PAtk: 80}, Score: 50},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		want       want
+		beforeTest func(ctx echo.Context, param args, want want)
+	}{
+		{
+			name: "success get recommendations",
+			args: args{pathID: "1", queryParams: map[string]string{"limit": "3", "include_owned": "true"}},
+			want: want{
+				responseBody: controller.DataResponse[domain.AccessoryRecommendationResponse]{
+					Data: recommendations,
+				},
+				statusCode: http.StatusOK,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				input := domain.RecommendAccessoryRequest{Limit: 3, IncludeOwned: true}
+				s.travellerService.On("GetRecommendedAccessories", ctx.Request().Context(), 1, input).Return(recommendations, nil).Once()
+			},
+		},
+		{
+			name: "failed invalid id",
+			args: args{pathID: ""},
+			want: want{
+				responseBody: controller.ErrorResponse{
+					Message: "invalid id parameter",
+				},
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "failed validation",
+			args: args{pathID: "1", queryParams: map[string]string{"limit": "500"}},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "failed traveller not found",
+			args: args{pathID: "2"},
+			want: want{
+				statusCode: http.StatusNotFound,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				s.travellerService.On("GetRecommendedAccessories", ctx.Request().Context(), 2, domain.RecommendAccessoryRequest{}).
+					Return(domain.AccessoryRecommendationResponse{}, domain.NewNotFoundError("traveller", 2, nil)).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			queryValues := url.Values{}
+			for k, v := range tt.args.queryParams {
+				queryValues.Set(k, v)
+			}
+			pathParam := map[string]string{"id": tt.args.pathID}
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers/1/recommended-accessories", nil, queryValues, pathParam)
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(ctx, tt.args, tt.want)
+			}
+
+			err := s.handler.GetRecommendedAccessories(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.want.statusCode, ctx.Response().Status)
+
+			if tt.want.responseBody != nil {
+				wantRespBytes, err := json.Marshal(tt.want.responseBody)
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
+			}
+		})
+	}
+}
+
+func (s *TravellerHandlerSuite) TestTravellerHandler_Compare() {
+
+	type args struct {
+		queryParams map[string]string
+	}
+	type want struct {
+		responseBody interface{}
+		statusCode   int
+	}
+
+	comparison := domain.CompareTravellers([]*domain.Traveller{
+		{CommonModel: domain.CommonModel{ID: 1}, Name: "Fiore", JobID: constants.JobWarriorID},
+		{CommonModel: domain.CommonModel{ID: 2}, Name: "Viola", JobID: constants.JobDancerID},
+	})
+
+	tests := []struct {
+		name       string
+		args       args
+		want       want
+		beforeTest func(ctx echo.Context, param args, want want)
+	}{
+		{
+			name: "success compare",
+			args: args{queryParams: map[string]string{"ids": "1,2"}},
+			want: want{
+				responseBody: controller.DataResponse[domain.TravellerComparisonResponse]{
+					Data: comparison,
+				},
+				statusCode: http.StatusOK,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				s.travellerService.On("Compare", ctx.Request().Context(), domain.CompareTravellerRequest{IDs: "1,2"}).Return(comparison, nil).Once()
+			},
+		},
+		{
+			name: "failed missing ids",
+			args: args{queryParams: map[string]string{}},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "failed too many ids",
+			args: args{queryParams: map[string]string{"ids": "1,2,3,4"}},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				s.travellerService.On("Compare", ctx.Request().Context(), domain.CompareTravellerRequest{IDs: "1,2,3,4"}).
+					Return(domain.TravellerComparisonResponse{}, domain.NewValidationError([]domain.FieldError{{Field: "ids", Message: "ids must list at most 3 travellers"}})).Once()
+			},
+		},
+		{
+			name: "failed traveller not found",
+			args: args{queryParams: map[string]string{"ids": "1,9"}},
+			want: want{
+				statusCode: http.StatusNotFound,
+			},
+			beforeTest: func(ctx echo.Context, param args, want want) {
+				s.travellerService.On("Compare", ctx.Request().Context(), domain.CompareTravellerRequest{IDs: "1,9"}).
+					Return(domain.TravellerComparisonResponse{}, domain.NewNotFoundError("traveller", "9", nil)).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			queryValues := url.Values{}
+			for k, v := range tt.args.queryParams {
+				queryValues.Set(k, v)
+			}
+			rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers/compare", nil, queryValues, nil)
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(ctx, tt.args, tt.want)
+			}
+
+			err := s.handler.Compare(ctx)
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tt.want.statusCode, ctx.Response().Status)
+
+			if tt.want.responseBody != nil {
+				wantRespBytes, err := json.Marshal(tt.want.responseBody)
+				assert.NoError(s.T(), err)
+				assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
+			}
+		})
+	}
+}
+
+func (s *TravellerHandlerSuite) TestTravellerHandler_GetDuplicates() {
+	report := domain.DuplicateReportResponse{Candidates: []domain.DuplicateCandidate{
+		domain.ScoreDuplicate(
+			&domain.Traveller{CommonModel: domain.CommonModel{ID: 1}, Name: "Viola", JobID: constants.JobDancerID},
+			&domain.Traveller{CommonModel: domain.CommonModel{ID: 4}, Name: "viola", JobID: constants.JobDancerID},
+			1,
+		),
+	}}
+
+	s.Run("success", func() {
+		rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers/duplicates", nil, url.Values{"min_score": {"50"}}, nil)
+		s.travellerService.On("FindDuplicates", ctx.Request().Context(), domain.DuplicateCandidateRequest{MinScore: 50}).Return(report, nil).Once()
+
+		err := s.handler.GetDuplicates(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusOK, ctx.Response().Status)
+
+		wantRespBytes, err := json.Marshal(controller.DataResponse[domain.DuplicateReportResponse]{Data: report})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
+	})
+
+	s.Run("failed invalid min_score", func() {
+		_, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers/duplicates", nil, url.Values{"min_score": {"150"}}, nil)
+
+		err := s.handler.GetDuplicates(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusBadRequest, ctx.Response().Status)
+	})
+}
+
+func (s *TravellerHandlerSuite) TestTravellerHandler_GetByIDs() {
+	batch := helpers.BatchResponse[domain.TravellerResponse]{
+		Data:     []domain.TravellerResponse{{ID: 2, Name: "Viola"}, {ID: 1, Name: "Fiore"}},
+		NotFound: []int{5},
+	}
+
+	s.Run("success multi-get via list endpoint", func() {
+		rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers", nil, url.Values{"ids": {"2,1,5"}, "name": {"ignored"}}, nil)
+		s.travellerService.On("GetByIDs", ctx.Request().Context(), domain.BatchGetRequest{IDs: "2,1,5"}, domain.TravellerIncludeAll).Return(batch, nil).Once()
+
+		err := s.handler.GetList(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusOK, ctx.Response().Status)
+
+		wantRespBytes, err := json.Marshal(controller.DataResponse[helpers.BatchResponse[domain.TravellerResponse]]{Data: batch})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
+	})
+
+	s.Run("failed invalid ids", func() {
+		_, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers", nil, url.Values{"ids": {""}}, nil)
+		s.travellerService.On("GetByIDs", ctx.Request().Context(), domain.BatchGetRequest{}, domain.TravellerIncludeAll).
+			Return(helpers.BatchResponse[domain.TravellerResponse]{}, domain.NewValidationError([]domain.FieldError{{Field: "ids", Message: "invalid"}})).Once()
+
+		err := s.handler.GetList(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusBadRequest, ctx.Response().Status)
+	})
+}
+
+func (s *TravellerHandlerSuite) TestTravellerHandler_GetPage() {
+	page := helpers.CursorResponse[domain.TravellerListItemResponse]{
+		Data:       []domain.TravellerListItemResponse{{ID: 4, Name: "Viola"}},
+		Limit:      1,
+		NextCursor: "next",
+	}
+
+	s.Run("limit switches to keyset pagination", func() {
+		rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers", nil, url.Values{"limit": {"1"}, "order_by": {"rarity"}}, nil)
+		s.travellerService.On("GetPage", ctx.Request().Context(), domain.ListTravellerRequest{OrderBy: "rarity"}, helpers.CursorParams{Limit: 1}).Return(page, nil).Once()
+
+		err := s.handler.GetList(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusOK, ctx.Response().Status)
+		assert.Empty(s.T(), ctx.Response().Header().Get("ETag"))
+		assert.Equal(s.T(), `</travellers?cursor=&limit=1&order_by=rarity>; rel="first", </travellers?cursor=next&limit=1&order_by=rarity>; rel="next"`, ctx.Response().Header().Get("Link"))
+
+		want := page
+		want.Links = &helpers.PageLinks{
+			Self:  "/travellers?limit=1&order_by=rarity",
+			First: "/travellers?cursor=&limit=1&order_by=rarity",
+			Next:  "/travellers?cursor=next&limit=1&order_by=rarity",
+		}
+		wantRespBytes, err := json.Marshal(controller.DataResponse[helpers.CursorResponse[domain.TravellerListItemResponse]]{Data: want})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
+	})
+
+	s.Run("failed invalid cursor", func() {
+		_, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers", nil, url.Values{"cursor": {"forged"}}, nil)
+		s.travellerService.On("GetPage", ctx.Request().Context(), domain.ListTravellerRequest{}, helpers.CursorParams{Cursor: "forged"}).
+			Return(helpers.CursorResponse[domain.TravellerListItemResponse]{}, domain.NewValidationError([]domain.FieldError{{Field: "cursor", Message: "invalid"}})).Once()
+
+		err := s.handler.GetList(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusBadRequest, ctx.Response().Status)
+	})
+}
+
+func (s *TravellerHandlerSuite) TestTravellerHandler_Fields() {
+	traveller := &domain.Traveller{
+		CommonModel: domain.CommonModel{ID: 1},
+		Name:        "Viola",
+		Rarity:      5,
+		JobID:       constants.JobDancerID,
+		Accessory:   &domain.Accessory{Name: "Crown of Wisdom"},
+	}
+
+	s.Run("get by id returns only the requested fields", func() {
+		rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers/1", nil, url.Values{"fields": {"name,job"}}, map[string]string{"id": "1"})
+		s.travellerService.On("GetByID", ctx.Request().Context(), 1, domain.TravellerInclude{}).Return(traveller, nil).Once()
+
+		err := s.handler.GetByID(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusOK, ctx.Response().Status)
+		assert.Equal(s.T(), `{"data":{"id":1,"job":"Dancer","links":{"self":"/api/v1/travellers/1"},"name":"Viola"}}`, strings.TrimSpace(rec.Body.String()))
+	})
+
+	s.Run("get by id loads and returns included relations", func() {
+		rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers/1", nil, url.Values{"fields": {"name"}, "include": {"accessory"}}, map[string]string{"id": "1"})
+		s.travellerService.On("GetByID", ctx.Request().Context(), 1, domain.TravellerInclude{Accessory: true}).Return(traveller, nil).Once()
+
+		err := s.handler.GetByID(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusOK, ctx.Response().Status)
+		assert.Contains(s.T(), rec.Body.String(), `"accessory":{`)
+	})
+
+	s.Run("failed unknown include", func() {
+		rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers/1", nil, url.Values{"include": {"skills"}}, map[string]string{"id": "1"})
+
+		err := s.handler.GetByID(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusBadRequest, ctx.Response().Status)
+		assert.Contains(s.T(), rec.Body.String(), "include")
+	})
+
+	s.Run("list keeps included relations", func() {
+		rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers", nil, url.Values{"fields": {"rarity"}, "include": {"accessory"}}, nil)
+		page := helpers.PaginatedResponse[domain.TravellerListItemResponse]{
+			Data:     []domain.TravellerListItemResponse{domain.ToTravellerListItemResponse(traveller)},
+			Page:     1,
+			PageSize: 10,
+		}
+		s.travellerService.On("GetList", ctx.Request().Context(), domain.ListTravellerRequest{Include: "accessory"}, helpers.PaginationParams{}).Return(page, nil).Once()
+
+		err := s.handler.GetList(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusOK, ctx.Response().Status)
+
+		var body struct {
+			Data helpers.PaginatedResponse[map[string]interface{}] `json:"data"`
+		}
+		assert.NoError(s.T(), json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.ElementsMatch(s.T(), []string{"id", "rarity", "accessory", "links"}, slices.Collect(maps.Keys(body.Data.Data[0])))
+		assert.Equal(s.T(), 10, body.Data.PageSize)
+		assert.Equal(s.T(), "/travellers?fields=rarity&include=accessory&page=1&page_size=10", body.Data.Links.Self)
+	})
+
+	s.Run("failed unknown field", func() {
+		rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers/by-slug/viola", nil, url.Values{"fields": {"name,skills"}}, map[string]string{"slug": "viola"})
+
+		err := s.handler.GetBySlug(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusBadRequest, ctx.Response().Status)
+		assert.Contains(s.T(), rec.Body.String(), `"field":"fields"`)
+		s.travellerService.AssertNotCalled(s.T(), "GetBySlug", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("failed unknown include", func() {
+		_, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/travellers", nil, url.Values{"include": {"skills"}}, nil)
+
+		err := s.handler.GetList(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusBadRequest, ctx.Response().Status)
+	})
+}
+
+func (s *TravellerHandlerSuite) TestTravellerHandler_GetList_Links() {
+	rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodGet, "/api/v1/travellers", nil, url.Values{"job": {"Dancer"}, "page": {"2"}, "page_size": {"1"}}, nil)
+	page := helpers.NewPaginatedResponse([]domain.TravellerListItemResponse{}, helpers.PaginationParams{Page: 2, PageSize: 1}, 3)
+	s.travellerService.On("GetList", ctx.Request().Context(), domain.ListTravellerRequest{Job: "Dancer"}, helpers.PaginationParams{Page: 2, PageSize: 1}).Return(page, nil).Once()
+
+	err := s.handler.GetList(ctx)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusOK, ctx.Response().Status)
+	assert.Equal(s.T(), `</api/v1/travellers?job=Dancer&page=1&page_size=1>; rel="first", `+
+		`</api/v1/travellers?job=Dancer&page=1&page_size=1>; rel="prev", `+
+		`</api/v1/travellers?job=Dancer&page=3&page_size=1>; rel="next", `+
+		`</api/v1/travellers?job=Dancer&page=3&page_size=1>; rel="last"`, ctx.Response().Header().Get("Link"))
+	assert.Contains(s.T(), rec.Body.String(), `"next":"/api/v1/travellers?job=Dancer\u0026page=3\u0026page_size=1"`)
+}
+
+func (s *TravellerHandlerSuite) TestTravellerHandler_Prefer() {
+	request := domain.CreateTravellerRequest{Name: "Fiore", Rarity: 5, Influence: "Fame", Job: "Warrior"}
+	traveller := &domain.Traveller{
+		CommonModel: domain.CommonModel{ID: 7, Version: 2, UpdatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+		Name:        "Fiore",
+		Rarity:      5,
+		InfluenceID: constants.GetInfluenceID("Fame"),
+		JobID:       constants.GetJobID("Warrior"),
+	}
+
+	s.Run("return=minimal sends only headers", func() {
+		s.SetupTest()
+		rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodPost, "/travellers", request, nil, nil)
+		ctx.Request().Header.Set("Prefer", "return=minimal")
+		s.travellerService.On("Create", ctx.Request().Context(), request).Return(traveller, nil).Once()
+
+		err := s.handler.Create(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusNoContent, ctx.Response().Status)
+		assert.Empty(s.T(), rec.Body.String())
+		assert.Equal(s.T(), "/api/v1/travellers/7", rec.Header().Get("Location"))
+		assert.Equal(s.T(), `"2"`, rec.Header().Get("ETag"))
+		assert.Equal(s.T(), "Thu, 02 Jan 2025 03:04:05 GMT", rec.Header().Get("Last-Modified"))
+		assert.Equal(s.T(), "return=minimal", rec.Header().Get("Preference-Applied"))
+		s.TearDownTest()
+	})
+
+	s.Run("return=representation sends the traveller", func() {
+		s.SetupTest()
+		rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodPost, "/travellers", request, nil, nil)
+		ctx.Request().Header.Set("Prefer", "return=representation")
+		s.travellerService.On("Create", ctx.Request().Context(), request).Return(traveller, nil).Once()
+
+		err := s.handler.Create(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusCreated, ctx.Response().Status)
+		assert.Equal(s.T(), "return=representation", rec.Header().Get("Preference-Applied"))
+		wantRespBytes, _ := json.Marshal(controller.DataResponse[domain.TravellerResponse]{Data: domain.ToTravellerResponse(traveller)})
+		assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
+		s.TearDownTest()
+	})
+
+	s.Run("respond-async starts an operation", func() {
+		s.SetupTest()
+		updateRequest := domain.UpdateTravellerRequest{Name: "Fiore", Rarity: 5, Influence: "Fame", Job: "Warrior"}
+		rec, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodPut, "/travellers/7", updateRequest, nil, map[string]string{"id": "7"})
+		ctx.Request().Header.Set("Prefer", "respond-async, return=minimal")
+
+		op := &domain.Operation{ID: "op-1", Name: "update traveller", Status: domain.OperationStatusRunning}
+		var run domain.OperationFunc
+		s.operations.On("Start", ctx.Request().Context(), "update traveller", mock.Anything).
+			Run(func(args mock.Arguments) { run = args.Get(2).(domain.OperationFunc) }).
+			Return(op).Once()
+
+		err := s.handler.Update(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusAccepted, ctx.Response().Status)
+		assert.Equal(s.T(), "/api/v1/operations/op-1", rec.Header().Get("Location"))
+		assert.Equal(s.T(), "respond-async", rec.Header().Get("Preference-Applied"))
+		wantRespBytes, _ := json.Marshal(controller.DataResponse[domain.OperationResponse]{Data: domain.ToOperationResponse(op)})
+		assert.Equal(s.T(), string(wantRespBytes), strings.TrimSpace(rec.Body.String()))
+
+		// The write only happens when the operation runs
+		s.travellerService.On("Update", mock.Anything, 7, updateRequest).Return(traveller, nil).Once()
+		location, err := run(context.TODO())
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), "/api/v1/travellers/7", location)
+		s.TearDownTest()
+	})
+
+	s.Run("respond-async still validates up front", func() {
+		s.SetupTest()
+		_, ctx := helpers.GetHTTPTestRecorder(s.T(), http.MethodPost, "/travellers", domain.CreateTravellerRequest{Name: "Fiore"}, nil, nil)
+		ctx.Request().Header.Set("Prefer", "respond-async")
+
+		err := s.handler.Create(ctx)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), http.StatusBadRequest, ctx.Response().Status)
+		s.TearDownTest()
+	})
+}
@@ -56,7 +56,7 @@ func TestTravellerRepository_Integration(t *testing.T) {
 			AccessoryID: &newAccID,
 		}))
 
-		traveller, err := repo.GetByID(ctx, int(newAcc.ID))
+		traveller, err := repo.GetByID(ctx, int(newAcc.ID), domain.TravellerIncludeAll)
 		assert.Nil(t, err)
 		assert.Equal(t, "Celine", traveller.Name)
 		assert.Equal(t, 5, traveller.Rarity)
@@ -72,7 +72,7 @@ func TestTravellerRepository_Integration(t *testing.T) {
 		assert.Nil(t, tx.WithContext(ctx).Create(&domain.Traveller{Name: "Tahir", Rarity: 4, InfluenceID: 2, JobID: 1}).Error)
 		assert.Nil(t, tx.WithContext(ctx).Create(&domain.Traveller{Name: "Celine", Rarity: 5, InfluenceID: 3, JobID: 8}).Error)
 
-		resList, total, err := repo.GetList(ctx, domain.ListTravellerRequest{}, 0, 10)
+		resList, total, _, err := repo.GetList(ctx, domain.ListTravellerRequest{}, 0, 10)
 		assert.Nil(t, err)
 		assert.Equal(t, int64(2), total)
 
@@ -80,6 +80,31 @@ func TestTravellerRepository_Integration(t *testing.T) {
 		assert.Equal(t, "Celine", resList[1].Name)
 	})
 
+	t.Run("list travellers with range, multi-value and presence filters", func(t *testing.T) {
+		tx := db.Begin()
+		defer tx.Rollback()
+
+		repo := NewTravellerRepository(tx, logger)
+
+		acc := &domain.Accessory{Name: "Ribbon"}
+		assert.Nil(t, tx.WithContext(ctx).Create(acc).Error)
+		accID := int(acc.ID)
+
+		assert.Nil(t, tx.WithContext(ctx).Create(&domain.Traveller{Name: "Tahir", Rarity: 4, InfluenceID: 2, JobID: 1, AccessoryID: &accID}).Error)
+		assert.Nil(t, tx.WithContext(ctx).Create(&domain.Traveller{Name: "Celine", Rarity: 5, InfluenceID: 3, JobID: 8}).Error)
+		assert.Nil(t, tx.WithContext(ctx).Create(&domain.Traveller{Name: "Meena", Rarity: 3, InfluenceID: 1, JobID: 5}).Error)
+
+		hasAccessory := false
+		resList, total, _, err := repo.GetList(ctx, domain.ListTravellerRequest{
+			JobIDs:            []int{1, 5, 8},
+			RarityMinValue:    4,
+			HasAccessoryValue: &hasAccessory,
+		}, 0, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, "Celine", resList[0].Name)
+	})
+
 	t.Run("update traveller fields", func(t *testing.T) {
 		tx := db.Begin()
 		defer tx.Rollback()
@@ -96,7 +121,7 @@ func TestTravellerRepository_Integration(t *testing.T) {
 		})
 		assert.Nil(t, err)
 
-		updated, err := repo.GetByID(ctx, int(tr.ID))
+		updated, err := repo.GetByID(ctx, int(tr.ID), domain.TravellerIncludeAll)
 		assert.Nil(t, err)
 		assert.Equal(t, 6, updated.Rarity)
 		assert.Equal(t, "Ribbon", updated.Accessory.Name)
@@ -112,7 +137,7 @@ func TestTravellerRepository_Integration(t *testing.T) {
 
 		assert.Nil(t, repo.Delete(ctx, int(tr.ID)))
 
-		_, err := repo.GetByID(ctx, int(tr.ID))
+		_, err := repo.GetByID(ctx, int(tr.ID), domain.TravellerIncludeAll)
 		var nfe *domain.NotFoundError
 		assert.True(t, errors.As(err, &nfe), "expected NotFoundError but got: %v", err)
 	})
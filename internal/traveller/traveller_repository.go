@@ -3,15 +3,75 @@ package traveller
 import (
 	"context"
 	"errors"
+	"fmt"
+	"lizobly/ctc-db-api/pkg/audit"
+	"lizobly/ctc-db-api/pkg/constants"
 	"lizobly/ctc-db-api/pkg/domain"
+	filterexpr "lizobly/ctc-db-api/pkg/filter"
+	"lizobly/ctc-db-api/pkg/helpers"
 	"lizobly/ctc-db-api/pkg/logging"
 	"lizobly/ctc-db-api/pkg/telemetry"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// travellerSortColumns maps order_by keys to m_traveller columns
+var travellerSortColumns = map[string]string{
+	"name":         "name",
+	"rarity":       "rarity",
+	"release_date": "release_date",
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+}
+
+// travellerNameSorts maps the order_by keys of ID columns to the names they sort by, the ones
+// responses show
+var travellerNameSorts = map[string]string{
+	"influence": nameSortExpression("m_traveller.influence_id", constants.InfluenceNames()),
+	"job":       nameSortExpression("m_traveller.job_id", constants.JobNames()),
+}
+
+// nameSortExpression maps an ID column to the names by ID, with an empty name for unknown IDs
+// as responses show them
+func nameSortExpression(column string, names map[int]string) string {
+	ids := slices.Sorted(maps.Keys(names))
+	var expression strings.Builder
+	expression.WriteString("CASE " + column)
+	for _, id := range ids {
+		fmt.Fprintf(&expression, " WHEN %d THEN '%s'", id, strings.ReplaceAll(names[id], "'", "''"))
+	}
+	expression.WriteString(" ELSE '' END")
+	return expression.String()
+}
+
+// travellerNullableSortFields lists the order_by keys whose column can be NULL. Lists put NULLs
+// last in either direction.
+var travellerNullableSortFields = map[string]bool{
+	"release_date": true,
+}
+
+// travellerFilterSchema lists the fields and operators accepted by the filter expression
+var travellerFilterSchema = filterexpr.Schema{
+	"name":          {Column: "m_traveller.name", Kind: filterexpr.String},
+	"banner":        {Column: "m_traveller.banner", Kind: filterexpr.String},
+	"rarity":        {Column: "m_traveller.rarity", Kind: filterexpr.Number},
+	"release_date":  {Column: "m_traveller.release_date", Kind: filterexpr.Date},
+	"job":           {Column: "m_traveller.job_id", Kind: filterexpr.Enum, Lookup: filterexpr.IDLookup(constants.GetJobID)},
+	"influence":     {Column: "m_traveller.influence_id", Kind: filterexpr.Enum, Lookup: filterexpr.IDLookup(constants.GetInfluenceID)},
+	"has_accessory": {Column: "(m_traveller.accessory_id IS NOT NULL)", Kind: filterexpr.Bool},
+}
+
+// travellerNameIndex is the unique index on the name key of live travellers
+const travellerNameIndex = "m_traveller_name_key_idx"
+
 type travellerRepository struct {
 	db     *gorm.DB
 	logger *logging.Logger
@@ -23,14 +83,16 @@ func NewTravellerRepository(db *gorm.DB, logger *logging.Logger) *travellerRepos
 		logger: logger.Named("repository.traveller"),
 	}
 }
-func (r *travellerRepository) GetByID(ctx context.Context, id int) (result *domain.Traveller, err error) {
+
+// GetByID returns the traveller with the relations in include
+func (r *travellerRepository) GetByID(ctx context.Context, id int, include domain.TravellerInclude) (result *domain.Traveller, err error) {
 	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.GetByID", "select", "m_traveller",
 		attribute.Int("traveller.id", id),
 	)
 	defer op.End(err)
 
 	result = &domain.Traveller{}
-	err = r.db.WithContext(ctx).Preload("Accessory").First(result, "id = ?", id).Error
+	err = includeTravellerRelations(helpers.Conn(ctx, r.db), include, time.Time{}).First(result, "id = ?", id).Error
 
 	logFields := append(
 		logging.DatabaseFields("select", "m_traveller", op.Duration()),
@@ -50,32 +112,117 @@ func (r *travellerRepository) GetByID(ctx context.Context, id int) (result *doma
 	return
 }
 
-func (r *travellerRepository) GetList(ctx context.Context, filter domain.ListTravellerRequest, offset, limit int) (result []*domain.Traveller, total int64, err error) {
-	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.GetList", "select", "m_traveller")
+// GetByIDAsOf returns the traveller and its accessory as they were at the given time. A traveller
+// that didn't exist then, or had been deleted, is not found.
+func (r *travellerRepository) GetByIDAsOf(ctx context.Context, id int, at time.Time) (result *domain.Traveller, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.GetByIDAsOf", "select", "m_traveller",
+		attribute.Int("traveller.id", id),
+		attribute.String("as_of", at.Format(time.RFC3339)),
+	)
 	defer op.End(err)
 
-	query := r.db.WithContext(ctx).Preload("Accessory")
-
-	// Apply filters
-	if filter.Name != "" {
-		query = query.Where("LOWER(name) LIKE LOWER(?)", "%"+filter.Name+"%")
+	result = &domain.Traveller{}
+	err = audit.AsOf(r.db.WithContext(ctx), domain.TrashTypeTraveller, at).
+		Preload("Accessory", accessoryAsOf(at)).
+		First(result, "m_traveller.id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("traveller", id, nil)
+		}
+		// r.logger.WithContext(ctx).Error("failed to get traveller as of", zap.Int("traveller.id", id), zap.Error(err))
+		return
 	}
-	if filter.InfluenceID != 0 {
-		query = query.Where("influence_id = ?", filter.InfluenceID)
+
+	return
+}
+
+// GetByIDs returns the travellers with the given ids, and the relations in include, in no
+// particular order; missing ids are left out
+func (r *travellerRepository) GetByIDs(ctx context.Context, ids []int, include domain.TravellerInclude) (result []*domain.Traveller, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.GetByIDs", "select", "m_traveller",
+		attribute.IntSlice("traveller.ids", ids),
+	)
+	defer op.End(err)
+
+	err = includeTravellerRelations(r.db.WithContext(ctx), include, time.Time{}).Where("id IN ?", ids).Find(&result).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to get travellers by ids", zap.Ints("traveller.ids", ids), zap.Error(err))
+		return
 	}
-	if filter.JobID != 0 {
-		query = query.Where("job_id = ?", filter.JobID)
+
+	return
+}
+
+// GetBySlug returns the traveller with the relations in include
+func (r *travellerRepository) GetBySlug(ctx context.Context, slug string, include domain.TravellerInclude) (result *domain.Traveller, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.GetBySlug", "select", "m_traveller",
+		attribute.String("traveller.slug", slug),
+	)
+	defer op.End(err)
+
+	result = &domain.Traveller{}
+	err = includeTravellerRelations(r.db.WithContext(ctx), include, time.Time{}).First(result, "slug = ?", slug).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("traveller", slug, nil)
+		}
+		return
 	}
 
-	// Get total count
-	err = query.Model(&domain.Traveller{}).Count(&total).Error
+	return
+}
+
+func (r *travellerRepository) GetList(ctx context.Context, filter domain.ListTravellerRequest, offset, limit int) (result []*domain.Traveller, total int64, lastModified time.Time, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.GetList", "select", "m_traveller")
+	defer op.End(err)
+
+	query := applyTravellerFilters(r.db.WithContext(ctx), filter, "")
+
+	// Get total count and the newest change in the filtered set; together they version the list
+	var stats struct {
+		Total        int64
+		LastModified *time.Time
+	}
+	err = query.Session(&gorm.Session{}).Model(&domain.Traveller{}).
+		Select("COUNT(*) AS total, MAX(m_traveller.updated_at) AS last_modified").
+		Scan(&stats).Error
 	if err != nil {
 		// r.logger.WithContext(ctx).Error("failed to count travellers", zap.Error(err))
 		return
 	}
+	total = stats.Total
+	if stats.LastModified != nil {
+		lastModified = *stats.LastModified
+	}
+
+	// Apply ordering with id as a stable tiebreak so pages don't shift between requests
+	for _, sort := range filter.Sort {
+		if expression, ok := travellerNameSorts[sort.Field]; ok {
+			query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: expression, Raw: true}, Desc: sort.Desc})
+			continue
+		}
+		column, ok := travellerSortColumns[sort.Field]
+		if !ok {
+			continue
+		}
+		if travellerNullableSortFields[sort.Field] {
+			direction := ""
+			if sort.Desc {
+				direction = " DESC"
+			}
+			query = query.Order(`"m_traveller"."` + column + `"` + direction + " NULLS LAST")
+			continue
+		}
+		query = query.Order(clause.OrderByColumn{
+			Column: clause.Column{Table: "m_traveller", Name: column},
+			Desc:   sort.Desc,
+		})
+	}
+	query = query.Order(clause.OrderByColumn{Column: clause.Column{Table: "m_traveller", Name: "id"}})
 
 	// Apply pagination
-	err = query.Offset(offset).Limit(limit).Find(&result).Error
+	err = includeTravellerRelations(query, filter.Relations, filter.AsOfTime).Offset(offset).Limit(limit).Find(&result).Error
 
 	logFields := append(
 		logging.DatabaseFields("select", "m_traveller", op.Duration()),
@@ -92,6 +239,303 @@ func (r *travellerRepository) GetList(ctx context.Context, filter domain.ListTra
 	return
 }
 
+// SuggestNames returns up to limit traveller names that closely resemble one of the words in name,
+// most similar first. It backs the "did you mean" hint when a name filter matches nothing.
+func (r *travellerRepository) SuggestNames(ctx context.Context, name string, limit int) (result []string, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.SuggestNames", "select", "m_traveller",
+		attribute.String("traveller.name", name),
+	)
+	defer op.End(err)
+
+	err = r.db.WithContext(ctx).Model(&domain.Traveller{}).
+		Where("? <% name", name).
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: "word_similarity(?, name) DESC, name", Vars: []interface{}{name}}}).
+		Limit(limit).
+		Pluck("name", &result).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to suggest traveller names", zap.String("traveller.name", name), zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// duplicateAttributeMatches tells in SQL whether a pair shares each attribute domain.ScoreDuplicate
+// compares. As there, an unset attribute never matches.
+var duplicateAttributeMatches = map[string]string{
+	"job":          "(a.job_id <> 0 AND a.job_id = b.job_id)",
+	"influence":    "(a.influence_id <> 0 AND a.influence_id = b.influence_id)",
+	"rarity":       "(a.rarity = b.rarity)",
+	"release_date": "COALESCE(a.release_date = b.release_date, false)",
+}
+
+// duplicateScore is domain.ScoreDuplicate in SQL, for the pair of travellers a and b
+func duplicateScore() string {
+	fields := domain.DuplicateAttributeFields()
+	matches := make([]string, len(fields))
+	for i, field := range fields {
+		matches[i] = duplicateAttributeMatches[field] + "::int"
+	}
+	return fmt.Sprintf("similarity(a.name_key, b.name_key) * %d + (%s) * %d.0 / %d",
+		domain.DuplicateNameWeight, strings.Join(matches, " + "), domain.DuplicateAttributeWeight, len(fields))
+}
+
+// FindSimilarPairs returns up to limit pairs of travellers whose name keys have a trigram
+// similarity of at least threshold and that score at least minScore as duplicates, by name and
+// attributes, best first. The pairs are joined with pg_trgm's % operator, so a trigram index on
+// name_key serves the join. Each pair is listed once, with the lower ID first.
+func (r *travellerRepository) FindSimilarPairs(ctx context.Context, threshold, minScore float64, limit int) (result []domain.SimilarPair, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.FindSimilarPairs", "select", "m_traveller",
+		attribute.Float64("threshold", threshold),
+		attribute.Float64("min_score", minScore),
+	)
+	defer op.End(err)
+
+	score := duplicateScore()
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// % compares against this setting rather than an argument; set_config scopes it to the transaction
+		err := tx.Exec("SELECT set_config('pg_trgm.similarity_threshold', ?, true)", strconv.FormatFloat(threshold, 'f', -1, 64)).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Table("m_traveller AS a").
+			Select("a.id AS first_id, b.id AS second_id, similarity(a.name_key, b.name_key) AS name_similarity").
+			Joins("JOIN m_traveller AS b ON a.id < b.id AND a.name_key % b.name_key AND b.deleted_at IS NULL").
+			Where("a.deleted_at IS NULL AND "+score+" >= ?", minScore).
+			Order(score + " DESC, a.id, b.id").
+			Limit(limit).
+			Scan(&result).Error
+	})
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to find similar travellers", zap.Float64("threshold", threshold), zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// travellerSortValues reads each order_by key from a traveller, to build cursors
+var travellerSortValues = map[string]func(t *domain.Traveller) interface{}{
+	"name":         func(t *domain.Traveller) interface{} { return t.Name },
+	"rarity":       func(t *domain.Traveller) interface{} { return t.Rarity },
+	"release_date": func(t *domain.Traveller) interface{} { return nullableTime(t.ReleaseDate) },
+	"created_at":   func(t *domain.Traveller) interface{} { return t.CreatedAt },
+	"updated_at":   func(t *domain.Traveller) interface{} { return t.UpdatedAt },
+	"influence":    func(t *domain.Traveller) interface{} { return constants.GetInfluenceName(t.InfluenceID) },
+	"job":          func(t *domain.Traveller) interface{} { return constants.GetJobName(t.JobID) },
+}
+
+// nullableTime returns nil for a time read from a NULL column, so cursors hold it as null
+func nullableTime(value time.Time) *time.Time {
+	if value.IsZero() {
+		return nil
+	}
+	return &value
+}
+
+// travellerKeyset returns the keyset columns for a sort, ending with id as the unique tiebreak,
+// and the name of the ordering that cursors are tied to
+func travellerKeyset(sort []domain.SortField) (columns []helpers.KeysetColumn, order string) {
+	keys := make([]string, 0, len(sort)+1)
+	for _, s := range sort {
+		column, ok := travellerNameSorts[s.Field]
+		if !ok {
+			column, ok = travellerSortColumns[s.Field]
+			column = "m_traveller." + column
+		}
+		if !ok {
+			continue
+		}
+		columns = append(columns, helpers.KeysetColumn{Column: column, Desc: s.Desc, Nullable: travellerNullableSortFields[s.Field]})
+		if s.Desc {
+			keys = append(keys, s.Field+":desc")
+		} else {
+			keys = append(keys, s.Field)
+		}
+	}
+	columns = append(columns, helpers.KeysetColumn{Column: "m_traveller.id"})
+	return columns, strings.Join(append(keys, "id"), ",")
+}
+
+// travellerCursorKeys returns the keyset values of a traveller for the given sort
+func travellerCursorKeys(t *domain.Traveller, sort []domain.SortField) []interface{} {
+	keys := make([]interface{}, 0, len(sort)+1)
+	for _, s := range sort {
+		if value, ok := travellerSortValues[s.Field]; ok {
+			keys = append(keys, value(t))
+		}
+	}
+	return append(keys, t.ID)
+}
+
+// GetPage returns up to limit travellers after the cursor position, or before it for a backward
+// cursor, in list order. A nil cursor starts at the beginning. hasMore reports whether more rows
+// follow in the direction read. Unlike GetList it neither counts nor uses OFFSET, so its cost
+// doesn't grow with the position and concurrent writes don't shift rows between pages.
+func (r *travellerRepository) GetPage(ctx context.Context, filter domain.ListTravellerRequest, cursor *helpers.Cursor, limit int) (result []*domain.Traveller, hasMore bool, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.GetPage", "select", "m_traveller",
+		attribute.Int("limit", limit),
+	)
+	defer op.End(err)
+
+	columns, _ := travellerKeyset(filter.Sort)
+	query := applyTravellerFilters(r.db.WithContext(ctx), filter, "")
+
+	backward := false
+	if cursor != nil {
+		backward = cursor.Backward
+		var values []interface{}
+		values, err = cursor.Values(travellerCursorKeys(&domain.Traveller{}, filter.Sort)...)
+		if err != nil {
+			return
+		}
+		query = query.Where(helpers.KeysetCondition(columns, values, backward))
+	}
+
+	// Fetch one extra row to learn whether another page follows
+	err = includeTravellerRelations(query, filter.Relations, filter.AsOfTime).Order(helpers.KeysetOrder(columns, backward)).Limit(limit + 1).Find(&result).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to get traveller page", zap.Error(err))
+		return
+	}
+
+	if len(result) > limit {
+		result = result[:limit]
+		hasMore = true
+	}
+	if backward {
+		slices.Reverse(result)
+	}
+
+	return
+}
+
+// Count returns the number of travellers matching the list filters
+func (r *travellerRepository) Count(ctx context.Context, filter domain.ListTravellerRequest) (total int64, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.Count", "select", "m_traveller")
+	defer op.End(err)
+
+	err = applyTravellerFilters(r.db.WithContext(ctx).Model(&domain.Traveller{}), filter, "").Count(&total).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to count travellers", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// travellerFacetColumns maps facet names to the m_traveller column they count
+var travellerFacetColumns = map[string]string{
+	"job":       "job_id",
+	"influence": "influence_id",
+	"rarity":    "rarity",
+}
+
+// includeTravellerRelations preloads the relations in include, as they were at asOf when it is set
+func includeTravellerRelations(query *gorm.DB, include domain.TravellerInclude, asOf time.Time) *gorm.DB {
+	if include.Accessory {
+		if asOf.IsZero() {
+			query = query.Preload("Accessory")
+		} else {
+			query = query.Preload("Accessory", accessoryAsOf(asOf))
+		}
+	}
+	return query
+}
+
+// accessoryAsOf scopes a preload of the accessory to its state at the given time
+func accessoryAsOf(at time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return audit.AsOf(db, domain.TrashTypeAccessory, at)
+	}
+}
+
+// applyTravellerFilters adds the list filters to query. The filters on skipFacet's own
+// field, including filter= terms that test only it, are left out so its facet counts every value
+// the user could switch to. With as_of the query reads the travellers as they were then.
+func applyTravellerFilters(query *gorm.DB, filter domain.ListTravellerRequest, skipFacet string) *gorm.DB {
+	if !filter.AsOfTime.IsZero() {
+		query = audit.AsOf(query, domain.TrashTypeTraveller, filter.AsOfTime)
+	}
+	if filter.Name != "" {
+		query = query.Where("LOWER(name) LIKE LOWER(?)", filterexpr.ContainsPattern(filter.Name))
+	}
+	if filter.Banner != "" {
+		query = query.Where("LOWER(banner) LIKE LOWER(?)", filterexpr.ContainsPattern(filter.Banner))
+	}
+	if len(filter.InfluenceIDs) > 0 && skipFacet != "influence" {
+		query = query.Where("influence_id IN ?", filter.InfluenceIDs)
+	}
+	if len(filter.JobIDs) > 0 && skipFacet != "job" {
+		query = query.Where("job_id IN ?", filter.JobIDs)
+	}
+	if filter.RarityMinValue != 0 && skipFacet != "rarity" {
+		query = query.Where("rarity >= ?", filter.RarityMinValue)
+	}
+	if filter.RarityMaxValue != 0 && skipFacet != "rarity" {
+		query = query.Where("rarity <= ?", filter.RarityMaxValue)
+	}
+	if !filter.ReleasedAfterDate.IsZero() {
+		query = query.Where("release_date >= ?", filter.ReleasedAfterDate)
+	}
+	if !filter.ReleasedBeforeDate.IsZero() {
+		query = query.Where("release_date <= ?", filter.ReleasedBeforeDate)
+	}
+	if filter.HasAccessoryValue != nil {
+		if *filter.HasAccessoryValue {
+			query = query.Where("accessory_id IS NOT NULL")
+		} else {
+			query = query.Where("accessory_id IS NULL")
+		}
+	}
+	condition := filter.FilterCondition
+	if facetless, ok := condition.Without(skipFacet); ok {
+		condition = facetless
+	}
+	if condition != nil {
+		query = query.Where(condition.SQL, condition.Args...)
+	}
+
+	return query
+}
+
+// GetFacets counts the filtered travellers per value of each requested facet
+func (r *travellerRepository) GetFacets(ctx context.Context, filter domain.ListTravellerRequest, facets []string) (result map[string]map[int]int64, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.GetFacets", "select", "m_traveller",
+		attribute.StringSlice("traveller.facets", facets),
+	)
+	defer op.End(err)
+
+	result = make(map[string]map[int]int64, len(facets))
+	for _, facet := range facets {
+		column, ok := travellerFacetColumns[facet]
+		if !ok {
+			continue
+		}
+
+		var rows []struct {
+			Value int
+			Count int64
+		}
+		err = applyTravellerFilters(r.db.WithContext(ctx).Model(&domain.Traveller{}), filter, facet).
+			Select(column + " AS value, COUNT(*) AS count").
+			Group(column).
+			Scan(&rows).Error
+		if err != nil {
+			// r.logger.WithContext(ctx).Error("failed to count traveller facet", zap.String("facet", facet), zap.Error(err))
+			return nil, err
+		}
+
+		result[facet] = make(map[int]int64, len(rows))
+		for _, row := range rows {
+			result[facet][row.Value] = row.Count
+		}
+	}
+
+	return
+}
+
 func (r *travellerRepository) Create(ctx context.Context, input *domain.Traveller) (err error) {
 	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.Create", "insert", "m_traveller",
 		attribute.String("traveller.name", input.Name),
@@ -99,7 +543,15 @@ func (r *travellerRepository) Create(ctx context.Context, input *domain.Travelle
 	)
 	defer op.End(err)
 
-	err = r.db.WithContext(ctx).Create(input).Error
+	input.NameKey = helpers.NameKey(input.Name)
+	err = helpers.RetrySlugConflict(func() error {
+		slug, err := helpers.UniqueSlug(r.db.WithContext(ctx), "m_traveller", input.Name, 0)
+		if err != nil {
+			return err
+		}
+		input.Slug = slug
+		return r.db.WithContext(ctx).Create(input).Error
+	})
 
 	logFields := append(
 		logging.DatabaseFields("insert", "m_traveller", op.Duration()),
@@ -110,7 +562,7 @@ func (r *travellerRepository) Create(ctx context.Context, input *domain.Travelle
 		// Check for duplicate key violation
 		if errors.Is(err, gorm.ErrDuplicatedKey) {
 			// r.logger.WithContext(ctx).Warn("duplicate traveller name", append(logFields, logging.ErrorFields(err)...)...)
-			return domain.NewConflictError("traveller with this name already exists", err)
+			return travellerConflict(err)
 		}
 		logFields = append(logFields, logging.ErrorFields(err)...)
 		// r.logger.WithContext(ctx).Error("failed to create traveller", logFields...)
@@ -127,8 +579,25 @@ func (r *travellerRepository) Update(ctx context.Context, input *domain.Travelle
 	)
 	defer op.End(err)
 
-	result := r.db.WithContext(ctx).Updates(input)
-	err = result.Error
+	var result *gorm.DB
+	err = helpers.RetrySlugConflict(func() error {
+		// Regenerate the slug only when the traveller is renamed
+		if input.Name != "" {
+			slug, err := helpers.SyncSlug(r.db.WithContext(ctx), "m_traveller", input.ID, input.Name)
+			if err != nil {
+				return err
+			}
+			input.Slug = slug
+		}
+
+		// A non-zero version makes the write conditional on it
+		query := r.db.WithContext(ctx).Model(&domain.Traveller{}).Where("id = ?", input.ID)
+		if input.Version != 0 {
+			query = query.Where("version = ?", input.Version)
+		}
+		result = query.Updates(travellerUpdateColumns(input))
+		return result.Error
+	})
 
 	logFields := append(
 		logging.DatabaseFields("update", "m_traveller", op.Duration()),
@@ -139,7 +608,7 @@ func (r *travellerRepository) Update(ctx context.Context, input *domain.Travelle
 		// Check for duplicate key violation
 		if errors.Is(err, gorm.ErrDuplicatedKey) {
 			// r.logger.WithContext(ctx).Warn("duplicate traveller name", append(logFields, logging.ErrorFields(err)...)...)
-			return domain.NewConflictError("traveller with this name already exists", err)
+			return travellerConflict(err)
 		}
 		logFields = append(logFields, logging.ErrorFields(err)...)
 		// r.logger.WithContext(ctx).Error("failed to update traveller", logFields...)
@@ -148,6 +617,9 @@ func (r *travellerRepository) Update(ctx context.Context, input *domain.Travelle
 
 	// Check if any rows were affected (resource existed)
 	if result.RowsAffected == 0 {
+		if input.Version != 0 {
+			return domain.NewPreconditionFailedError(constants.MessagePreconditionFailed, nil)
+		}
 		// r.logger.WithContext(ctx).Warn("traveller not found for update", logFields...)
 		return domain.NewNotFoundError("traveller", input.ID, nil)
 	}
@@ -193,23 +665,14 @@ func (r *travellerRepository) CreateTravellerWithAccessory(ctx context.Context,
 	defer op.End(err)
 
 	// Start transaction
-	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err = helpers.SlugTransaction(r.db.WithContext(ctx), func(tx *gorm.DB) error {
 		// Create accessory first if provided
 		if accessory != nil {
-			_, accOp := telemetry.StartDBSpan(ctx, "repository.traveller",
-				"CreateAccessory", "insert", "m_accessory",
-				attribute.String("accessory.name", accessory.Name),
-			)
-
-			if err := tx.Create(accessory).Error; err != nil {
-				accOp.End(err)
+			accessoryID, err := saveTravellerAccessory(ctx, tx, nil, accessory)
+			if err != nil {
 				return err
 			}
-			accOp.End(nil)
-
-			// Set accessory ID on traveller
-			accessoryIDInt := int(accessory.ID)
-			traveller.AccessoryID = &accessoryIDInt
+			traveller.AccessoryID = accessoryID
 		}
 
 		// Create traveller
@@ -218,6 +681,14 @@ func (r *travellerRepository) CreateTravellerWithAccessory(ctx context.Context,
 			attribute.String("traveller.name", traveller.Name),
 		)
 
+		slug, err := helpers.UniqueSlug(tx, "m_traveller", traveller.Name, 0)
+		if err != nil {
+			travOp.End(err)
+			return err
+		}
+		traveller.Slug = slug
+		traveller.NameKey = helpers.NameKey(traveller.Name)
+
 		if err := tx.Create(traveller).Error; err != nil {
 			travOp.End(err)
 			// Check for duplicate key violation
@@ -226,13 +697,13 @@ func (r *travellerRepository) CreateTravellerWithAccessory(ctx context.Context,
 				// 	zap.String("traveller.name", traveller.Name),
 				// 	zap.Error(err),
 				// )
-				return domain.NewConflictError("traveller with this name already exists", err)
+				return travellerConflict(err)
 			}
 			return err
 		}
 		travOp.End(nil)
 
-		return nil
+		return reloadTraveller(ctx, tx, traveller.ID, traveller)
 	})
 
 	if err != nil {
@@ -259,102 +730,11 @@ func (r *travellerRepository) UpdateTravellerWithAccessory(ctx context.Context,
 	defer op.End(err)
 
 	// Start transaction
-	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// First, fetch existing traveller to check if it has an accessory
-		_, fetchOp := telemetry.StartDBSpan(ctx, "repository.traveller",
-			"FetchExistingTraveller", "select", "m_traveller",
-			attribute.Int("traveller.id", id),
-		)
-
-		var existingTraveller domain.Traveller
-		if err := tx.Select("id", "accessory_id").First(&existingTraveller, id).Error; err != nil {
-			fetchOp.End(err)
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				// r.logger.WithContext(ctx).Warn("traveller not found for update",
-				// 	zap.Int("traveller.id", id),
-				// )
-				return domain.NewNotFoundError("traveller", id, nil)
-			}
-			return err
-		}
-		fetchOp.End(nil)
-
-		// Handle accessory if provided
-		if accessory != nil {
-			if existingTraveller.AccessoryID != nil {
-				// Update existing accessory
-				accessory.ID = int64(*existingTraveller.AccessoryID)
-
-				_, accUpdateOp := telemetry.StartDBSpan(ctx, "repository.traveller",
-					"UpdateAccessory", "update", "m_accessory",
-					attribute.Int64("accessory.id", accessory.ID),
-					attribute.String("accessory.name", accessory.Name),
-				)
-
-				updateData := map[string]interface{}{
-					"name":   accessory.Name,
-					"hp":     accessory.HP,
-					"sp":     accessory.SP,
-					"patk":   accessory.PAtk,
-					"pdef":   accessory.PDef,
-					"eatk":   accessory.EAtk,
-					"edef":   accessory.EDef,
-					"spd":    accessory.Spd,
-					"crit":   accessory.Crit,
-					"effect": accessory.Effect,
-				}
-				if err := tx.Model(&domain.Accessory{}).Where("id = ?", accessory.ID).Updates(updateData).Error; err != nil {
-					accUpdateOp.End(err)
-					return err
-				}
-				accUpdateOp.End(nil)
-
-				traveller.AccessoryID = existingTraveller.AccessoryID
-			} else {
-				// Create new accessory
-				_, accCreateOp := telemetry.StartDBSpan(ctx, "repository.traveller",
-					"CreateAccessory", "insert", "m_accessory",
-					attribute.String("accessory.name", accessory.Name),
-				)
-
-				if err := tx.Create(accessory).Error; err != nil {
-					accCreateOp.End(err)
-					return err
-				}
-				accCreateOp.End(nil)
-
-				// Set new accessory ID on traveller
-				accessoryIDInt := int(accessory.ID)
-				traveller.AccessoryID = &accessoryIDInt
-			}
-		} else {
-			// Keep existing accessory ID (no change to accessory)
-			traveller.AccessoryID = existingTraveller.AccessoryID
-		}
-
-		// Update traveller
-		_, travUpdateOp := telemetry.StartDBSpan(ctx, "repository.traveller",
-			"UpdateTraveller", "update", "m_traveller",
-			attribute.Int("traveller.id", id),
-			attribute.String("traveller.name", traveller.Name),
-		)
-
-		result := tx.Updates(traveller)
-		if err := result.Error; err != nil {
-			travUpdateOp.End(err)
-			// Check for duplicate key violation
-			if errors.Is(err, gorm.ErrDuplicatedKey) {
-				// r.logger.WithContext(ctx).Warn("duplicate traveller name",
-				// 	zap.String("traveller.name", traveller.Name),
-				// 	zap.Error(err),
-				// )
-				return domain.NewConflictError("traveller with this name already exists", err)
-			}
+	err = helpers.SlugTransaction(r.db.WithContext(ctx), func(tx *gorm.DB) error {
+		if err := updateTraveller(ctx, tx, id, traveller, accessory); err != nil {
 			return err
 		}
-		travUpdateOp.End(nil)
-
-		return nil
+		return reloadTraveller(ctx, tx, int64(id), traveller)
 	})
 
 	if err != nil {
@@ -370,3 +750,922 @@ func (r *travellerRepository) UpdateTravellerWithAccessory(ctx context.Context,
 
 	return
 }
+
+// UpsertTravellerWithAccessory creates or updates the traveller an external ID maps to in a single
+// transaction. A mapped traveller is updated like UpdateTravellerWithAccessory. An unmapped ID adopts
+// the traveller with the same name key, its natural key, or creates one with its accessory like
+// CreateTravellerWithAccessory. The accessory is mapped under the same external ID.
+func (r *travellerRepository) UpsertTravellerWithAccessory(ctx context.Context, key domain.ExternalKey, traveller *domain.Traveller, accessory *domain.Accessory) (created bool, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.UpsertTravellerWithAccessory", "transaction", "m_traveller",
+		attribute.String("external.source", key.Source),
+		attribute.String("external.id", key.ExternalID),
+		attribute.String("traveller.name", traveller.Name),
+		attribute.Bool("has_accessory", accessory != nil),
+	)
+	defer op.End(err)
+
+	err = helpers.SlugTransaction(r.db.WithContext(ctx), func(tx *gorm.DB) error {
+		// Claiming the ID locks its mapping, so concurrent upserts of one ID run one at a time
+		mapping, err := claimExternalID(ctx, tx, domain.ExternalEntityTraveller, key)
+		if err != nil {
+			return err
+		}
+
+		var id int64
+		if mapping.EntityID != nil {
+			id = *mapping.EntityID
+			err = updateTraveller(ctx, tx, int(id), traveller, accessory)
+			var nfe *domain.NotFoundError
+			if errors.As(err, &nfe) {
+				// The mapped traveller was deleted since, so the ID is mapped afresh
+				id = 0
+			} else if err != nil {
+				return err
+			}
+		}
+
+		if id == 0 {
+			id, created, err = insertTravellerByName(ctx, tx, traveller)
+			if err != nil {
+				return err
+			}
+
+			if !created {
+				err = updateTraveller(ctx, tx, int(id), traveller, accessory)
+				if err != nil {
+					return err
+				}
+			} else {
+				// A new traveller has no version an If-Match could have named
+				if traveller.Version != 0 {
+					return domain.NewPreconditionFailedError(constants.MessagePreconditionFailed, nil)
+				}
+				if accessory != nil {
+					accessoryID, err := saveTravellerAccessory(ctx, tx, nil, accessory)
+					if err != nil {
+						return err
+					}
+					err = tx.Model(&domain.Traveller{}).Where("id = ?", id).UpdateColumn("accessory_id", *accessoryID).Error
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		if mapping.EntityID == nil || *mapping.EntityID != id {
+			if err := mapExternalID(ctx, tx, domain.ExternalEntityTraveller, key, id); err != nil {
+				return err
+			}
+		}
+
+		if err := reloadTraveller(ctx, tx, id, traveller); err != nil {
+			return err
+		}
+
+		if accessory != nil && traveller.AccessoryID != nil {
+			return mapExternalID(ctx, tx, domain.ExternalEntityAccessory, key, int64(*traveller.AccessoryID))
+		}
+		return nil
+	})
+
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("transaction failed",
+		// 	append(
+		// 		logging.DatabaseFields("transaction", "m_traveller", op.Duration()),
+		// 		zap.String("external.source", key.Source),
+		// 		zap.String("external.id", key.ExternalID),
+		// 		zap.Error(err),
+		// 	)...,
+		// )
+		return false, err
+	}
+
+	return
+}
+
+// updateTraveller writes an update of a traveller within tx, creating or updating its accessory.
+// Fields left empty keep their stored value, and a set Version must match the stored one.
+func updateTraveller(ctx context.Context, tx *gorm.DB, id int, traveller *domain.Traveller, accessory *domain.Accessory) error {
+	// First, fetch existing traveller to check if it has an accessory
+	_, fetchOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+		"FetchExistingTraveller", "select", "m_traveller",
+		attribute.Int("traveller.id", id),
+	)
+
+	var existingTraveller domain.Traveller
+	if err := tx.Select("id", "accessory_id", "version").First(&existingTraveller, id).Error; err != nil {
+		fetchOp.End(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// r.logger.WithContext(ctx).Warn("traveller not found for update",
+			// 	zap.Int("traveller.id", id),
+			// )
+			return domain.NewNotFoundError("traveller", id, nil)
+		}
+		return err
+	}
+	fetchOp.End(nil)
+
+	// Reject a stale write before touching the accessory
+	expectedVersion := traveller.Version
+	if expectedVersion != 0 && expectedVersion != existingTraveller.Version {
+		return domain.NewPreconditionFailedError(constants.MessagePreconditionFailed, nil)
+	}
+
+	// Handle accessory if provided
+	if accessory != nil {
+		accessoryID, err := saveTravellerAccessory(ctx, tx, existingTraveller.AccessoryID, accessory)
+		if err != nil {
+			return err
+		}
+		traveller.AccessoryID = accessoryID
+	} else {
+		// Keep existing accessory ID (no change to accessory)
+		traveller.AccessoryID = existingTraveller.AccessoryID
+	}
+
+	// Update traveller
+	_, travUpdateOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+		"UpdateTraveller", "update", "m_traveller",
+		attribute.Int("traveller.id", id),
+		attribute.String("traveller.name", traveller.Name),
+	)
+
+	if traveller.Name != "" {
+		slug, err := helpers.SyncSlug(tx, "m_traveller", int64(id), traveller.Name)
+		if err != nil {
+			travUpdateOp.End(err)
+			return err
+		}
+		traveller.Slug = slug
+	}
+
+	// The version guard and bump share one statement, so concurrent writers can't both pass
+	query := tx.Model(&domain.Traveller{}).Where("id = ?", id)
+	if expectedVersion != 0 {
+		query = query.Where("version = ?", expectedVersion)
+	}
+	result := query.Updates(travellerUpdateColumns(traveller))
+	if err := result.Error; err != nil {
+		travUpdateOp.End(err)
+		// Check for duplicate key violation
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			// r.logger.WithContext(ctx).Warn("duplicate traveller name",
+			// 	zap.String("traveller.name", traveller.Name),
+			// 	zap.Error(err),
+			// )
+			return travellerConflict(err)
+		}
+		return err
+	}
+	if result.RowsAffected == 0 {
+		travUpdateOp.End(nil)
+		return domain.NewPreconditionFailedError(constants.MessagePreconditionFailed, nil)
+	}
+	travUpdateOp.End(nil)
+
+	return nil
+}
+
+// PatchTravellerWithAccessory writes the full patched state of a traveller in a single transaction.
+// Unlike UpdateTravellerWithAccessory every column is written, so emptied fields are cleared
+// and a nil accessory unlinks the traveller's current accessory.
+func (r *travellerRepository) PatchTravellerWithAccessory(ctx context.Context, id int, traveller *domain.Traveller, accessory *domain.Accessory) (err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.PatchTravellerWithAccessory", "transaction", "m_traveller",
+		attribute.Int("traveller.id", id),
+		attribute.String("traveller.name", traveller.Name),
+		attribute.Bool("has_accessory", accessory != nil),
+	)
+	defer op.End(err)
+
+	err = helpers.SlugTransaction(helpers.Conn(ctx, r.db), func(tx *gorm.DB) error {
+		_, fetchOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+			"FetchExistingTraveller", "select", "m_traveller",
+			attribute.Int("traveller.id", id),
+		)
+
+		var existingTraveller domain.Traveller
+		if err := tx.Select("id", "accessory_id", "version").First(&existingTraveller, id).Error; err != nil {
+			fetchOp.End(err)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.NewNotFoundError("traveller", id, nil)
+			}
+			return err
+		}
+		fetchOp.End(nil)
+
+		// Reject a stale write before touching the accessory
+		expectedVersion := traveller.Version
+		if expectedVersion != 0 && expectedVersion != existingTraveller.Version {
+			return domain.NewPreconditionFailedError(constants.MessagePreconditionFailed, nil)
+		}
+
+		traveller.AccessoryID = nil
+		if accessory != nil {
+			accessoryID, err := saveTravellerAccessory(ctx, tx, existingTraveller.AccessoryID, accessory)
+			if err != nil {
+				return err
+			}
+			traveller.AccessoryID = accessoryID
+		}
+
+		_, travPatchOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+			"PatchTraveller", "update", "m_traveller",
+			attribute.Int("traveller.id", id),
+			attribute.String("traveller.name", traveller.Name),
+		)
+
+		slug, err := helpers.SyncSlug(tx, "m_traveller", int64(id), traveller.Name)
+		if err != nil {
+			travPatchOp.End(err)
+			return err
+		}
+		traveller.Slug = slug
+
+		// A cleared release date is stored as NULL rather than the zero time
+		var releaseDate interface{}
+		if !traveller.ReleaseDate.IsZero() {
+			releaseDate = traveller.ReleaseDate
+		}
+
+		updateData := map[string]interface{}{
+			"name":         traveller.Name,
+			"name_key":     helpers.NameKey(traveller.Name),
+			"slug":         traveller.Slug,
+			"rarity":       traveller.Rarity,
+			"banner":       traveller.Banner,
+			"release_date": releaseDate,
+			"influence_id": traveller.InfluenceID,
+			"job_id":       traveller.JobID,
+			"accessory_id": traveller.AccessoryID,
+			"version":      gorm.Expr("version + 1"),
+		}
+		for column, value := range traveller.Publication.Columns() {
+			updateData[column] = value
+		}
+		query := tx.Model(&domain.Traveller{}).Where("id = ?", id)
+		if expectedVersion != 0 {
+			query = query.Where("version = ?", expectedVersion)
+		}
+		result := query.Updates(updateData)
+		if err := result.Error; err != nil {
+			travPatchOp.End(err)
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return travellerConflict(err)
+			}
+			return err
+		}
+		if result.RowsAffected == 0 {
+			travPatchOp.End(nil)
+			return domain.NewPreconditionFailedError(constants.MessagePreconditionFailed, nil)
+		}
+		travPatchOp.End(nil)
+
+		return reloadTraveller(ctx, tx, int64(id), traveller)
+	})
+
+	return
+}
+
+// MergeTravellers merges the source traveller into the target in a single transaction, filling what
+// the target leaves empty from the source. References to the source are re-pointed at the target:
+// its accessory, when the target has none or keepAccessory picks it, and its external IDs. An
+// accessory that isn't kept is detached and stays as one without a traveller. Pending change
+// requests for the source are closed, as they were proposed against its version. The source is
+// then deleted; its revisions stay with it, so it can still be restored. A non-zero
+// expectedVersion must match the target's. The merged target is returned as persisted.
+func (r *travellerRepository) MergeTravellers(ctx context.Context, targetID, sourceID int, keepAccessory string, expectedVersion int64) (result *domain.Traveller, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.MergeTravellers", "transaction", "m_traveller",
+		attribute.Int("traveller.id", targetID),
+		attribute.Int("traveller.source_id", sourceID),
+	)
+	defer op.End(err)
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock both rows in ID order, so merges of the same pair in either direction can't deadlock
+		_, fetchOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+			"LockMergedTravellers", "select", "m_traveller",
+			attribute.IntSlice("traveller.ids", []int{targetID, sourceID}),
+		)
+
+		var rows []*domain.Traveller
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", []int{targetID, sourceID}).Order("id").Find(&rows).Error; err != nil {
+			fetchOp.End(err)
+			return err
+		}
+		fetchOp.End(nil)
+
+		var target, source *domain.Traveller
+		for _, row := range rows {
+			switch row.ID {
+			case int64(targetID):
+				target = row
+			case int64(sourceID):
+				source = row
+			}
+		}
+		if target == nil {
+			return domain.NewNotFoundError("traveller", targetID, nil)
+		}
+		if source == nil {
+			return domain.NewNotFoundError("traveller", sourceID, nil)
+		}
+		if expectedVersion != 0 && expectedVersion != target.Version {
+			return domain.NewPreconditionFailedError(constants.MessagePreconditionFailed, nil)
+		}
+
+		if err := domain.MergeTravellers(target, source, keepAccessory); err != nil {
+			return err
+		}
+
+		// The source lets go of its accessory first, so a moved one never has two owners and one
+		// that isn't kept doesn't stay with a deleted traveller
+		_, sourceOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+			"DeleteMergedTraveller", "delete", "m_traveller",
+			attribute.Int("traveller.id", sourceID),
+		)
+		if source.AccessoryID != nil {
+			if err := tx.Model(&domain.Traveller{}).Where("id = ?", sourceID).UpdateColumn("accessory_id", nil).Error; err != nil {
+				sourceOp.End(err)
+				return err
+			}
+		}
+		if err := tx.Delete(&domain.Traveller{}, sourceID).Error; err != nil {
+			sourceOp.End(err)
+			return err
+		}
+		sourceOp.End(nil)
+
+		_, mergeOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+			"MergeTraveller", "update", "m_traveller",
+			attribute.Int("traveller.id", targetID),
+		)
+		if err := tx.Model(&domain.Traveller{}).Where("id = ?", targetID).Updates(travellerUpdateColumns(target)).Error; err != nil {
+			mergeOp.End(err)
+			return err
+		}
+		mergeOp.End(nil)
+
+		_, mappingOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+			"RepointExternalIDs", "update", "m_external_id",
+			attribute.Int("traveller.id", targetID),
+		)
+		err := tx.Model(&domain.ExternalID{}).
+			Where("entity_type = ? AND entity_id = ?", domain.ExternalEntityTraveller, sourceID).
+			Update("entity_id", targetID).Error
+		mappingOp.End(err)
+		if err != nil {
+			return err
+		}
+
+		if err := closeMergedChangeRequests(ctx, tx, targetID, sourceID); err != nil {
+			return err
+		}
+
+		result = &domain.Traveller{}
+		return reloadTraveller(ctx, tx, int64(targetID), result)
+	})
+
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("transaction failed",
+		// 	append(
+		// 		logging.DatabaseFields("transaction", "m_traveller", op.Duration()),
+		// 		zap.Int("traveller.id", targetID),
+		// 		zap.Int("traveller.source_id", sourceID),
+		// 		zap.Error(err),
+		// 	)...,
+		// )
+		return nil, err
+	}
+
+	return
+}
+
+// closeMergedChangeRequests rejects the pending change requests for a merged source traveller,
+// with a comment pointing at the traveller it was merged into
+func closeMergedChangeRequests(ctx context.Context, tx *gorm.DB, targetID, sourceID int) (err error) {
+	_, op := telemetry.StartDBSpan(ctx, "repository.traveller",
+		"CloseMergedChangeRequests", "update", "m_change_request",
+		attribute.Int("traveller.source_id", sourceID),
+	)
+	defer op.End(err)
+
+	var ids []int64
+	err = tx.Model(&domain.ChangeRequest{}).
+		Where("entity_type = ? AND entity_id = ? AND status = ?", domain.ChangeRequestEntityTraveller, sourceID, domain.ChangeRequestStatusPending).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return
+	}
+
+	reviewer := logging.GetUserID(ctx)
+	err = tx.Model(&domain.ChangeRequest{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"status":      domain.ChangeRequestStatusRejected,
+		"reviewed_by": reviewer,
+		"reviewed_at": time.Now(),
+		"version":     gorm.Expr("version + 1"),
+	}).Error
+	if err != nil {
+		return
+	}
+
+	body := fmt.Sprintf("Closed: traveller %d was merged into traveller %d", sourceID, targetID)
+	comments := make([]domain.ChangeRequestComment, len(ids))
+	for i, id := range ids {
+		comments[i] = domain.ChangeRequestComment{ChangeRequestID: id, Author: reviewer, Body: body}
+	}
+	return tx.Create(&comments).Error
+}
+
+// RestoreTraveller brings a deleted traveller back, as a new version of it. Its name must not have
+// been taken by a live traveller in the meantime. If its accessory has since gone to another
+// traveller, it comes back without one. The restored traveller is returned as persisted.
+func (r *travellerRepository) RestoreTraveller(ctx context.Context, id int) (result *domain.Traveller, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.RestoreTraveller", "transaction", "m_traveller",
+		attribute.Int("traveller.id", id),
+	)
+	defer op.End(err)
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, fetchOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+			"LockDeletedTraveller", "select", "m_traveller",
+			attribute.Int("traveller.id", id),
+		)
+		var deleted domain.Traveller
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("deleted_at IS NOT NULL").First(&deleted, id).Error
+		fetchOp.End(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.NewNotFoundError("deleted traveller", id, nil)
+		}
+		if err != nil {
+			return err
+		}
+
+		_, conflictOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+			"CheckRestoreConflicts", "select", "m_traveller",
+			attribute.Int("traveller.id", id),
+		)
+		var namesakes int64
+		err = tx.Model(&domain.Traveller{}).Where("name_key = ?", deleted.NameKey).Count(&namesakes).Error
+		if err != nil {
+			conflictOp.End(err)
+			return err
+		}
+		var accessoryOwners int64
+		if deleted.AccessoryID != nil {
+			err = tx.Model(&domain.Traveller{}).Where("accessory_id = ?", *deleted.AccessoryID).Count(&accessoryOwners).Error
+			if err != nil {
+				conflictOp.End(err)
+				return err
+			}
+		}
+		conflictOp.End(nil)
+		if namesakes > 0 {
+			return domain.NewConflictError("traveller with this name already exists", nil)
+		}
+
+		_, restoreOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+			"RestoreTraveller", "update", "m_traveller",
+			attribute.Int("traveller.id", id),
+		)
+		columns := map[string]interface{}{
+			"deleted_at": nil,
+			"deleted_by": nil,
+			"version":    gorm.Expr("version + 1"),
+		}
+		if accessoryOwners > 0 {
+			columns["accessory_id"] = nil
+		}
+		err = tx.Unscoped().Model(&domain.Traveller{}).Where("id = ?", id).Updates(columns).Error
+		restoreOp.End(err)
+		if err != nil {
+			// The partial unique index still catches a namesake created concurrently
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return travellerConflict(err)
+			}
+			return err
+		}
+
+		result = &domain.Traveller{}
+		return reloadTraveller(ctx, tx, int64(id), result)
+	})
+
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("transaction failed",
+		// 	append(
+		// 		logging.DatabaseFields("transaction", "m_traveller", op.Duration()),
+		// 		zap.Int("traveller.id", id),
+		// 		zap.Error(err),
+		// 	)...,
+		// )
+		return nil, err
+	}
+
+	return
+}
+
+// GetHistory returns one page of the revisions of a traveller, deleted ones included, and of its
+// current accessory, most recent first
+func (r *travellerRepository) GetHistory(ctx context.Context, id int, offset, limit int) (result []domain.Revision, total int64, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.GetHistory", "select", "m_revision",
+		attribute.Int("traveller.id", id),
+	)
+	defer op.End(err)
+
+	var traveller domain.Traveller
+	err = r.db.WithContext(ctx).Unscoped().Select("id", "accessory_id").First(&traveller, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, domain.NewNotFoundError("traveller", id, nil)
+	}
+	if err != nil {
+		return
+	}
+
+	query := r.db.WithContext(ctx).Model(&domain.Revision{}).Scopes(travellerRevisions(&traveller))
+
+	err = query.Count(&total).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to count traveller history", zap.Int("traveller.id", id), zap.Error(err))
+		return
+	}
+
+	err = query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&result).Error
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("failed to get traveller history", zap.Int("traveller.id", id), zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// RevertRevision sets the fields a revision of a traveller, or of its current accessory, changed
+// back to their values before it, as a new version, and returns the traveller as persisted.
+// Fields changed again since the revision are a conflict.
+func (r *travellerRepository) RevertRevision(ctx context.Context, id int, revisionID int64) (result *domain.Traveller, err error) {
+	ctx, op := telemetry.StartDBSpan(ctx, "repository.traveller", "TravellerRepository.RevertRevision", "transaction", "m_traveller",
+		attribute.Int("traveller.id", id),
+		attribute.Int64("revision.id", revisionID),
+	)
+	defer op.End(err)
+
+	err = helpers.SlugTransaction(r.db.WithContext(audit.WithRevisionAction(ctx, domain.RevisionActionRevert)), func(tx *gorm.DB) error {
+		_, fetchOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+			"LockRevisionTarget", "select", "m_traveller",
+			attribute.Int("traveller.id", id),
+			attribute.Int64("revision.id", revisionID),
+		)
+		var traveller domain.Traveller
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&traveller, id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			fetchOp.End(nil)
+			return domain.NewNotFoundError("traveller", id, nil)
+		}
+		if err != nil {
+			fetchOp.End(err)
+			return err
+		}
+		var revision domain.Revision
+		err = tx.Scopes(travellerRevisions(&traveller)).First(&revision, revisionID).Error
+		fetchOp.End(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.NewNotFoundError("revision", revisionID, nil)
+		}
+		if err != nil {
+			return err
+		}
+
+		_, revertOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+			"RevertRevision", "update", "m_"+revision.EntityType,
+			attribute.Int64("revision.id", revisionID),
+		)
+		if revision.EntityType == domain.TrashTypeAccessory {
+			err = revertAccessory(tx, revision)
+		} else {
+			err = revertTraveller(tx, revision, &traveller)
+		}
+		revertOp.End(err)
+		if err != nil {
+			// A partial unique index still catches a value taken since the revision
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return domain.NewConflictError("reverted values are already in use", err)
+			}
+			return err
+		}
+
+		result = &domain.Traveller{}
+		return reloadTraveller(ctx, tx, int64(id), result)
+	})
+
+	if err != nil {
+		// r.logger.WithContext(ctx).Error("transaction failed",
+		// 	append(
+		// 		logging.DatabaseFields("transaction", "m_traveller", op.Duration()),
+		// 		zap.Int("traveller.id", id),
+		// 		zap.Int64("revision.id", revisionID),
+		// 		zap.Error(err),
+		// 	)...,
+		// )
+		return nil, err
+	}
+
+	return
+}
+
+// travellerRevisions limits a query to the revisions of a traveller and of its current accessory
+func travellerRevisions(traveller *domain.Traveller) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if traveller.AccessoryID == nil {
+			return db.Where("entity_type = ? AND entity_id = ?", domain.TrashTypeTraveller, traveller.ID)
+		}
+		return db.Where("(entity_type = ? AND entity_id = ?) OR (entity_type = ? AND entity_id = ?)",
+			domain.TrashTypeTraveller, traveller.ID, domain.TrashTypeAccessory, *traveller.AccessoryID)
+	}
+}
+
+// revertTraveller writes the state of a locked traveller with the fields revision changed set back
+func revertTraveller(tx *gorm.DB, revision domain.Revision, traveller *domain.Traveller) error {
+	var state domain.TravellerState
+	if err := revision.Revert(traveller.RevisionState(), &state); err != nil {
+		return err
+	}
+	columns, err := state.Columns()
+	if err != nil {
+		return err
+	}
+
+	slug, err := helpers.SyncSlug(tx, "m_traveller", traveller.ID, state.Name)
+	if err != nil {
+		return err
+	}
+	columns["slug"] = slug
+	columns["name_key"] = helpers.NameKey(state.Name)
+	columns["version"] = gorm.Expr("version + 1")
+
+	return tx.Model(&domain.Traveller{}).Where("id = ?", traveller.ID).Updates(columns).Error
+}
+
+// revertAccessory writes the state of the traveller's accessory with the fields revision changed
+// set back, and bumps the version of the traveller that embeds it
+func revertAccessory(tx *gorm.DB, revision domain.Revision) error {
+	var accessory domain.Accessory
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&accessory, revision.EntityID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.NewNotFoundError("accessory", revision.EntityID, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	var state domain.AccessoryState
+	if err := revision.Revert(accessory.RevisionState(), &state); err != nil {
+		return err
+	}
+	columns := state.Columns()
+
+	slug, err := helpers.SyncSlug(tx, "m_accessory", accessory.ID, state.Name)
+	if err != nil {
+		return err
+	}
+	columns["slug"] = slug
+	columns["version"] = gorm.Expr("version + 1")
+
+	err = tx.Model(&domain.Accessory{}).Where("id = ?", accessory.ID).Updates(columns).Error
+	if err != nil {
+		return err
+	}
+	return tx.Model(&domain.Traveller{}).Where("accessory_id = ?", accessory.ID).
+		UpdateColumn("version", gorm.Expr("version + 1")).Error
+}
+
+// claimExternalID inserts the mapping of an external ID, or locks the existing one, and returns it.
+// A new mapping has no entity yet; the lock holds until the transaction ends.
+func claimExternalID(ctx context.Context, tx *gorm.DB, entityType string, key domain.ExternalKey) (*domain.ExternalID, error) {
+	_, claimOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+		"ClaimExternalID", "upsert", "m_external_id",
+		attribute.String("external.source", key.Source),
+		attribute.String("external.id", key.ExternalID),
+	)
+
+	mapping := &domain.ExternalID{EntityType: entityType, Source: key.Source, ExternalID: key.ExternalID}
+	err := tx.Clauses(
+		clause.OnConflict{
+			Columns:   externalIDConflictColumns,
+			DoUpdates: clause.AssignmentColumns([]string{"updated_at"}),
+		},
+		clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "entity_id"}}},
+	).Create(mapping).Error
+	claimOp.End(err)
+	if err != nil {
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// mapExternalID points an external ID at an entity, adding the mapping if there is none
+func mapExternalID(ctx context.Context, tx *gorm.DB, entityType string, key domain.ExternalKey, entityID int64) error {
+	_, mapOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+		"MapExternalID", "upsert", "m_external_id",
+		attribute.String("external.entity_type", entityType),
+		attribute.Int64("external.entity_id", entityID),
+	)
+
+	mapping := &domain.ExternalID{EntityType: entityType, EntityID: &entityID, Source: key.Source, ExternalID: key.ExternalID}
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   externalIDConflictColumns,
+		DoUpdates: clause.AssignmentColumns([]string{"entity_id", "updated_at"}),
+	}).Create(mapping).Error
+	mapOp.End(err)
+	return err
+}
+
+// externalIDConflictColumns is the unique key of m_external_id
+var externalIDConflictColumns = []clause.Column{{Name: "entity_type"}, {Name: "source"}, {Name: "external_id"}}
+
+// insertTravellerByName inserts a traveller unless one with its name key, the natural key, exists,
+// and returns the ID of whichever it is. A copy is inserted, so create hooks don't touch traveller.
+func insertTravellerByName(ctx context.Context, tx *gorm.DB, traveller *domain.Traveller) (id int64, inserted bool, err error) {
+	_, insertOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+		"InsertTravellerByName", "upsert", "m_traveller",
+		attribute.String("traveller.name", traveller.Name),
+	)
+	defer func() { insertOp.End(err) }()
+
+	slug, err := helpers.UniqueSlug(tx, "m_traveller", traveller.Name, 0)
+	if err != nil {
+		return 0, false, err
+	}
+
+	row := *traveller
+	row.Slug = slug
+	row.NameKey = helpers.NameKey(traveller.Name)
+	row.Version = 0
+	// Names are unique among live travellers only, so deleted ones can be restored or reused
+	result := tx.Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "name_key"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "deleted_at IS NULL"}}},
+		DoNothing:   true,
+	}).Create(&row)
+	if result.Error != nil {
+		return 0, false, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return row.ID, true, nil
+	}
+
+	// The insert waited for any concurrent writer of the name, so the existing row is visible now
+	var existing domain.Traveller
+	err = tx.Select("id").Where("name_key = ?", row.NameKey).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// The traveller with the name was deleted before this could read it
+		return 0, false, domain.NewConflictError("traveller with this name already exists", err)
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return existing.ID, false, nil
+}
+
+// travellerConflict turns a unique violation writing a traveller into a ConflictError, naming
+// what was taken when the violated index is known. Other errors are returned as they are.
+func travellerConflict(err error) error {
+	switch {
+	case helpers.ViolatedConstraint(err) == travellerNameIndex:
+		return domain.NewConflictError("traveller with this name already exists", err)
+	case helpers.IsSlugConflict(err):
+		return domain.NewConflictError("generated slug was taken by a concurrent write, try again", err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		// An index renamed or added outside this repo is still a conflict, not a server error
+		return domain.NewConflictError("traveller conflicts with an existing one", err)
+	}
+	return err
+}
+
+// reloadTraveller reads a traveller written in tx back with its accessory, so the caller gets
+// the persisted row, including its new version and timestamps, without another round trip
+func reloadTraveller(ctx context.Context, tx *gorm.DB, id int64, traveller *domain.Traveller) error {
+	_, reloadOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+		"ReloadTraveller", "select", "m_traveller",
+		attribute.Int64("traveller.id", id),
+	)
+
+	var persisted domain.Traveller
+	err := tx.Preload("Accessory").First(&persisted, id).Error
+	reloadOp.End(err)
+	if err != nil {
+		return err
+	}
+
+	*traveller = persisted
+	return nil
+}
+
+// saveTravellerAccessory updates the traveller's existing accessory, or creates one when it has none,
+// and returns the accessory ID to link
+func saveTravellerAccessory(ctx context.Context, tx *gorm.DB, existingAccessoryID *int, accessory *domain.Accessory) (*int, error) {
+	if existingAccessoryID != nil {
+		// Update existing accessory
+		accessory.ID = int64(*existingAccessoryID)
+
+		_, accUpdateOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+			"UpdateAccessory", "update", "m_accessory",
+			attribute.Int64("accessory.id", accessory.ID),
+			attribute.String("accessory.name", accessory.Name),
+		)
+
+		slug, err := helpers.SyncSlug(tx, "m_accessory", accessory.ID, accessory.Name)
+		if err != nil {
+			accUpdateOp.End(err)
+			return nil, err
+		}
+		accessory.Slug = slug
+
+		updateData := map[string]interface{}{
+			"name":    accessory.Name,
+			"slug":    accessory.Slug,
+			"hp":      accessory.HP,
+			"sp":      accessory.SP,
+			"patk":    accessory.PAtk,
+			"pdef":    accessory.PDef,
+			"eatk":    accessory.EAtk,
+			"edef":    accessory.EDef,
+			"spd":     accessory.Spd,
+			"crit":    accessory.Crit,
+			"effect":  accessory.Effect,
+			"version": gorm.Expr("version + 1"),
+		}
+		// An accessory written without a status keeps its stored one
+		for column, value := range accessory.Publication.Columns() {
+			updateData[column] = value
+		}
+		if err := tx.Model(&domain.Accessory{}).Where("id = ?", accessory.ID).Updates(updateData).Error; err != nil {
+			accUpdateOp.End(err)
+			return nil, err
+		}
+		accUpdateOp.End(nil)
+
+		return existingAccessoryID, nil
+	}
+
+	// Create new accessory
+	_, accCreateOp := telemetry.StartDBSpan(ctx, "repository.traveller",
+		"CreateAccessory", "insert", "m_accessory",
+		attribute.String("accessory.name", accessory.Name),
+	)
+
+	slug, err := helpers.UniqueSlug(tx, "m_accessory", accessory.Name, 0)
+	if err != nil {
+		accCreateOp.End(err)
+		return nil, err
+	}
+	accessory.Slug = slug
+	// A retried write may have set the ID of an accessory it rolled back
+	accessory.ID = 0
+
+	if err := tx.Create(accessory).Error; err != nil {
+		accCreateOp.End(err)
+		return nil, err
+	}
+	accCreateOp.End(nil)
+
+	accessoryIDInt := int(accessory.ID)
+	return &accessoryIDInt, nil
+}
+
+// travellerUpdateColumns lists the non-empty fields of a traveller for an update and bumps its version.
+// Empty fields are left unchanged, matching how PUT has always treated them.
+func travellerUpdateColumns(traveller *domain.Traveller) map[string]interface{} {
+	columns := map[string]interface{}{
+		"version": gorm.Expr("version + 1"),
+	}
+	if traveller.Name != "" {
+		columns["name"] = traveller.Name
+		columns["name_key"] = helpers.NameKey(traveller.Name)
+	}
+	if traveller.Slug != "" {
+		columns["slug"] = traveller.Slug
+	}
+	if traveller.Rarity != 0 {
+		columns["rarity"] = traveller.Rarity
+	}
+	if traveller.Banner != "" {
+		columns["banner"] = traveller.Banner
+	}
+	if !traveller.ReleaseDate.IsZero() {
+		columns["release_date"] = traveller.ReleaseDate
+	}
+	if traveller.InfluenceID != 0 {
+		columns["influence_id"] = traveller.InfluenceID
+	}
+	if traveller.JobID != 0 {
+		columns["job_id"] = traveller.JobID
+	}
+	if traveller.AccessoryID != nil {
+		columns["accessory_id"] = traveller.AccessoryID
+	}
+	for column, value := range traveller.Publication.Columns() {
+		columns[column] = value
+	}
+	return columns
+}
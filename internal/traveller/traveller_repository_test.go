@@ -2,15 +2,21 @@ package traveller
 
 import (
 	"context"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
+	"lizobly/ctc-db-api/pkg/constants"
 	"lizobly/ctc-db-api/pkg/domain"
+	filterexpr "lizobly/ctc-db-api/pkg/filter"
 	"lizobly/ctc-db-api/pkg/helpers"
 	"lizobly/ctc-db-api/pkg/logging"
+	"lizobly/ctc-db-api/pkg/publication"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
@@ -39,17 +45,20 @@ func (s *TravellerRepositorySuite) SetupTest() {
 }
 
 func (s *TravellerRepositorySuite) TestTravellerRepository_GetByID() {
+	accessoryID := 3
 	tests := []struct {
 		name    string
 		id      int
+		include domain.TravellerInclude
 		mockSet func()
 		want    *domain.Traveller
 		wantErr bool
 		checkFn func(*testing.T, error)
 	}{
 		{
-			name: "found",
-			id:   1,
+			name:    "found",
+			id:      1,
+			include: domain.TravellerIncludeAll,
 			mockSet: func() {
 				releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
 				want := domain.Traveller{Name: "Fiore", Rarity: 5, Banner: "General", ReleaseDate: releaseDate, CommonModel: domain.CommonModel{ID: int64(1)}}
@@ -62,6 +71,27 @@ func (s *TravellerRepositorySuite) TestTravellerRepository_GetByID() {
 			}(),
 			wantErr: false,
 		},
+		{
+			name:    "included accessory is preloaded",
+			id:      1,
+			include: domain.TravellerInclude{Accessory: true},
+			mockSet: func() {
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE id = $1 AND "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $2`)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "accessory_id"}).AddRow(1, "Fiore", accessoryID))
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_accessory" WHERE "m_accessory"."id" = $1 AND "m_accessory"."deleted_at" IS NULL`)).WithArgs(accessoryID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(accessoryID, "Crown of Wisdom"))
+			},
+			want: &domain.Traveller{Name: "Fiore", AccessoryID: &accessoryID, Accessory: &domain.Accessory{Name: "Crown of Wisdom", CommonModel: domain.CommonModel{ID: int64(accessoryID)}}, CommonModel: domain.CommonModel{ID: int64(1)}},
+		},
+		{
+			name: "accessory not included is not queried",
+			id:   1,
+			mockSet: func() {
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE id = $1 AND "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $2`)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "accessory_id"}).AddRow(1, "Fiore", accessoryID))
+			},
+			want: &domain.Traveller{Name: "Fiore", AccessoryID: &accessoryID, CommonModel: domain.CommonModel{ID: int64(1)}},
+		},
 		{
 			name: "not found",
 			id:   999,
@@ -82,7 +112,62 @@ func (s *TravellerRepositorySuite) TestTravellerRepository_GetByID() {
 			s.SetupTest()
 			tt.mockSet()
 
-			res, err := s.repo.GetByID(context.TODO(), tt.id)
+			res, err := s.repo.GetByID(context.TODO(), tt.id, tt.include)
+			if tt.wantErr {
+				assert.Error(s.T(), err)
+				if tt.checkFn != nil {
+					tt.checkFn(s.T(), err)
+				}
+				return
+			}
+			assert.NoError(s.T(), err)
+			assert.Equal(s.T(), tt.want, res)
+			assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *TravellerRepositorySuite) TestTravellerRepository_GetBySlug() {
+	tests := []struct {
+		name    string
+		slug    string
+		mockSet func()
+		want    *domain.Traveller
+		wantErr bool
+		checkFn func(*testing.T, error)
+	}{
+		{
+			name: "found",
+			slug: "fiore",
+			mockSet: func() {
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE slug = $1 AND "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $2`)).
+					WithArgs("fiore", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug", "rarity"}).AddRow(1, "Fiore", "fiore", 5))
+			},
+			want:    &domain.Traveller{Name: "Fiore", Slug: "fiore", Rarity: 5, CommonModel: domain.CommonModel{ID: int64(1)}},
+			wantErr: false,
+		},
+		{
+			name: "not found",
+			slug: "unknown",
+			mockSet: func() {
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE slug = $1 AND "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $2`)).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantErr: true,
+			checkFn: func(t *testing.T, err error) {
+				var nfe *domain.NotFoundError
+				assert.True(t, errors.As(err, &nfe), "expected NotFoundError")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			tt.mockSet()
+
+			res, err := s.repo.GetBySlug(context.TODO(), tt.slug, domain.TravellerIncludeAll)
 			if tt.wantErr {
 				assert.Error(s.T(), err)
 				if tt.checkFn != nil {
@@ -96,6 +181,399 @@ func (s *TravellerRepositorySuite) TestTravellerRepository_GetByID() {
 	}
 }
 
+func (s *TravellerRepositorySuite) TestTravellerRepository_HidesDrafts() {
+	s.SetupTest()
+	s.Require().NoError(publication.Register(s.db))
+	published := `WHERE "m_traveller"."status" = $1 AND "m_traveller"."deleted_at" IS NULL`
+
+	s.Run("list total and last modified leave drafts out", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total, MAX(m_traveller.updated_at) AS last_modified FROM "m_traveller" ` + published)).
+			WithArgs(domain.PublishStatusPublished).
+			WillReturnRows(sqlmock.NewRows([]string{"total", "last_modified"}).AddRow(1, nil))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" `+published)).
+			WithArgs(domain.PublishStatusPublished, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		res, total, _, err := s.repo.GetList(context.TODO(), domain.ListTravellerRequest{}, 0, 10)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(len(res)), total)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("facets leave drafts out", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT job_id AS value, COUNT(*) AS count FROM "m_traveller" ` + published)).
+			WithArgs(domain.PublishStatusPublished).
+			WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).AddRow(1, 1))
+
+		_, err := s.repo.GetFacets(context.TODO(), domain.ListTravellerRequest{}, []string{"job"})
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *TravellerRepositorySuite) TestTravellerRepository_GetFacets() {
+	filter := domain.ListTravellerRequest{Name: "a", JobIDs: []int{1}, RarityMinValue: 4}
+
+	s.Run("each facet ignores only its own filter", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT job_id AS value, COUNT(*) AS count FROM "m_traveller" WHERE LOWER(name) LIKE LOWER($1) AND rarity >= $2 AND "m_traveller"."deleted_at" IS NULL GROUP BY "job_id"`)).
+			WithArgs("%a%", 4).
+			WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).AddRow(1, 3).AddRow(8, 2))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT rarity AS value, COUNT(*) AS count FROM "m_traveller" WHERE LOWER(name) LIKE LOWER($1) AND job_id IN ($2) AND "m_traveller"."deleted_at" IS NULL GROUP BY "rarity"`)).
+			WithArgs("%a%", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).AddRow(5, 3))
+
+		res, err := s.repo.GetFacets(context.TODO(), filter, []string{"job", "rarity"})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), map[string]map[int]int64{
+			"job":    {1: 3, 8: 2},
+			"rarity": {5: 3},
+		}, res)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("filter terms on a facet's own field are left out of its count", func() {
+		s.SetupTest()
+		condition, err := travellerFilterSchema.Compile(`job in (Warrior,Dancer) and rarity>=4`)
+		s.Require().NoError(err)
+		filter := domain.ListTravellerRequest{FilterCondition: condition}
+
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT job_id AS value, COUNT(*) AS count FROM "m_traveller" WHERE m_traveller.rarity >= $1 AND "m_traveller"."deleted_at" IS NULL GROUP BY "job_id"`)).
+			WithArgs(4).
+			WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).AddRow(1, 3).AddRow(2, 1))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT rarity AS value, COUNT(*) AS count FROM "m_traveller" WHERE m_traveller.job_id IN ($1,$2) AND "m_traveller"."deleted_at" IS NULL GROUP BY "rarity"`)).
+			WithArgs(constants.JobWarriorID, constants.JobDancerID).
+			WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).AddRow(5, 2))
+
+		res, err := s.repo.GetFacets(context.TODO(), filter, []string{"job", "rarity"})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), map[string]map[int]int64{
+			"job":    {1: 3, 2: 1},
+			"rarity": {5: 2},
+		}, res)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("database error", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT influence_id AS value`)).WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := s.repo.GetFacets(context.TODO(), filter, []string{"influence"})
+		assert.Error(s.T(), err)
+	})
+}
+
+func (s *TravellerRepositorySuite) TestTravellerRepository_GetPage() {
+	sort := []domain.SortField{{Field: "rarity", Desc: true}}
+
+	s.Run("first page fetches one extra row", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."deleted_at" IS NULL ORDER BY m_traveller.rarity DESC,m_traveller.id LIMIT $1`)).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "rarity"}).AddRow(1, "Fiore", 5).AddRow(4, "Viola", 5).AddRow(2, "Shen", 4))
+
+		res, hasMore, err := s.repo.GetPage(context.TODO(), domain.ListTravellerRequest{Sort: sort}, nil, 2)
+		assert.NoError(s.T(), err)
+		assert.True(s.T(), hasMore)
+		assert.Len(s.T(), res, 2)
+		assert.Equal(s.T(), "Viola", res[1].Name)
+	})
+
+	s.Run("after a cursor with filters", func() {
+		s.SetupTest()
+		cursor, _ := helpers.NewCursor("rarity:desc,id", false, 5, int64(4))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE LOWER(name) LIKE LOWER($1) AND (((m_traveller.rarity < $2) OR (m_traveller.rarity = $3 AND m_traveller.id > $4))) AND "m_traveller"."deleted_at" IS NULL ORDER BY m_traveller.rarity DESC,m_traveller.id LIMIT $5`)).
+			WithArgs("%e%", 5, 5, int64(4), 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "rarity"}).AddRow(2, "Shen", 4))
+
+		res, hasMore, err := s.repo.GetPage(context.TODO(), domain.ListTravellerRequest{Name: "e", Sort: sort}, &cursor, 2)
+		assert.NoError(s.T(), err)
+		assert.False(s.T(), hasMore)
+		assert.Len(s.T(), res, 1)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("after a cursor by job name", func() {
+		s.SetupTest()
+		job := travellerNameSorts["job"]
+		cursor, _ := helpers.NewCursor("job,id", false, constants.JobMerchant, int64(4))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE (((`+job+` > $1) OR (`+job+` = $2 AND m_traveller.id > $3))) AND "m_traveller"."deleted_at" IS NULL ORDER BY `+job+`,m_traveller.id LIMIT $4`)).
+			WithArgs(constants.JobMerchant, constants.JobMerchant, int64(4), 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "job_id"}).AddRow(2, "Shen", constants.JobThiefID))
+
+		res, _, err := s.repo.GetPage(context.TODO(), domain.ListTravellerRequest{Sort: []domain.SortField{{Field: "job"}}}, &cursor, 2)
+		assert.NoError(s.T(), err)
+		assert.Len(s.T(), res, 1)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("backward reads in reverse and restores list order", func() {
+		s.SetupTest()
+		cursor, _ := helpers.NewCursor("id", true, int64(10))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE ((m_traveller.id < $1)) AND "m_traveller"."deleted_at" IS NULL ORDER BY m_traveller.id DESC LIMIT $2`)).
+			WithArgs(int64(10), 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(9, "Viola").AddRow(8, "Fiore").AddRow(7, "Shen"))
+
+		res, hasMore, err := s.repo.GetPage(context.TODO(), domain.ListTravellerRequest{}, &cursor, 2)
+		assert.NoError(s.T(), err)
+		assert.True(s.T(), hasMore)
+		assert.Equal(s.T(), []int64{8, 9}, []int64{res[0].ID, res[1].ID})
+	})
+
+	s.Run("NULL release dates sort last in either direction", func() {
+		byReleaseDate := []domain.SortField{{Field: "release_date"}}
+		byReleaseDateDesc := []domain.SortField{{Field: "release_date", Desc: true}}
+		releaseDate := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+		columns := []string{"id", "name", "release_date"}
+
+		tests := []struct {
+			name     string
+			sort     []domain.SortField
+			cursor   []interface{}
+			backward bool
+			where    string
+			order    string
+			args     []driver.Value
+			rows     *sqlmock.Rows
+			ids      []int64
+		}{
+			{
+				name:   "forward from a date reaches the undated",
+				sort:   byReleaseDate,
+				cursor: []interface{}{releaseDate, int64(4)},
+				where:  `(((m_traveller.release_date > $1 OR m_traveller.release_date IS NULL)) OR (m_traveller.release_date = $2 AND m_traveller.id > $3))`,
+				order:  `m_traveller.release_date NULLS LAST,m_traveller.id`,
+				args:   []driver.Value{releaseDate, releaseDate, int64(4)},
+				rows:   sqlmock.NewRows(columns).AddRow(5, "Shen", releaseDate.AddDate(0, 1, 0)).AddRow(2, "Fiore", nil),
+				ids:    []int64{5, 2},
+			},
+			{
+				name:   "forward among the undated",
+				sort:   byReleaseDate,
+				cursor: []interface{}{nil, int64(2)},
+				where:  `((m_traveller.release_date IS NULL AND m_traveller.id > $1))`,
+				order:  `m_traveller.release_date NULLS LAST,m_traveller.id`,
+				args:   []driver.Value{int64(2)},
+				rows:   sqlmock.NewRows(columns).AddRow(6, "Lynette", nil),
+				ids:    []int64{6},
+			},
+			{
+				name:     "backward from the undated reaches the dated",
+				sort:     byReleaseDate,
+				cursor:   []interface{}{nil, int64(2)},
+				backward: true,
+				where:    `((m_traveller.release_date IS NOT NULL) OR (m_traveller.release_date IS NULL AND m_traveller.id < $1))`,
+				order:    `m_traveller.release_date DESC NULLS FIRST,m_traveller.id DESC`,
+				args:     []driver.Value{int64(2)},
+				rows:     sqlmock.NewRows(columns).AddRow(1, "Viola", nil).AddRow(5, "Shen", releaseDate),
+				ids:      []int64{5, 1},
+			},
+			{
+				name:   "descending forward from a date reaches the undated",
+				sort:   byReleaseDateDesc,
+				cursor: []interface{}{releaseDate, int64(4)},
+				where:  `(((m_traveller.release_date < $1 OR m_traveller.release_date IS NULL)) OR (m_traveller.release_date = $2 AND m_traveller.id > $3))`,
+				order:  `m_traveller.release_date DESC NULLS LAST,m_traveller.id`,
+				args:   []driver.Value{releaseDate, releaseDate, int64(4)},
+				rows:   sqlmock.NewRows(columns).AddRow(2, "Fiore", nil),
+				ids:    []int64{2},
+			},
+			{
+				name:     "descending backward from the undated reaches the dated",
+				sort:     byReleaseDateDesc,
+				cursor:   []interface{}{nil, int64(2)},
+				backward: true,
+				where:    `((m_traveller.release_date IS NOT NULL) OR (m_traveller.release_date IS NULL AND m_traveller.id < $1))`,
+				order:    `m_traveller.release_date NULLS FIRST,m_traveller.id DESC`,
+				args:     []driver.Value{int64(2)},
+				rows:     sqlmock.NewRows(columns).AddRow(4, "Tressa", releaseDate),
+				ids:      []int64{4},
+			},
+		}
+
+		for _, tt := range tests {
+			s.Run(tt.name, func() {
+				s.SetupTest()
+				_, order := travellerKeyset(tt.sort)
+				cursor, _ := helpers.NewCursor(order, tt.backward, tt.cursor...)
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE (` + tt.where + `) AND "m_traveller"."deleted_at" IS NULL ORDER BY ` + tt.order + ` LIMIT $`)).
+					WithArgs(append(tt.args, 3)...).
+					WillReturnRows(tt.rows)
+
+				res, _, err := s.repo.GetPage(context.TODO(), domain.ListTravellerRequest{Sort: tt.sort}, &cursor, 2)
+				assert.NoError(s.T(), err)
+				ids := make([]int64, len(res))
+				for i, traveller := range res {
+					ids[i] = traveller.ID
+				}
+				assert.Equal(s.T(), tt.ids, ids)
+				assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+			})
+		}
+	})
+
+	s.Run("undated travellers get a null cursor key", func() {
+		keys := travellerCursorKeys(&domain.Traveller{CommonModel: domain.CommonModel{ID: 2}}, []domain.SortField{{Field: "release_date"}})
+		cursor, err := helpers.NewCursor("release_date,id", false, keys...)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), "null", string(cursor.Keys[0]))
+	})
+
+	s.Run("cursor keys that don't fit the sort", func() {
+		s.SetupTest()
+		cursor, _ := helpers.NewCursor("rarity:desc,id", false, "five", int64(4))
+
+		_, _, err := s.repo.GetPage(context.TODO(), domain.ListTravellerRequest{Sort: sort}, &cursor, 2)
+		assert.ErrorIs(s.T(), err, helpers.ErrInvalidCursor)
+	})
+}
+
+func (s *TravellerRepositorySuite) TestTravellerRepository_Count() {
+	s.SetupTest()
+	s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "m_traveller" WHERE rarity >= $1 AND "m_traveller"."deleted_at" IS NULL`)).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	total, err := s.repo.Count(context.TODO(), domain.ListTravellerRequest{RarityMinValue: 4})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(7), total)
+}
+
+func (s *TravellerRepositorySuite) TestTravellerRepository_SuggestNames() {
+	s.Run("most similar first", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "name" FROM "m_traveller" WHERE $1 <% name AND "m_traveller"."deleted_at" IS NULL ORDER BY word_similarity($2, name) DESC, name LIMIT $3`)).
+			WithArgs("Fiorre", "Fiorre", 3).
+			WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("Fiore").AddRow("Fiona"))
+
+		res, err := s.repo.SuggestNames(context.TODO(), "Fiorre", 3)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), []string{"Fiore", "Fiona"}, res)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("database error", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "name" FROM "m_traveller"`)).WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := s.repo.SuggestNames(context.TODO(), "Fiorre", 3)
+		assert.Error(s.T(), err)
+	})
+}
+
+func (s *TravellerRepositorySuite) TestTravellerRepository_FindSimilarPairs() {
+	score := `similarity(a.name_key, b.name_key) * 70 + ((a.job_id <> 0 AND a.job_id = b.job_id)::int + (a.influence_id <> 0 AND a.influence_id = b.influence_id)::int + (a.rarity = b.rarity)::int + COALESCE(a.release_date = b.release_date, false)::int) * 30.0 / 4`
+
+	s.Run("every attribute the domain scores is matched in sql", func() {
+		for _, field := range domain.DuplicateAttributeFields() {
+			assert.Contains(s.T(), duplicateAttributeMatches, field)
+		}
+	})
+
+	s.Run("best scoring first, joined on the indexable similarity operator", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`SELECT set_config('pg_trgm.similarity_threshold', $1, true)`)).
+			WithArgs("0.4").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT a.id AS first_id, b.id AS second_id, similarity(a.name_key, b.name_key) AS name_similarity FROM m_traveller AS a JOIN m_traveller AS b ON a.id < b.id AND a.name_key % b.name_key AND b.deleted_at IS NULL WHERE a.deleted_at IS NULL AND `+score+` >= $1 ORDER BY `+score+` DESC, a.id, b.id LIMIT $2`)).
+			WithArgs(60.0, 20).
+			WillReturnRows(sqlmock.NewRows([]string{"first_id", "second_id", "name_similarity"}).AddRow(1, 4, 0.8).AddRow(2, 3, 0.5))
+		s.mock.ExpectCommit()
+
+		res, err := s.repo.FindSimilarPairs(context.TODO(), 0.4, 60, 20)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), []domain.SimilarPair{
+			{FirstID: 1, SecondID: 4, NameSimilarity: 0.8},
+			{FirstID: 2, SecondID: 3, NameSimilarity: 0.5},
+		}, res)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("database error", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`SELECT set_config(`)).WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT a.id AS first_id`)).WillReturnError(gorm.ErrInvalidDB)
+		s.mock.ExpectRollback()
+
+		_, err := s.repo.FindSimilarPairs(context.TODO(), 0.4, 60, 20)
+		assert.Error(s.T(), err)
+	})
+}
+
+func (s *TravellerRepositorySuite) TestTravellerRepository_GetByIDAsOf() {
+	at := time.Date(2024, 10, 1, 9, 0, 0, 0, time.UTC)
+	asOfTraveller := regexp.QuoteMeta(`SELECT * FROM (SELECT ids.id, `) + `.+` +
+		regexp.QuoteMeta(`) AS m_traveller WHERE m_traveller.id = $8 AND "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $9`)
+
+	s.Run("reads the traveller and its accessory as of the time", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(asOfTraveller).
+			WithArgs(domain.TrashTypeTraveller, domain.TrashTypeTraveller, at, domain.TrashTypeTraveller, at, at, at, 1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "rarity", "accessory_id"}).AddRow(1, "Fiore", 4, 9))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM (SELECT ids.id, `)+`.+`+
+			regexp.QuoteMeta(`) AS m_accessory WHERE "m_accessory"."id" = $8 AND "m_accessory"."deleted_at" IS NULL`)).
+			WithArgs(domain.TrashTypeAccessory, domain.TrashTypeAccessory, at, domain.TrashTypeAccessory, at, at, at, 9).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(9, "Old Ring"))
+
+		res, err := s.repo.GetByIDAsOf(context.TODO(), 1, at)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), 4, res.Rarity)
+		assert.Equal(s.T(), "Old Ring", res.Accessory.Name)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("not found when it didn't exist then", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(asOfTraveller).WillReturnError(gorm.ErrRecordNotFound)
+
+		_, err := s.repo.GetByIDAsOf(context.TODO(), 1, at)
+		var nfe *domain.NotFoundError
+		assert.True(s.T(), errors.As(err, &nfe))
+	})
+}
+
+func (s *TravellerRepositorySuite) TestTravellerRepository_GetList_AsOf() {
+	s.SetupTest()
+	at := time.Date(2024, 10, 1, 9, 0, 0, 0, time.UTC)
+	s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total, MAX(m_traveller.updated_at) AS last_modified FROM (SELECT ids.id, `)+`.+`+
+		regexp.QuoteMeta(`) AS m_traveller WHERE rarity >= $8 AND "m_traveller"."deleted_at" IS NULL`)).
+		WithArgs(domain.TrashTypeTraveller, domain.TrashTypeTraveller, at, domain.TrashTypeTraveller, at, at, at, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"total", "last_modified"}).AddRow(0, nil))
+	s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM (SELECT ids.id, `) + `.+` +
+		regexp.QuoteMeta(`) AS m_traveller WHERE rarity >= $8 AND "m_traveller"."deleted_at" IS NULL`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, total, _, err := s.repo.GetList(context.TODO(), domain.ListTravellerRequest{RarityMinValue: 5, AsOfTime: at}, 0, 10)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(0), total)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TravellerRepositorySuite) TestTravellerRepository_GetByIDs() {
+	query := regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE id IN ($1,$2,$3) AND "m_traveller"."deleted_at" IS NULL`)
+
+	s.Run("returns existing travellers", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(query).WithArgs(3, 1, 9).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Fiore").AddRow(3, "Viola"))
+
+		res, err := s.repo.GetByIDs(context.TODO(), []int{3, 1, 9}, domain.TravellerIncludeAll)
+		assert.NoError(s.T(), err)
+		assert.Len(s.T(), res, 2)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("database error", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(query).WithArgs(3, 1, 9).WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := s.repo.GetByIDs(context.TODO(), []int{3, 1, 9}, domain.TravellerIncludeAll)
+		assert.Error(s.T(), err)
+	})
+}
+
 func (s *TravellerRepositorySuite) TestTravellerRepository_GetList() {
 	tests := []struct {
 		name    string
@@ -104,6 +582,7 @@ func (s *TravellerRepositorySuite) TestTravellerRepository_GetList() {
 		limit   int
 		mockSet func()
 		wantTot int64
+		wantMod time.Time
 		wantLen int
 	}{
 		{
@@ -112,44 +591,169 @@ func (s *TravellerRepositorySuite) TestTravellerRepository_GetList() {
 			offset: 0,
 			limit:  10,
 			mockSet: func() {
-				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "m_traveller" WHERE "m_traveller"."deleted_at" IS NULL`)).
-					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total, MAX(m_traveller.updated_at) AS last_modified FROM "m_traveller" WHERE "m_traveller"."deleted_at" IS NULL`)).
+					WillReturnRows(sqlmock.NewRows([]string{"total", "last_modified"}).AddRow(2, time.Date(2026, 1, 27, 10, 0, 0, 0, time.UTC)))
 
 				date1 := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
 				date2 := time.Date(2023, 6, 20, 0, 0, 0, 0, time.UTC)
-				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."deleted_at" IS NULL LIMIT $1`)).
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $1`)).
 					WithArgs(10).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "rarity", "banner", "release_date"}).AddRow(1, "Fiore", 5, "General", date1).AddRow(2, "Shen", 4, "MT Orsterra", date2))
 			},
 			wantTot: 2,
+			wantMod: time.Date(2026, 1, 27, 10, 0, 0, 0, time.UTC),
 			wantLen: 2,
 		},
+		{
+			name:   "influence sorts by name, not ID",
+			filter: domain.ListTravellerRequest{Sort: []domain.SortField{{Field: "influence", Desc: true}}},
+			offset: 0,
+			limit:  10,
+			mockSet: func() {
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total, MAX(m_traveller.updated_at) AS last_modified FROM "m_traveller" WHERE "m_traveller"."deleted_at" IS NULL`)).
+					WillReturnRows(sqlmock.NewRows([]string{"total", "last_modified"}).AddRow(1, nil))
+
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."deleted_at" IS NULL ORDER BY ` + travellerNameSorts["influence"] + ` DESC,"m_traveller"."id" LIMIT $1`)).
+					WithArgs(10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "influence_id"}).AddRow(1, "Fiore", constants.InfluenceWealthID))
+			},
+			wantTot: 1,
+			wantLen: 1,
+		},
+		{
+			name:   "include accessory preloads it",
+			filter: domain.ListTravellerRequest{Relations: domain.TravellerIncludeAll},
+			offset: 0,
+			limit:  10,
+			mockSet: func() {
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total, MAX(m_traveller.updated_at) AS last_modified FROM "m_traveller" WHERE "m_traveller"."deleted_at" IS NULL`)).
+					WillReturnRows(sqlmock.NewRows([]string{"total", "last_modified"}).AddRow(1, nil))
+
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $1`)).
+					WithArgs(10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "accessory_id"}).AddRow(1, "Fiore", 3))
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_accessory" WHERE "m_accessory"."id" = $1 AND "m_accessory"."deleted_at" IS NULL`)).
+					WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "Crown of Wisdom"))
+			},
+			wantTot: 1,
+			wantLen: 1,
+		},
 		{
 			name: "with filters",
 			filter: domain.ListTravellerRequest{
-				Name:        "Fiore",
-				JobID:       1,
-				InfluenceID: 1,
+				Name:         "Fiore",
+				JobIDs:       []int{1},
+				InfluenceIDs: []int{1},
 			},
 			offset: 0,
 			limit:  10,
 			mockSet: func() {
-				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "m_traveller" WHERE LOWER(name) LIKE LOWER($1) AND influence_id = $2 AND job_id = $3 AND "m_traveller"."deleted_at" IS NULL`)).
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total, MAX(m_traveller.updated_at) AS last_modified FROM "m_traveller" WHERE LOWER(name) LIKE LOWER($1) AND influence_id IN ($2) AND job_id IN ($3) AND "m_traveller"."deleted_at" IS NULL`)).
 					WithArgs("%Fiore%", 1, 1).
-					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+					WillReturnRows(sqlmock.NewRows([]string{"total", "last_modified"}).AddRow(1, nil))
 
 				releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
-				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE LOWER(name) LIKE LOWER($1) AND influence_id = $2 AND job_id = $3 AND "m_traveller"."deleted_at" IS NULL LIMIT $4`)).
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE LOWER(name) LIKE LOWER($1) AND influence_id IN ($2) AND job_id IN ($3) AND "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $4`)).
 					WithArgs("%Fiore%", 1, 1, 10).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "rarity", "banner", "release_date", "job_id", "influence_id", "accessory_id"}).AddRow(1, "Fiore", 5, "General", releaseDate, 1, 1, 0))
+			},
+			wantTot: 1,
+			wantLen: 1,
+		},
+		{
+			name: "with range, multi-value and presence filters",
+			filter: domain.ListTravellerRequest{
+				Banner:             "Standard",
+				InfluenceIDs:       []int{1, 2},
+				JobIDs:             []int{3, 8},
+				RarityMinValue:     4,
+				RarityMaxValue:     5,
+				ReleasedAfterDate:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				ReleasedBeforeDate: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+				HasAccessoryValue:  func() *bool { b := true; return &b }(),
+			},
+			offset: 0,
+			limit:  10,
+			mockSet: func() {
+				after := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+				before := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+				where := `WHERE LOWER(banner) LIKE LOWER($1) AND influence_id IN ($2,$3) AND job_id IN ($4,$5) AND rarity >= $6 AND rarity <= $7 AND release_date >= $8 AND release_date <= $9 AND accessory_id IS NOT NULL AND "m_traveller"."deleted_at" IS NULL`
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total, MAX(m_traveller.updated_at) AS last_modified FROM "m_traveller" `+where)).
+					WithArgs("%Standard%", 1, 2, 3, 8, 4, 5, after, before).
+					WillReturnRows(sqlmock.NewRows([]string{"total", "last_modified"}).AddRow(1, nil))
 
-				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_accessory" WHERE "m_accessory"."id" = $1 AND "m_accessory"."deleted_at" IS NULL`)).
-					WithArgs(0).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" `+where+` ORDER BY "m_traveller"."id" LIMIT $10`)).
+					WithArgs("%Standard%", 1, 2, 3, 8, 4, 5, after, before, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "rarity", "banner", "release_date", "job_id", "influence_id", "accessory_id"}).AddRow(1, "Fiore", 5, "Standard Banner", after, 3, 1, 7))
 			},
 			wantTot: 1,
 			wantLen: 1,
 		},
+		{
+			name: "without accessory",
+			filter: domain.ListTravellerRequest{
+				HasAccessoryValue: func() *bool { b := false; return &b }(),
+			},
+			offset: 0,
+			limit:  10,
+			mockSet: func() {
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total, MAX(m_traveller.updated_at) AS last_modified FROM "m_traveller" WHERE accessory_id IS NULL AND "m_traveller"."deleted_at" IS NULL`)).
+					WillReturnRows(sqlmock.NewRows([]string{"total", "last_modified"}).AddRow(0, nil))
+
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE accessory_id IS NULL AND "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $1`)).
+					WithArgs(10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+			},
+			wantTot: 0,
+			wantLen: 0,
+		},
+		{
+			name: "with filter expression and literal wildcards",
+			filter: domain.ListTravellerRequest{
+				Name: `50%_\`,
+				FilterCondition: func() *filterexpr.Condition {
+					cond, _ := travellerFilterSchema.Compile(`rarity>=4 or job in (Warrior,Dancer)`)
+					return cond
+				}(),
+			},
+			offset: 0,
+			limit:  10,
+			mockSet: func() {
+				where := `WHERE LOWER(name) LIKE LOWER($1) AND ((m_traveller.rarity >= $2 OR m_traveller.job_id IN ($3,$4))) AND "m_traveller"."deleted_at" IS NULL`
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total, MAX(m_traveller.updated_at) AS last_modified FROM "m_traveller" `+where)).
+					WithArgs(`%50\%\_\\%`, 4, 1, 8).
+					WillReturnRows(sqlmock.NewRows([]string{"total", "last_modified"}).AddRow(0, nil))
+
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" `+where+` ORDER BY "m_traveller"."id" LIMIT $5`)).
+					WithArgs(`%50\%\_\\%`, 4, 1, 8, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+			},
+			wantTot: 0,
+			wantLen: 0,
+		},
+		{
+			name: "with multiple sort keys",
+			filter: domain.ListTravellerRequest{
+				Sort: []domain.SortField{
+					{Field: "rarity", Desc: true},
+					{Field: "release_date"},
+				},
+			},
+			offset: 10,
+			limit:  10,
+			mockSet: func() {
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total, MAX(m_traveller.updated_at) AS last_modified FROM "m_traveller" WHERE "m_traveller"."deleted_at" IS NULL`)).
+					WillReturnRows(sqlmock.NewRows([]string{"total", "last_modified"}).AddRow(11, nil))
+
+				releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."rarity" DESC,"m_traveller"."release_date" NULLS LAST,"m_traveller"."id" LIMIT $1 OFFSET $2`)).
+					WithArgs(10, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "rarity", "banner", "release_date"}).AddRow(11, "Fiore", 5, "General", releaseDate))
+			},
+			wantTot: 11,
+			wantLen: 1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -157,19 +761,21 @@ func (s *TravellerRepositorySuite) TestTravellerRepository_GetList() {
 			s.SetupTest()
 			tt.mockSet()
 
-			result, total, err := s.repo.GetList(context.TODO(), tt.filter, tt.offset, tt.limit)
+			result, total, lastModified, err := s.repo.GetList(context.TODO(), tt.filter, tt.offset, tt.limit)
 			assert.NoError(s.T(), err)
+			assert.NoError(s.T(), s.mock.ExpectationsWereMet())
 			assert.Equal(s.T(), tt.wantTot, total)
+			assert.True(s.T(), tt.wantMod.Equal(lastModified), "last modified %v, want %v", lastModified, tt.wantMod)
 			assert.Equal(s.T(), tt.wantLen, len(result))
 
 			if tt.wantLen > 0 {
 				for i := 0; i < tt.wantLen; i++ {
 					assert.Equal(s.T(), tt.filter.Name == "" || regexp.MustCompile("(?i)"+tt.filter.Name).MatchString(result[i].Name), true)
-					if tt.filter.InfluenceID != 0 {
-						assert.Equal(s.T(), result[i].InfluenceID, tt.filter.InfluenceID)
+					if len(tt.filter.InfluenceIDs) > 0 {
+						assert.Contains(s.T(), tt.filter.InfluenceIDs, result[i].InfluenceID)
 					}
-					if tt.filter.JobID != 0 {
-						assert.Equal(s.T(), result[i].JobID, tt.filter.JobID)
+					if len(tt.filter.JobIDs) > 0 {
+						assert.Contains(s.T(), tt.filter.JobIDs, result[i].JobID)
 					}
 				}
 			}
@@ -196,9 +802,12 @@ func (s *TravellerRepositorySuite) TestTravellerRepository_Create() {
 			mockSet: func() {
 				releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
 				t := &domain.Traveller{Name: "Fiore", Rarity: 5, Banner: "General", ReleaseDate: releaseDate, CommonModel: domain.CommonModel{CreatedAt: timeNow, UpdatedAt: timeNow}}
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "slug" FROM "m_traveller" WHERE (slug = $1 OR slug LIKE $2) AND id <> $3`)).
+					WithArgs("fiore", "fiore-%", 0).
+					WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("fiore"))
 				s.mock.ExpectBegin()
-				s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_traveller" ("created_by","updated_by","deleted_by","created_at","updated_at","deleted_at","name","rarity","banner","release_date","influence_id","job_id","accessory_id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13) RETURNING "id"`)).
-					WithArgs(t.CreatedBy, t.UpdatedBy, t.DeletedBy, t.CreatedAt, t.UpdatedAt, t.DeletedAt, t.Name, t.Rarity, t.Banner, t.ReleaseDate, t.InfluenceID, t.JobID, t.AccessoryID).
+				s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_traveller" ("created_by","updated_by","deleted_by","created_at","updated_at","version","deleted_at","name","name_key","slug","rarity","banner","release_date","influence_id","job_id","accessory_id","status","publish_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18) RETURNING "id"`)).
+					WithArgs(t.CreatedBy, t.UpdatedBy, t.DeletedBy, t.CreatedAt, t.UpdatedAt, int64(1), t.DeletedAt, t.Name, "fiore", "fiore-2", t.Rarity, t.Banner, t.ReleaseDate, t.InfluenceID, t.JobID, t.AccessoryID, domain.PublishStatusPublished, t.PublishAt).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 				s.mock.ExpectCommit()
 			},
@@ -213,18 +822,76 @@ func (s *TravellerRepositorySuite) TestTravellerRepository_Create() {
 			mockSet: func() {
 				releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
 				t := &domain.Traveller{Name: "Fiore", Rarity: 5, Banner: "General", ReleaseDate: releaseDate, CommonModel: domain.CommonModel{CreatedAt: timeNow, UpdatedAt: timeNow}}
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "slug" FROM "m_traveller" WHERE (slug = $1 OR slug LIKE $2) AND id <> $3`)).
+					WithArgs("fiore", "fiore-%", 0).
+					WillReturnRows(sqlmock.NewRows([]string{"slug"}))
 				s.mock.ExpectBegin()
-				s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_traveller" ("created_by","updated_by","deleted_by","created_at","updated_at","deleted_at","name","rarity","banner","release_date","influence_id","job_id","accessory_id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13) RETURNING "id"`)).
-					WithArgs(t.CreatedBy, t.UpdatedBy, t.DeletedBy, t.CreatedAt, t.UpdatedAt, t.DeletedAt, t.Name, t.Rarity, t.Banner, t.ReleaseDate, t.InfluenceID, t.JobID, t.AccessoryID).
-					WillReturnError(gorm.ErrDuplicatedKey)
+				s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_traveller" ("created_by","updated_by","deleted_by","created_at","updated_at","version","deleted_at","name","name_key","slug","rarity","banner","release_date","influence_id","job_id","accessory_id","status","publish_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18) RETURNING "id"`)).
+					WithArgs(t.CreatedBy, t.UpdatedBy, t.DeletedBy, t.CreatedAt, t.UpdatedAt, int64(1), t.DeletedAt, t.Name, "fiore", "fiore", t.Rarity, t.Banner, t.ReleaseDate, t.InfluenceID, t.JobID, t.AccessoryID, domain.PublishStatusPublished, t.PublishAt).
+					WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: travellerNameIndex})
 				s.mock.ExpectRollback()
 			},
 			wantErr: true,
 			checkFn: func(t *testing.T, err error) {
 				var ce *domain.ConflictError
 				assert.True(t, errors.As(err, &ce), "expected ConflictError")
+				assert.Equal(t, "traveller with this name already exists", ce.Message)
 			},
 		},
+		{
+			name: "duplicate on another index is still a conflict",
+			traveller: func() *domain.Traveller {
+				releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
+				return &domain.Traveller{Name: "Fiore", Rarity: 5, Banner: "General", ReleaseDate: releaseDate, CommonModel: domain.CommonModel{CreatedAt: timeNow, UpdatedAt: timeNow}}
+			}(),
+			mockSet: func() {
+				releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
+				t := &domain.Traveller{Name: "Fiore", Rarity: 5, Banner: "General", ReleaseDate: releaseDate, CommonModel: domain.CommonModel{CreatedAt: timeNow, UpdatedAt: timeNow}}
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "slug" FROM "m_traveller" WHERE (slug = $1 OR slug LIKE $2) AND id <> $3`)).
+					WithArgs("fiore", "fiore-%", 0).
+					WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+				s.mock.ExpectBegin()
+				s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_traveller" ("created_by","updated_by","deleted_by","created_at","updated_at","version","deleted_at","name","name_key","slug","rarity","banner","release_date","influence_id","job_id","accessory_id","status","publish_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18) RETURNING "id"`)).
+					WithArgs(t.CreatedBy, t.UpdatedBy, t.DeletedBy, t.CreatedAt, t.UpdatedAt, int64(1), t.DeletedAt, t.Name, "fiore", "fiore", t.Rarity, t.Banner, t.ReleaseDate, t.InfluenceID, t.JobID, t.AccessoryID, domain.PublishStatusPublished, t.PublishAt).
+					WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "m_traveller_renamed_idx"})
+				s.mock.ExpectRollback()
+			},
+			wantErr: true,
+			checkFn: func(t *testing.T, err error) {
+				var ce *domain.ConflictError
+				assert.True(t, errors.As(err, &ce), "expected ConflictError")
+				assert.Equal(t, "traveller conflicts with an existing one", ce.Message)
+			},
+		},
+		{
+			name: "slug taken concurrently is retried with the next one",
+			traveller: func() *domain.Traveller {
+				releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
+				return &domain.Traveller{Name: "Fiore", Rarity: 5, Banner: "General", ReleaseDate: releaseDate, CommonModel: domain.CommonModel{CreatedAt: timeNow, UpdatedAt: timeNow}}
+			}(),
+			mockSet: func() {
+				releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
+				t := &domain.Traveller{Name: "Fiore", Rarity: 5, Banner: "General", ReleaseDate: releaseDate, CommonModel: domain.CommonModel{CreatedAt: timeNow, UpdatedAt: timeNow}}
+				insert := regexp.QuoteMeta(`INSERT INTO "m_traveller" ("created_by","updated_by","deleted_by","created_at","updated_at","version","deleted_at","name","name_key","slug","rarity","banner","release_date","influence_id","job_id","accessory_id","status","publish_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18) RETURNING "id"`)
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "slug" FROM "m_traveller" WHERE (slug = $1 OR slug LIKE $2) AND id <> $3`)).
+					WithArgs("fiore", "fiore-%", 0).
+					WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+				s.mock.ExpectBegin()
+				s.mock.ExpectQuery(insert).
+					WithArgs(t.CreatedBy, t.UpdatedBy, t.DeletedBy, t.CreatedAt, t.UpdatedAt, int64(1), t.DeletedAt, t.Name, "fiore", "fiore", t.Rarity, t.Banner, t.ReleaseDate, t.InfluenceID, t.JobID, t.AccessoryID, domain.PublishStatusPublished, t.PublishAt).
+					WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: helpers.SlugIndex("m_traveller")})
+				s.mock.ExpectRollback()
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "slug" FROM "m_traveller" WHERE (slug = $1 OR slug LIKE $2) AND id <> $3`)).
+					WithArgs("fiore", "fiore-%", 0).
+					WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("fiore"))
+				s.mock.ExpectBegin()
+				s.mock.ExpectQuery(insert).
+					WithArgs(t.CreatedBy, t.UpdatedBy, t.DeletedBy, t.CreatedAt, t.UpdatedAt, int64(1), t.DeletedAt, t.Name, "fiore", "fiore-2", t.Rarity, t.Banner, t.ReleaseDate, t.InfluenceID, t.JobID, t.AccessoryID, domain.PublishStatusPublished, t.PublishAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				s.mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -240,6 +907,7 @@ func (s *TravellerRepositorySuite) TestTravellerRepository_Create() {
 				return
 			}
 			assert.NoError(s.T(), err)
+			assert.NoError(s.T(), s.mock.ExpectationsWereMet())
 		})
 	}
 }
@@ -261,8 +929,11 @@ func (s *TravellerRepositorySuite) TestTravellerRepository_Update() {
 			mockSet: func() {
 				releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
 				t := &domain.Traveller{Name: "Fiore", Rarity: 5, Banner: "General", ReleaseDate: releaseDate, CommonModel: domain.CommonModel{ID: int64(1)}}
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "name","slug" FROM "m_traveller" WHERE id = $1 LIMIT $2`)).
+					WithArgs(t.ID, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"name", "slug"}).AddRow("Fiore", "fiore"))
 				s.mock.ExpectBegin()
-				s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "updated_at"=$1,"name"=$2,"rarity"=$3,"banner"=$4,"release_date"=$5 WHERE "m_traveller"."deleted_at" IS NULL AND "id" = $6`)).WithArgs(helpers.AnyTime{}, t.Name, t.Rarity, t.Banner, t.ReleaseDate, t.ID).
+				s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "banner"=$1,"name"=$2,"name_key"=$3,"rarity"=$4,"release_date"=$5,"slug"=$6,"version"=version + 1,"updated_at"=$7 WHERE id = $8 AND "m_traveller"."deleted_at" IS NULL`)).WithArgs(t.Banner, t.Name, "fiore", t.Rarity, t.ReleaseDate, "fiore", helpers.AnyTime{}, t.ID).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 				s.mock.ExpectCommit()
 			},
@@ -277,8 +948,14 @@ func (s *TravellerRepositorySuite) TestTravellerRepository_Update() {
 			mockSet: func() {
 				releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
 				t := &domain.Traveller{Name: "Fiore", Rarity: 5, Banner: "General", ReleaseDate: releaseDate, CommonModel: domain.CommonModel{ID: int64(999)}}
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "name","slug" FROM "m_traveller" WHERE id = $1 LIMIT $2`)).
+					WithArgs(t.ID, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"name", "slug"}))
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "slug" FROM "m_traveller" WHERE (slug = $1 OR slug LIKE $2) AND id <> $3`)).
+					WithArgs("fiore", "fiore-%", t.ID).
+					WillReturnRows(sqlmock.NewRows([]string{"slug"}))
 				s.mock.ExpectBegin()
-				s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "updated_at"=$1,"name"=$2,"rarity"=$3,"banner"=$4,"release_date"=$5 WHERE "m_traveller"."deleted_at" IS NULL AND "id" = $6`)).WithArgs(helpers.AnyTime{}, t.Name, t.Rarity, t.Banner, t.ReleaseDate, t.ID).
+				s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "banner"=$1,"name"=$2,"name_key"=$3,"rarity"=$4,"release_date"=$5,"slug"=$6,"version"=version + 1,"updated_at"=$7 WHERE id = $8 AND "m_traveller"."deleted_at" IS NULL`)).WithArgs(t.Banner, t.Name, "fiore", t.Rarity, t.ReleaseDate, "fiore", helpers.AnyTime{}, t.ID).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 				s.mock.ExpectCommit()
 			},
@@ -288,6 +965,27 @@ func (s *TravellerRepositorySuite) TestTravellerRepository_Update() {
 				assert.True(t, errors.As(err, &nfe), "expected NotFoundError")
 			},
 		},
+		{
+			name: "stale version",
+			traveller: func() *domain.Traveller {
+				return &domain.Traveller{Name: "Fiore", Rarity: 5, CommonModel: domain.CommonModel{ID: int64(1), Version: 3}}
+			}(),
+			mockSet: func() {
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "name","slug" FROM "m_traveller" WHERE id = $1 LIMIT $2`)).
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"name", "slug"}).AddRow("Fiore", "fiore"))
+				s.mock.ExpectBegin()
+				s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "name"=$1,"name_key"=$2,"rarity"=$3,"slug"=$4,"version"=version + 1,"updated_at"=$5 WHERE id = $6 AND version = $7 AND "m_traveller"."deleted_at" IS NULL`)).
+					WithArgs("Fiore", "fiore", 5, "fiore", helpers.AnyTime{}, 1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				s.mock.ExpectCommit()
+			},
+			wantErr: true,
+			checkFn: func(t *testing.T, err error) {
+				var pfe *domain.PreconditionFailedError
+				assert.True(t, errors.As(err, &pfe), "expected PreconditionFailedError")
+			},
+		},
 		{
 			name: "duplicate name error",
 			traveller: func() *domain.Traveller {
@@ -297,15 +995,22 @@ func (s *TravellerRepositorySuite) TestTravellerRepository_Update() {
 			mockSet: func() {
 				releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
 				t := &domain.Traveller{Name: "Fiore", Rarity: 5, Banner: "General", ReleaseDate: releaseDate, CommonModel: domain.CommonModel{ID: int64(1)}}
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "name","slug" FROM "m_traveller" WHERE id = $1 LIMIT $2`)).
+					WithArgs(t.ID, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"name", "slug"}).AddRow("Fior", "fior"))
+				s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "slug" FROM "m_traveller" WHERE (slug = $1 OR slug LIKE $2) AND id <> $3`)).
+					WithArgs("fiore", "fiore-%", t.ID).
+					WillReturnRows(sqlmock.NewRows([]string{"slug"}))
 				s.mock.ExpectBegin()
-				s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "updated_at"=$1,"name"=$2,"rarity"=$3,"banner"=$4,"release_date"=$5 WHERE "m_traveller"."deleted_at" IS NULL AND "id" = $6`)).WithArgs(helpers.AnyTime{}, t.Name, t.Rarity, t.Banner, t.ReleaseDate, t.ID).
-					WillReturnError(gorm.ErrDuplicatedKey)
+				s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "banner"=$1,"name"=$2,"name_key"=$3,"rarity"=$4,"release_date"=$5,"slug"=$6,"version"=version + 1,"updated_at"=$7 WHERE id = $8 AND "m_traveller"."deleted_at" IS NULL`)).WithArgs(t.Banner, t.Name, "fiore", t.Rarity, t.ReleaseDate, "fiore", helpers.AnyTime{}, t.ID).
+					WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: travellerNameIndex})
 				s.mock.ExpectRollback()
 			},
 			wantErr: true,
 			checkFn: func(t *testing.T, err error) {
 				var ce *domain.ConflictError
 				assert.True(t, errors.As(err, &ce), "expected ConflictError")
+				assert.Equal(t, "traveller with this name already exists", ce.Message)
 			},
 		},
 	}
@@ -327,6 +1032,243 @@ func (s *TravellerRepositorySuite) TestTravellerRepository_Update() {
 	}
 }
 
+func (s *TravellerRepositorySuite) TestTravellerRepository_PatchTravellerWithAccessory() {
+	selectExisting := regexp.QuoteMeta(`SELECT "id","accessory_id","version" FROM "m_traveller" WHERE "m_traveller"."id" = $1 AND "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $2`)
+	selectSlug := regexp.QuoteMeta(`SELECT "name","slug" FROM "m_traveller" WHERE id = $1 LIMIT $2`)
+	updateTraveller := regexp.QuoteMeta(`UPDATE "m_traveller" SET "accessory_id"=$1,"banner"=$2,"influence_id"=$3,"job_id"=$4,"name"=$5,"name_key"=$6,"rarity"=$7,"release_date"=$8,"slug"=$9,"version"=version + 1,"updated_at"=$10 WHERE id = $11 AND "m_traveller"."deleted_at" IS NULL`)
+	reloadTraveller := regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."id" = $1 AND "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $2`)
+
+	s.Run("clears fields and unlinks accessory", func() {
+		s.SetupTest()
+		traveller := &domain.Traveller{Name: "Fiore", Rarity: 4, InfluenceID: 2, JobID: 2}
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(selectExisting).WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "accessory_id", "version"}).AddRow(1, 3, 2))
+		s.mock.ExpectQuery(selectSlug).WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "slug"}).AddRow("Fiore", "fiore"))
+		s.mock.ExpectExec(updateTraveller).
+			WithArgs(nil, "", 2, 2, "Fiore", "fiore", 4, nil, "fiore", helpers.AnyTime{}, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(reloadTraveller).WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug", "rarity", "influence_id", "job_id", "accessory_id", "version"}).
+				AddRow(1, "Fiore", "fiore", 4, 2, 2, nil, 3))
+		s.mock.ExpectCommit()
+
+		err := s.repo.PatchTravellerWithAccessory(context.TODO(), 1, traveller, nil)
+		assert.NoError(s.T(), err)
+		assert.Nil(s.T(), traveller.AccessoryID)
+		assert.Equal(s.T(), int64(1), traveller.ID)
+		assert.Equal(s.T(), int64(3), traveller.Version)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("updates linked accessory", func() {
+		s.SetupTest()
+		releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
+		traveller := &domain.Traveller{Name: "Fiore", Rarity: 4, Banner: "General", ReleaseDate: releaseDate, InfluenceID: 2, JobID: 2}
+		accessory := &domain.Accessory{Name: "Crown of Wisdom", HP: 200}
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(selectExisting).WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "accessory_id", "version"}).AddRow(1, 3, 2))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "name","slug" FROM "m_accessory" WHERE id = $1 LIMIT $2`)).WithArgs(3, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "slug"}).AddRow("Crown of Wisdom", "crown-of-wisdom"))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_accessory" SET`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(selectSlug).WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "slug"}).AddRow("Fiore", "fiore"))
+		s.mock.ExpectExec(updateTraveller).
+			WithArgs(3, "General", 2, 2, "Fiore", "fiore", 4, releaseDate, "fiore", helpers.AnyTime{}, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(reloadTraveller).WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug", "rarity", "influence_id", "job_id", "accessory_id", "version"}).
+				AddRow(1, "Fiore", "fiore", 4, 2, 2, 3, 3))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_accessory" WHERE "m_accessory"."id" = $1 AND "m_accessory"."deleted_at" IS NULL`)).WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "hp", "version"}).AddRow(3, "Crown of Wisdom", 200, 2))
+		s.mock.ExpectCommit()
+
+		err := s.repo.PatchTravellerWithAccessory(context.TODO(), 1, traveller, accessory)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), 3, *traveller.AccessoryID)
+		assert.Equal(s.T(), int64(3), traveller.Version)
+		assert.Equal(s.T(), 200, traveller.Accessory.HP)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("stale version rejected before writing", func() {
+		s.SetupTest()
+		traveller := &domain.Traveller{Name: "Fiore", Rarity: 4, InfluenceID: 2, JobID: 2, CommonModel: domain.CommonModel{Version: 1}}
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(selectExisting).WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "accessory_id", "version"}).AddRow(1, 3, 2))
+		s.mock.ExpectRollback()
+
+		err := s.repo.PatchTravellerWithAccessory(context.TODO(), 1, traveller, &domain.Accessory{Name: "Crown of Wisdom"})
+		var pfe *domain.PreconditionFailedError
+		assert.True(s.T(), errors.As(err, &pfe), "expected PreconditionFailedError")
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("concurrent write loses the version guard", func() {
+		s.SetupTest()
+		traveller := &domain.Traveller{Name: "Fiore", Rarity: 4, InfluenceID: 2, JobID: 2, CommonModel: domain.CommonModel{Version: 2}}
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(selectExisting).WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "accessory_id", "version"}).AddRow(1, nil, 2))
+		s.mock.ExpectQuery(selectSlug).WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "slug"}).AddRow("Fiore", "fiore"))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET`)+`.* WHERE id = \$11 AND version = \$12`).
+			WithArgs(nil, "", 2, 2, "Fiore", "fiore", 4, nil, "fiore", helpers.AnyTime{}, 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectRollback()
+
+		err := s.repo.PatchTravellerWithAccessory(context.TODO(), 1, traveller, nil)
+		var pfe *domain.PreconditionFailedError
+		assert.True(s.T(), errors.As(err, &pfe), "expected PreconditionFailedError")
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("not found", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(selectExisting).WithArgs(999, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "accessory_id"}))
+		s.mock.ExpectRollback()
+
+		err := s.repo.PatchTravellerWithAccessory(context.TODO(), 999, &domain.Traveller{Name: "Fiore"}, nil)
+		var nfe *domain.NotFoundError
+		assert.True(s.T(), errors.As(err, &nfe), "expected NotFoundError")
+	})
+}
+
+func (s *TravellerRepositorySuite) TestTravellerRepository_UpsertTravellerWithAccessory() {
+	key := domain.ExternalKey{Source: "cotc-wiki", ExternalID: "viola-5"}
+	claim := regexp.QuoteMeta(`INSERT INTO "m_external_id" ("entity_type","entity_id","source","external_id","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6) ON CONFLICT ("entity_type","source","external_id") DO UPDATE SET "updated_at"="excluded"."updated_at" RETURNING "id","entity_id"`)
+	mapID := regexp.QuoteMeta(`INSERT INTO "m_external_id" ("entity_type","entity_id","source","external_id","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6) ON CONFLICT ("entity_type","source","external_id") DO UPDATE SET "entity_id"="excluded"."entity_id","updated_at"="excluded"."updated_at" RETURNING "id"`)
+	travellerSlugs := regexp.QuoteMeta(`SELECT "slug" FROM "m_traveller" WHERE (slug = $1 OR slug LIKE $2) AND id <> $3`)
+	insertByName := regexp.QuoteMeta(`INSERT INTO "m_traveller"`) + `.*` + regexp.QuoteMeta(`ON CONFLICT ("name_key") WHERE deleted_at IS NULL DO NOTHING RETURNING "id"`)
+	selectExisting := regexp.QuoteMeta(`SELECT "id","accessory_id","version" FROM "m_traveller" WHERE "m_traveller"."id" = $1 AND "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $2`)
+	selectSlug := regexp.QuoteMeta(`SELECT "name","slug" FROM "m_traveller" WHERE id = $1 LIMIT $2`)
+	updateTraveller := regexp.QuoteMeta(`UPDATE "m_traveller" SET`)
+	reloadTraveller := regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."id" = $1 AND "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $2`)
+	travellerColumns := []string{"id", "name", "slug", "rarity", "influence_id", "job_id", "accessory_id", "version"}
+
+	s.Run("new id creates the traveller and its accessory", func() {
+		s.SetupTest()
+		traveller := &domain.Traveller{Name: "Viola", Rarity: 5, InfluenceID: 1, JobID: 1}
+		accessory := &domain.Accessory{Name: "Crown of Wisdom", HP: 150}
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(claim).WithArgs(domain.ExternalEntityTraveller, nil, "cotc-wiki", "viola-5", helpers.AnyTime{}, helpers.AnyTime{}).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "entity_id"}).AddRow(11, nil))
+		s.mock.ExpectQuery(travellerSlugs).WithArgs("viola", "viola-%", 0).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		s.mock.ExpectQuery(insertByName).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "slug" FROM "m_accessory"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_accessory"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "accessory_id"=$1 WHERE id = $2 AND "m_traveller"."deleted_at" IS NULL`)).
+			WithArgs(3, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(mapID).WithArgs(domain.ExternalEntityTraveller, 7, "cotc-wiki", "viola-5", helpers.AnyTime{}, helpers.AnyTime{}).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+		s.mock.ExpectQuery(reloadTraveller).WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(7, "Viola", "viola", 5, 1, 1, 3, 1))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_accessory" WHERE "m_accessory"."id" = $1`)).WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "hp", "version"}).AddRow(3, "Crown of Wisdom", 150, 1))
+		s.mock.ExpectQuery(mapID).WithArgs(domain.ExternalEntityAccessory, 3, "cotc-wiki", "viola-5", helpers.AnyTime{}, helpers.AnyTime{}).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+		s.mock.ExpectCommit()
+
+		created, err := s.repo.UpsertTravellerWithAccessory(context.TODO(), key, traveller, accessory)
+		assert.NoError(s.T(), err)
+		assert.True(s.T(), created)
+		assert.Equal(s.T(), int64(7), traveller.ID)
+		assert.Equal(s.T(), "Crown of Wisdom", traveller.Accessory.Name)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("mapped id updates its traveller", func() {
+		s.SetupTest()
+		traveller := &domain.Traveller{Name: "Viola", Rarity: 5, InfluenceID: 1, JobID: 1}
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(claim).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "entity_id"}).AddRow(11, 7))
+		s.mock.ExpectQuery(selectExisting).WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "accessory_id", "version"}).AddRow(7, nil, 2))
+		s.mock.ExpectQuery(selectSlug).WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "slug"}).AddRow("Viola", "viola"))
+		s.mock.ExpectExec(updateTraveller).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(reloadTraveller).WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(7, "Viola", "viola", 5, 1, 1, nil, 3))
+		s.mock.ExpectCommit()
+
+		created, err := s.repo.UpsertTravellerWithAccessory(context.TODO(), key, traveller, nil)
+		assert.NoError(s.T(), err)
+		assert.False(s.T(), created)
+		assert.Equal(s.T(), int64(3), traveller.Version)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("new id adopts the traveller with the same name", func() {
+		s.SetupTest()
+		traveller := &domain.Traveller{Name: "Viola", Rarity: 5, InfluenceID: 1, JobID: 1}
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(claim).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "entity_id"}).AddRow(11, nil))
+		s.mock.ExpectQuery(travellerSlugs).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("viola"))
+		s.mock.ExpectQuery(insertByName).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "m_traveller" WHERE name_key = $1 AND "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $2`)).WithArgs("viola", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		s.mock.ExpectQuery(selectExisting).WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "accessory_id", "version"}).AddRow(7, nil, 2))
+		s.mock.ExpectQuery(selectSlug).WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "slug"}).AddRow("Viola", "viola"))
+		s.mock.ExpectExec(updateTraveller).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(mapID).WithArgs(domain.ExternalEntityTraveller, 7, "cotc-wiki", "viola-5", helpers.AnyTime{}, helpers.AnyTime{}).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+		s.mock.ExpectQuery(reloadTraveller).WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(7, "Viola", "viola", 5, 1, 1, nil, 3))
+		s.mock.ExpectCommit()
+
+		created, err := s.repo.UpsertTravellerWithAccessory(context.TODO(), key, traveller, nil)
+		assert.NoError(s.T(), err)
+		assert.False(s.T(), created)
+		assert.Equal(s.T(), int64(7), traveller.ID)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("If-Match fails when the traveller would be created", func() {
+		s.SetupTest()
+		traveller := &domain.Traveller{Name: "Viola", Rarity: 5, InfluenceID: 1, JobID: 1, CommonModel: domain.CommonModel{Version: 2}}
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(claim).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "entity_id"}).AddRow(11, nil))
+		s.mock.ExpectQuery(travellerSlugs).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		s.mock.ExpectQuery(insertByName).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		s.mock.ExpectRollback()
+
+		created, err := s.repo.UpsertTravellerWithAccessory(context.TODO(), key, traveller, nil)
+		var pfe *domain.PreconditionFailedError
+		assert.True(s.T(), errors.As(err, &pfe), "expected PreconditionFailedError")
+		assert.False(s.T(), created)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
+
 func (s *TravellerRepositorySuite) TestTravellerRepository_Delete() {
 	tests := []struct {
 		name    string
@@ -379,3 +1321,381 @@ func (s *TravellerRepositorySuite) TestTravellerRepository_Delete() {
 		})
 	}
 }
+
+func (s *TravellerRepositorySuite) TestTravellerRepository_MergeTravellers() {
+	lockBoth := regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE id IN ($1,$2) AND "m_traveller"."deleted_at" IS NULL ORDER BY id FOR UPDATE`)
+	unlinkAccessory := regexp.QuoteMeta(`UPDATE "m_traveller" SET "accessory_id"=$1 WHERE id = $2 AND "m_traveller"."deleted_at" IS NULL`)
+	deleteSource := regexp.QuoteMeta(`UPDATE "m_traveller" SET "deleted_at"=$1 WHERE "m_traveller"."id" = $2 AND "m_traveller"."deleted_at" IS NULL`)
+	updateTarget := regexp.QuoteMeta(`UPDATE "m_traveller" SET "accessory_id"=$1,"banner"=$2,"influence_id"=$3,"job_id"=$4,"name"=$5,"name_key"=$6,"rarity"=$7,"release_date"=$8,"slug"=$9,"version"=version + 1,"updated_at"=$10 WHERE id = $11 AND "m_traveller"."deleted_at" IS NULL`)
+	repointExternalIDs := regexp.QuoteMeta(`UPDATE "m_external_id" SET "entity_id"=$1,"updated_at"=$2 WHERE entity_type = $3 AND entity_id = $4`)
+	pendingChangeRequests := regexp.QuoteMeta(`SELECT "id" FROM "m_change_request" WHERE (entity_type = $1 AND entity_id = $2 AND status = $3) AND "m_change_request"."deleted_at" IS NULL`)
+	reloadTraveller := regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."id" = $1 AND "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $2`)
+	travellerColumns := []string{"id", "name", "slug", "rarity", "banner", "release_date", "influence_id", "job_id", "accessory_id", "version"}
+	releaseDate := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	s.Run("fills the target from the source and moves its accessory", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(lockBoth).WithArgs(4, 7).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).
+				AddRow(4, "Viola", "viola", 5, "", nil, 3, 8, nil, 2).
+				AddRow(7, "viola", "viola-2", 5, "Standard Banner", releaseDate, 3, 8, 9, 1))
+		s.mock.ExpectExec(unlinkAccessory).WithArgs(nil, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectExec(deleteSource).WithArgs(helpers.AnyTime{}, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectExec(updateTarget).
+			WithArgs(9, "Standard Banner", 3, 8, "Viola", "viola", 5, releaseDate, "viola", helpers.AnyTime{}, 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectExec(repointExternalIDs).WithArgs(4, helpers.AnyTime{}, domain.ExternalEntityTraveller, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(pendingChangeRequests).WithArgs(domain.ChangeRequestEntityTraveller, 7, domain.ChangeRequestStatusPending).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		s.mock.ExpectQuery(reloadTraveller).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(4, "Viola", "viola", 5, "Standard Banner", releaseDate, 3, 8, 9, 3))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_accessory" WHERE "m_accessory"."id" = $1`)).WithArgs(9).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "version"}).AddRow(9, "Crown of Wisdom", 1))
+		s.mock.ExpectCommit()
+
+		res, err := s.repo.MergeTravellers(context.TODO(), 4, 7, "", 2)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(4), res.ID)
+		assert.Equal(s.T(), int64(3), res.Version)
+		assert.Equal(s.T(), "Crown of Wisdom", res.Accessory.Name)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("detaches the accessory not kept and closes the source's change requests", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(lockBoth).WithArgs(4, 7).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).
+				AddRow(4, "Viola", "viola", 5, "Viola's Banner", releaseDate, 3, 8, 5, 2).
+				AddRow(7, "viola", "viola-2", 5, "Standard Banner", releaseDate, 3, 8, 9, 1))
+		s.mock.ExpectExec(unlinkAccessory).WithArgs(nil, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectExec(deleteSource).WithArgs(helpers.AnyTime{}, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectExec(updateTarget).
+			WithArgs(5, "Viola's Banner", 3, 8, "Viola", "viola", 5, releaseDate, "viola", helpers.AnyTime{}, 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectExec(repointExternalIDs).WithArgs(4, helpers.AnyTime{}, domain.ExternalEntityTraveller, 7).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectQuery(pendingChangeRequests).WithArgs(domain.ChangeRequestEntityTraveller, 7, domain.ChangeRequestStatusPending).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(21).AddRow(22))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_change_request" SET "reviewed_at"=$1,"reviewed_by"=$2,"status"=$3,"version"=version + 1,"updated_at"=$4 WHERE id IN ($5,$6) AND "m_change_request"."deleted_at" IS NULL`)).
+			WithArgs(helpers.AnyTime{}, "", domain.ChangeRequestStatusRejected, helpers.AnyTime{}, 21, 22).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_change_request_comment" ("change_request_id","author","body","created_at") VALUES ($1,$2,$3,$4),($5,$6,$7,$8) RETURNING "id"`)).
+			WithArgs(21, "", "Closed: traveller 7 was merged into traveller 4", helpers.AnyTime{}, 22, "", "Closed: traveller 7 was merged into traveller 4", helpers.AnyTime{}).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+		s.mock.ExpectQuery(reloadTraveller).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(4, "Viola", "viola", 5, "Viola's Banner", releaseDate, 3, 8, 5, 3))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_accessory" WHERE "m_accessory"."id" = $1`)).WithArgs(5).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "version"}).AddRow(5, "Ring of Power", 1))
+		s.mock.ExpectCommit()
+
+		res, err := s.repo.MergeTravellers(context.TODO(), 4, 7, domain.MergeKeepTarget, 0)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), "Ring of Power", res.Accessory.Name)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("both have an accessory and none is picked", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(lockBoth).WithArgs(4, 7).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).
+				AddRow(4, "Viola", "viola", 5, "", nil, 3, 8, 5, 2).
+				AddRow(7, "viola", "viola-2", 5, "", nil, 3, 8, 9, 1))
+		s.mock.ExpectRollback()
+
+		_, err := s.repo.MergeTravellers(context.TODO(), 4, 7, "", 0)
+		var ve *domain.ValidationError
+		assert.True(s.T(), errors.As(err, &ve), "expected ValidationError")
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("missing source", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(lockBoth).WithArgs(4, 7).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(4, "Viola", "viola", 5, "", nil, 3, 8, nil, 2))
+		s.mock.ExpectRollback()
+
+		_, err := s.repo.MergeTravellers(context.TODO(), 4, 7, "", 0)
+		var nfe *domain.NotFoundError
+		assert.True(s.T(), errors.As(err, &nfe), "expected NotFoundError")
+		assert.Contains(s.T(), err.Error(), "'7'")
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("stale target version", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(lockBoth).WithArgs(4, 7).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).
+				AddRow(4, "Viola", "viola", 5, "", nil, 3, 8, nil, 2).
+				AddRow(7, "viola", "viola-2", 5, "", nil, 3, 8, nil, 1))
+		s.mock.ExpectRollback()
+
+		_, err := s.repo.MergeTravellers(context.TODO(), 4, 7, "", 1)
+		var pfe *domain.PreconditionFailedError
+		assert.True(s.T(), errors.As(err, &pfe), "expected PreconditionFailedError")
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *TravellerRepositorySuite) TestTravellerRepository_RestoreTraveller() {
+	lockDeleted := regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE deleted_at IS NOT NULL AND "m_traveller"."id" = $1 ORDER BY "m_traveller"."id" LIMIT $2 FOR UPDATE`)
+	countNamesakes := regexp.QuoteMeta(`SELECT count(*) FROM "m_traveller" WHERE name_key = $1 AND "m_traveller"."deleted_at" IS NULL`)
+	countAccessoryOwners := regexp.QuoteMeta(`SELECT count(*) FROM "m_traveller" WHERE accessory_id = $1 AND "m_traveller"."deleted_at" IS NULL`)
+	reloadTraveller := regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."id" = $1 AND "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $2`)
+	travellerColumns := []string{"id", "name", "name_key", "slug", "accessory_id", "version", "deleted_at"}
+	deletedAt := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	s.Run("success", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(lockDeleted).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(4, "Viola", "viola", "viola", nil, 2, deletedAt))
+		s.mock.ExpectQuery(countNamesakes).WithArgs("viola").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "deleted_at"=$1,"deleted_by"=$2,"version"=version + 1,"updated_at"=$3 WHERE id = $4`)).
+			WithArgs(nil, nil, helpers.AnyTime{}, 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(reloadTraveller).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(4, "Viola", "viola", "viola", nil, 3, nil))
+		s.mock.ExpectCommit()
+
+		res, err := s.repo.RestoreTraveller(context.TODO(), 4)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(3), res.Version)
+		assert.False(s.T(), res.DeletedAt.Valid)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("accessory taken by another traveller", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(lockDeleted).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(4, "Viola", "viola", "viola", 9, 2, deletedAt))
+		s.mock.ExpectQuery(countNamesakes).WithArgs("viola").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		s.mock.ExpectQuery(countAccessoryOwners).WithArgs(9).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "accessory_id"=$1,"deleted_at"=$2,"deleted_by"=$3,"version"=version + 1,"updated_at"=$4 WHERE id = $5`)).
+			WithArgs(nil, nil, nil, helpers.AnyTime{}, 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(reloadTraveller).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(4, "Viola", "viola", "viola", nil, 3, nil))
+		s.mock.ExpectCommit()
+
+		res, err := s.repo.RestoreTraveller(context.TODO(), 4)
+		assert.NoError(s.T(), err)
+		assert.Nil(s.T(), res.AccessoryID)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("failed name taken by a live traveller", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(lockDeleted).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(4, "Viola", "viola", "viola", nil, 2, deletedAt))
+		s.mock.ExpectQuery(countNamesakes).WithArgs("viola").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		s.mock.ExpectRollback()
+
+		_, err := s.repo.RestoreTraveller(context.TODO(), 4)
+		var ce *domain.ConflictError
+		assert.True(s.T(), errors.As(err, &ce), "expected ConflictError")
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("failed not deleted", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(lockDeleted).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns))
+		s.mock.ExpectRollback()
+
+		_, err := s.repo.RestoreTraveller(context.TODO(), 4)
+		var nfe *domain.NotFoundError
+		assert.True(s.T(), errors.As(err, &nfe), "expected NotFoundError")
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *TravellerRepositorySuite) TestTravellerRepository_GetHistory() {
+	findTraveller := regexp.QuoteMeta(`SELECT "id","accessory_id" FROM "m_traveller" WHERE "m_traveller"."id" = $1 ORDER BY "m_traveller"."id" LIMIT $2`)
+	revisionColumns := []string{"id", "entity_type", "entity_id", "action", "before", "after", "actor", "request_id", "created_at"}
+
+	s.Run("success with accessory revisions", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(findTraveller).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "accessory_id"}).AddRow(4, 9))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "m_revision" WHERE (entity_type = $1 AND entity_id = $2) OR (entity_type = $3 AND entity_id = $4)`)).
+			WithArgs("traveller", 4, "accessory", 9).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_revision" WHERE (entity_type = $1 AND entity_id = $2) OR (entity_type = $3 AND entity_id = $4) ORDER BY created_at DESC, id DESC LIMIT $5`)).
+			WithArgs("traveller", 4, "accessory", 9, 10).
+			WillReturnRows(sqlmock.NewRows(revisionColumns).
+				AddRow(12, "accessory", 9, "update", []byte(`{"hp":100}`), []byte(`{"hp":150}`), "isla", "req-2", time.Now()).
+				AddRow(11, "traveller", 4, "create", nil, []byte(`{"name":"Viola"}`), "isla", "req-1", time.Now()))
+
+		res, total, err := s.repo.GetHistory(context.TODO(), 4, 0, 10)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(2), total)
+		assert.Len(s.T(), res, 2)
+		assert.Equal(s.T(), "accessory", res[0].EntityType)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("success without accessory", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(findTraveller).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "accessory_id"}).AddRow(4, nil))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "m_revision" WHERE entity_type = $1 AND entity_id = $2`)).
+			WithArgs("traveller", 4).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_revision" WHERE entity_type = $1 AND entity_id = $2 ORDER BY created_at DESC, id DESC LIMIT $3 OFFSET $4`)).
+			WithArgs("traveller", 4, 10, 10).
+			WillReturnRows(sqlmock.NewRows(revisionColumns))
+
+		res, total, err := s.repo.GetHistory(context.TODO(), 4, 10, 10)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(0), total)
+		assert.Empty(s.T(), res)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("failed traveller not found", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(findTraveller).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "accessory_id"}))
+
+		_, _, err := s.repo.GetHistory(context.TODO(), 4, 0, 10)
+		var nfe *domain.NotFoundError
+		assert.True(s.T(), errors.As(err, &nfe), "expected NotFoundError")
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *TravellerRepositorySuite) TestTravellerRepository_RevertRevision() {
+	lockTraveller := regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."id" = $1 AND "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $2 FOR UPDATE`)
+	findRevision := regexp.QuoteMeta(`SELECT * FROM "m_revision" WHERE "m_revision"."id" = $1 AND ((entity_type = $2 AND entity_id = $3) OR (entity_type = $4 AND entity_id = $5)) ORDER BY "m_revision"."id" LIMIT $6`)
+	reloadTraveller := regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."id" = $1 AND "m_traveller"."deleted_at" IS NULL ORDER BY "m_traveller"."id" LIMIT $2`)
+	travellerColumns := []string{"id", "name", "name_key", "slug", "rarity", "influence_id", "job_id", "accessory_id", "version"}
+	revisionColumns := []string{"id", "entity_type", "entity_id", "action", "before", "after"}
+	state := func(rarity int) []byte {
+		encoded, _ := json.Marshal(domain.TravellerState{Name: "Viola", Rarity: rarity, InfluenceID: 1, JobID: 2, AccessoryID: func() *int { id := 9; return &id }()})
+		return encoded
+	}
+
+	s.Run("success traveller revision", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(lockTraveller).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(4, "Viola", "viola", "viola", 5, 1, 2, 9, 3))
+		s.mock.ExpectQuery(findRevision).WithArgs(12, "traveller", 4, "accessory", 9, 1).
+			WillReturnRows(sqlmock.NewRows(revisionColumns).AddRow(12, "traveller", 4, "update", state(4), state(5)))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "name","slug" FROM "m_traveller" WHERE id = $1`)).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "slug"}).AddRow("Viola", "viola"))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "accessory_id"=$1,"banner"=$2,"influence_id"=$3,"job_id"=$4,"name"=$5,"name_key"=$6,"rarity"=$7,"release_date"=$8,"slug"=$9,"status"=$10,"version"=version + 1,"updated_at"=$11 WHERE id = $12 AND "m_traveller"."deleted_at" IS NULL`)).
+			WithArgs(9, "", 1, 2, "Viola", "viola", 4, nil, "viola", domain.PublishStatusPublished, helpers.AnyTime{}, 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(reloadTraveller).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(4, "Viola", "viola", "viola", 4, 1, 2, 9, 4))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_accessory" WHERE "m_accessory"."id" = $1`)).
+			WithArgs(9).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(9, "Crown"))
+		s.mock.ExpectCommit()
+
+		res, err := s.repo.RevertRevision(context.TODO(), 4, 12)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), 4, res.Rarity)
+		assert.Equal(s.T(), int64(4), res.Version)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("success accessory revision", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(lockTraveller).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(4, "Viola", "viola", "viola", 5, 1, 2, 9, 3))
+		s.mock.ExpectQuery(findRevision).WithArgs(13, "traveller", 4, "accessory", 9, 1).
+			WillReturnRows(sqlmock.NewRows(revisionColumns).
+				AddRow(13, "accessory", 9, "update", []byte(`{"name":"Crown","hp":100}`), []byte(`{"name":"Crown","hp":150}`)))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_accessory" WHERE "m_accessory"."id" = $1 AND "m_accessory"."deleted_at" IS NULL ORDER BY "m_accessory"."id" LIMIT $2 FOR UPDATE`)).
+			WithArgs(9, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug", "hp"}).AddRow(9, "Crown", "crown", 150))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "name","slug" FROM "m_accessory" WHERE id = $1`)).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "slug"}).AddRow("Crown", "crown"))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_accessory" SET "crit"=$1,"eatk"=$2,"edef"=$3,"effect"=$4,"hp"=$5,"name"=$6,"patk"=$7,"pdef"=$8,"slug"=$9,"sp"=$10,"spd"=$11,"status"=$12,"version"=version + 1,"updated_at"=$13 WHERE id = $14`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "version"=version + 1 WHERE accessory_id = $1`)).
+			WithArgs(9).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(reloadTraveller).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(4, "Viola", "viola", "viola", 5, 1, 2, 9, 4))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_accessory" WHERE "m_accessory"."id" = $1`)).
+			WithArgs(9).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug", "hp"}).AddRow(9, "Crown", "crown", 100))
+		s.mock.ExpectCommit()
+
+		res, err := s.repo.RevertRevision(context.TODO(), 4, 13)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), 100, res.Accessory.HP)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("failed field changed since", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(lockTraveller).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(4, "Viola", "viola", "viola", 3, 1, 2, 9, 3))
+		s.mock.ExpectQuery(findRevision).WithArgs(12, "traveller", 4, "accessory", 9, 1).
+			WillReturnRows(sqlmock.NewRows(revisionColumns).AddRow(12, "traveller", 4, "update", state(4), state(5)))
+		s.mock.ExpectRollback()
+
+		_, err := s.repo.RevertRevision(context.TODO(), 4, 12)
+		var ce *domain.ConflictError
+		assert.True(s.T(), errors.As(err, &ce), "expected ConflictError")
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("failed revision of another traveller", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(lockTraveller).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns).AddRow(4, "Viola", "viola", "viola", 5, 1, 2, 9, 3))
+		s.mock.ExpectQuery(findRevision).WithArgs(12, "traveller", 4, "accessory", 9, 1).
+			WillReturnRows(sqlmock.NewRows(revisionColumns))
+		s.mock.ExpectRollback()
+
+		_, err := s.repo.RevertRevision(context.TODO(), 4, 12)
+		var nfe *domain.NotFoundError
+		assert.True(s.T(), errors.As(err, &nfe), "expected NotFoundError")
+		assert.Equal(s.T(), "revision", nfe.Resource)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("failed traveller not found", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(lockTraveller).WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows(travellerColumns))
+		s.mock.ExpectRollback()
+
+		_, err := s.repo.RevertRevision(context.TODO(), 4, 12)
+		var nfe *domain.NotFoundError
+		assert.True(s.T(), errors.As(err, &nfe), "expected NotFoundError")
+		assert.Equal(s.T(), "traveller", nfe.Resource)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
+
+func TestNameSortExpression(t *testing.T) {
+	got := nameSortExpression("m_traveller.job_id", map[int]string{2: "Merchant", 1: "Warrior", 3: "Sword's Edge"})
+	assert.Equal(t, `CASE m_traveller.job_id WHEN 1 THEN 'Warrior' WHEN 2 THEN 'Merchant' WHEN 3 THEN 'Sword''s Edge' ELSE '' END`, got)
+}
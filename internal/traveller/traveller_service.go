@@ -2,44 +2,108 @@ package traveller
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"lizobly/ctc-db-api/pkg/constants"
 	"lizobly/ctc-db-api/pkg/domain"
 	"lizobly/ctc-db-api/pkg/helpers"
 	"lizobly/ctc-db-api/pkg/logging"
 	"lizobly/ctc-db-api/pkg/telemetry"
+	"strconv"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 )
 
 type TravellerRepository interface {
-	GetByID(ctx context.Context, id int) (result *domain.Traveller, err error)
-	GetList(ctx context.Context, filter domain.ListTravellerRequest, offset, limit int) (result []*domain.Traveller, total int64, err error)
+	GetByID(ctx context.Context, id int, include domain.TravellerInclude) (result *domain.Traveller, err error)
+	GetByIDAsOf(ctx context.Context, id int, at time.Time) (result *domain.Traveller, err error)
+	GetBySlug(ctx context.Context, slug string, include domain.TravellerInclude) (result *domain.Traveller, err error)
+	GetByIDs(ctx context.Context, ids []int, include domain.TravellerInclude) (result []*domain.Traveller, err error)
+	GetList(ctx context.Context, filter domain.ListTravellerRequest, offset, limit int) (result []*domain.Traveller, total int64, lastModified time.Time, err error)
+	GetPage(ctx context.Context, filter domain.ListTravellerRequest, cursor *helpers.Cursor, limit int) (result []*domain.Traveller, hasMore bool, err error)
+	Count(ctx context.Context, filter domain.ListTravellerRequest) (total int64, err error)
+	GetFacets(ctx context.Context, filter domain.ListTravellerRequest, facets []string) (result map[string]map[int]int64, err error)
+	SuggestNames(ctx context.Context, name string, limit int) (result []string, err error)
 	Create(ctx context.Context, input *domain.Traveller) (err error)
 	Update(ctx context.Context, input *domain.Traveller) (err error)
 	Delete(ctx context.Context, id int) (err error)
 	CreateTravellerWithAccessory(ctx context.Context, traveller *domain.Traveller, accessory *domain.Accessory) (err error)
 	UpdateTravellerWithAccessory(ctx context.Context, id int, traveller *domain.Traveller, accessory *domain.Accessory) (err error)
+	PatchTravellerWithAccessory(ctx context.Context, id int, traveller *domain.Traveller, accessory *domain.Accessory) (err error)
+	UpsertTravellerWithAccessory(ctx context.Context, key domain.ExternalKey, traveller *domain.Traveller, accessory *domain.Accessory) (created bool, err error)
+	FindSimilarPairs(ctx context.Context, threshold, minScore float64, limit int) (result []domain.SimilarPair, err error)
+	MergeTravellers(ctx context.Context, targetID, sourceID int, keepAccessory string, expectedVersion int64) (result *domain.Traveller, err error)
+	RestoreTraveller(ctx context.Context, id int) (result *domain.Traveller, err error)
+	GetHistory(ctx context.Context, id int, offset, limit int) (result []domain.Revision, total int64, err error)
+	RevertRevision(ctx context.Context, id int, revisionID int64) (result *domain.Traveller, err error)
+}
+
+// AccessoryRepository is the subset of the accessory repository used for recommendations
+type AccessoryRepository interface {
+	GetList(ctx context.Context, filter domain.ListAccessoryRequest, offset, limit int) (result []*domain.Accessory, ownerNames map[int64]string, total int64, lastModified time.Time, err error)
 }
 
 type travellerService struct {
 	travellerRepo TravellerRepository
+	accessoryRepo AccessoryRepository
+	compareLimit  int
+	cursors       *helpers.CursorCodec
 	logger        *logging.Logger
 }
 
-func NewTravellerService(t TravellerRepository, logger *logging.Logger) *travellerService {
+// NewTravellerService creates the traveller service. compareLimit caps how many travellers
+// one comparison may include; values below 2 fall back to domain.DefaultCompareLimit.
+// cursors signs the list cursors handed out in keyset pagination mode.
+func NewTravellerService(t TravellerRepository, a AccessoryRepository, compareLimit int, cursors *helpers.CursorCodec, logger *logging.Logger) *travellerService {
+	if compareLimit < 2 {
+		compareLimit = domain.DefaultCompareLimit
+	}
 	return &travellerService{
 		travellerRepo: t,
+		accessoryRepo: a,
+		compareLimit:  compareLimit,
+		cursors:       cursors,
 		logger:        logger.Named("service.traveller"),
 	}
 }
 
-func (s *travellerService) GetByID(ctx context.Context, id int) (res *domain.Traveller, err error) {
+// GetByID returns the traveller with the relations in include
+func (s *travellerService) GetByID(ctx context.Context, id int, include domain.TravellerInclude) (res *domain.Traveller, err error) {
 	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.GetByID",
 		attribute.Int("traveller.id", id),
 	)
 	defer telemetry.EndSpanWithError(span, err)
 
-	res, err = s.travellerRepo.GetByID(ctx, id)
+	res, err = s.travellerRepo.GetByID(ctx, id, include)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetByIDAsOf returns the traveller as it was at the given time
+func (s *travellerService) GetByIDAsOf(ctx context.Context, id int, at time.Time) (res *domain.Traveller, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.GetByIDAsOf",
+		attribute.Int("traveller.id", id),
+		attribute.String("as_of", at.Format(time.RFC3339)),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	res, err = s.travellerRepo.GetByIDAsOf(ctx, id, at)
+	return
+}
+
+// GetBySlug returns the traveller with the relations in include
+func (s *travellerService) GetBySlug(ctx context.Context, slug string, include domain.TravellerInclude) (res *domain.Traveller, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.GetBySlug",
+		attribute.String("traveller.slug", slug),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	res, err = s.travellerRepo.GetBySlug(ctx, slug, include)
 	if err != nil {
 		return
 	}
@@ -57,15 +121,13 @@ func (s *travellerService) GetList(ctx context.Context, filter domain.ListTravel
 	// Normalize pagination params
 	params.Normalize()
 
-	// Populate ID fields from plaintext values
-	if filter.Influence != "" {
-		filter.InfluenceID = constants.GetInfluenceID(filter.Influence)
-	}
-	if filter.Job != "" {
-		filter.JobID = constants.GetJobID(filter.Job)
+	// Populate parsed fields from plaintext values
+	err = parseListFilter(&filter)
+	if err != nil {
+		return
 	}
 
-	travellers, total, err := s.travellerRepo.GetList(ctx, filter, params.Offset(), params.PageSize)
+	travellers, total, lastModified, err := s.travellerRepo.GetList(ctx, filter, params.Offset(), params.PageSize)
 	if err != nil {
 		return
 	}
@@ -77,11 +139,214 @@ func (s *travellerService) GetList(ctx context.Context, filter domain.ListTravel
 	}
 
 	res = helpers.NewPaginatedResponse(items, params, total)
+	res.UpdatedAt = lastModified
+
+	if len(filter.FacetFields) > 0 {
+		var counts map[string]map[int]int64
+		counts, err = s.travellerRepo.GetFacets(ctx, filter, filter.FacetFields)
+		if err != nil {
+			return
+		}
+		res.Facets = toTravellerFacets(filter.FacetFields, counts)
+	}
+
+	// Offer close names when the name filter is what most likely emptied the list
+	if total == 0 && filter.Name != "" {
+		res.DidYouMean, err = s.travellerRepo.SuggestNames(ctx, filter.Name, didYouMeanLimit)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// GetPage returns a keyset-paginated page of the list. It takes the same filters as GetList;
+// the total is only counted when asked for.
+func (s *travellerService) GetPage(ctx context.Context, filter domain.ListTravellerRequest, params helpers.CursorParams) (res helpers.CursorResponse[domain.TravellerListItemResponse], err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.GetPage",
+		attribute.Int("limit", params.Limit),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	params.Normalize()
+
+	err = parseListFilter(&filter)
+	if err != nil {
+		return
+	}
+
+	_, order := travellerKeyset(filter.Sort)
+	var cursor *helpers.Cursor
+	if params.Cursor != "" {
+		decoded, decodeErr := s.cursors.Decode(params.Cursor, order)
+		if decodeErr != nil {
+			err = invalidCursorError()
+			return
+		}
+		cursor = &decoded
+	}
+
+	travellers, hasMore, err := s.travellerRepo.GetPage(ctx, filter, cursor, params.Limit)
+	if err != nil {
+		if errors.Is(err, helpers.ErrInvalidCursor) {
+			err = invalidCursorError()
+		}
+		return
+	}
+
+	res.Data = make([]domain.TravellerListItemResponse, len(travellers))
+	for i, t := range travellers {
+		res.Data[i] = domain.ToTravellerListItemResponse(t)
+	}
+	res.Limit = params.Limit
+
+	res.NextCursor, res.PrevCursor, err = s.cursors.PageCursors(cursor, order, len(travellers), hasMore, func(i int) []interface{} {
+		return travellerCursorKeys(travellers[i], filter.Sort)
+	})
+	if err != nil {
+		return
+	}
+
+	if params.IncludeTotal {
+		var total int64
+		total, err = s.travellerRepo.Count(ctx, filter)
+		if err != nil {
+			return
+		}
+		res.Total = &total
+	}
+
+	return
+}
+
+func invalidCursorError() error {
+	return domain.NewValidationError([]domain.FieldError{{Field: "cursor", Message: "cursor is invalid or was issued for a different order_by"}})
+}
+
+// didYouMeanLimit is the number of names suggested when a name filter matches nothing
+const didYouMeanLimit = 3
+
+// travellerFacetValues lists every value of a facet in display order, so values without matches still get a zero count
+var travellerFacetValues = map[string][]int{
+	"job": {
+		constants.JobWarriorID, constants.JobMerchantID, constants.JobThiefID, constants.JobApothecaryID,
+		constants.JobHunterID, constants.JobClericID, constants.JobScholarID, constants.JobDancerID,
+	},
+	"influence": {
+		constants.InfluenceWealthID, constants.InfluencePowerID, constants.InfluenceFameID,
+		constants.InfluenceOpulenceID, constants.InfluenceDominanceID, constants.InfluencePrestigeID,
+	},
+	"rarity": {1, 2, 3, 4, 5},
+}
+
+// toTravellerFacets labels raw facet counts with job and influence names
+func toTravellerFacets(facets []string, counts map[string]map[int]int64) map[string][]helpers.FacetCount {
+	result := make(map[string][]helpers.FacetCount, len(facets))
+	for _, facet := range facets {
+		values := travellerFacetValues[facet]
+		buckets := make([]helpers.FacetCount, len(values))
+		for i, value := range values {
+			var label string
+			switch facet {
+			case "job":
+				label = constants.GetJobName(value)
+			case "influence":
+				label = constants.GetInfluenceName(value)
+			default:
+				label = strconv.Itoa(value)
+			}
+			buckets[i] = helpers.FacetCount{Value: label, Count: counts[facet][value]}
+		}
+		result[facet] = buckets
+	}
+	return result
+}
+
+// parseListFilter converts the query string values of a list filter into typed values
+func parseListFilter(filter *domain.ListTravellerRequest) (err error) {
+	if filter.Influence != "" {
+		names := strings.Split(filter.Influence, ",")
+		filter.InfluenceIDs = make([]int, len(names))
+		for i, name := range names {
+			filter.InfluenceIDs[i] = constants.GetInfluenceID(strings.TrimSpace(name))
+		}
+	}
+	if filter.Job != "" {
+		names := strings.Split(filter.Job, ",")
+		filter.JobIDs = make([]int, len(names))
+		for i, name := range names {
+			filter.JobIDs[i] = constants.GetJobID(strings.TrimSpace(name))
+		}
+	}
+
+	validationErr := &domain.ValidationError{}
+
+	filter.AsOfTime, err = helpers.ParseDate(filter.AsOf, time.RFC3339)
+	if err != nil {
+		validationErr.AddFieldError("as_of", "as_of must be an RFC 3339 timestamp, e.g. 2024-10-01T09:00:00Z")
+	}
+
+	if filter.RarityMin != "" {
+		filter.RarityMinValue, _ = strconv.Atoi(filter.RarityMin)
+	}
+	if filter.RarityMax != "" {
+		filter.RarityMaxValue, _ = strconv.Atoi(filter.RarityMax)
+	}
+	if filter.RarityMinValue != 0 && filter.RarityMaxValue != 0 && filter.RarityMinValue > filter.RarityMaxValue {
+		validationErr.AddFieldError("rarity_max", "rarity_max must be greater than or equal to rarity_min")
+	}
+
+	filter.ReleasedAfterDate, err = helpers.ParseDate(filter.ReleasedAfter, constants.DateFormat)
+	if err != nil {
+		validationErr.AddFieldError("released_after", "invalid date format")
+	}
+	filter.ReleasedBeforeDate, err = helpers.ParseDate(filter.ReleasedBefore, constants.DateFormat)
+	if err != nil {
+		validationErr.AddFieldError("released_before", "invalid date format")
+	}
+	if !filter.ReleasedAfterDate.IsZero() && !filter.ReleasedBeforeDate.IsZero() && filter.ReleasedAfterDate.After(filter.ReleasedBeforeDate) {
+		validationErr.AddFieldError("released_before", "released_before must not be earlier than released_after")
+	}
+
+	if filter.HasAccessory != "" {
+		hasAccessory, parseErr := strconv.ParseBool(filter.HasAccessory)
+		if parseErr != nil {
+			validationErr.AddFieldError("has_accessory", "has_accessory must be a boolean")
+		} else {
+			filter.HasAccessoryValue = &hasAccessory
+		}
+	}
 
+	if filter.Filter != "" {
+		var compileErr error
+		filter.FilterCondition, compileErr = travellerFilterSchema.Compile(filter.Filter)
+		if compileErr != nil {
+			validationErr.AddFieldError("filter", compileErr.Error())
+		}
+	}
+
+	filter.FacetFields = domain.ParseFacetFields(filter.Facets)
+	for _, facet := range filter.FacetFields {
+		// A facet is counted without the filter= terms on its own field, which needs them apart
+		if _, ok := filter.FilterCondition.Without(facet); !ok {
+			validationErr.AddFieldError("facets", "facet "+facet+" can't be counted while filter tests "+facet+" together with other fields")
+		}
+	}
+
+	if len(validationErr.Errors) > 0 {
+		return validationErr
+	}
+
+	filter.Relations = domain.ParseTravellerInclude(filter.Include)
+
+	// Parse sort keys and directions
+	filter.Sort, err = domain.ParseSortFields(filter.OrderBy, filter.OrderDir)
 	return
 }
 
-func (s *travellerService) Create(ctx context.Context, input domain.CreateTravellerRequest) (id int64, err error) {
+// Create stores a new traveller and returns it as persisted, with its ID, version and timestamps
+func (s *travellerService) Create(ctx context.Context, input domain.CreateTravellerRequest) (res *domain.Traveller, err error) {
 	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.Create",
 		attribute.String("traveller.name", input.Name),
 	)
@@ -90,26 +355,38 @@ func (s *travellerService) Create(ctx context.Context, input domain.CreateTravel
 	// Parse release date
 	releaseDate, err := helpers.ParseDate(input.ReleaseDate, constants.DateFormat)
 	if err != nil {
-		return 0, &domain.ValidationError{
+		return nil, &domain.ValidationError{
 			Errors: []domain.FieldError{
 				{Field: "release_date", Message: "invalid date format"},
 			},
 		}
 	}
 
+	// A scheduled traveller without a publish time is published on its release date
+	publication, err := domain.NewPublication(input.Status, input.PublishAt, releaseDate)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build traveller domain object
 	newTraveller := domain.Traveller{
-		Name:        input.Name,
+		Name:        helpers.NormalizeName(input.Name),
 		Rarity:      input.Rarity,
 		Banner:      input.Banner,
 		ReleaseDate: releaseDate,
 		InfluenceID: constants.GetInfluenceID(input.Influence),
 		JobID:       constants.GetJobID(input.Job),
+		Publication: publication,
 	}
 
 	// Build accessory domain object if provided
 	var newAccessory *domain.Accessory
 	if input.Accessory != nil {
+		// The accessory is released with its traveller
+		accessoryPublication, err := domain.NewPublication(input.Accessory.Status, input.Accessory.PublishAt, releaseDate)
+		if err != nil {
+			return nil, err
+		}
 		newAccessory = &domain.Accessory{
 			Name:   input.Accessory.Name,
 			HP:     input.Accessory.HP,
@@ -121,49 +398,273 @@ func (s *travellerService) Create(ctx context.Context, input domain.CreateTravel
 			Spd:    input.Accessory.Spd,
 			Crit:   input.Accessory.Crit,
 			Effect: input.Accessory.Effect,
+
+			Publication: accessoryPublication,
 		}
 	}
 
 	// Create traveller with accessory in transaction
 	err = s.travellerRepo.CreateTravellerWithAccessory(ctx, &newTraveller, newAccessory)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return newTraveller.ID, nil
+	return &newTraveller, nil
 }
 
-func (s *travellerService) Update(ctx context.Context, id int, input domain.UpdateTravellerRequest) (err error) {
+// Update replaces a traveller and returns it as persisted
+func (s *travellerService) Update(ctx context.Context, id int, input domain.UpdateTravellerRequest) (res *domain.Traveller, err error) {
 	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.Update",
 		attribute.Int("traveller.id", id),
 		attribute.String("traveller.name", input.Name),
 	)
 	defer telemetry.EndSpanWithError(span, err)
 
+	updatedTraveller, updatedAccessory, err := toUpdatedTraveller(id, input)
+	if err != nil {
+		return
+	}
+
+	// Update traveller with accessory in transaction
+	// Repository handles checking if accessory exists and decides INSERT vs UPDATE
+	err = s.travellerRepo.UpdateTravellerWithAccessory(ctx, id, updatedTraveller, updatedAccessory)
+	if err != nil {
+		return
+	}
+
+	return updatedTraveller, nil
+}
+
+// Patch stores the already patched state of a traveller. Empty fields clear the stored value
+// and a nil accessory unlinks the current one. The traveller is returned as persisted.
+func (s *travellerService) Patch(ctx context.Context, id int, input domain.UpdateTravellerRequest) (res *domain.Traveller, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.Patch",
+		attribute.Int("traveller.id", id),
+		attribute.String("traveller.name", input.Name),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	patchedTraveller, patchedAccessory, err := toUpdatedTraveller(id, input)
+	if err != nil {
+		return
+	}
+
+	err = s.travellerRepo.PatchTravellerWithAccessory(ctx, id, patchedTraveller, patchedAccessory)
+	if err != nil {
+		return
+	}
+
+	return patchedTraveller, nil
+}
+
+// Upsert creates or updates the traveller an external ID maps to and returns it as persisted.
+// created reports whether a new traveller was inserted.
+func (s *travellerService) Upsert(ctx context.Context, key domain.ExternalKey, input domain.UpdateTravellerRequest) (res *domain.Traveller, created bool, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.Upsert",
+		attribute.String("external.source", key.Source),
+		attribute.String("external.id", key.ExternalID),
+		attribute.String("traveller.name", input.Name),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	traveller, accessory, err := toUpdatedTraveller(0, input)
+	if err != nil {
+		return
+	}
+
+	created, err = s.travellerRepo.UpsertTravellerWithAccessory(ctx, key, traveller, accessory)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return traveller, created, nil
+}
+
+// FindDuplicates reports pairs of travellers that may be the same one entered twice. Pairs with
+// similar names are scored on their name similarity and the attributes they share.
+func (s *travellerService) FindDuplicates(ctx context.Context, input domain.DuplicateCandidateRequest) (res domain.DuplicateReportResponse, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.FindDuplicates",
+		attribute.Float64("min_score", input.MinScore),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	minScore := input.MinScore
+	if minScore == 0 {
+		minScore = domain.DefaultDuplicateMinScore
+	}
+	limit := input.Limit
+	if limit == 0 {
+		limit = domain.DefaultDuplicateLimit
+	}
+
+	pairs, err := s.travellerRepo.FindSimilarPairs(ctx, domain.DuplicateNameThreshold, minScore, limit)
+	if err != nil {
+		return
+	}
+
+	res.Candidates = []domain.DuplicateCandidate{}
+	if len(pairs) == 0 {
+		return
+	}
+
+	seen := make(map[int64]bool)
+	var ids []int
+	for _, pair := range pairs {
+		for _, id := range []int64{pair.FirstID, pair.SecondID} {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, int(id))
+			}
+		}
+	}
+
+	travellers, err := s.travellerRepo.GetByIDs(ctx, ids, domain.TravellerInclude{})
+	if err != nil {
+		return
+	}
+	byID := make(map[int64]*domain.Traveller, len(travellers))
+	for _, t := range travellers {
+		byID[t.ID] = t
+	}
+
+	candidates := make([]domain.DuplicateCandidate, 0, len(pairs))
+	for _, pair := range pairs {
+		first, second := byID[pair.FirstID], byID[pair.SecondID]
+		// Either may have been deleted between the two reads
+		if first == nil || second == nil {
+			continue
+		}
+		candidates = append(candidates, domain.ScoreDuplicate(first, second, pair.NameSimilarity))
+	}
+	res.Candidates = domain.RankDuplicates(candidates, minScore, limit)
+
+	return
+}
+
+// Merge merges another traveller into the one with id, re-pointing the other's references,
+// and returns the merged traveller as persisted
+func (s *travellerService) Merge(ctx context.Context, id int, input domain.MergeTravellerRequest) (res *domain.Traveller, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.Merge",
+		attribute.Int("traveller.id", id),
+		attribute.Int64("traveller.source_id", input.SourceID),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	if input.SourceID == int64(id) {
+		err = domain.NewValidationError([]domain.FieldError{
+			{Field: "source_id", Message: "source_id must name a different traveller"},
+		})
+		return
+	}
+
+	res, err = s.travellerRepo.MergeTravellers(ctx, id, int(input.SourceID), input.KeepAccessory, input.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// Restore brings a deleted traveller back and returns it as persisted
+func (s *travellerService) Restore(ctx context.Context, id int) (res *domain.Traveller, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.Restore",
+		attribute.Int("traveller.id", id),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	res, err = s.travellerRepo.RestoreTraveller(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// History returns one page of the revisions of a traveller and its accessory, most recent first,
+// with the fields each one changed
+func (s *travellerService) History(ctx context.Context, id int, params helpers.PaginationParams) (res helpers.PaginatedResponse[domain.RevisionResponse], err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.History",
+		attribute.Int("traveller.id", id),
+		attribute.Int("page", params.Page),
+		attribute.Int("page_size", params.PageSize),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	params.Normalize()
+
+	revisions, total, err := s.travellerRepo.GetHistory(ctx, id, params.Offset(), params.PageSize)
+	if err != nil {
+		return
+	}
+
+	items := make([]domain.RevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		item, itemErr := domain.ToRevisionResponse(revision)
+		if itemErr != nil {
+			err = itemErr
+			return
+		}
+		items = append(items, item)
+	}
+
+	res = helpers.NewPaginatedResponse(items, params, total)
+	return
+}
+
+// Revert undoes the changes of one revision of a traveller or its accessory and returns the
+// traveller as persisted
+func (s *travellerService) Revert(ctx context.Context, id int, input domain.RevertRevisionRequest) (res *domain.Traveller, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.Revert",
+		attribute.Int("traveller.id", id),
+		attribute.Int64("revision.id", input.Revision),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	res, err = s.travellerRepo.RevertRevision(ctx, id, input.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// toUpdatedTraveller builds the traveller and optional accessory domain objects for an update request
+func toUpdatedTraveller(id int, input domain.UpdateTravellerRequest) (*domain.Traveller, *domain.Accessory, error) {
 	// Parse release date
 	releaseDate, err := helpers.ParseDate(input.ReleaseDate, constants.DateFormat)
 	if err != nil {
-		return &domain.ValidationError{
+		return nil, nil, &domain.ValidationError{
 			Errors: []domain.FieldError{
 				{Field: "release_date", Message: "invalid date format"},
 			},
 		}
 	}
 
+	// A scheduled traveller without a publish time is published on its release date
+	publication, err := domain.NewPublication(input.Status, input.PublishAt, releaseDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Build traveller domain object
-	updatedTraveller := domain.Traveller{
-		CommonModel: domain.CommonModel{ID: int64(id)},
-		Name:        input.Name,
+	updatedTraveller := &domain.Traveller{
+		CommonModel: domain.CommonModel{ID: int64(id), Version: input.Version},
+		Name:        helpers.NormalizeName(input.Name),
 		Rarity:      input.Rarity,
 		Banner:      input.Banner,
 		ReleaseDate: releaseDate,
 		InfluenceID: constants.GetInfluenceID(input.Influence),
 		JobID:       constants.GetJobID(input.Job),
+		Publication: publication,
 	}
 
 	// Build accessory domain object if provided
 	var updatedAccessory *domain.Accessory
 	if input.Accessory != nil {
+		accessoryPublication, err := domain.NewPublication(input.Accessory.Status, input.Accessory.PublishAt, releaseDate)
+		if err != nil {
+			return nil, nil, err
+		}
 		updatedAccessory = &domain.Accessory{
 			Name:   input.Accessory.Name,
 			HP:     input.Accessory.HP,
@@ -175,12 +676,21 @@ func (s *travellerService) Update(ctx context.Context, id int, input domain.Upda
 			Spd:    input.Accessory.Spd,
 			Crit:   input.Accessory.Crit,
 			Effect: input.Accessory.Effect,
+
+			Publication: accessoryPublication,
 		}
 	}
 
-	// Update traveller with accessory in transaction
-	// Repository handles checking if accessory exists and decides INSERT vs UPDATE
-	err = s.travellerRepo.UpdateTravellerWithAccessory(ctx, id, &updatedTraveller, updatedAccessory)
+	return updatedTraveller, updatedAccessory, nil
+}
+
+func (s *travellerService) Delete(ctx context.Context, id int) (err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.Delete",
+		attribute.Int("traveller.id", id),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	err = s.travellerRepo.Delete(ctx, id)
 	if err != nil {
 		return
 	}
@@ -188,16 +698,139 @@ func (s *travellerService) Update(ctx context.Context, id int, input domain.Upda
 	return
 }
 
-func (s *travellerService) Delete(ctx context.Context, id int) (err error) {
-	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.Delete",
+func (s *travellerService) GetRecommendedAccessories(ctx context.Context, id int, input domain.RecommendAccessoryRequest) (res domain.AccessoryRecommendationResponse, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.GetRecommendedAccessories",
 		attribute.Int("traveller.id", id),
+		attribute.Bool("include_owned", input.IncludeOwned),
 	)
 	defer telemetry.EndSpanWithError(span, err)
 
-	err = s.travellerRepo.Delete(ctx, id)
+	traveller, err := s.travellerRepo.GetByID(ctx, id, domain.TravellerInclude{})
+	if err != nil {
+		return
+	}
+
+	// Limit -1 disables pagination so every accessory is a candidate
+	accessories, ownerNames, _, _, err := s.accessoryRepo.GetList(ctx, domain.ListAccessoryRequest{}, 0, -1)
+	if err != nil {
+		return
+	}
+
+	// Skip accessories locked to other travellers unless asked
+	candidates := make([]*domain.Accessory, 0, len(accessories))
+	for _, acc := range accessories {
+		ownedBySelf := traveller.AccessoryID != nil && int64(*traveller.AccessoryID) == acc.ID
+		if !input.IncludeOwned && ownerNames[acc.ID] != "" && !ownedBySelf {
+			continue
+		}
+		candidates = append(candidates, acc)
+	}
+
+	limit := input.Limit
+	if limit == 0 {
+		limit = domain.DefaultRecommendationLimit
+	}
+
+	jobName := constants.GetJobName(traveller.JobID)
+	weights := domain.GetJobStatWeights(traveller.JobID)
+
+	res = domain.AccessoryRecommendationResponse{
+		Traveller:       traveller.Name,
+		Job:             jobName,
+		Weights:         weights,
+		Recommendations: domain.ScoreAccessories(candidates, ownerNames, weights, jobName, limit),
+	}
+
+	return
+}
+
+// GetByIDs returns the travellers with the requested ids, and the relations in include, in
+// request order
+func (s *travellerService) GetByIDs(ctx context.Context, input domain.BatchGetRequest, include domain.TravellerInclude) (res helpers.BatchResponse[domain.TravellerResponse], err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.GetByIDs",
+		attribute.String("traveller.ids", input.IDs),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	err = domain.ParseBatchGetRequest(&input)
+	if err != nil {
+		return
+	}
+
+	travellers, err := s.travellerRepo.GetByIDs(ctx, input.IDValues, include)
+	if err != nil {
+		return
+	}
+
+	ordered, missing := orderTravellersByID(travellers, input.IDValues)
+	res.Data = make([]domain.TravellerResponse, len(ordered))
+	for i, t := range ordered {
+		res.Data[i] = domain.ToTravellerResponse(t)
+	}
+	res.NotFound = missing
+
+	return
+}
+
+func (s *travellerService) Compare(ctx context.Context, input domain.CompareTravellerRequest) (res domain.TravellerComparisonResponse, err error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "service.traveller", "TravellerService.Compare",
+		attribute.String("traveller.ids", input.IDs),
+	)
+	defer telemetry.EndSpanWithError(span, err)
+
+	input.IDValues, err = domain.ParseIDList("ids", input.IDs)
+	if err != nil {
+		return
+	}
+	if len(input.IDValues) < 2 {
+		err = domain.NewValidationError([]domain.FieldError{
+			{Field: "ids", Message: "ids must list at least 2 distinct travellers"},
+		})
+		return
+	}
+	if len(input.IDValues) > s.compareLimit {
+		err = domain.NewValidationError([]domain.FieldError{
+			{Field: "ids", Message: fmt.Sprintf("ids must list at most %d travellers", s.compareLimit)},
+		})
+		return
+	}
+
+	travellers, err := s.travellerRepo.GetByIDs(ctx, input.IDValues, domain.TravellerIncludeAll)
 	if err != nil {
 		return
 	}
 
+	ordered, missing := orderTravellersByID(travellers, input.IDValues)
+	if len(missing) > 0 {
+		names := make([]string, len(missing))
+		for i, id := range missing {
+			names[i] = strconv.Itoa(id)
+		}
+		err = domain.NewNotFoundError("traveller", strings.Join(names, ","), nil)
+		return
+	}
+
+	res = domain.CompareTravellers(ordered)
+
+	return
+}
+
+// orderTravellersByID arranges travellers in the order of ids and lists the ids that weren't found
+func orderTravellersByID(travellers []*domain.Traveller, ids []int) (ordered []*domain.Traveller, missing []int) {
+	byID := make(map[int64]*domain.Traveller, len(travellers))
+	for _, t := range travellers {
+		byID[t.ID] = t
+	}
+
+	ordered = make([]*domain.Traveller, 0, len(ids))
+	missing = []int{}
+	for _, id := range ids {
+		t, ok := byID[int64(id)]
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+		ordered = append(ordered, t)
+	}
 	return
 }
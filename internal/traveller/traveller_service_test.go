@@ -2,12 +2,16 @@ package traveller
 
 import (
 	"context"
+	"errors"
 	"lizobly/ctc-db-api/internal/traveller/mocks"
 	"lizobly/ctc-db-api/pkg/constants"
 	"lizobly/ctc-db-api/pkg/domain"
 	"lizobly/ctc-db-api/pkg/helpers"
 	"lizobly/ctc-db-api/pkg/logging"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -18,6 +22,7 @@ import (
 type TravellerServiceSuite struct {
 	suite.Suite
 	travellerRepo *mocks.MockTravellerRepository
+	accessoryRepo *mocks.MockAccessoryRepository
 	svc           *travellerService
 }
 
@@ -29,11 +34,13 @@ func (s *TravellerServiceSuite) SetupTest() {
 	logger, _ := logging.NewDevelopmentLogger()
 
 	s.travellerRepo = new(mocks.MockTravellerRepository)
-	s.svc = NewTravellerService(s.travellerRepo, logger)
+	s.accessoryRepo = new(mocks.MockAccessoryRepository)
+	s.svc = NewTravellerService(s.travellerRepo, s.accessoryRepo, 3, helpers.NewCursorCodec("test-secret"), logger)
 }
 
 func (s *TravellerServiceSuite) TearDownTest() {
 	s.travellerRepo.AssertExpectations(s.T())
+	s.accessoryRepo.AssertExpectations(s.T())
 }
 
 func (s *TravellerServiceSuite) TestTravellerService_NewService() {
@@ -41,7 +48,8 @@ func (s *TravellerServiceSuite) TestTravellerService_NewService() {
 	s.T().Run("success", func(t *testing.T) {
 		logger, _ := logging.NewDevelopmentLogger()
 		repo := new(mocks.MockTravellerRepository)
-		NewTravellerService(repo, logger)
+		accessoryRepo := new(mocks.MockAccessoryRepository)
+		NewTravellerService(repo, accessoryRepo, 0, helpers.NewCursorCodec("test-secret"), logger)
 	})
 }
 
@@ -71,7 +79,7 @@ func (s *TravellerServiceSuite) TestTravellerService_GetByID() {
 			}},
 			wantErr: false,
 			beforeTest: func(ctx context.Context, args args, want want) {
-				s.travellerRepo.On("GetByID", mock.Anything, args.id).Return(want.traveller, want.err).Once()
+				s.travellerRepo.On("GetByID", mock.Anything, args.id, domain.TravellerIncludeAll).Return(want.traveller, want.err).Once()
 
 			},
 		}, {
@@ -80,7 +88,7 @@ func (s *TravellerServiceSuite) TestTravellerService_GetByID() {
 			want:    want{err: domain.NewNotFoundError("traveller", 1, nil)},
 			wantErr: true,
 			beforeTest: func(ctx context.Context, args args, want want) {
-				s.travellerRepo.On("GetByID", mock.Anything, args.id).Return(want.traveller, want.err).Once()
+				s.travellerRepo.On("GetByID", mock.Anything, args.id, domain.TravellerIncludeAll).Return(want.traveller, want.err).Once()
 
 			},
 		},
@@ -94,7 +102,7 @@ func (s *TravellerServiceSuite) TestTravellerService_GetByID() {
 				tt.beforeTest(ctx, tt.args, tt.want)
 			}
 
-			got, err := s.svc.GetByID(ctx, tt.args.id)
+			got, err := s.svc.GetByID(ctx, tt.args.id, domain.TravellerIncludeAll)
 			if tt.wantErr {
 				assert.Equal(s.T(), err, tt.want.err)
 				return
@@ -107,6 +115,26 @@ func (s *TravellerServiceSuite) TestTravellerService_GetByID() {
 	}
 }
 
+func (s *TravellerServiceSuite) TestTravellerService_GetBySlug() {
+	s.Run("success", func() {
+		want := &domain.Traveller{Name: "Fiore", Slug: "fiore", CommonModel: domain.CommonModel{ID: 1}}
+		s.travellerRepo.On("GetBySlug", mock.Anything, "fiore", domain.TravellerIncludeAll).Return(want, nil).Once()
+
+		got, err := s.svc.GetBySlug(context.TODO(), "fiore", domain.TravellerIncludeAll)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), want, got)
+	})
+
+	s.Run("failed", func() {
+		wantErr := domain.NewNotFoundError("traveller", "unknown", nil)
+		s.travellerRepo.On("GetBySlug", mock.Anything, "unknown", domain.TravellerIncludeAll).Return(nil, wantErr).Once()
+
+		got, err := s.svc.GetBySlug(context.TODO(), "unknown", domain.TravellerIncludeAll)
+		assert.Equal(s.T(), wantErr, err)
+		assert.Nil(s.T(), got)
+	})
+}
+
 func (s *TravellerServiceSuite) TestTravellerService_Create() {
 	type args struct {
 		request domain.CreateTravellerRequest
@@ -206,17 +234,66 @@ func (s *TravellerServiceSuite) TestTravellerService_Create() {
 				tt.beforeTest(ctx, tt.args, tt.want)
 			}
 
-			id, err := s.svc.Create(ctx, tt.args.request)
+			created, err := s.svc.Create(ctx, tt.args.request)
 			if tt.wantErr {
 				assert.Equal(s.T(), err, tt.want.err)
+				assert.Nil(s.T(), created)
 				return
 			}
 
 			assert.Nil(s.T(), err)
-			assert.Greater(s.T(), id, int64(0))
+			assert.Equal(s.T(), int64(123), created.ID)
+			assert.Equal(s.T(), tt.args.request.Name, created.Name)
 
 		})
 	}
+
+	s.Run("name is normalised before storing", func() {
+		s.travellerRepo.On("CreateTravellerWithAccessory", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+		created, err := s.svc.Create(context.TODO(), domain.CreateTravellerRequest{
+			Name:      "  Agne\u0300s ",
+			Rarity:    5,
+			Influence: constants.InfluencePower,
+			Job:       constants.JobWarrior,
+		})
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), "Agn\u00e8s", created.Name)
+	})
+
+	s.Run("scheduled without publish_at is published on the release date", func() {
+		var accessory *domain.Accessory
+		s.travellerRepo.On("CreateTravellerWithAccessory", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			accessory = args.Get(2).(*domain.Accessory)
+		}).Return(nil).Once()
+
+		created, err := s.svc.Create(context.TODO(), domain.CreateTravellerRequest{
+			Name:        "Viola",
+			Rarity:      5,
+			ReleaseDate: "15-05-2023",
+			Influence:   constants.InfluencePower,
+			Job:         constants.JobWarrior,
+			Status:      domain.PublishStatusScheduled,
+			Accessory:   &domain.CreateAccessoryRequest{Name: "Test Accessory", PublishAt: "2023-05-16T09:00:00Z"},
+		})
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), domain.PublishStatusScheduled, created.Status)
+		assert.Equal(s.T(), time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC), *created.PublishAt)
+		assert.Equal(s.T(), domain.PublishStatusScheduled, accessory.Status)
+		assert.Equal(s.T(), time.Date(2023, 5, 16, 9, 0, 0, 0, time.UTC), *accessory.PublishAt)
+	})
+
+	s.Run("scheduled without publish_at or release date", func() {
+		_, err := s.svc.Create(context.TODO(), domain.CreateTravellerRequest{
+			Name:      "Viola",
+			Rarity:    5,
+			Influence: constants.InfluencePower,
+			Job:       constants.JobWarrior,
+			Status:    domain.PublishStatusScheduled,
+		})
+		var ve *domain.ValidationError
+		assert.ErrorAs(s.T(), err, &ve)
+	})
 }
 
 func (s *TravellerServiceSuite) TestTravellerService_Update() {
@@ -364,18 +441,290 @@ func (s *TravellerServiceSuite) TestTravellerService_Update() {
 				tt.beforeTest(ctx, tt.args, tt.want)
 			}
 
-			err := s.svc.Update(ctx, tt.args.id, tt.args.input)
+			updated, err := s.svc.Update(ctx, tt.args.id, tt.args.input)
 			if tt.wantErr {
 				assert.Equal(s.T(), err, tt.want.err)
 				return
 			}
 
 			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), int64(tt.args.id), updated.ID)
+			assert.Equal(s.T(), tt.args.input.Name, updated.Name)
 
 		})
 	}
 }
 
+func (s *TravellerServiceSuite) TestTravellerService_Patch() {
+	s.Run("success clears empty fields", func() {
+		input := domain.UpdateTravellerRequest{
+			Name:      "Fiore",
+			Rarity:    4,
+			Influence: constants.InfluencePower,
+			Job:       constants.JobMerchant,
+		}
+		expected := &domain.Traveller{
+			CommonModel: domain.CommonModel{ID: 1},
+			Name:        "Fiore",
+			Rarity:      4,
+			InfluenceID: constants.InfluencePowerID,
+			JobID:       constants.JobMerchantID,
+		}
+		s.travellerRepo.On("PatchTravellerWithAccessory", mock.Anything, 1, expected, (*domain.Accessory)(nil)).Return(nil).Once()
+
+		patched, err := s.svc.Patch(context.TODO(), 1, input)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), expected, patched)
+	})
+
+	s.Run("success with accessory", func() {
+		input := domain.UpdateTravellerRequest{
+			Name:        "Fiore",
+			Rarity:      4,
+			ReleaseDate: "15-05-2023",
+			Influence:   constants.InfluencePower,
+			Job:         constants.JobMerchant,
+			Accessory:   &domain.UpdateAccessoryRequest{Name: "Crown of Wisdom", HP: 150},
+		}
+		s.travellerRepo.On("PatchTravellerWithAccessory", mock.Anything, 1, mock.Anything, &domain.Accessory{Name: "Crown of Wisdom", HP: 150}).Return(nil).Once()
+
+		_, err := s.svc.Patch(context.TODO(), 1, input)
+		assert.Nil(s.T(), err)
+	})
+
+	s.Run("failed invalid release date", func() {
+		input := domain.UpdateTravellerRequest{Name: "Fiore", ReleaseDate: "2023-05-15"}
+
+		_, err := s.svc.Patch(context.TODO(), 1, input)
+		var ve *domain.ValidationError
+		assert.True(s.T(), errors.As(err, &ve))
+	})
+
+	s.Run("failed repository error", func() {
+		input := domain.UpdateTravellerRequest{Name: "Fiore", Rarity: 4, Influence: constants.InfluencePower, Job: constants.JobMerchant}
+		wantErr := domain.NewNotFoundError("traveller", 1, nil)
+		s.travellerRepo.On("PatchTravellerWithAccessory", mock.Anything, 1, mock.Anything, mock.Anything).Return(wantErr).Once()
+
+		patched, err := s.svc.Patch(context.TODO(), 1, input)
+		assert.Equal(s.T(), wantErr, err)
+		assert.Nil(s.T(), patched)
+	})
+}
+
+func (s *TravellerServiceSuite) TestTravellerService_Upsert() {
+	key := domain.ExternalKey{Source: "cotc-wiki", ExternalID: "fiore-4"}
+	input := domain.UpdateTravellerRequest{
+		Name:      "Fiore",
+		Rarity:    4,
+		Influence: constants.InfluencePower,
+		Job:       constants.JobMerchant,
+		Accessory: &domain.UpdateAccessoryRequest{Name: "Crown of Wisdom", HP: 150},
+		Version:   2,
+	}
+
+	s.Run("success", func() {
+		expected := &domain.Traveller{
+			CommonModel: domain.CommonModel{Version: 2},
+			Name:        "Fiore",
+			Rarity:      4,
+			InfluenceID: constants.InfluencePowerID,
+			JobID:       constants.JobMerchantID,
+		}
+		s.travellerRepo.On("UpsertTravellerWithAccessory", mock.Anything, key, expected, &domain.Accessory{Name: "Crown of Wisdom", HP: 150}).
+			Run(func(args mock.Arguments) {
+				args.Get(2).(*domain.Traveller).ID = 7
+			}).Return(true, nil).Once()
+
+		upserted, created, err := s.svc.Upsert(context.TODO(), key, input)
+		assert.Nil(s.T(), err)
+		assert.True(s.T(), created)
+		assert.Equal(s.T(), int64(7), upserted.ID)
+	})
+
+	s.Run("failed invalid release date", func() {
+		_, _, err := s.svc.Upsert(context.TODO(), key, domain.UpdateTravellerRequest{Name: "Fiore", ReleaseDate: "2023-05-15"})
+		var ve *domain.ValidationError
+		assert.True(s.T(), errors.As(err, &ve))
+	})
+
+	s.Run("failed repository error", func() {
+		wantErr := domain.NewConflictError("traveller with this name already exists", nil)
+		s.travellerRepo.On("UpsertTravellerWithAccessory", mock.Anything, key, mock.Anything, mock.Anything).Return(false, wantErr).Once()
+
+		upserted, created, err := s.svc.Upsert(context.TODO(), key, input)
+		assert.Equal(s.T(), wantErr, err)
+		assert.False(s.T(), created)
+		assert.Nil(s.T(), upserted)
+	})
+}
+
+func (s *TravellerServiceSuite) TestTravellerService_FindDuplicates() {
+	viola := &domain.Traveller{CommonModel: domain.CommonModel{ID: 1}, Name: "Viola", Rarity: 5, JobID: constants.JobDancerID, InfluenceID: constants.InfluenceFameID}
+	violaCopy := &domain.Traveller{CommonModel: domain.CommonModel{ID: 4}, Name: "viola", Rarity: 5, JobID: constants.JobDancerID, InfluenceID: constants.InfluenceFameID}
+	fiore := &domain.Traveller{CommonModel: domain.CommonModel{ID: 2}, Name: "Fiore", Rarity: 4, JobID: constants.JobMerchantID}
+	fiona := &domain.Traveller{CommonModel: domain.CommonModel{ID: 3}, Name: "Fiona", Rarity: 3, JobID: constants.JobClericID}
+
+	s.Run("scores, filters and ranks similar pairs", func() {
+		s.travellerRepo.On("FindSimilarPairs", mock.Anything, domain.DuplicateNameThreshold, 30.0, domain.DefaultDuplicateLimit).Return([]domain.SimilarPair{
+			{FirstID: 1, SecondID: 4, NameSimilarity: 1},
+			{FirstID: 2, SecondID: 3, NameSimilarity: 0.5},
+			{FirstID: 1, SecondID: 9, NameSimilarity: 0.45},
+		}, nil).Once()
+		s.travellerRepo.On("GetByIDs", mock.Anything, []int{1, 4, 2, 3, 9}, domain.TravellerInclude{}).Return([]*domain.Traveller{viola, fiore, fiona, violaCopy}, nil).Once()
+
+		res, err := s.svc.FindDuplicates(context.TODO(), domain.DuplicateCandidateRequest{MinScore: 30})
+		assert.NoError(s.T(), err)
+		assert.Len(s.T(), res.Candidates, 2)
+		assert.Equal(s.T(), int64(4), res.Candidates[0].Travellers[1].ID)
+		assert.Equal(s.T(), 92.5, res.Candidates[0].Score)
+		assert.Equal(s.T(), 35.0, res.Candidates[1].Score)
+	})
+
+	s.Run("default minimum score", func() {
+		s.travellerRepo.On("FindSimilarPairs", mock.Anything, domain.DuplicateNameThreshold, float64(domain.DefaultDuplicateMinScore), domain.DefaultDuplicateLimit).Return([]domain.SimilarPair{
+			{FirstID: 2, SecondID: 3, NameSimilarity: 0.5},
+		}, nil).Once()
+		s.travellerRepo.On("GetByIDs", mock.Anything, []int{2, 3}, domain.TravellerInclude{}).Return([]*domain.Traveller{fiore, fiona}, nil).Once()
+
+		res, err := s.svc.FindDuplicates(context.TODO(), domain.DuplicateCandidateRequest{})
+		assert.NoError(s.T(), err)
+		assert.Empty(s.T(), res.Candidates)
+	})
+
+	s.Run("no similar pairs", func() {
+		s.travellerRepo.On("FindSimilarPairs", mock.Anything, domain.DuplicateNameThreshold, float64(domain.DefaultDuplicateMinScore), domain.DefaultDuplicateLimit).Return(nil, nil).Once()
+
+		res, err := s.svc.FindDuplicates(context.TODO(), domain.DuplicateCandidateRequest{})
+		assert.NoError(s.T(), err)
+		assert.NotNil(s.T(), res.Candidates)
+		assert.Empty(s.T(), res.Candidates)
+	})
+
+	s.Run("failed repository error", func() {
+		s.travellerRepo.On("FindSimilarPairs", mock.Anything, domain.DuplicateNameThreshold, float64(domain.DefaultDuplicateMinScore), domain.DefaultDuplicateLimit).Return(nil, gorm.ErrInvalidDB).Once()
+
+		_, err := s.svc.FindDuplicates(context.TODO(), domain.DuplicateCandidateRequest{})
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+	})
+}
+
+func (s *TravellerServiceSuite) TestTravellerService_Merge() {
+	s.Run("success", func() {
+		merged := &domain.Traveller{CommonModel: domain.CommonModel{ID: 1, Version: 3}, Name: "Viola"}
+		s.travellerRepo.On("MergeTravellers", mock.Anything, 1, 4, domain.MergeKeepSource, int64(2)).Return(merged, nil).Once()
+
+		res, err := s.svc.Merge(context.TODO(), 1, domain.MergeTravellerRequest{SourceID: 4, KeepAccessory: domain.MergeKeepSource, Version: 2})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), merged, res)
+	})
+
+	s.Run("failed merging a traveller into itself", func() {
+		_, err := s.svc.Merge(context.TODO(), 1, domain.MergeTravellerRequest{SourceID: 1})
+		var ve *domain.ValidationError
+		assert.True(s.T(), errors.As(err, &ve))
+	})
+
+	s.Run("failed repository error", func() {
+		wantErr := domain.NewNotFoundError("traveller", 4, nil)
+		s.travellerRepo.On("MergeTravellers", mock.Anything, 1, 4, "", int64(0)).Return(nil, wantErr).Once()
+
+		res, err := s.svc.Merge(context.TODO(), 1, domain.MergeTravellerRequest{SourceID: 4})
+		assert.Equal(s.T(), wantErr, err)
+		assert.Nil(s.T(), res)
+	})
+}
+
+func (s *TravellerServiceSuite) TestTravellerService_Restore() {
+	s.Run("success", func() {
+		restored := &domain.Traveller{CommonModel: domain.CommonModel{ID: 1, Version: 4}, Name: "Viola"}
+		s.travellerRepo.On("RestoreTraveller", mock.Anything, 1).Return(restored, nil).Once()
+
+		res, err := s.svc.Restore(context.TODO(), 1)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), restored, res)
+	})
+
+	s.Run("failed name taken", func() {
+		wantErr := domain.NewConflictError("traveller with this name already exists", nil)
+		s.travellerRepo.On("RestoreTraveller", mock.Anything, 1).Return(nil, wantErr).Once()
+
+		res, err := s.svc.Restore(context.TODO(), 1)
+		assert.Equal(s.T(), wantErr, err)
+		assert.Nil(s.T(), res)
+	})
+}
+
+func (s *TravellerServiceSuite) TestTravellerService_History() {
+	s.Run("success", func() {
+		revisions := []domain.Revision{
+			{ID: 12, EntityType: domain.TrashTypeTraveller, EntityID: 1, Action: domain.RevisionActionUpdate, Before: []byte(`{"name":"Viola","rarity":4}`), After: []byte(`{"name":"Viola","rarity":5}`), Actor: "isla"},
+		}
+		s.travellerRepo.On("GetHistory", mock.Anything, 1, 10, 10).Return(revisions, int64(11), nil).Once()
+
+		res, err := s.svc.History(context.TODO(), 1, helpers.PaginationParams{Page: 2, PageSize: 10})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(11), res.Total)
+		assert.Equal(s.T(), []domain.FieldChange{{Field: "rarity", Before: float64(4), After: float64(5)}}, res.Data[0].Changes)
+	})
+
+	s.Run("failed traveller not found", func() {
+		wantErr := domain.NewNotFoundError("traveller", 1, nil)
+		s.travellerRepo.On("GetHistory", mock.Anything, 1, 0, 10).Return(nil, int64(0), wantErr).Once()
+
+		_, err := s.svc.History(context.TODO(), 1, helpers.PaginationParams{})
+		assert.Equal(s.T(), wantErr, err)
+	})
+}
+
+func (s *TravellerServiceSuite) TestTravellerService_GetByIDAsOf() {
+	at := time.Date(2024, 10, 1, 9, 0, 0, 0, time.UTC)
+	past := &domain.Traveller{CommonModel: domain.CommonModel{ID: 1}, Name: "Viola", Rarity: 4}
+	s.travellerRepo.On("GetByIDAsOf", mock.Anything, 1, at).Return(past, nil).Once()
+
+	res, err := s.svc.GetByIDAsOf(context.TODO(), 1, at)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), past, res)
+}
+
+func (s *TravellerServiceSuite) TestTravellerService_GetList_AsOf() {
+	s.Run("parses as_of", func() {
+		at := time.Date(2024, 10, 1, 9, 0, 0, 0, time.UTC)
+		s.travellerRepo.On("GetList", mock.Anything, mock.MatchedBy(func(filter domain.ListTravellerRequest) bool {
+			return filter.AsOfTime.Equal(at)
+		}), 0, 10).Return([]*domain.Traveller{}, int64(0), time.Time{}, nil).Once()
+
+		_, err := s.svc.GetList(context.TODO(), domain.ListTravellerRequest{AsOf: "2024-10-01T09:00:00Z"}, helpers.PaginationParams{Page: 1, PageSize: 10})
+		assert.NoError(s.T(), err)
+	})
+
+	s.Run("failed invalid as_of", func() {
+		_, err := s.svc.GetList(context.TODO(), domain.ListTravellerRequest{AsOf: "yesterday"}, helpers.PaginationParams{Page: 1, PageSize: 10})
+		var ve *domain.ValidationError
+		assert.True(s.T(), errors.As(err, &ve))
+		assert.Equal(s.T(), "as_of", ve.Errors[0].Field)
+	})
+}
+
+func (s *TravellerServiceSuite) TestTravellerService_Revert() {
+	s.Run("success", func() {
+		reverted := &domain.Traveller{CommonModel: domain.CommonModel{ID: 1, Version: 5}, Name: "Viola", Rarity: 4}
+		s.travellerRepo.On("RevertRevision", mock.Anything, 1, int64(12)).Return(reverted, nil).Once()
+
+		res, err := s.svc.Revert(context.TODO(), 1, domain.RevertRevisionRequest{Revision: 12})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), reverted, res)
+	})
+
+	s.Run("failed changed since", func() {
+		wantErr := domain.NewConflictError("rarity changed since revision 12", nil)
+		s.travellerRepo.On("RevertRevision", mock.Anything, 1, int64(12)).Return(nil, wantErr).Once()
+
+		res, err := s.svc.Revert(context.TODO(), 1, domain.RevertRevisionRequest{Revision: 12})
+		assert.Equal(s.T(), wantErr, err)
+		assert.Nil(s.T(), res)
+	})
+}
+
 func (s *TravellerServiceSuite) TestTravellerService_Delete() {
 	type args struct {
 		request int
@@ -439,6 +788,7 @@ func (s *TravellerServiceSuite) TestTravellerService_GetList() {
 	type want struct {
 		count         int
 		total         int64
+		updatedAt     time.Time
 		err           error
 		hasPagination bool
 	}
@@ -458,6 +808,7 @@ func (s *TravellerServiceSuite) TestTravellerService_GetList() {
 			want: want{
 				count:         2,
 				total:         2,
+				updatedAt:     time.Date(2026, 1, 27, 10, 0, 0, 0, time.UTC),
 				err:           nil,
 				hasPagination: true,
 			},
@@ -467,7 +818,7 @@ func (s *TravellerServiceSuite) TestTravellerService_GetList() {
 					{CommonModel: domain.CommonModel{ID: 1}, Name: "Fiore", Rarity: 5},
 					{CommonModel: domain.CommonModel{ID: 2}, Name: "Viola", Rarity: 4},
 				}
-				s.travellerRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(travellers, want.total, want.err).Once()
+				s.travellerRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(travellers, want.total, want.updatedAt, want.err).Once()
 			},
 		},
 		{
@@ -487,13 +838,13 @@ func (s *TravellerServiceSuite) TestTravellerService_GetList() {
 				travellers := []*domain.Traveller{
 					{CommonModel: domain.CommonModel{ID: 1}, Name: "Fiore", Rarity: 5},
 				}
-				s.travellerRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(travellers, want.total, want.err).Once()
+				s.travellerRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(travellers, want.total, time.Time{}, want.err).Once()
 			},
 		},
 		{
 			name: "success with influence filter",
 			args: args{
-				filter: domain.ListTravellerRequest{Influence: constants.InfluencePower, InfluenceID: constants.GetInfluenceID(constants.InfluencePower)},
+				filter: domain.ListTravellerRequest{Influence: constants.InfluencePower, InfluenceIDs: []int{constants.GetInfluenceID(constants.InfluencePower)}},
 				params: helpers.PaginationParams{Page: 1, PageSize: 10},
 			},
 			want: want{
@@ -507,13 +858,13 @@ func (s *TravellerServiceSuite) TestTravellerService_GetList() {
 				travellers := []*domain.Traveller{
 					{CommonModel: domain.CommonModel{ID: 1}, Name: "Fiore", Rarity: 5, InfluenceID: constants.GetInfluenceID(constants.InfluencePower)},
 				}
-				s.travellerRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(travellers, want.total, want.err).Once()
+				s.travellerRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(travellers, want.total, time.Time{}, want.err).Once()
 			},
 		},
 		{
 			name: "success with job filter",
 			args: args{
-				filter: domain.ListTravellerRequest{Job: constants.JobWarrior, JobID: constants.GetJobID(constants.JobWarrior)},
+				filter: domain.ListTravellerRequest{Job: constants.JobWarrior, JobIDs: []int{constants.GetJobID(constants.JobWarrior)}},
 				params: helpers.PaginationParams{Page: 1, PageSize: 10},
 			},
 			want: want{
@@ -527,7 +878,7 @@ func (s *TravellerServiceSuite) TestTravellerService_GetList() {
 				travellers := []*domain.Traveller{
 					{CommonModel: domain.CommonModel{ID: 1}, Name: "Fiore", Rarity: 5, JobID: constants.GetJobID(constants.JobWarrior)},
 				}
-				s.travellerRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(travellers, want.total, want.err).Once()
+				s.travellerRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(travellers, want.total, time.Time{}, want.err).Once()
 			},
 		},
 		{
@@ -549,7 +900,7 @@ func (s *TravellerServiceSuite) TestTravellerService_GetList() {
 					travellers[i] = &domain.Traveller{CommonModel: domain.CommonModel{ID: int64(i + 1)}, Name: "Test"}
 				}
 				// Normalized params: page 1, page_size 10, offset 0
-				s.travellerRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(travellers, want.total, want.err).Once()
+				s.travellerRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(travellers, want.total, time.Time{}, want.err).Once()
 			},
 		},
 		{
@@ -571,7 +922,7 @@ func (s *TravellerServiceSuite) TestTravellerService_GetList() {
 					travellers[i] = &domain.Traveller{CommonModel: domain.CommonModel{ID: int64(i + 11)}, Name: "Test"}
 				}
 				// Page 2: offset = (2-1)*10 = 10
-				s.travellerRepo.On("GetList", mock.Anything, args.filter, 10, 10).Return(travellers, want.total, want.err).Once()
+				s.travellerRepo.On("GetList", mock.Anything, args.filter, 10, 10).Return(travellers, want.total, time.Time{}, want.err).Once()
 			},
 		},
 		{
@@ -589,8 +940,101 @@ func (s *TravellerServiceSuite) TestTravellerService_GetList() {
 			wantErr: false,
 			beforeTest: func(ctx context.Context, args args, want want) {
 				travellers := []*domain.Traveller{}
-				s.travellerRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(travellers, want.total, want.err).Once()
+				s.travellerRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(travellers, want.total, time.Time{}, want.err).Once()
+				s.travellerRepo.On("SuggestNames", mock.Anything, "NonExistent", 3).Return(nil, nil).Once()
+			},
+		},
+		{
+			name: "success with sort keys",
+			args: args{
+				filter: domain.ListTravellerRequest{OrderBy: "rarity,name", OrderDir: "desc,asc"},
+				params: helpers.PaginationParams{Page: 1, PageSize: 10},
+			},
+			want: want{
+				count:         1,
+				total:         1,
+				err:           nil,
+				hasPagination: true,
+			},
+			wantErr: false,
+			beforeTest: func(ctx context.Context, args args, want want) {
+				travellers := []*domain.Traveller{
+					{CommonModel: domain.CommonModel{ID: 1}, Name: "Fiore", Rarity: 5},
+				}
+				parsedFilter := args.filter
+				parsedFilter.Sort = []domain.SortField{
+					{Field: "rarity", Desc: true},
+					{Field: "name", Desc: false},
+				}
+				s.travellerRepo.On("GetList", mock.Anything, parsedFilter, 0, 10).Return(travellers, want.total, time.Time{}, want.err).Once()
+			},
+		},
+		{
+			name: "success with range, multi-value and presence filters",
+			args: args{
+				filter: domain.ListTravellerRequest{
+					Influence:      "Power,Fame",
+					Job:            "Warrior, Dancer",
+					RarityMin:      "4",
+					RarityMax:      "5",
+					ReleasedAfter:  "01-01-2023",
+					ReleasedBefore: "31-12-2023",
+					HasAccessory:   "false",
+					Banner:         "Standard",
+				},
+				params: helpers.PaginationParams{Page: 1, PageSize: 10},
+			},
+			want: want{
+				count:         0,
+				total:         0,
+				err:           nil,
+				hasPagination: true,
+			},
+			wantErr: false,
+			beforeTest: func(ctx context.Context, args args, want want) {
+				hasAccessory := false
+				parsedFilter := args.filter
+				parsedFilter.InfluenceIDs = []int{constants.InfluencePowerID, constants.InfluenceFameID}
+				parsedFilter.JobIDs = []int{constants.JobWarriorID, constants.JobDancerID}
+				parsedFilter.RarityMinValue = 4
+				parsedFilter.RarityMaxValue = 5
+				parsedFilter.ReleasedAfterDate = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+				parsedFilter.ReleasedBeforeDate = time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+				parsedFilter.HasAccessoryValue = &hasAccessory
+				s.travellerRepo.On("GetList", mock.Anything, parsedFilter, 0, 10).Return([]*domain.Traveller{}, want.total, time.Time{}, want.err).Once()
+			},
+		},
+		{
+			name: "failed with inverted ranges",
+			args: args{
+				filter: domain.ListTravellerRequest{
+					RarityMin:      "5",
+					RarityMax:      "3",
+					ReleasedAfter:  "01-06-2023",
+					ReleasedBefore: "01-01-2023",
+				},
+				params: helpers.PaginationParams{Page: 1, PageSize: 10},
+			},
+			want: want{
+				err: domain.NewValidationError([]domain.FieldError{
+					{Field: "rarity_max", Message: "rarity_max must be greater than or equal to rarity_min"},
+					{Field: "released_before", Message: "released_before must not be earlier than released_after"},
+				}),
 			},
+			wantErr: true,
+		},
+		{
+			name: "failed with too many sort directions",
+			args: args{
+				filter: domain.ListTravellerRequest{OrderBy: "rarity", OrderDir: "desc,asc"},
+				params: helpers.PaginationParams{Page: 1, PageSize: 10},
+			},
+			want: want{
+				err: domain.NewValidationError([]domain.FieldError{
+					{Field: "order_dir", Message: "order_dir has more values than order_by"},
+				}),
+			},
+			wantErr: true,
 		},
 		{
 			name: "failed to fetch list",
@@ -606,7 +1050,7 @@ func (s *TravellerServiceSuite) TestTravellerService_GetList() {
 			},
 			wantErr: true,
 			beforeTest: func(ctx context.Context, args args, want want) {
-				s.travellerRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(nil, int64(0), want.err).Once()
+				s.travellerRepo.On("GetList", mock.Anything, args.filter, 0, 10).Return(nil, int64(0), time.Time{}, want.err).Once()
 			},
 		},
 	}
@@ -628,6 +1072,7 @@ func (s *TravellerServiceSuite) TestTravellerService_GetList() {
 			assert.Nil(s.T(), err)
 			assert.Equal(s.T(), tt.want.count, len(result.Data))
 			assert.Equal(s.T(), tt.want.total, result.Total)
+			assert.Equal(s.T(), tt.want.updatedAt, result.UpdatedAt)
 			if tt.want.hasPagination {
 				assert.Greater(s.T(), result.Page, 0)
 				assert.Greater(s.T(), result.PageSize, 0)
@@ -636,3 +1081,409 @@ func (s *TravellerServiceSuite) TestTravellerService_GetList() {
 		})
 	}
 }
+
+func (s *TravellerServiceSuite) TestTravellerService_GetRecommendedAccessories() {
+	ownAccessoryID := 1
+	traveller := &domain.Traveller{
+		CommonModel: domain.CommonModel{ID: 10},
+		Name:        "Fiore",
+		JobID:       constants.JobWarriorID,
+		AccessoryID: &ownAccessoryID,
+	}
+	accessories := []*domain.Accessory{
+		{CommonModel: domain.CommonModel{ID: 1}, Name: "Fiore's Blade", PAtk: 50},
+		{CommonModel: domain.CommonModel{ID: 2}, Name: "Viola's Fan", PAtk: 100, Crit: 20},
+		{CommonModel: domain.CommonModel{ID: 3}, Name: "Iron Ring", PAtk: 80, HP: 200},
+		{CommonModel: domain.CommonModel{ID: 4}, Name: "Scholar Tome", EAtk: 150},
+	}
+	ownerNames := map[int64]string{1: "Fiore", 2: "Viola"}
+
+	type args struct {
+		id    int
+		input domain.RecommendAccessoryRequest
+	}
+	type want struct {
+		names []string
+		err   error
+	}
+	tests := []struct {
+		name       string
+		args       args
+		want       want
+		wantErr    bool
+		beforeTest func(ctx context.Context, args args, want want)
+	}{
+		{
+			name: "success excludes accessories owned by other travellers",
+			args: args{id: 10},
+			want: want{names: []string{"Iron Ring", "Fiore's Blade", "Scholar Tome"}},
+			beforeTest: func(ctx context.Context, args args, want want) {
+				s.travellerRepo.On("GetByID", mock.Anything, args.id, domain.TravellerInclude{}).Return(traveller, nil).Once()
+				s.accessoryRepo.On("GetList", mock.Anything, domain.ListAccessoryRequest{}, 0, -1).Return(accessories, ownerNames, int64(4), time.Time{}, nil).Once()
+			},
+		},
+		{
+			name: "success include owned with limit",
+			args: args{id: 10, input: domain.RecommendAccessoryRequest{Limit: 2, IncludeOwned: true}},
+			want: want{names: []string{"Viola's Fan", "Iron Ring"}},
+			beforeTest: func(ctx context.Context, args args, want want) {
+				s.travellerRepo.On("GetByID", mock.Anything, args.id, domain.TravellerInclude{}).Return(traveller, nil).Once()
+				s.accessoryRepo.On("GetList", mock.Anything, domain.ListAccessoryRequest{}, 0, -1).Return(accessories, ownerNames, int64(4), time.Time{}, nil).Once()
+			},
+		},
+		{
+			name:    "failed traveller not found",
+			args:    args{id: 99},
+			want:    want{err: domain.NewNotFoundError("traveller", 99, nil)},
+			wantErr: true,
+			beforeTest: func(ctx context.Context, args args, want want) {
+				s.travellerRepo.On("GetByID", mock.Anything, args.id, domain.TravellerInclude{}).Return(nil, want.err).Once()
+			},
+		},
+		{
+			name:    "failed to fetch accessories",
+			args:    args{id: 10},
+			want:    want{err: gorm.ErrInvalidDB},
+			wantErr: true,
+			beforeTest: func(ctx context.Context, args args, want want) {
+				s.travellerRepo.On("GetByID", mock.Anything, args.id, domain.TravellerInclude{}).Return(traveller, nil).Once()
+				s.accessoryRepo.On("GetList", mock.Anything, domain.ListAccessoryRequest{}, 0, -1).Return(nil, nil, int64(0), time.Time{}, want.err).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ctx := context.TODO()
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(ctx, tt.args, tt.want)
+			}
+
+			result, err := s.svc.GetRecommendedAccessories(ctx, tt.args.id, tt.args.input)
+			if tt.wantErr {
+				assert.Equal(s.T(), err, tt.want.err)
+				return
+			}
+
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), "Fiore", result.Traveller)
+			assert.Equal(s.T(), constants.JobWarrior, result.Job)
+
+			names := make([]string, len(result.Recommendations))
+			for i, r := range result.Recommendations {
+				names[i] = r.Accessory.Name
+				assert.NotEmpty(s.T(), r.Explanation)
+			}
+			assert.Equal(s.T(), tt.want.names, names)
+		})
+	}
+}
+
+func (s *TravellerServiceSuite) TestTravellerService_GetList_Facets() {
+	s.Run("labels counts and fills empty values", func() {
+		s.SetupTest()
+		filter := domain.ListTravellerRequest{Job: "Warrior", Facets: "job,rarity,job"}
+		parsed := filter
+		parsed.JobIDs = []int{constants.JobWarriorID}
+		parsed.FacetFields = []string{"job", "rarity"}
+
+		s.travellerRepo.On("GetList", mock.Anything, parsed, 0, 10).Return([]*domain.Traveller{}, int64(3), time.Time{}, nil).Once()
+		s.travellerRepo.On("GetFacets", mock.Anything, parsed, []string{"job", "rarity"}).Return(map[string]map[int]int64{
+			"job":    {constants.JobWarriorID: 3, constants.JobDancerID: 2},
+			"rarity": {5: 3},
+		}, nil).Once()
+
+		res, err := s.svc.GetList(context.TODO(), filter, helpers.PaginationParams{})
+		assert.NoError(s.T(), err)
+		assert.Len(s.T(), res.Facets, 2)
+		assert.Len(s.T(), res.Facets["job"], 8)
+		assert.Equal(s.T(), helpers.FacetCount{Value: "Warrior", Count: 3}, res.Facets["job"][0])
+		assert.Equal(s.T(), helpers.FacetCount{Value: "Merchant", Count: 0}, res.Facets["job"][1])
+		assert.Equal(s.T(), helpers.FacetCount{Value: "Dancer", Count: 2}, res.Facets["job"][7])
+		assert.Equal(s.T(), []helpers.FacetCount{
+			{Value: "1"}, {Value: "2"}, {Value: "3"}, {Value: "4"}, {Value: "5", Count: 3},
+		}, res.Facets["rarity"])
+	})
+
+	s.Run("no facets requested", func() {
+		s.SetupTest()
+		s.travellerRepo.On("GetList", mock.Anything, domain.ListTravellerRequest{}, 0, 10).Return([]*domain.Traveller{}, int64(0), time.Time{}, nil).Once()
+
+		res, err := s.svc.GetList(context.TODO(), domain.ListTravellerRequest{}, helpers.PaginationParams{})
+		assert.NoError(s.T(), err)
+		assert.Nil(s.T(), res.Facets)
+	})
+
+	s.Run("facet error", func() {
+		s.SetupTest()
+		filter := domain.ListTravellerRequest{Facets: "influence", FacetFields: []string{"influence"}}
+		s.travellerRepo.On("GetList", mock.Anything, filter, 0, 10).Return([]*domain.Traveller{}, int64(0), time.Time{}, nil).Once()
+		s.travellerRepo.On("GetFacets", mock.Anything, filter, []string{"influence"}).Return(nil, gorm.ErrInvalidDB).Once()
+
+		_, err := s.svc.GetList(context.TODO(), domain.ListTravellerRequest{Facets: "influence"}, helpers.PaginationParams{})
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+	})
+
+	s.Run("facet tested together with other fields in filter", func() {
+		s.SetupTest()
+
+		_, err := s.svc.GetList(context.TODO(), domain.ListTravellerRequest{Filter: "job=Warrior or rarity=5", Facets: "influence,job"}, helpers.PaginationParams{})
+
+		var ve *domain.ValidationError
+		s.Require().ErrorAs(err, &ve)
+		assert.Equal(s.T(), []domain.FieldError{
+			{Field: "facets", Message: "facet job can't be counted while filter tests job together with other fields"},
+		}, ve.Errors)
+		s.travellerRepo.AssertNotCalled(s.T(), "GetList", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *TravellerServiceSuite) TestTravellerService_GetList_DidYouMean() {
+	s.Run("suggests names when the name filter matches nothing", func() {
+		s.SetupTest()
+		filter := domain.ListTravellerRequest{Name: "Fiorre"}
+		s.travellerRepo.On("GetList", mock.Anything, filter, 0, 10).Return([]*domain.Traveller{}, int64(0), time.Time{}, nil).Once()
+		s.travellerRepo.On("SuggestNames", mock.Anything, "Fiorre", 3).Return([]string{"Fiore", "Fiona"}, nil).Once()
+
+		res, err := s.svc.GetList(context.TODO(), filter, helpers.PaginationParams{})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), []string{"Fiore", "Fiona"}, res.DidYouMean)
+	})
+
+	s.Run("no suggestions when something matched", func() {
+		s.SetupTest()
+		filter := domain.ListTravellerRequest{Name: "Fio"}
+		s.travellerRepo.On("GetList", mock.Anything, filter, 0, 10).Return([]*domain.Traveller{{Name: "Fiore"}}, int64(1), time.Time{}, nil).Once()
+
+		res, err := s.svc.GetList(context.TODO(), filter, helpers.PaginationParams{})
+		assert.NoError(s.T(), err)
+		assert.Nil(s.T(), res.DidYouMean)
+		s.travellerRepo.AssertNotCalled(s.T(), "SuggestNames")
+	})
+
+	s.Run("no suggestions without a name filter", func() {
+		s.SetupTest()
+		filter := domain.ListTravellerRequest{RarityMin: "5", RarityMinValue: 5}
+		s.travellerRepo.On("GetList", mock.Anything, filter, 0, 10).Return([]*domain.Traveller{}, int64(0), time.Time{}, nil).Once()
+
+		_, err := s.svc.GetList(context.TODO(), domain.ListTravellerRequest{RarityMin: "5"}, helpers.PaginationParams{})
+		assert.NoError(s.T(), err)
+		s.travellerRepo.AssertNotCalled(s.T(), "SuggestNames")
+	})
+
+	s.Run("suggestion error", func() {
+		s.SetupTest()
+		filter := domain.ListTravellerRequest{Name: "Fiorre"}
+		s.travellerRepo.On("GetList", mock.Anything, filter, 0, 10).Return([]*domain.Traveller{}, int64(0), time.Time{}, nil).Once()
+		s.travellerRepo.On("SuggestNames", mock.Anything, "Fiorre", 3).Return(nil, gorm.ErrInvalidDB).Once()
+
+		_, err := s.svc.GetList(context.TODO(), filter, helpers.PaginationParams{})
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+	})
+}
+
+func (s *TravellerServiceSuite) TestTravellerService_GetList_FilterExpression() {
+	s.Run("compiles the expression for the repository", func() {
+		s.SetupTest()
+		s.travellerRepo.On("GetList", mock.Anything, mock.MatchedBy(func(f domain.ListTravellerRequest) bool {
+			return f.FilterCondition != nil &&
+				f.FilterCondition.SQL == "(m_traveller.rarity >= ? AND m_traveller.job_id IN ?)" &&
+				assert.ObjectsAreEqual([]interface{}{4, []interface{}{constants.JobWarriorID, constants.JobDancerID}}, f.FilterCondition.Args)
+		}), 0, 10).Return([]*domain.Traveller{}, int64(0), time.Time{}, nil).Once()
+
+		_, err := s.svc.GetList(context.TODO(), domain.ListTravellerRequest{Filter: "rarity>=4 and job in (Warrior,Dancer)"}, helpers.PaginationParams{})
+		assert.NoError(s.T(), err)
+		s.travellerRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("invalid expression", func() {
+		s.SetupTest()
+		_, err := s.svc.GetList(context.TODO(), domain.ListTravellerRequest{Filter: "accessory_id=1"}, helpers.PaginationParams{})
+
+		var ve *domain.ValidationError
+		assert.True(s.T(), errors.As(err, &ve), "expected ValidationError")
+		assert.Equal(s.T(), "filter", ve.Errors[0].Field)
+		assert.Equal(s.T(), `unknown field "accessory_id" at position 0`, ve.Errors[0].Message)
+		s.travellerRepo.AssertNotCalled(s.T(), "GetList")
+	})
+}
+
+func (s *TravellerServiceSuite) TestTravellerService_GetPage() {
+	cursors := helpers.NewCursorCodec("test-secret")
+	sortedByRarity := mock.MatchedBy(func(f domain.ListTravellerRequest) bool {
+		return assert.ObjectsAreEqual([]domain.SortField{{Field: "rarity", Desc: true}}, f.Sort)
+	})
+
+	s.Run("page read from a cursor links both ways", func() {
+		s.SetupTest()
+		token, _ := cursors.Encode(helpers.Cursor{Order: "rarity:desc,id"})
+		cursor, _ := cursors.Decode(token, "rarity:desc,id")
+		s.travellerRepo.On("GetPage", mock.Anything, sortedByRarity, &cursor, 2).Return([]*domain.Traveller{
+			{CommonModel: domain.CommonModel{ID: 4}, Name: "Viola", Rarity: 5},
+			{CommonModel: domain.CommonModel{ID: 2}, Name: "Shen", Rarity: 4},
+		}, true, nil).Once()
+
+		res, err := s.svc.GetPage(context.TODO(), domain.ListTravellerRequest{OrderBy: "rarity", OrderDir: "desc"}, helpers.CursorParams{Cursor: token, Limit: 2})
+		assert.NoError(s.T(), err)
+		assert.Len(s.T(), res.Data, 2)
+		assert.Equal(s.T(), 2, res.Limit)
+
+		next, err := cursors.Decode(res.NextCursor, "rarity:desc,id")
+		assert.NoError(s.T(), err)
+		assert.False(s.T(), next.Backward)
+		values, _ := next.Values(0, int64(0))
+		assert.Equal(s.T(), []interface{}{4, int64(2)}, values)
+
+		prev, err := cursors.Decode(res.PrevCursor, "rarity:desc,id")
+		assert.NoError(s.T(), err)
+		assert.True(s.T(), prev.Backward)
+		values, _ = prev.Values(0, int64(0))
+		assert.Equal(s.T(), []interface{}{5, int64(4)}, values)
+	})
+
+	s.Run("counts the total when asked", func() {
+		s.SetupTest()
+		s.travellerRepo.On("GetPage", mock.Anything, mock.Anything, (*helpers.Cursor)(nil), 10).Return([]*domain.Traveller{}, false, nil).Once()
+		s.travellerRepo.On("Count", mock.Anything, mock.Anything).Return(int64(12), nil).Once()
+
+		res, err := s.svc.GetPage(context.TODO(), domain.ListTravellerRequest{}, helpers.CursorParams{IncludeTotal: true})
+		assert.NoError(s.T(), err)
+		assert.Empty(s.T(), res.NextCursor)
+		assert.Equal(s.T(), int64(12), *res.Total)
+	})
+
+	s.Run("cursor issued for another order", func() {
+		s.SetupTest()
+		token, _ := cursors.Encode(helpers.Cursor{Order: "name,id"})
+
+		_, err := s.svc.GetPage(context.TODO(), domain.ListTravellerRequest{OrderBy: "rarity"}, helpers.CursorParams{Cursor: token})
+		var ve *domain.ValidationError
+		assert.True(s.T(), errors.As(err, &ve), "expected ValidationError")
+		assert.Equal(s.T(), "cursor", ve.Errors[0].Field)
+		s.travellerRepo.AssertNotCalled(s.T(), "GetPage")
+	})
+
+	s.Run("cursor keys the repository can't use", func() {
+		s.SetupTest()
+		s.travellerRepo.On("GetPage", mock.Anything, mock.Anything, (*helpers.Cursor)(nil), 10).Return(nil, false, helpers.ErrInvalidCursor).Once()
+
+		_, err := s.svc.GetPage(context.TODO(), domain.ListTravellerRequest{}, helpers.CursorParams{})
+		var ve *domain.ValidationError
+		assert.True(s.T(), errors.As(err, &ve), "expected ValidationError")
+	})
+
+	s.Run("repository error", func() {
+		s.SetupTest()
+		s.travellerRepo.On("GetPage", mock.Anything, mock.Anything, (*helpers.Cursor)(nil), 10).Return(nil, false, gorm.ErrInvalidDB).Once()
+
+		_, err := s.svc.GetPage(context.TODO(), domain.ListTravellerRequest{}, helpers.CursorParams{})
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+	})
+}
+
+func (s *TravellerServiceSuite) TestTravellerService_GetList_Include() {
+	s.SetupTest()
+	s.travellerRepo.On("GetList", mock.Anything, mock.MatchedBy(func(f domain.ListTravellerRequest) bool {
+		return f.Relations.Accessory
+	}), 0, 10).Return([]*domain.Traveller{
+		{Name: "Fiore", Accessory: &domain.Accessory{Name: "Crown of Wisdom"}},
+	}, int64(1), time.Time{}, nil).Once()
+
+	res, err := s.svc.GetList(context.TODO(), domain.ListTravellerRequest{Include: "accessory"}, helpers.PaginationParams{})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "Crown of Wisdom", res.Data[0].Accessory.Name)
+	s.travellerRepo.AssertExpectations(s.T())
+}
+
+func (s *TravellerServiceSuite) TestTravellerService_GetByIDs() {
+	fiore := &domain.Traveller{CommonModel: domain.CommonModel{ID: 1}, Name: "Fiore", Accessory: &domain.Accessory{Name: "Blade"}}
+	viola := &domain.Traveller{CommonModel: domain.CommonModel{ID: 2}, Name: "Viola"}
+
+	s.Run("success in request order with missing ids", func() {
+		s.SetupTest()
+		s.travellerRepo.On("GetByIDs", mock.Anything, []int{2, 5, 1}, domain.TravellerIncludeAll).Return([]*domain.Traveller{fiore, viola}, nil).Once()
+
+		res, err := s.svc.GetByIDs(context.TODO(), domain.BatchGetRequest{IDs: "2,5,1"}, domain.TravellerIncludeAll)
+		assert.NoError(s.T(), err)
+		assert.Len(s.T(), res.Data, 2)
+		assert.Equal(s.T(), "Viola", res.Data[0].Name)
+		assert.Equal(s.T(), "Fiore", res.Data[1].Name)
+		assert.Equal(s.T(), "Blade", res.Data[1].Accessory.Name)
+		assert.Equal(s.T(), []int{5}, res.NotFound)
+		s.travellerRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("all found reports an empty not_found", func() {
+		s.SetupTest()
+		s.travellerRepo.On("GetByIDs", mock.Anything, []int{1}, domain.TravellerIncludeAll).Return([]*domain.Traveller{fiore}, nil).Once()
+
+		res, err := s.svc.GetByIDs(context.TODO(), domain.BatchGetRequest{IDs: "1"}, domain.TravellerIncludeAll)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), []int{}, res.NotFound)
+	})
+
+	s.Run("too many ids", func() {
+		s.SetupTest()
+		ids := make([]string, domain.MaxBatchGetSize+1)
+		for i := range ids {
+			ids[i] = strconv.Itoa(i + 1)
+		}
+
+		_, err := s.svc.GetByIDs(context.TODO(), domain.BatchGetRequest{IDs: strings.Join(ids, ",")}, domain.TravellerIncludeAll)
+		var ve *domain.ValidationError
+		assert.True(s.T(), errors.As(err, &ve), "expected ValidationError")
+	})
+}
+
+func (s *TravellerServiceSuite) TestTravellerService_Compare() {
+	fiore := &domain.Traveller{CommonModel: domain.CommonModel{ID: 1}, Name: "Fiore", JobID: constants.JobWarriorID}
+	viola := &domain.Traveller{CommonModel: domain.CommonModel{ID: 2}, Name: "Viola", JobID: constants.JobDancerID}
+
+	s.Run("success keeps requested order and drops duplicates", func() {
+		s.SetupTest()
+		s.travellerRepo.On("GetByIDs", mock.Anything, []int{2, 1}, domain.TravellerIncludeAll).Return([]*domain.Traveller{fiore, viola}, nil).Once()
+
+		res, err := s.svc.Compare(context.TODO(), domain.CompareTravellerRequest{IDs: "2, 1,2"})
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), "Viola", res.Travellers[0].Name)
+		assert.Equal(s.T(), "Fiore", res.Travellers[1].Name)
+		s.travellerRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("missing travellers are reported", func() {
+		s.SetupTest()
+		s.travellerRepo.On("GetByIDs", mock.Anything, []int{1, 8, 9}, domain.TravellerIncludeAll).Return([]*domain.Traveller{fiore}, nil).Once()
+
+		_, err := s.svc.Compare(context.TODO(), domain.CompareTravellerRequest{IDs: "1,8,9"})
+		var nfe *domain.NotFoundError
+		assert.True(s.T(), errors.As(err, &nfe), "expected NotFoundError")
+		assert.Equal(s.T(), "8,9", nfe.ID)
+		s.travellerRepo.AssertExpectations(s.T())
+	})
+
+	for _, tt := range []struct {
+		name string
+		ids  string
+	}{
+		{name: "invalid id", ids: "1,abc"},
+		{name: "non-positive id", ids: "1,0"},
+		{name: "single traveller", ids: "1,1"},
+		{name: "over the configured limit", ids: "1,2,3,4"},
+	} {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			_, err := s.svc.Compare(context.TODO(), domain.CompareTravellerRequest{IDs: tt.ids})
+			var ve *domain.ValidationError
+			assert.True(s.T(), errors.As(err, &ve), "expected ValidationError")
+		})
+	}
+
+	s.Run("repository error", func() {
+		s.SetupTest()
+		s.travellerRepo.On("GetByIDs", mock.Anything, []int{1, 2}, domain.TravellerIncludeAll).Return(nil, gorm.ErrInvalidDB).Once()
+
+		_, err := s.svc.Compare(context.TODO(), domain.CompareTravellerRequest{IDs: "1,2"})
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+	})
+}
@@ -39,8 +39,8 @@ func (_m *MockTokenService) EXPECT() *MockTokenService_Expecter {
 }
 
 // GenerateToken provides a mock function for the type MockTokenService
-func (_mock *MockTokenService) GenerateToken(ctx context.Context, username string) (string, time.Time, error) {
-	ret := _mock.Called(ctx, username)
+func (_mock *MockTokenService) GenerateToken(ctx context.Context, username string, editor bool) (string, time.Time, error) {
+	ret := _mock.Called(ctx, username, editor)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateToken")
@@ -49,21 +49,21 @@ func (_mock *MockTokenService) GenerateToken(ctx context.Context, username strin
 	var r0 string
 	var r1 time.Time
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, time.Time, error)); ok {
-		return returnFunc(ctx, username)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) (string, time.Time, error)); ok {
+		return returnFunc(ctx, username, editor)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = returnFunc(ctx, username)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) string); ok {
+		r0 = returnFunc(ctx, username, editor)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) time.Time); ok {
-		r1 = returnFunc(ctx, username)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bool) time.Time); ok {
+		r1 = returnFunc(ctx, username, editor)
 	} else {
 		r1 = ret.Get(1).(time.Time)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
-		r2 = returnFunc(ctx, username)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, bool) error); ok {
+		r2 = returnFunc(ctx, username, editor)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -78,11 +78,12 @@ type MockTokenService_GenerateToken_Call struct {
 // GenerateToken is a helper method to define mock.On call
 //   - ctx context.Context
 //   - username string
-func (_e *MockTokenService_Expecter) GenerateToken(ctx interface{}, username interface{}) *MockTokenService_GenerateToken_Call {
-	return &MockTokenService_GenerateToken_Call{Call: _e.mock.On("GenerateToken", ctx, username)}
+//   - editor bool
+func (_e *MockTokenService_Expecter) GenerateToken(ctx interface{}, username interface{}, editor interface{}) *MockTokenService_GenerateToken_Call {
+	return &MockTokenService_GenerateToken_Call{Call: _e.mock.On("GenerateToken", ctx, username, editor)}
 }
 
-func (_c *MockTokenService_GenerateToken_Call) Run(run func(ctx context.Context, username string)) *MockTokenService_GenerateToken_Call {
+func (_c *MockTokenService_GenerateToken_Call) Run(run func(ctx context.Context, username string, editor bool)) *MockTokenService_GenerateToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -92,9 +93,14 @@ func (_c *MockTokenService_GenerateToken_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -105,7 +111,7 @@ func (_c *MockTokenService_GenerateToken_Call) Return(token string, expiresAt ti
 	return _c
 }
 
-func (_c *MockTokenService_GenerateToken_Call) RunAndReturn(run func(ctx context.Context, username string) (string, time.Time, error)) *MockTokenService_GenerateToken_Call {
+func (_c *MockTokenService_GenerateToken_Call) RunAndReturn(run func(ctx context.Context, username string, editor bool) (string, time.Time, error)) *MockTokenService_GenerateToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -16,7 +16,7 @@ type UserRepository interface {
 }
 
 type TokenService interface {
-	GenerateToken(ctx context.Context, username string) (token string, expiresAt time.Time, err error)
+	GenerateToken(ctx context.Context, username string, editor bool) (token string, expiresAt time.Time, err error)
 }
 
 type userService struct {
@@ -60,7 +60,7 @@ func (s *userService) Login(ctx context.Context, req domain.LoginRequest) (res d
 	}
 
 	// Generate JWT token
-	token, _, err := s.tokenService.GenerateToken(ctx, user.Username)
+	token, _, err := s.tokenService.GenerateToken(ctx, user.Username, user.Editor)
 	if err != nil {
 		return res, err
 	}
@@ -84,7 +84,7 @@ func (s *UserServiceSuite) TestUserService_Login() {
 			wantErr: false,
 			beforeTest: func(ctx context.Context, args args, want want) {
 				s.userRepo.On("GetByUsername", mock.Anything, args.request.Username).Return(want.user, want.err).Once()
-				s.tokenService.On("GenerateToken", mock.Anything, args.request.Username).Return("valid-token", time.Now().Add(10*time.Minute), nil).Once()
+				s.tokenService.On("GenerateToken", mock.Anything, args.request.Username, false).Return("valid-token", time.Now().Add(10*time.Minute), nil).Once()
 			},
 		},
 		{
@@ -11,12 +11,21 @@ import (
 
 	_ "lizobly/ctc-db-api/docs"
 	"lizobly/ctc-db-api/internal/accessory"
+	"lizobly/ctc-db-api/internal/changerequest"
 	internalJWT "lizobly/ctc-db-api/internal/jwt"
+	"lizobly/ctc-db-api/internal/operation"
+	"lizobly/ctc-db-api/internal/publish"
+	"lizobly/ctc-db-api/internal/search"
+	"lizobly/ctc-db-api/internal/trash"
 	"lizobly/ctc-db-api/internal/traveller"
 	"lizobly/ctc-db-api/internal/user"
+	"lizobly/ctc-db-api/pkg/audit"
+	"lizobly/ctc-db-api/pkg/constants"
+	"lizobly/ctc-db-api/pkg/domain"
 	"lizobly/ctc-db-api/pkg/helpers"
 	"lizobly/ctc-db-api/pkg/logging"
 	pkgMiddleware "lizobly/ctc-db-api/pkg/middleware"
+	"lizobly/ctc-db-api/pkg/publication"
 	"lizobly/ctc-db-api/pkg/telemetry"
 	"lizobly/ctc-db-api/pkg/validator"
 
@@ -138,9 +147,9 @@ func initDatabase(logger *logging.Logger) (*gorm.DB, *sql.DB) {
 			zap.String("db.port", dbPort))
 	}
 
-	db, err := gorm.Open(postgres.New(postgres.Config{
+	db, err := gorm.Open(helpers.NewDialector(postgres.New(postgres.Config{
 		Conn: dbConn,
-	}), &gorm.Config{
+	})), &gorm.Config{
 		TranslateError: true,
 	})
 	if err != nil {
@@ -149,6 +158,19 @@ func initDatabase(logger *logging.Logger) (*gorm.DB, *sql.DB) {
 			zap.String("db.system", "postgres"))
 	}
 
+	// Record the authenticated user in the audit columns of every write
+	if err = audit.Register(db); err != nil {
+		logger.Fatal("Failed to register audit callbacks", zap.Error(err))
+	}
+	// Record a revision of every traveller and accessory write, for their history and reverts
+	if err = audit.RegisterRevisions(db); err != nil {
+		logger.Fatal("Failed to register revision callbacks", zap.Error(err))
+	}
+	// Hide drafts and scheduled records from readers who aren't editors
+	if err = publication.Register(db); err != nil {
+		logger.Fatal("Failed to register publication callbacks", zap.Error(err))
+	}
+
 	if err = dbConn.Ping(); err != nil {
 		logger.Fatal("Failed to ping database",
 			zap.Error(err),
@@ -237,27 +259,87 @@ func setupRoutes(e *echo.Echo, db *gorm.DB, logger *logging.Logger) {
 	}
 	tokenService := internalJWT.NewTokenService(jwtSecretKey, jwtTimeout, logger)
 
+	// Initialize operation service for writes sent with Prefer: respond-async
+	operationTimeoutStr := helpers.EnvWithDefault("OPERATION_TIMEOUT", "5m")
+	operationTimeout, err := time.ParseDuration(operationTimeoutStr)
+	if err != nil {
+		logger.Fatal("Invalid OPERATION_TIMEOUT format",
+			zap.String("operation.timeout", operationTimeoutStr),
+			zap.Error(err))
+	}
+	operationRetentionStr := helpers.EnvWithDefault("OPERATION_RETENTION", "1h")
+	operationRetention, err := time.ParseDuration(operationRetentionStr)
+	if err != nil {
+		logger.Fatal("Invalid OPERATION_RETENTION format",
+			zap.String("operation.retention", operationRetentionStr),
+			zap.Error(err))
+	}
+	operationService := operation.NewOperationService(operationTimeout, operationRetention, logger)
+
+	// Deleted records are purged once they have been in the trash for TRASH_RETENTION
+	trashRetentionStr := helpers.EnvWithDefault("TRASH_RETENTION", "720h")
+	trashRetention, err := time.ParseDuration(trashRetentionStr)
+	if err != nil {
+		logger.Fatal("Invalid TRASH_RETENTION format",
+			zap.String("trash.retention", trashRetentionStr),
+			zap.Error(err))
+	}
+	trashPurgeIntervalStr := helpers.EnvWithDefault("TRASH_PURGE_INTERVAL", "1h")
+	trashPurgeInterval, err := time.ParseDuration(trashPurgeIntervalStr)
+	if err != nil || trashPurgeInterval <= 0 {
+		logger.Fatal("Invalid TRASH_PURGE_INTERVAL format",
+			zap.String("trash.purge_interval", trashPurgeIntervalStr),
+			zap.Error(err))
+	}
+
+	// Scheduled records are published every PUBLISH_INTERVAL once their publish time has come
+	publishIntervalStr := helpers.EnvWithDefault("PUBLISH_INTERVAL", "1m")
+	publishInterval, err := time.ParseDuration(publishIntervalStr)
+	if err != nil || publishInterval <= 0 {
+		logger.Fatal("Invalid PUBLISH_INTERVAL format",
+			zap.String("publish.interval", publishIntervalStr),
+			zap.Error(err))
+	}
+
 	// Initialize repositories
 	travellerRepo := traveller.NewTravellerRepository(db, logger)
 	accessoryRepo := accessory.NewAccessoryRepository(db, logger)
 	userRepo := user.NewUserRepository(db, logger)
+	searchRepo := search.NewSearchRepository(db, logger)
+	trashRepo := trash.NewTrashRepository(db, logger)
+	publishRepo := publish.NewPublishRepository(db, logger)
+	changeRequestRepo := changerequest.NewChangeRequestRepository(db, logger)
 
 	// Initialize services
-	travellerService := traveller.NewTravellerService(travellerRepo, logger)
+	cursors := helpers.NewCursorCodec(helpers.EnvWithDefault("CURSOR_SECRET", jwtSecretKey))
+	compareLimit := helpers.EnvWithDefaultInt("TRAVELLER_COMPARE_LIMIT", domain.DefaultCompareLimit)
+	travellerService := traveller.NewTravellerService(travellerRepo, accessoryRepo, compareLimit, cursors, logger)
 	userService := user.NewUserService(userRepo, tokenService, logger)
-	accessoryService := accessory.NewAccessoryService(accessoryRepo, logger)
+	accessoryService := accessory.NewAccessoryService(accessoryRepo, cursors, logger)
+	searchService := search.NewSearchService(searchRepo, logger)
+	trashService := trash.NewTrashService(trashRepo, trashRetention, logger)
+	go trashService.RunRetention(context.Background(), trashPurgeInterval)
+	publishService := publish.NewPublishService(publishRepo, logger)
+	go publishService.RunPublisher(context.Background(), publishInterval)
+	changeRequestService := changerequest.NewChangeRequestService(changeRequestRepo, travellerService, accessoryService, e.Validator, logger)
 
 	// Setup API group with optional JWT middleware
-	v1 := e.Group("/api/v1")
+	v1 := e.Group(constants.APIBasePath)
 	if helpers.EnvWithDefaultBool("AUTH_IS_ENABLED", false) {
 		jwtMiddleware := pkgMiddleware.NewJWTMiddleware()
 		v1.Use(jwtMiddleware)
+	} else {
+		v1.Use(pkgMiddleware.NewUnauthenticatedEditorMiddleware())
 	}
 
 	// Register handlers
-	traveller.NewTravellerHandler(v1, travellerService, logger)
+	traveller.NewTravellerHandler(v1, travellerService, operationService, logger)
 	user.NewUserHandler(v1, userService, logger)
 	accessory.NewAccessoryHandler(v1, accessoryService, logger)
+	search.NewSearchHandler(v1, searchService, logger)
+	operation.NewOperationHandler(v1, operationService, logger)
+	trash.NewTrashHandler(v1, trashService, logger)
+	changerequest.NewChangeRequestHandler(v1, changeRequestService, logger)
 
 	// Health check
 	e.GET("/health", func(c echo.Context) error {
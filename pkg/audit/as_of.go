@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"database/sql"
+	"fmt"
+	"lizobly/ctc-db-api/pkg/domain"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// asOfColumn is a column a revision records. Convert turns the recorded text, %s, into the
+// column's type.
+type asOfColumn struct {
+	name    string
+	convert string
+}
+
+// asOfTable describes how to rebuild the rows of a revisioned table from its revisions
+type asOfTable struct {
+	name  string
+	state []asOfColumn
+	// kept lists the columns revisions don't record; rebuilt rows take them from the current row
+	kept []string
+}
+
+// asOfTables lists the revisioned tables by the entity type their revisions are recorded under
+var asOfTables = map[string]asOfTable{
+	domain.TrashTypeTraveller: {
+		name: "m_traveller",
+		state: []asOfColumn{
+			{"name", "%s"},
+			{"rarity", "(%s)::int"},
+			{"banner", "%s"},
+			{"release_date", "to_date(NULLIF(%s, ''), 'DD-MM-YYYY')"},
+			{"influence_id", "(%s)::int"},
+			{"job_id", "(%s)::int"},
+			{"accessory_id", "(%s)::int"},
+			{"status", "COALESCE(%s, 'published')"},
+		},
+		kept: []string{"name_key", "slug", "publish_at"},
+	},
+	domain.TrashTypeAccessory: {
+		name: "m_accessory",
+		state: []asOfColumn{
+			{"name", "%s"},
+			{"hp", "(%s)::int"},
+			{"sp", "(%s)::int"},
+			{"patk", "(%s)::int"},
+			{"pdef", "(%s)::int"},
+			{"eatk", "(%s)::int"},
+			{"edef", "(%s)::int"},
+			{"spd", "(%s)::int"},
+			{"crit", "(%s)::int"},
+			{"effect", "%s"},
+			{"status", "COALESCE(%s, 'published')"},
+		},
+		kept: []string{"slug", "publish_at"},
+	},
+}
+
+// asOfCommonColumns are the CommonModel columns rebuilt rows take from the current row
+var asOfCommonColumns = []string{"created_by", "updated_by", "deleted_by", "created_at", "version"}
+
+// AsOf makes db read the table of entityType as it was at the given time, under the table's own
+// name, so filters, ordering and counts written against the table work unchanged. The rows are
+// rebuilt from the revisions around that time: the state the last revision before it left, or
+// the state the first one after it found. Rows without revisions are read as they are now if
+// they existed then. Only the revisioned fields are historical; the slug, version and created
+// columns are the current ones, and updated_at is the time of the last revision before at when
+// there is one.
+func AsOf(db *gorm.DB, entityType string, at time.Time) *gorm.DB {
+	table, ok := asOfTables[entityType]
+	if !ok {
+		_ = db.AddError(fmt.Errorf("no revisioned table for %q", entityType))
+		return db
+	}
+	return db.Table("(?) AS "+table.name, Snapshot(db, entityType, at))
+}
+
+// Snapshot returns a subquery selecting the rows of entityType's table as they were at the given
+// time, for joins AsOf can't express
+func Snapshot(db *gorm.DB, entityType string, at time.Time) *gorm.DB {
+	tx := db.Session(&gorm.Session{NewDB: true})
+	table, ok := asOfTables[entityType]
+	if !ok {
+		_ = tx.AddError(fmt.Errorf("no revisioned table for %q", entityType))
+		return tx
+	}
+	return tx.Raw(table.snapshotSQL(), sql.Named("type", entityType), sql.Named("at", at))
+}
+
+func (t asOfTable) snapshotSQL() string {
+	columns := []string{"ids.id"}
+	for _, column := range t.state {
+		recorded := fmt.Sprintf(column.convert, "s.state->>'"+column.name+"'")
+		columns = append(columns, fmt.Sprintf("CASE WHEN s.state IS NULL THEN cur.%[1]s ELSE %[2]s END AS %[1]s", column.name, recorded))
+	}
+	for _, column := range append(append([]string{}, t.kept...), asOfCommonColumns...) {
+		columns = append(columns, "cur."+column)
+	}
+	columns = append(columns,
+		"COALESCE(prev_rev.created_at, CASE WHEN next_rev.action IS NULL THEN cur.updated_at END, cur.created_at) AS updated_at",
+		"CAST(NULL AS timestamptz) AS deleted_at",
+	)
+
+	// A row existed at the time unless the revision before it removed it, or the one after it
+	// created it. Rows no revision covers are judged by their own timestamps.
+	return "SELECT " + strings.Join(columns, ", ") +
+		" FROM (SELECT id FROM " + t.name + " UNION SELECT entity_id FROM m_revision WHERE entity_type = @type) AS ids" +
+		" LEFT JOIN " + t.name + " AS cur ON cur.id = ids.id" +
+		" LEFT JOIN LATERAL (SELECT action, after, created_at FROM m_revision WHERE entity_type = @type AND entity_id = ids.id AND created_at <= @at ORDER BY created_at DESC, id DESC LIMIT 1) AS prev_rev ON true" +
+		" LEFT JOIN LATERAL (SELECT action, before FROM m_revision WHERE entity_type = @type AND entity_id = ids.id AND created_at > @at ORDER BY created_at, id LIMIT 1) AS next_rev ON true" +
+		" CROSS JOIN LATERAL (SELECT COALESCE(prev_rev.after, next_rev.before) AS state) AS s" +
+		" WHERE CASE" +
+		" WHEN prev_rev.action IS NOT NULL THEN prev_rev.action NOT IN ('delete', 'purge')" +
+		" WHEN next_rev.action IS NOT NULL THEN next_rev.action NOT IN ('create', 'restore')" +
+		" ELSE cur.created_at <= @at AND (cur.deleted_at IS NULL OR cur.deleted_at > @at)" +
+		" END"
+}
@@ -0,0 +1,49 @@
+package audit
+
+import (
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAsOf tests reading a table as it was at a point in time
+func TestAsOf(t *testing.T) {
+	at := time.Date(2024, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("reads the rebuilt rows under the table's name", func(t *testing.T) {
+		db, mock, err := helpers.NewMockDB()
+		assert.NoError(t, err)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM (SELECT ids.id, CASE WHEN s.state IS NULL THEN cur.name ELSE s.state->>'name' END AS name, `+
+			`CASE WHEN s.state IS NULL THEN cur.rarity ELSE (s.state->>'rarity')::int END AS rarity, `)).
+			WithArgs(domain.TrashTypeTraveller, domain.TrashTypeTraveller, at, domain.TrashTypeTraveller, at, at, at, 5).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "rarity"}).AddRow(1, "Viola", 5))
+
+		var travellers []domain.Traveller
+		err = AsOf(db, domain.TrashTypeTraveller, at).Where("m_traveller.rarity = ?", 5).Find(&travellers).Error
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Traveller{{CommonModel: domain.CommonModel{ID: 1}, Name: "Viola", Rarity: 5}}, travellers)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rebuilds dates and keeps unrecorded columns", func(t *testing.T) {
+		sql := asOfTables[domain.TrashTypeTraveller].snapshotSQL()
+		assert.Contains(t, sql, `to_date(NULLIF(s.state->>'release_date', ''), 'DD-MM-YYYY')`)
+		assert.Contains(t, sql, "cur.slug")
+		assert.Contains(t, sql, "FROM (SELECT id FROM m_traveller UNION SELECT entity_id FROM m_revision WHERE entity_type = @type) AS ids")
+	})
+
+	t.Run("unknown entity type", func(t *testing.T) {
+		db, _, err := helpers.NewMockDB()
+		assert.NoError(t, err)
+
+		var rows []map[string]interface{}
+		err = AsOf(db, "influence", at).Find(&rows).Error
+		assert.ErrorContains(t, err, `no revisioned table for "influence"`)
+	})
+}
@@ -0,0 +1,100 @@
+// Package audit records who writes each row. The username comes from the context the JWT
+// middleware fills through logging.WithUserID, so it follows every statement run with that
+// context, including those inside transactions.
+package audit
+
+import (
+	"lizobly/ctc-db-api/pkg/logging"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Audit columns, as named in CommonModel
+const (
+	createdByColumn = "created_by"
+	updatedByColumn = "updated_by"
+	deletedByColumn = "deleted_by"
+)
+
+// Register adds callbacks to db that fill created_by and updated_by on create, updated_by on
+// update and deleted_by on soft delete. Writes without a user in their context, and models
+// without the columns, are left alone. Updates that skip hooks, like UpdateColumn, are
+// housekeeping and don't count as the user's change.
+func Register(db *gorm.DB) error {
+	err := db.Callback().Create().Before("gorm:create").Register("audit:create", onCreate)
+	if err != nil {
+		return err
+	}
+	err = db.Callback().Update().Before("gorm:update").Register("audit:update", onUpdate)
+	if err != nil {
+		return err
+	}
+	return db.Callback().Delete().Before("gorm:delete").Register("audit:delete", onDelete)
+}
+
+func onCreate(db *gorm.DB) {
+	user := logging.GetUserID(db.Statement.Context)
+	if db.Error != nil || user == "" || db.Statement.Schema == nil {
+		return
+	}
+
+	for _, column := range []string{createdByColumn, updatedByColumn} {
+		if db.Statement.Schema.LookUpField(column) != nil {
+			db.Statement.SetColumn(column, user, true)
+		}
+	}
+}
+
+func onUpdate(db *gorm.DB) {
+	user := logging.GetUserID(db.Statement.Context)
+	if db.Error != nil || user == "" || db.Statement.Schema == nil || db.Statement.SkipHooks {
+		return
+	}
+
+	if db.Statement.Schema.LookUpField(updatedByColumn) != nil {
+		db.Statement.SetColumn(updatedByColumn, user, true)
+	}
+}
+
+// onDelete builds a soft delete that also sets deleted_by. GORM's soft delete clause only sets
+// deleted_at and replaces any SET clause given to it, so the statement is built here the way
+// gorm.SoftDeleteDeleteClause builds it; with the SQL in place, that clause then steps aside.
+func onDelete(db *gorm.DB) {
+	stmt := db.Statement
+	user := logging.GetUserID(stmt.Context)
+	if db.Error != nil || user == "" || stmt.Schema == nil || stmt.Unscoped || stmt.SQL.Len() > 0 {
+		return
+	}
+
+	deletedBy := stmt.Schema.LookUpField(deletedByColumn)
+	if deletedBy == nil {
+		return
+	}
+	for _, c := range stmt.Schema.DeleteClauses {
+		if softDelete, ok := c.(gorm.SoftDeleteDeleteClause); ok {
+			buildSoftDelete(stmt, softDelete, deletedBy, user)
+			return
+		}
+	}
+}
+
+func buildSoftDelete(stmt *gorm.Statement, softDelete gorm.SoftDeleteDeleteClause, deletedBy *schema.Field, user string) {
+	now := stmt.DB.NowFunc()
+	stmt.AddClause(clause.Set{
+		{Column: clause.Column{Name: softDelete.Field.DBName}, Value: now},
+		{Column: clause.Column{Name: deletedBy.DBName}, Value: user},
+	})
+	stmt.SetColumn(softDelete.Field.DBName, now, true)
+	stmt.SetColumn(deletedBy.DBName, &user, true)
+
+	// Limit the delete to the primary keys of the value and the model, if they have them
+	if identity := identityConditions(stmt); len(identity) > 0 {
+		stmt.AddClause(clause.Where{Exprs: identity})
+	}
+
+	gorm.SoftDeleteQueryClause(softDelete).ModifyStatement(stmt)
+	stmt.AddClauseIfNotExists(clause.Update{})
+	stmt.Build(stmt.DB.Callback().Update().Clauses...)
+}
@@ -0,0 +1,183 @@
+package audit
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type AuditSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+	ctx  context.Context
+}
+
+func TestAuditSuite(t *testing.T) {
+	suite.Run(t, new(AuditSuite))
+}
+
+func (s *AuditSuite) SetupTest() {
+	var err error
+	s.db, s.mock, err = helpers.NewMockDB()
+	if err != nil {
+		s.T().Fatal()
+	}
+	if err = Register(s.db); err != nil {
+		s.T().Fatal(err)
+	}
+	s.ctx = logging.WithUserID(context.Background(), "isla")
+}
+
+func (s *AuditSuite) TestCreate() {
+	s.Run("records the user as creator and last editor", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_accessory" ("created_by","updated_by","deleted_by","created_at","updated_at","version","deleted_at","name","slug"`)).
+			WithArgs("isla", "isla", nil, helpers.AnyTime{}, helpers.AnyTime{}, 1, nil, "Crown of Wisdom", "crown-of-wisdom",
+				0, 0, 0, 0, 0, 0, 0, 0, "", domain.PublishStatusPublished, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+		s.mock.ExpectCommit()
+
+		accessory := &domain.Accessory{Slug: "crown-of-wisdom", Name: "Crown of Wisdom"}
+		err := s.db.WithContext(s.ctx).Create(accessory).Error
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), "isla", accessory.CreatedBy)
+		assert.Equal(s.T(), "isla", accessory.UpdatedBy)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("leaves anonymous writes alone", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_accessory"`)).
+			WithArgs("", "", nil, helpers.AnyTime{}, helpers.AnyTime{}, 1, nil, "Crown of Wisdom", "crown-of-wisdom",
+				0, 0, 0, 0, 0, 0, 0, 0, "", domain.PublishStatusPublished, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+		s.mock.ExpectCommit()
+
+		err := s.db.WithContext(context.Background()).Create(&domain.Accessory{Slug: "crown-of-wisdom", Name: "Crown of Wisdom"}).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *AuditSuite) TestUpdate() {
+	s.Run("records the user as last editor", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "banner"=$1,"updated_by"=$2,"updated_at"=$3 WHERE id = $4 AND "m_traveller"."deleted_at" IS NULL`)).
+			WithArgs("Standard Banner", "isla", helpers.AnyTime{}, 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		err := s.db.WithContext(s.ctx).Model(&domain.Traveller{}).Where("id = ?", 4).
+			Updates(map[string]interface{}{"banner": "Standard Banner"}).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("inside a transaction", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "rarity"=$1,"updated_by"=$2,"updated_at"=$3 WHERE id = $4`)).
+			WithArgs(5, "isla", helpers.AnyTime{}, 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		err := s.db.WithContext(s.ctx).Transaction(func(tx *gorm.DB) error {
+			return tx.Model(&domain.Traveller{}).Where("id = ?", 4).Update("rarity", 5).Error
+		})
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("housekeeping skips hooks", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "accessory_id"=$1 WHERE id = $2 AND "m_traveller"."deleted_at" IS NULL`)).
+			WithArgs(nil, 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		err := s.db.WithContext(s.ctx).Model(&domain.Traveller{}).Where("id = ?", 4).UpdateColumn("accessory_id", nil).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("models without audit columns", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_external_id" SET "entity_id"=$1,"updated_at"=$2 WHERE entity_type = $3`)).
+			WithArgs(4, helpers.AnyTime{}, domain.ExternalEntityTraveller).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		err := s.db.WithContext(s.ctx).Model(&domain.ExternalID{}).Where("entity_type = ?", domain.ExternalEntityTraveller).
+			Update("entity_id", 4).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *AuditSuite) TestDelete() {
+	s.Run("soft delete records the user", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "deleted_at"=$1,"deleted_by"=$2 WHERE "m_traveller"."id" = $3 AND "m_traveller"."deleted_at" IS NULL`)).
+			WithArgs(helpers.AnyTime{}, "isla", 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		result := s.db.WithContext(s.ctx).Delete(&domain.Traveller{}, 4)
+		assert.NoError(s.T(), result.Error)
+		assert.Equal(s.T(), int64(1), result.RowsAffected)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("soft delete of a loaded row", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "deleted_at"=$1,"deleted_by"=$2 WHERE "m_traveller"."id" = $3 AND "m_traveller"."deleted_at" IS NULL`)).
+			WithArgs(helpers.AnyTime{}, "isla", 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		traveller := &domain.Traveller{CommonModel: domain.CommonModel{ID: 4}}
+		assert.NoError(s.T(), s.db.WithContext(s.ctx).Delete(traveller).Error)
+		assert.Equal(s.T(), "isla", *traveller.DeletedBy)
+		assert.True(s.T(), traveller.DeletedAt.Valid)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("anonymous soft delete", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "deleted_at"=$1 WHERE "m_traveller"."id" = $2 AND "m_traveller"."deleted_at" IS NULL`)).
+			WithArgs(helpers.AnyTime{}, 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		assert.NoError(s.T(), s.db.WithContext(context.Background()).Delete(&domain.Traveller{}, 4).Error)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("hard delete", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "m_traveller" WHERE deleted_at IS NOT NULL`)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		s.mock.ExpectCommit()
+
+		assert.NoError(s.T(), s.db.WithContext(s.ctx).Unscoped().Where("deleted_at IS NOT NULL").Delete(&domain.Traveller{}).Error)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,272 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/logging"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// revisionActionKey carries an action that replaces update in the revisions of a write
+type revisionActionKey struct{}
+
+// revisionRowsKey holds the rows a write is about to change, as read before it runs
+const revisionRowsKey = "audit:revision_rows"
+
+// WithRevisionAction records the updates made with the returned context under action instead
+// of update, as a revert does
+func WithRevisionAction(ctx context.Context, action string) context.Context {
+	return context.WithValue(ctx, revisionActionKey{}, action)
+}
+
+// RegisterRevisions adds callbacks to db that record a domain.Revision for every row of a
+// domain.Revisioned model a write changes, in the same transaction as the write. Updates and
+// deletes lock and read the rows they match first, so each revision holds the row's state before
+// and after. Writes that leave the recorded state as it was, like version bumps, record nothing.
+func RegisterRevisions(db *gorm.DB) error {
+	err := db.Callback().Create().After("gorm:create").Before("gorm:commit_or_rollback_transaction").Register("audit:revision_create", recordCreated)
+	if err != nil {
+		return err
+	}
+	err = db.Callback().Update().Before("gorm:update").Register("audit:revision_capture_update", captureRevisionRows)
+	if err != nil {
+		return err
+	}
+	err = db.Callback().Update().After("gorm:update").Before("gorm:commit_or_rollback_transaction").Register("audit:revision_update", recordChanged)
+	if err != nil {
+		return err
+	}
+	err = db.Callback().Delete().Before("gorm:delete").Register("audit:revision_capture_delete", captureRevisionRows)
+	if err != nil {
+		return err
+	}
+	return db.Callback().Delete().After("gorm:delete").Before("gorm:commit_or_rollback_transaction").Register("audit:revision_delete", recordChanged)
+}
+
+// revisionRow is the recorded state of one row
+type revisionRow struct {
+	id         int64
+	entityType string
+	deleted    bool
+	state      []byte
+}
+
+func recordCreated(db *gorm.DB) {
+	if db.Error != nil || db.RowsAffected == 0 || !isRevisioned(db.Statement) {
+		return
+	}
+
+	rows, err := revisionRows(db.Statement, db.Statement.ReflectValue)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	revisions := make([]domain.Revision, 0, len(rows))
+	for _, row := range rows {
+		// Rows an ON CONFLICT DO NOTHING skipped get no ID
+		if row.id == 0 {
+			continue
+		}
+		revisions = append(revisions, newRevision(db.Statement.Context, row, domain.RevisionActionCreate, nil, row.state))
+	}
+	saveRevisions(db, revisions)
+}
+
+// captureRevisionRows locks and reads the rows the write matches, for recordChanged to compare
+// with once it has run
+func captureRevisionRows(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || !isRevisioned(stmt) {
+		return
+	}
+
+	where, hasWhere := stmt.Clauses["WHERE"].Expression.(clause.Where)
+	identity := identityConditions(stmt)
+	if !hasWhere && len(identity) == 0 {
+		// GORM refuses writes without conditions
+		return
+	}
+
+	// Deleted rows are read too, so restores and purges have a before state
+	query := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"})
+	if hasWhere {
+		query = query.Clauses(where)
+	}
+	if len(identity) > 0 {
+		query = query.Clauses(clause.Where{Exprs: identity})
+	}
+
+	found := reflect.New(reflect.SliceOf(reflect.PointerTo(stmt.Schema.ModelType)))
+	if err := query.Find(found.Interface()).Error; err != nil {
+		db.AddError(err)
+		return
+	}
+
+	rows, err := revisionRows(stmt, found.Elem())
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+	db.InstanceSet(revisionRowsKey, rows)
+}
+
+// recordChanged reads back the rows captureRevisionRows read and records a revision for each
+// one the write changed
+func recordChanged(db *gorm.DB) {
+	stmt := db.Statement
+	value, ok := db.InstanceGet(revisionRowsKey)
+	if db.Error != nil || db.RowsAffected == 0 || !ok {
+		return
+	}
+	before := value.([]revisionRow)
+	if len(before) == 0 {
+		return
+	}
+
+	ids := make([]int64, 0, len(before))
+	for _, row := range before {
+		ids = append(ids, row.id)
+	}
+	found := reflect.New(reflect.SliceOf(reflect.PointerTo(stmt.Schema.ModelType)))
+	err := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Unscoped().
+		Where(clause.IN{Column: clause.PrimaryColumn, Values: toValues(ids)}).
+		Find(found.Interface()).Error
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+	after, err := revisionRows(stmt, found.Elem())
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+	afterByID := make(map[int64]revisionRow, len(after))
+	for _, row := range after {
+		afterByID[row.id] = row
+	}
+
+	override, _ := stmt.Context.Value(revisionActionKey{}).(string)
+	revisions := make([]domain.Revision, 0, len(before))
+	for _, b := range before {
+		a, exists := afterByID[b.id]
+		switch {
+		case !exists:
+			revisions = append(revisions, newRevision(stmt.Context, b, domain.RevisionActionPurge, b.state, nil))
+		case !b.deleted && a.deleted:
+			revisions = append(revisions, newRevision(stmt.Context, b, domain.RevisionActionDelete, b.state, a.state))
+		case b.deleted && !a.deleted:
+			revisions = append(revisions, newRevision(stmt.Context, b, domain.RevisionActionRestore, b.state, a.state))
+		case !bytes.Equal(b.state, a.state):
+			action := domain.RevisionActionUpdate
+			if override != "" {
+				action = override
+			}
+			revisions = append(revisions, newRevision(stmt.Context, b, action, b.state, a.state))
+		}
+	}
+	saveRevisions(db, revisions)
+}
+
+func isRevisioned(stmt *gorm.Statement) bool {
+	if stmt.Schema == nil {
+		return false
+	}
+	_, ok := reflect.New(stmt.Schema.ModelType).Interface().(domain.Revisioned)
+	return ok
+}
+
+// revisionRows records the state of each row in value, a model or a slice of them
+func revisionRows(stmt *gorm.Statement, value reflect.Value) ([]revisionRow, error) {
+	value = reflect.Indirect(value)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		row, err := newRevisionRow(stmt, value)
+		if err != nil {
+			return nil, err
+		}
+		return []revisionRow{row}, nil
+	}
+
+	rows := make([]revisionRow, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		row, err := newRevisionRow(stmt, reflect.Indirect(value.Index(i)))
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func newRevisionRow(stmt *gorm.Statement, value reflect.Value) (revisionRow, error) {
+	model := value.Interface().(domain.Revisioned)
+	state, err := json.Marshal(model.RevisionState())
+	if err != nil {
+		return revisionRow{}, err
+	}
+
+	row := revisionRow{entityType: model.RevisionType(), state: state}
+	if id, isZero := stmt.Schema.PrioritizedPrimaryField.ValueOf(stmt.Context, value); !isZero {
+		row.id = reflect.ValueOf(id).Int()
+	}
+	if deletedAt := stmt.Schema.LookUpField("deleted_at"); deletedAt != nil {
+		_, isZero := deletedAt.ValueOf(stmt.Context, value)
+		row.deleted = !isZero
+	}
+	return row, nil
+}
+
+func newRevision(ctx context.Context, row revisionRow, action string, before, after []byte) domain.Revision {
+	return domain.Revision{
+		EntityType: row.entityType,
+		EntityID:   row.id,
+		Action:     action,
+		Before:     before,
+		After:      after,
+		Actor:      logging.GetUserID(ctx),
+		RequestID:  logging.GetRequestID(ctx),
+	}
+}
+
+func saveRevisions(db *gorm.DB, revisions []domain.Revision) {
+	if len(revisions) == 0 {
+		return
+	}
+	if err := db.Session(&gorm.Session{NewDB: true}).Create(&revisions).Error; err != nil {
+		db.AddError(err)
+	}
+}
+
+// identityConditions limits a statement to the primary keys of its value and its model, if they
+// have them, as GORM does when it builds updates and deletes
+func identityConditions(stmt *gorm.Statement) []clause.Expression {
+	var conditions []clause.Expression
+	_, queryValues := schema.GetIdentityFieldValuesMap(stmt.Context, stmt.ReflectValue, stmt.Schema.PrimaryFields)
+	column, values := schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, queryValues)
+	if len(values) > 0 {
+		conditions = append(conditions, clause.IN{Column: column, Values: values})
+	}
+	if stmt.ReflectValue.CanAddr() && stmt.Dest != stmt.Model && stmt.Model != nil {
+		_, queryValues = schema.GetIdentityFieldValuesMap(stmt.Context, reflect.ValueOf(stmt.Model), stmt.Schema.PrimaryFields)
+		column, values = schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, queryValues)
+		if len(values) > 0 {
+			conditions = append(conditions, clause.IN{Column: column, Values: values})
+		}
+	}
+	return conditions
+}
+
+func toValues(ids []int64) []interface{} {
+	values := make([]interface{}, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+	return values
+}
@@ -0,0 +1,226 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type RevisionSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+	ctx  context.Context
+}
+
+func TestRevisionSuite(t *testing.T) {
+	suite.Run(t, new(RevisionSuite))
+}
+
+func (s *RevisionSuite) SetupTest() {
+	var err error
+	s.db, s.mock, err = helpers.NewMockDB()
+	if err != nil {
+		s.T().Fatal()
+	}
+	if err = RegisterRevisions(s.db); err != nil {
+		s.T().Fatal(err)
+	}
+	s.ctx = logging.WithRequestID(logging.WithUserID(context.Background(), "isla"), "req-1")
+}
+
+// travellerRows returns traveller rows with the given rarities and deleted_at values
+func travellerRows(id int64, rarity int, deletedAt interface{}) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "name", "rarity", "banner", "influence_id", "job_id", "status", "deleted_at"}).
+		AddRow(id, "Viola", rarity, "", 1, 2, domain.PublishStatusPublished, deletedAt)
+}
+
+func travellerState(rarity int) string {
+	state, _ := json.Marshal(domain.TravellerState{Name: "Viola", Rarity: rarity, InfluenceID: 1, JobID: 2, Status: domain.PublishStatusPublished})
+	return string(state)
+}
+
+func (s *RevisionSuite) expectRevision(entityID int64, action string, before, after interface{}) {
+	s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_revision" ("entity_type","entity_id","action","before","after","actor","request_id","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`)).
+		WithArgs(domain.TrashTypeTraveller, entityID, action, before, after, "isla", "req-1", helpers.AnyTime{}).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+}
+
+func (s *RevisionSuite) TestCreate() {
+	s.Run("records the created state", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_traveller"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		s.expectRevision(4, domain.RevisionActionCreate, []byte(nil), []byte(travellerState(5)))
+		s.mock.ExpectCommit()
+
+		err := s.db.WithContext(s.ctx).Create(&domain.Traveller{Name: "Viola", Rarity: 5, InfluenceID: 1, JobID: 2}).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("models without revisions", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_external_id"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+		s.mock.ExpectCommit()
+
+		err := s.db.WithContext(s.ctx).Create(&domain.ExternalID{EntityType: domain.ExternalEntityTraveller, Source: "wiki", ExternalID: "viola"}).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *RevisionSuite) TestUpdate() {
+	s.Run("records the state before and after", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE id = $1 FOR UPDATE`)).
+			WithArgs(4).
+			WillReturnRows(travellerRows(4, 4, nil))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "rarity"=$1`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."id" = $1`)).
+			WithArgs(4).
+			WillReturnRows(travellerRows(4, 5, nil))
+		s.expectRevision(4, domain.RevisionActionUpdate, []byte(travellerState(4)), []byte(travellerState(5)))
+		s.mock.ExpectCommit()
+
+		err := s.db.WithContext(s.ctx).Model(&domain.Traveller{}).Where("id = ?", 4).Update("rarity", 5).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("records a revert under its own action", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE id = $1 FOR UPDATE`)).
+			WillReturnRows(travellerRows(4, 5, nil))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "rarity"=$1`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."id" = $1`)).
+			WillReturnRows(travellerRows(4, 4, nil))
+		s.expectRevision(4, domain.RevisionActionRevert, []byte(travellerState(5)), []byte(travellerState(4)))
+		s.mock.ExpectCommit()
+
+		ctx := WithRevisionAction(s.ctx, domain.RevisionActionRevert)
+		err := s.db.WithContext(ctx).Model(&domain.Traveller{}).Where("id = ?", 4).Update("rarity", 4).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("unchanged state records nothing", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE accessory_id = $1 FOR UPDATE`)).
+			WillReturnRows(travellerRows(4, 5, nil))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "version"=version + 1`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."id" = $1`)).
+			WillReturnRows(travellerRows(4, 5, nil))
+		s.mock.ExpectCommit()
+
+		err := s.db.WithContext(s.ctx).Model(&domain.Traveller{}).Where("accessory_id = ?", 7).
+			UpdateColumn("version", gorm.Expr("version + 1")).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("no matching rows records nothing", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE id = $1 FOR UPDATE`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "rarity"=$1`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectCommit()
+
+		err := s.db.WithContext(s.ctx).Model(&domain.Traveller{}).Where("id = ?", 4).Update("rarity", 5).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("a failed revision rolls the write back", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE id = $1 FOR UPDATE`)).
+			WillReturnRows(travellerRows(4, 4, nil))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "rarity"=$1`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."id" = $1`)).
+			WillReturnRows(travellerRows(4, 5, nil))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "m_revision"`)).
+			WillReturnError(gorm.ErrInvalidDB)
+		s.mock.ExpectRollback()
+
+		err := s.db.WithContext(s.ctx).Model(&domain.Traveller{}).Where("id = ?", 4).Update("rarity", 5).Error
+		assert.ErrorIs(s.T(), err, gorm.ErrInvalidDB)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *RevisionSuite) TestDelete() {
+	deletedAt := time.Date(2024, 10, 1, 9, 30, 0, 0, time.UTC)
+
+	s.Run("soft delete", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."id" = $1 FOR UPDATE`)).
+			WithArgs(4).
+			WillReturnRows(travellerRows(4, 5, nil))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "deleted_at"=$1`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."id" = $1`)).
+			WillReturnRows(travellerRows(4, 5, deletedAt))
+		s.expectRevision(4, domain.RevisionActionDelete, []byte(travellerState(5)), []byte(travellerState(5)))
+		s.mock.ExpectCommit()
+
+		assert.NoError(s.T(), s.db.WithContext(s.ctx).Delete(&domain.Traveller{}, 4).Error)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("restore", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE id = $1 FOR UPDATE`)).
+			WillReturnRows(travellerRows(4, 5, deletedAt))
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "m_traveller" SET "deleted_at"=$1`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."id" = $1`)).
+			WillReturnRows(travellerRows(4, 5, nil))
+		s.expectRevision(4, domain.RevisionActionRestore, []byte(travellerState(5)), []byte(travellerState(5)))
+		s.mock.ExpectCommit()
+
+		err := s.db.WithContext(s.ctx).Unscoped().Model(&domain.Traveller{}).Where("id = ?", 4).Update("deleted_at", nil).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("hard delete records a purge", func() {
+		s.SetupTest()
+		s.mock.ExpectBegin()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE deleted_at IS NOT NULL FOR UPDATE`)).
+			WillReturnRows(travellerRows(4, 5, deletedAt))
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "m_traveller" WHERE deleted_at IS NOT NULL`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."id" = $1`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		s.expectRevision(4, domain.RevisionActionPurge, []byte(travellerState(5)), []byte(nil))
+		s.mock.ExpectCommit()
+
+		assert.NoError(s.T(), s.db.WithContext(s.ctx).Unscoped().Where("deleted_at IS NOT NULL").Delete(&domain.Traveller{}).Error)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
@@ -1,5 +1,7 @@
 package constants
 
+import "maps"
+
 const (
 	// Date format constants
 	DateFormat = "02-01-2006"
@@ -59,6 +61,11 @@ func GetInfluenceName(influenceID int) string {
 	return res
 }
 
+// InfluenceNames returns the name of every influence by ID
+func InfluenceNames() map[int]string {
+	return maps.Clone(reverseInfluenceMap)
+}
+
 const (
 	JobWarrior    = "Warrior"
 	JobMerchant   = "Merchant"
@@ -121,6 +128,14 @@ func GetJobName(jobID int) string {
 	return res
 }
 
+// JobNames returns the name of every job by ID
+func JobNames() map[int]string {
+	return maps.Clone(reverseJobMap)
+}
+
+// APIBasePath is where the versioned API is mounted; resource links start with it
+const APIBasePath = "/api/v1"
+
 // Order direction constants
 const (
 	OrderDirAsc  = "asc"
@@ -132,3 +147,6 @@ const (
 	CacheMaxAgeList     = 300 // 5 minutes for list endpoints
 	CacheMaxAgeResource = 600 // 10 minutes for individual resource endpoints
 )
+
+// MessagePreconditionFailed is returned when an If-Match version no longer matches
+const MessagePreconditionFailed = "Resource has been modified by another request. Please refresh and try again."
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
 	"lizobly/ctc-db-api/pkg/logging"
 	pkgValidator "lizobly/ctc-db-api/pkg/validator"
 
@@ -36,6 +37,14 @@ func Ok[T any](ctx echo.Context, data T) error {
 	})
 }
 
+// Accepted returns 202 Accepted status with the Location of the operation's status monitor
+func Accepted[T any](ctx echo.Context, data T, location string) error {
+	ctx.Response().Header().Set("Location", location)
+	return ctx.JSON(http.StatusAccepted, DataResponse[T]{
+		Data: data,
+	})
+}
+
 // Created returns 201 Created status with Location header
 func Created[T any](ctx echo.Context, data T, location string) error {
 	if location != "" {
@@ -117,6 +126,52 @@ func ResponseErrorValidation(ctx echo.Context, err error) error {
 	})
 }
 
+// ParseFieldset reads the fields query parameter, checking it against the JSON fields of sample.
+// Unknown fields are reported as a validation error on fields.
+func ParseFieldset(ctx echo.Context, sample interface{}) (helpers.Fieldset, error) {
+	fields, err := helpers.ParseFieldset(ctx.QueryParam("fields"), sample)
+	if err != nil {
+		return nil, domain.NewValidationError([]domain.FieldError{{Field: "fields", Message: err.Error()}})
+	}
+	return fields, nil
+}
+
+// OperationError describes how an asynchronous operation failed, with the status and message
+// HandleServiceError would have responded with had it run synchronously
+func OperationError(err error) *domain.OperationError {
+	if err == nil {
+		return nil
+	}
+
+	var (
+		nfe *domain.NotFoundError
+		ce  *domain.ConflictError
+		pfe *domain.PreconditionFailedError
+		ae  *domain.AuthenticationError
+		ve  *domain.ValidationError
+		te  *domain.TimeoutError
+	)
+	switch {
+	case errors.As(err, &nfe):
+		return &domain.OperationError{Status: http.StatusNotFound, Message: nfe.PublicMessage()}
+	case errors.As(err, &ce):
+		return &domain.OperationError{Status: http.StatusConflict, Message: ce.Message}
+	case errors.As(err, &pfe):
+		return &domain.OperationError{Status: http.StatusPreconditionFailed, Message: pfe.Message}
+	case errors.As(err, &ae):
+		return &domain.OperationError{Status: http.StatusUnauthorized, Message: ae.Message}
+	case errors.As(err, &ve):
+		fieldErrors := make([]domain.FieldError, len(ve.Errors))
+		for i, fieldErr := range ve.Errors {
+			fieldErrors[i] = domain.FieldError{Field: strcase.ToSnake(fieldErr.Field), Message: fieldErr.Message}
+		}
+		return &domain.OperationError{Status: http.StatusBadRequest, Message: "validation failed", Errors: fieldErrors}
+	case errors.As(err, &te):
+		return &domain.OperationError{Status: http.StatusRequestTimeout, Message: te.Message}
+	}
+	return &domain.OperationError{Status: http.StatusInternalServerError, Message: "internal server error"}
+}
+
 // HandleServiceError maps domain errors to appropriate HTTP responses and logs at boundary
 func HandleServiceError(ctx echo.Context, err error, operation string, logger *logging.Logger) error {
 	if err == nil {
@@ -142,6 +197,15 @@ func HandleServiceError(ctx echo.Context, err error, operation string, logger *l
 		return ResponseError(ctx, http.StatusConflict, ce.Message)
 	}
 
+	var pfe *domain.PreconditionFailedError
+	if errors.As(err, &pfe) {
+		// Stale conditional write is client error - log as WARN
+		logger.WithContext(ctx.Request().Context()).Warn("precondition failed",
+			zap.Error(err),
+		)
+		return ResponseError(ctx, http.StatusPreconditionFailed, pfe.Message)
+	}
+
 	var ae *domain.AuthenticationError
 	if errors.As(err, &ae) {
 		// Auth failure is client error - log as WARN
@@ -151,6 +215,16 @@ func HandleServiceError(ctx echo.Context, err error, operation string, logger *l
 		return ResponseError(ctx, http.StatusUnauthorized, ae.Message)
 	}
 
+	// Services checking documents they build themselves, like patched ones, return the
+	// validator's errors as they are
+	var vse validator.ValidationErrors
+	if errors.As(err, &vse) {
+		logger.WithContext(ctx.Request().Context()).Warn("validation error",
+			zap.Error(err),
+		)
+		return ResponseErrorValidation(ctx, vse)
+	}
+
 	var ve *domain.ValidationError
 	if errors.As(err, &ve) {
 		// Validation is client error - log as WARN
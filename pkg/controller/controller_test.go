@@ -321,6 +321,15 @@ func TestHandleServiceError_ValidationError(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedMsg:    "validation failed",
 		},
+		{
+			name: "validator errors from service",
+			err: e.Validator.Validate(&struct {
+				Name string `validate:"required"`
+			}{}),
+			operation:      "create change request",
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "validation failed",
+		},
 	}
 
 	for _, tt := range tests {
@@ -328,6 +337,7 @@ func TestHandleServiceError_ValidationError(t *testing.T) {
 			req := httptest.NewRequest(http.MethodPost, "/test", nil)
 			rec := httptest.NewRecorder()
 			ctx := e.NewContext(req, rec)
+			ctx.Set("validator", e.Validator)
 
 			// Call HandleServiceError which should delegate to ResponseErrorValidation
 			responseErr := HandleServiceError(ctx, tt.err, tt.operation, setupTestLogger())
@@ -605,6 +615,13 @@ func TestHandleServiceError_ErrorTypes(t *testing.T) {
 			expectedStatus: http.StatusConflict,
 			expectedMsg:    "email already exists",
 		},
+		{
+			name:           "handles PreconditionFailedError from service",
+			err:            domain.NewPreconditionFailedError("resource has been modified", nil),
+			operation:      "update traveller",
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedMsg:    "resource has been modified",
+		},
 		{
 			name:           "handles AuthenticationError from service",
 			err:            domain.NewAuthenticationError("invalid credentials", nil),
@@ -696,3 +713,64 @@ func TestRequestTimeout_Response(t *testing.T) {
 		})
 	}
 }
+
+// TestAccepted_AcceptedResponse tests the Accepted() response helper
+func TestAccepted_AcceptedResponse(t *testing.T) {
+	e := setupTestEcho()
+
+	req := httptest.NewRequest(http.MethodPost, "/test", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	err := Accepted(ctx, map[string]string{"status": "running"}, "/operations/1")
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "/operations/1", rec.Header().Get("Location"))
+	assert.JSONEq(t, `{"data":{"status":"running"}}`, rec.Body.String())
+}
+
+// TestOperationError tests that operation failures get the status a synchronous request would
+func TestOperationError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want *domain.OperationError
+	}{
+		{
+			name: "no error",
+			err:  nil,
+			want: nil,
+		},
+		{
+			name: "not found hides the id",
+			err:  domain.NewNotFoundError("traveller", 123, nil),
+			want: &domain.OperationError{Status: http.StatusNotFound, Message: "traveller not found"},
+		},
+		{
+			name: "precondition failed",
+			err:  domain.NewPreconditionFailedError("resource has been modified", nil),
+			want: &domain.OperationError{Status: http.StatusPreconditionFailed, Message: "resource has been modified"},
+		},
+		{
+			name: "validation error keeps field errors",
+			err:  domain.NewValidationError([]domain.FieldError{{Field: "ReleaseDate", Message: "invalid date format"}}),
+			want: &domain.OperationError{
+				Status:  http.StatusBadRequest,
+				Message: "validation failed",
+				Errors:  []domain.FieldError{{Field: "release_date", Message: "invalid date format"}},
+			},
+		},
+		{
+			name: "unmapped error is hidden",
+			err:  errors.New("database connection failed"),
+			want: &domain.OperationError{Status: http.StatusInternalServerError, Message: "internal server error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, OperationError(tt.err))
+		})
+	}
+}
@@ -1,8 +1,16 @@
 package domain
 
+import (
+	"lizobly/ctc-db-api/pkg/constants"
+	"lizobly/ctc-db-api/pkg/filter"
+	"net/url"
+	"time"
+)
+
 type Accessory struct {
 	CommonModel
 	Name   string `json:"name" gorm:"column:name"`
+	Slug   string `json:"slug" gorm:"column:slug"`
 	HP     int    `json:"hp" gorm:"column:hp"`
 	SP     int    `json:"sp" gorm:"column:sp"`
 	PAtk   int    `json:"patk" gorm:"column:patk"`
@@ -12,6 +20,7 @@ type Accessory struct {
 	Spd    int    `json:"spd" gorm:"column:spd"`
 	Crit   int    `json:"crit" gorm:"column:crit"`
 	Effect string `json:"effect" gorm:"column:effect"`
+	Publication
 }
 
 func (Accessory) TableName() string {
@@ -19,44 +28,55 @@ func (Accessory) TableName() string {
 }
 
 type CreateAccessoryRequest struct {
-	Name   string `json:"name" validate:"required,lte=50" example:"Crimson Cloak"`
-	HP     int    `json:"hp" example:"500"`
-	SP     int    `json:"sp" example:"50"`
-	PAtk   int    `json:"patk" example:"120"`
-	PDef   int    `json:"pdef" example:"80"`
-	EAtk   int    `json:"eatk" example:"150"`
-	EDef   int    `json:"edef" example:"100"`
-	Spd    int    `json:"spd" example:"45"`
-	Crit   int    `json:"crit" example:"25"`
-	Effect string `json:"effect" validate:"omitempty,lte=200" example:"Increases elemental damage by 15%"`
+	Name      string `json:"name" validate:"required,lte=50" example:"Crimson Cloak"`
+	HP        int    `json:"hp" example:"500"`
+	SP        int    `json:"sp" example:"50"`
+	PAtk      int    `json:"patk" example:"120"`
+	PDef      int    `json:"pdef" example:"80"`
+	EAtk      int    `json:"eatk" example:"150"`
+	EDef      int    `json:"edef" example:"100"`
+	Spd       int    `json:"spd" example:"45"`
+	Crit      int    `json:"crit" example:"25"`
+	Effect    string `json:"effect" validate:"omitempty,lte=200" example:"Increases elemental damage by 15%"`
+	Status    string `json:"status" validate:"omitempty,oneof=draft scheduled published" example:"draft"`
+	PublishAt string `json:"publish_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2024-10-01T09:00:00Z"`
 }
 
 type UpdateAccessoryRequest struct {
-	Name   string `json:"name" validate:"required,lte=50"`
-	HP     int    `json:"hp"`
-	SP     int    `json:"sp"`
-	PAtk   int    `json:"patk"`
-	PDef   int    `json:"pdef"`
-	EAtk   int    `json:"eatk"`
-	EDef   int    `json:"edef"`
-	Spd    int    `json:"spd"`
-	Crit   int    `json:"crit"`
-	Effect string `json:"effect" validate:"omitempty,lte=200"`
+	Name      string `json:"name" validate:"required,lte=50"`
+	HP        int    `json:"hp"`
+	SP        int    `json:"sp"`
+	PAtk      int    `json:"patk"`
+	PDef      int    `json:"pdef"`
+	EAtk      int    `json:"eatk"`
+	EDef      int    `json:"edef"`
+	Spd       int    `json:"spd"`
+	Crit      int    `json:"crit"`
+	Effect    string `json:"effect" validate:"omitempty,lte=200"`
+	Status    string `json:"status" validate:"omitempty,oneof=draft scheduled published"`
+	PublishAt string `json:"publish_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+
+	// Version is the expected current version when the accessory is written on its own; zero makes the update unconditional
+	Version int64 `json:"-"`
 }
 
 // Response DTOs
 
 type AccessoryResponse struct {
-	Name   string `json:"name" example:"Crimson Cloak"`
-	HP     int    `json:"hp" example:"500"`
-	SP     int    `json:"sp" example:"50"`
-	PAtk   int    `json:"patk" example:"120"`
-	PDef   int    `json:"pdef" example:"80"`
-	EAtk   int    `json:"eatk" example:"150"`
-	EDef   int    `json:"edef" example:"100"`
-	Spd    int    `json:"spd" example:"45"`
-	Crit   int    `json:"crit" example:"25"`
-	Effect string `json:"effect" example:"Increases elemental damage by 15%"`
+	ID        int64      `json:"id" example:"1"`
+	Slug      string     `json:"slug" example:"crimson-cloak"`
+	Name      string     `json:"name" example:"Crimson Cloak"`
+	HP        int        `json:"hp" example:"500"`
+	SP        int        `json:"sp" example:"50"`
+	PAtk      int        `json:"patk" example:"120"`
+	PDef      int        `json:"pdef" example:"80"`
+	EAtk      int        `json:"eatk" example:"150"`
+	EDef      int        `json:"edef" example:"100"`
+	Spd       int        `json:"spd" example:"45"`
+	Crit      int        `json:"crit" example:"25"`
+	Effect    string     `json:"effect" example:"Increases elemental damage by 15%"`
+	Status    string     `json:"status" example:"published"`
+	PublishAt *time.Time `json:"publish_at,omitempty" example:"2024-10-01T09:00:00Z"`
 }
 
 // Request DTOs
@@ -66,22 +86,39 @@ type ListAccessoryRequest struct {
 	Effect   string `query:"effect"`
 	OrderBy  string `query:"order_by" validate:"omitempty,oneof=hp sp patk pdef eatk edef spd crit"`
 	OrderDir string `query:"order_dir" validate:"omitempty,oneof=asc desc"`
+	Facets   string `query:"facets" validate:"omitempty,oneofcsv=effect owner"`
+	Filter   string `query:"filter" validate:"omitempty,max=1000"`
+	AsOf     string `query:"as_of"`
+
+	// Parsed values populated by the service
+	FacetFields     []string          `json:"-"`
+	FilterCondition *filter.Condition `json:"-"`
+	AsOfTime        time.Time         `json:"-"`
 }
 
 // AccessoryListItemResponse represents an accessory with its owner's name
 // Note: Each accessory can only be owned by one traveller (stored as traveller.accessory_id FK)
 type AccessoryListItemResponse struct {
-	Name   string `json:"name"`
-	HP     int    `json:"hp"`
-	SP     int    `json:"sp"`
-	PAtk   int    `json:"patk"`
-	PDef   int    `json:"pdef"`
-	EAtk   int    `json:"eatk"`
-	EDef   int    `json:"edef"`
-	Spd    int    `json:"spd"`
-	Crit   int    `json:"crit"`
-	Effect string `json:"effect"`
-	Owner  string `json:"owner"`
+	ID     int64         `json:"id"`
+	Slug   string        `json:"slug"`
+	Name   string        `json:"name"`
+	HP     int           `json:"hp"`
+	SP     int           `json:"sp"`
+	PAtk   int           `json:"patk"`
+	PDef   int           `json:"pdef"`
+	EAtk   int           `json:"eatk"`
+	EDef   int           `json:"edef"`
+	Spd    int           `json:"spd"`
+	Crit   int           `json:"crit"`
+	Effect string        `json:"effect"`
+	Status string        `json:"status"`
+	Owner  string        `json:"owner"`
+	Links  ResourceLinks `json:"links"`
+}
+
+// AccessoryPath returns the URL path of an accessory. Accessories are addressed by slug.
+func AccessoryPath(slug string) string {
+	return constants.APIBasePath + "/accessories/by-slug/" + url.PathEscape(slug)
 }
 
 // Mapper functions
@@ -91,21 +128,47 @@ func ToAccessoryResponse(accessory *Accessory) *AccessoryResponse {
 		return nil
 	}
 	return &AccessoryResponse{
-		Name:   accessory.Name,
-		HP:     accessory.HP,
-		SP:     accessory.SP,
-		PAtk:   accessory.PAtk,
-		PDef:   accessory.PDef,
-		EAtk:   accessory.EAtk,
-		EDef:   accessory.EDef,
-		Spd:    accessory.Spd,
-		Crit:   accessory.Crit,
-		Effect: accessory.Effect,
+		ID:        accessory.ID,
+		Slug:      accessory.Slug,
+		Name:      accessory.Name,
+		HP:        accessory.HP,
+		SP:        accessory.SP,
+		PAtk:      accessory.PAtk,
+		PDef:      accessory.PDef,
+		EAtk:      accessory.EAtk,
+		EDef:      accessory.EDef,
+		Spd:       accessory.Spd,
+		Crit:      accessory.Crit,
+		Effect:    accessory.Effect,
+		Status:    accessory.Status,
+		PublishAt: accessory.PublishAt,
+	}
+}
+
+func ToUpdateAccessoryRequest(accessory *Accessory) *UpdateAccessoryRequest {
+	if accessory == nil {
+		return nil
+	}
+	return &UpdateAccessoryRequest{
+		Name:      accessory.Name,
+		HP:        accessory.HP,
+		SP:        accessory.SP,
+		PAtk:      accessory.PAtk,
+		PDef:      accessory.PDef,
+		EAtk:      accessory.EAtk,
+		EDef:      accessory.EDef,
+		Spd:       accessory.Spd,
+		Crit:      accessory.Crit,
+		Effect:    accessory.Effect,
+		Status:    accessory.Status,
+		PublishAt: formatPublishAt(accessory.PublishAt),
 	}
 }
 
 func ToAccessoryListItemResponse(accessory *Accessory, ownerNames map[int64]string) AccessoryListItemResponse {
 	return AccessoryListItemResponse{
+		ID:     accessory.ID,
+		Slug:   accessory.Slug,
 		Name:   accessory.Name,
 		HP:     accessory.HP,
 		SP:     accessory.SP,
@@ -116,6 +179,8 @@ func ToAccessoryListItemResponse(accessory *Accessory, ownerNames map[int64]stri
 		Spd:    accessory.Spd,
 		Crit:   accessory.Crit,
 		Effect: accessory.Effect,
+		Status: accessory.Status,
 		Owner:  ownerNames[accessory.ID],
+		Links:  ResourceLinks{Self: AccessoryPath(accessory.Slug)},
 	}
 }
@@ -198,3 +198,9 @@ func TestAccessory_TableName(t *testing.T) {
 	accessory := Accessory{}
 	assert.Equal(t, "m_accessory", accessory.TableName())
 }
+
+// TestAccessoryPath tests the self link of accessories, which are addressed by slug
+func TestAccessoryPath(t *testing.T) {
+	assert.Equal(t, "/api/v1/accessories/by-slug/crown-of-wisdom", AccessoryPath("crown-of-wisdom"))
+	assert.Equal(t, "/api/v1/accessories/by-slug/a%2Fb", ToAccessoryListItemResponse(&Accessory{Slug: "a/b"}, nil).Links.Self)
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"lizobly/ctc-db-api/pkg/constants"
+	"strconv"
+	"time"
+)
+
+// Change request statuses. A change request is reviewed once: approving applies its patch,
+// rejecting closes it.
+const (
+	ChangeRequestStatusPending  = "pending"
+	ChangeRequestStatusApproved = "approved"
+	ChangeRequestStatusRejected = "rejected"
+)
+
+// Entity types a change request can propose a change to
+const (
+	ChangeRequestEntityTraveller = "traveller"
+	ChangeRequestEntityAccessory = "accessory"
+)
+
+// ChangeRequest is a change proposed to a traveller or accessory, waiting for an editor to
+// review it. Patch is a JSON merge patch against the record's update document as it was at
+// BaseVersion; it can only be applied while the record is still at that version. The proposer
+// is CreatedBy.
+type ChangeRequest struct {
+	CommonModel
+	EntityType  string                 `gorm:"column:entity_type"`
+	EntityID    int64                  `gorm:"column:entity_id"`
+	BaseVersion int64                  `gorm:"column:base_version"`
+	Patch       []byte                 `gorm:"column:patch;type:jsonb"`
+	Summary     string                 `gorm:"column:summary"`
+	Status      string                 `gorm:"column:status"`
+	ReviewedBy  string                 `gorm:"column:reviewed_by"`
+	ReviewedAt  *time.Time             `gorm:"column:reviewed_at"`
+	Comments    []ChangeRequestComment `gorm:"foreignKey:ChangeRequestID"`
+}
+
+func (ChangeRequest) TableName() string {
+	return "m_change_request"
+}
+
+// ChangeRequestComment is one comment in the discussion of a change request
+type ChangeRequestComment struct {
+	ID              int64     `gorm:"column:id"`
+	ChangeRequestID int64     `gorm:"column:change_request_id"`
+	Author          string    `gorm:"column:author"`
+	Body            string    `gorm:"column:body"`
+	CreatedAt       time.Time `gorm:"column:created_at"`
+}
+
+func (ChangeRequestComment) TableName() string {
+	return "m_change_request_comment"
+}
+
+// ChangeRequestPath returns the URL path of a change request
+func ChangeRequestPath(id int64) string {
+	return constants.APIBasePath + "/change-requests/" + strconv.FormatInt(id, 10)
+}
+
+// DocumentChanges lists the fields that differ between two JSON update documents, the current
+// and the proposed one, ordered by field. Nested objects, like a traveller's accessory, are
+// compared field by field under dotted names.
+func DocumentChanges(current, proposed []byte) ([]FieldChange, error) {
+	before, err := decodeDocument(current)
+	if err != nil {
+		return nil, err
+	}
+	after, err := decodeDocument(proposed)
+	if err != nil {
+		return nil, err
+	}
+	return fieldChanges(flattenDocument("", before), flattenDocument("", after)), nil
+}
+
+func decodeDocument(raw []byte) (map[string]interface{}, error) {
+	document := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+	return document, nil
+}
+
+// flattenDocument moves the fields of nested objects up under prefixed names
+func flattenDocument(prefix string, document map[string]interface{}) map[string]interface{} {
+	flat := make(map[string]interface{}, len(document))
+	for field, value := range document {
+		if nested, ok := value.(map[string]interface{}); ok {
+			for nestedField, nestedValue := range flattenDocument(prefix+field+".", nested) {
+				flat[nestedField] = nestedValue
+			}
+			continue
+		}
+		flat[prefix+field] = value
+	}
+	return flat
+}
+
+// Request DTOs
+
+type CreateChangeRequestRequest struct {
+	EntityType string          `json:"entity_type" validate:"required,oneof=traveller accessory" example:"traveller"`
+	EntityID   int64           `json:"entity_id" validate:"required,gt=0" example:"1"`
+	Patch      json.RawMessage `json:"patch" validate:"required" swaggertype:"object"`
+	Summary    string          `json:"summary" validate:"omitempty,lte=200" example:"Fix Viola's release date"`
+
+	// Version is the version the proposer saw, taken from If-Match; zero proposes against the current one
+	Version int64 `json:"-"`
+}
+
+type ListChangeRequestRequest struct {
+	Status     string `query:"status" validate:"omitempty,oneof=pending approved rejected"`
+	EntityType string `query:"entity_type" validate:"omitempty,oneof=traveller accessory"`
+	EntityID   int64  `query:"entity_id" validate:"omitempty,gt=0"`
+}
+
+type CommentChangeRequestRequest struct {
+	Body string `json:"body" validate:"required,lte=1000" example:"The wiki lists 01-10-2024 too"`
+}
+
+// ReviewChangeRequestRequest approves or rejects a change request, optionally with a comment
+type ReviewChangeRequestRequest struct {
+	Comment string `json:"comment" validate:"omitempty,lte=1000" example:"Matches the official announcement"`
+}
+
+// Response DTOs
+
+type ChangeRequestCommentResponse struct {
+	ID        int64     `json:"id" example:"3"`
+	Author    string    `json:"author,omitempty" example:"isla"`
+	Body      string    `json:"body" example:"The wiki lists 01-10-2024 too"`
+	CreatedAt time.Time `json:"created_at" example:"2024-10-02T18:00:00Z"`
+}
+
+type ChangeRequestListItemResponse struct {
+	ID          int64      `json:"id" example:"7"`
+	EntityType  string     `json:"entity_type" example:"traveller"`
+	EntityID    int64      `json:"entity_id" example:"1"`
+	BaseVersion int64      `json:"base_version" example:"4"`
+	Status      string     `json:"status" example:"pending"`
+	Summary     string     `json:"summary,omitempty" example:"Fix Viola's release date"`
+	ProposedBy  string     `json:"proposed_by,omitempty" example:"alfyn"`
+	ReviewedBy  string     `json:"reviewed_by,omitempty" example:"isla"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty" example:"2024-10-03T09:00:00Z"`
+	CreatedAt   time.Time  `json:"created_at" example:"2024-10-02T18:00:00Z"`
+}
+
+// ChangeRequestResponse is a change request with its discussion and, while it is pending, the
+// changes it would make to the record as it is now. Stale reports that the record changed since
+// the proposal, so it can no longer be approved.
+type ChangeRequestResponse struct {
+	ChangeRequestListItemResponse
+	Patch          json.RawMessage                `json:"patch" swaggertype:"object"`
+	CurrentVersion int64                          `json:"current_version,omitempty" example:"4"`
+	Stale          bool                           `json:"stale"`
+	Changes        []FieldChange                  `json:"changes,omitempty"`
+	Comments       []ChangeRequestCommentResponse `json:"comments"`
+}
+
+// Mapper functions
+
+func ToChangeRequestListItemResponse(changeRequest ChangeRequest) ChangeRequestListItemResponse {
+	return ChangeRequestListItemResponse{
+		ID:          changeRequest.ID,
+		EntityType:  changeRequest.EntityType,
+		EntityID:    changeRequest.EntityID,
+		BaseVersion: changeRequest.BaseVersion,
+		Status:      changeRequest.Status,
+		Summary:     changeRequest.Summary,
+		ProposedBy:  changeRequest.CreatedBy,
+		ReviewedBy:  changeRequest.ReviewedBy,
+		ReviewedAt:  changeRequest.ReviewedAt,
+		CreatedAt:   changeRequest.CreatedAt,
+	}
+}
+
+func ToChangeRequestResponse(changeRequest ChangeRequest) ChangeRequestResponse {
+	comments := make([]ChangeRequestCommentResponse, len(changeRequest.Comments))
+	for i, comment := range changeRequest.Comments {
+		comments[i] = ChangeRequestCommentResponse{
+			ID:        comment.ID,
+			Author:    comment.Author,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt,
+		}
+	}
+	return ChangeRequestResponse{
+		ChangeRequestListItemResponse: ToChangeRequestListItemResponse(changeRequest),
+		Patch:                         json.RawMessage(changeRequest.Patch),
+		Comments:                      comments,
+	}
+}
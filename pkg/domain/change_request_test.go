@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDocumentChanges tests diffing a proposed update document against the current one
+func TestDocumentChanges(t *testing.T) {
+	t.Run("lists changed fields, nested ones by dotted name", func(t *testing.T) {
+		changes, err := DocumentChanges(
+			[]byte(`{"name":"Viola","rarity":4,"accessory":{"name":"Crown","hp":100}}`),
+			[]byte(`{"name":"Viola","rarity":5,"accessory":{"name":"Crown","hp":150}}`),
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, []FieldChange{
+			{Field: "accessory.hp", Before: float64(100), After: float64(150)},
+			{Field: "rarity", Before: float64(4), After: float64(5)},
+		}, changes)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		changes, err := DocumentChanges([]byte(`{"name":"Viola"}`), []byte(`{"name":"Viola"}`))
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("invalid document", func(t *testing.T) {
+		_, err := DocumentChanges([]byte(`{"name":"Viola"}`), []byte(`[`))
+		assert.Error(t, err)
+	})
+}
+
+// TestToChangeRequestResponse tests mapping a change request for responses
+func TestToChangeRequestResponse(t *testing.T) {
+	res := ToChangeRequestResponse(ChangeRequest{
+		CommonModel: CommonModel{ID: 7, CreatedBy: "alfyn"},
+		EntityType:  ChangeRequestEntityTraveller,
+		EntityID:    1,
+		BaseVersion: 4,
+		Patch:       []byte(`{"rarity":5}`),
+		Status:      ChangeRequestStatusPending,
+		Comments:    []ChangeRequestComment{{ID: 3, Author: "isla", Body: "Looks right"}},
+	})
+	assert.Equal(t, "alfyn", res.ProposedBy)
+	assert.JSONEq(t, `{"rarity":5}`, string(res.Patch))
+	assert.Equal(t, []ChangeRequestCommentResponse{{ID: 3, Author: "isla", Body: "Looks right"}}, res.Comments)
+}
@@ -2,7 +2,10 @@ package domain
 
 import (
 	"fmt"
+	"lizobly/ctc-db-api/pkg/constants"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -15,16 +18,185 @@ type CommonModel struct {
 	DeletedBy *string        `json:"deleted_by,omitempty" gorm:"column:deleted_by"`
 	CreatedAt time.Time      `json:"created_at,omitempty" gorm:"column:created_at"`
 	UpdatedAt time.Time      `json:"updated_at,omitempty" gorm:"column:updated_at"`
+	Version   int64          `json:"version" gorm:"column:version"`
 	DeletedAt gorm.DeletedAt `json:"deleted_at"`
 }
 
-// ETag generates an ETag value based on the resource's last modification time.
-// The ETag is a quoted string containing the Unix timestamp of UpdatedAt.
+// BeforeCreate starts every new row at version 1
+func (c *CommonModel) BeforeCreate(tx *gorm.DB) error {
+	if c.Version == 0 {
+		c.Version = 1
+	}
+	return nil
+}
+
+// ETag generates an ETag value based on the resource's version.
+// The version is incremented on every write, so the ETag changes even for
+// edits within the same second or to nested records.
 func (c CommonModel) ETag() string {
-	return fmt.Sprintf(`"%d"`, c.UpdatedAt.Unix())
+	return fmt.Sprintf(`"%d"`, c.Version)
+}
+
+// ParseETagVersion extracts the version from an ETag produced by ETag.
+// Weak or malformed ETags never match, so ok is false for them.
+func ParseETagVersion(etag string) (version int64, ok bool) {
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(etag[1:len(etag)-1], 10, 64)
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
 }
 
 // LastModified returns the last modification time in HTTP-date format (RFC 7231).
 func (c CommonModel) LastModified() string {
 	return c.UpdatedAt.UTC().Format(http.TimeFormat)
 }
+
+// AuditInfo records who created a record and who changed it last, and when
+type AuditInfo struct {
+	CreatedBy string    `json:"created_by,omitempty" example:"isla"`
+	CreatedAt time.Time `json:"created_at" example:"2024-10-01T09:30:00Z"`
+	UpdatedBy string    `json:"updated_by,omitempty" example:"isla"`
+	UpdatedAt time.Time `json:"updated_at" example:"2024-10-02T18:00:00Z"`
+}
+
+// ToAuditInfo returns the audit columns of a record, or nil if no user was recorded on it,
+// as for records written while authentication was disabled
+func ToAuditInfo(c CommonModel) *AuditInfo {
+	if c.CreatedBy == "" && c.UpdatedBy == "" {
+		return nil
+	}
+	return &AuditInfo{
+		CreatedBy: c.CreatedBy,
+		CreatedAt: c.CreatedAt,
+		UpdatedBy: c.UpdatedBy,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+// ResourceLinks holds the URL of a resource, so clients can follow it without building it themselves
+type ResourceLinks struct {
+	Self string `json:"self" example:"/api/v1/travellers/1"`
+}
+
+// SortField is a single ordering key parsed from order_by and order_dir
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSortFields pairs comma-separated order_by keys with order_dir directions.
+// A single direction applies to every key; otherwise directions are positional
+// and missing ones default to ascending.
+func ParseSortFields(orderBy, orderDir string) ([]SortField, error) {
+	if orderBy == "" {
+		return nil, nil
+	}
+
+	fields := strings.Split(orderBy, ",")
+	var dirs []string
+	if orderDir != "" {
+		dirs = strings.Split(orderDir, ",")
+	}
+	if len(dirs) > len(fields) {
+		return nil, NewValidationError([]FieldError{
+			{Field: "order_dir", Message: "order_dir has more values than order_by"},
+		})
+	}
+
+	result := make([]SortField, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+	for i, field := range fields {
+		field = strings.TrimSpace(field)
+		if seen[field] {
+			return nil, NewValidationError([]FieldError{
+				{Field: "order_by", Message: fmt.Sprintf("duplicate sort field '%s'", field)},
+			})
+		}
+		seen[field] = true
+
+		dir := constants.OrderDirAsc
+		if len(dirs) == 1 {
+			dir = dirs[0]
+		} else if i < len(dirs) {
+			dir = dirs[i]
+		}
+
+		result = append(result, SortField{
+			Field: field,
+			Desc:  strings.EqualFold(strings.TrimSpace(dir), constants.OrderDirDesc),
+		})
+	}
+
+	return result, nil
+}
+
+// MaxBatchGetSize caps how many ids one multi-get request may ask for
+const MaxBatchGetSize = 100
+
+// BatchGetRequest asks for several records by id in one request
+type BatchGetRequest struct {
+	IDs string `query:"ids"`
+
+	// Parsed values
+	IDValues []int `json:"-"`
+}
+
+// ParseIDList parses a comma-separated list of positive ids, dropping duplicates while
+// keeping their order. Errors are reported against the given field.
+func ParseIDList(field, raw string) ([]int, error) {
+	parts := strings.Split(raw, ",")
+	result := make([]int, 0, len(parts))
+	seen := make(map[int]bool, len(parts))
+	for _, part := range parts {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || id < 1 {
+			return nil, NewValidationError([]FieldError{
+				{Field: field, Message: fmt.Sprintf("%s must be a comma-separated list of positive integers", field)},
+			})
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+
+	return result, nil
+}
+
+// ParseBatchGetRequest populates IDValues, rejecting empty and oversized requests
+func ParseBatchGetRequest(input *BatchGetRequest) (err error) {
+	input.IDValues, err = ParseIDList("ids", input.IDs)
+	if err != nil {
+		return
+	}
+	if len(input.IDValues) > MaxBatchGetSize {
+		return NewValidationError([]FieldError{
+			{Field: "ids", Message: fmt.Sprintf("ids must list at most %d ids", MaxBatchGetSize)},
+		})
+	}
+	return nil
+}
+
+// ParseFacetFields splits a comma-separated facets parameter, dropping duplicates while keeping their order
+func ParseFacetFields(facets string) []string {
+	if facets == "" {
+		return nil
+	}
+	parts := strings.Split(facets, ",")
+	result := make([]string, 0, len(parts))
+	seen := make(map[string]bool, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if seen[part] {
+			continue
+		}
+		seen[part] = true
+		result = append(result, part)
+	}
+	return result
+}
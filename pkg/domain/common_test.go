@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,79 +14,78 @@ import (
 // TestCommonModel_ETag tests ETag generation with various scenarios
 func TestCommonModel_ETag(t *testing.T) {
 	tests := []struct {
-		name        string
-		model       CommonModel
-		validations func(t *testing.T, eTag string)
+		name  string
+		model CommonModel
+		want  string
 	}{
 		{
-			name: "generates quoted timestamp",
-			model: CommonModel{
-				ID:        1,
-				CreatedBy: "user",
-				UpdatedAt: time.Now(),
-			},
-			validations: func(t *testing.T, eTag string) {
-				assert.NotEmpty(t, eTag)
-				assert.True(t, eTag[0] == '"')
-				assert.True(t, eTag[len(eTag)-1] == '"')
-			},
+			name:  "quoted version",
+			model: CommonModel{ID: 1, Version: 3, UpdatedAt: time.Now()},
+			want:  `"3"`,
 		},
 		{
-			name: "properly formatted",
-			model: CommonModel{
-				ID:        42,
-				UpdatedAt: time.Date(2025, 6, 15, 10, 30, 45, 0, time.UTC),
-			},
-			validations: func(t *testing.T, eTag string) {
-				assert.Equal(t, '"', rune(eTag[0]))
-				assert.Equal(t, '"', rune(eTag[len(eTag)-1]))
-			},
+			name:  "ignores timestamps",
+			model: CommonModel{ID: 42, Version: 1, UpdatedAt: time.Date(2025, 6, 15, 10, 30, 45, 0, time.UTC)},
+			want:  `"1"`,
 		},
 		{
 			name:  "zero value model",
 			model: CommonModel{},
-			validations: func(t *testing.T, eTag string) {
-				assert.NotEmpty(t, eTag)
-			},
+			want:  `"0"`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			eTag := tt.model.ETag()
-			tt.validations(t, eTag)
+			assert.Equal(t, tt.want, tt.model.ETag())
 		})
 	}
 }
 
-// TestCommonModel_ETag_WithDifferentTimestamps tests ETag changes with UpdatedAt
-func TestCommonModel_ETag_WithDifferentTimestamps(t *testing.T) {
-	time1 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
-	time2 := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+// TestCommonModel_ETag_WithDifferentVersions tests ETag changes with every write, even within a second
+func TestCommonModel_ETag_WithDifferentVersions(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
 
-	model1 := CommonModel{ID: 1, UpdatedAt: time1}
-	model2 := CommonModel{ID: 1, UpdatedAt: time2}
+	model1 := CommonModel{ID: 1, UpdatedAt: now, Version: 1}
+	model2 := CommonModel{ID: 1, UpdatedAt: now, Version: 2}
+
+	assert.NotEqual(t, model1.ETag(), model2.ETag())
+}
 
-	eTag1 := model1.ETag()
-	eTag2 := model2.ETag()
+// TestCommonModel_BeforeCreate tests new rows start at version 1
+func TestCommonModel_BeforeCreate(t *testing.T) {
+	model := CommonModel{}
+	assert.NoError(t, model.BeforeCreate(nil))
+	assert.Equal(t, int64(1), model.Version)
 
-	// Different timestamps should produce different ETags
-	assert.NotEqual(t, eTag1, eTag2)
+	model = CommonModel{Version: 4}
+	assert.NoError(t, model.BeforeCreate(nil))
+	assert.Equal(t, int64(4), model.Version)
 }
 
-// TestCommonModel_ETag_Consistency tests ETag is consistent for same model
-func TestCommonModel_ETag_Consistency(t *testing.T) {
-	now := time.Now()
-	model := CommonModel{
-		ID:        1,
-		UpdatedAt: now,
+// TestParseETagVersion tests reading a version back from an If-Match value
+func TestParseETagVersion(t *testing.T) {
+	tests := []struct {
+		name   string
+		etag   string
+		want   int64
+		wantOk bool
+	}{
+		{name: "round trip", etag: CommonModel{Version: 7}.ETag(), want: 7, wantOk: true},
+		{name: "weak etag", etag: `W/"7"`, wantOk: false},
+		{name: "unquoted", etag: "7", wantOk: false},
+		{name: "not a number", etag: `"abc"`, wantOk: false},
+		{name: "zero version", etag: `"0"`, wantOk: false},
+		{name: "empty", etag: "", wantOk: false},
 	}
 
-	eTag1 := model.ETag()
-	eTag2 := model.ETag()
-
-	// Same model should produce same ETag
-	assert.Equal(t, eTag1, eTag2)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseETagVersion(tt.etag)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
 }
 
 // TestCommonModel_LastModified tests LastModified HTTP date format
@@ -172,3 +174,118 @@ func TestCommonModel_LastModified_WithMultipleTimes(t *testing.T) {
 		assert.NoError(t, err, "time %v should produce valid HTTP date format", testTime)
 	}
 }
+
+// TestParseSortFields tests pairing of order_by keys with order_dir directions
+func TestParseSortFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		orderBy  string
+		orderDir string
+		expected []SortField
+		wantErr  bool
+	}{
+		{
+			name:     "no sort",
+			expected: nil,
+		},
+		{
+			name:     "single key defaults to ascending",
+			orderBy:  "name",
+			expected: []SortField{{Field: "name"}},
+		},
+		{
+			name:     "single direction applies to every key",
+			orderBy:  "rarity,release_date",
+			orderDir: "desc",
+			expected: []SortField{{Field: "rarity", Desc: true}, {Field: "release_date", Desc: true}},
+		},
+		{
+			name:     "positional directions",
+			orderBy:  "rarity, name, job",
+			orderDir: "DESC,asc",
+			expected: []SortField{{Field: "rarity", Desc: true}, {Field: "name"}, {Field: "job"}},
+		},
+		{
+			name:     "more directions than keys",
+			orderBy:  "rarity",
+			orderDir: "desc,asc",
+			wantErr:  true,
+		},
+		{
+			name:    "duplicate key",
+			orderBy: "name,name",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseSortFields(tt.orderBy, tt.orderDir)
+			if tt.wantErr {
+				var ve *ValidationError
+				assert.ErrorAs(t, err, &ve)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// TestParseIDList tests parsing comma-separated id lists
+func TestParseIDList(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected []int
+		wantErr  bool
+	}{
+		{name: "single id", raw: "3", expected: []int{3}},
+		{name: "keeps order and trims spaces", raw: "3, 1 ,2", expected: []int{3, 1, 2}},
+		{name: "drops duplicates", raw: "3,1,3", expected: []int{3, 1}},
+		{name: "empty", raw: "", wantErr: true},
+		{name: "not a number", raw: "1,abc", wantErr: true},
+		{name: "zero", raw: "0", wantErr: true},
+		{name: "trailing comma", raw: "1,", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseIDList("ids", tt.raw)
+			if tt.wantErr {
+				var ve *ValidationError
+				assert.True(t, errors.As(err, &ve), "expected ValidationError")
+				assert.Equal(t, "ids", ve.Errors[0].Field)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+// TestParseBatchGetRequest tests multi-get request parsing and its size limit
+func TestParseBatchGetRequest(t *testing.T) {
+	t.Run("populates parsed ids", func(t *testing.T) {
+		input := BatchGetRequest{IDs: "2,1"}
+		assert.NoError(t, ParseBatchGetRequest(&input))
+		assert.Equal(t, []int{2, 1}, input.IDValues)
+	})
+
+	t.Run("rejects more than the maximum", func(t *testing.T) {
+		ids := make([]string, MaxBatchGetSize+1)
+		for i := range ids {
+			ids[i] = strconv.Itoa(i + 1)
+		}
+		input := BatchGetRequest{IDs: strings.Join(ids, ",")}
+
+		var ve *ValidationError
+		assert.True(t, errors.As(ParseBatchGetRequest(&input), &ve), "expected ValidationError")
+	})
+}
+
+// TestParseFacetFields tests splitting the facets parameter
+func TestParseFacetFields(t *testing.T) {
+	assert.Nil(t, ParseFacetFields(""))
+	assert.Equal(t, []string{"job", "rarity"}, ParseFacetFields("job, rarity,job"))
+}
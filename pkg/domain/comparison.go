@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"lizobly/ctc-db-api/pkg/constants"
+	"strconv"
+)
+
+// Request DTOs
+
+type CompareTravellerRequest struct {
+	IDs string `query:"ids" validate:"required"`
+
+	// Parsed values
+	IDValues []int `json:"-"`
+}
+
+// DefaultCompareLimit is the maximum number of travellers compared when no limit is configured
+const DefaultCompareLimit = 5
+
+// Response DTOs
+
+// StatComparison aligns one accessory stat across the compared travellers.
+// Values follow the order of the travellers; null means the traveller has no accessory.
+type StatComparison struct {
+	Stat   string  `json:"stat" example:"patk"`
+	Values []*int  `json:"values"`
+	Best   []int64 `json:"best"`
+	Worst  []int64 `json:"worst"`
+}
+
+// FieldComparison aligns one traveller attribute across the compared travellers
+type FieldComparison struct {
+	Field   string   `json:"field" example:"job"`
+	Values  []string `json:"values"`
+	Differs bool     `json:"differs" example:"true"`
+}
+
+type TravellerComparisonResponse struct {
+	Travellers     []TravellerResponse `json:"travellers"`
+	AccessoryStats []StatComparison    `json:"accessory_stats"`
+	Differences    []FieldComparison   `json:"differences"`
+}
+
+// CompareTravellers lays the travellers out side by side. Best and worst list the IDs
+// holding the highest and lowest value of each stat, and stay empty when every
+// equipped accessory has the same value.
+func CompareTravellers(travellers []*Traveller) TravellerComparisonResponse {
+	res := TravellerComparisonResponse{
+		Travellers:     make([]TravellerResponse, len(travellers)),
+		AccessoryStats: make([]StatComparison, 0, len(accessoryStats)),
+	}
+	for i, t := range travellers {
+		res.Travellers[i] = ToTravellerResponse(t)
+	}
+
+	for _, stat := range accessoryStats {
+		comparison := StatComparison{
+			Stat:   stat,
+			Values: make([]*int, len(travellers)),
+			Best:   []int64{},
+			Worst:  []int64{},
+		}
+
+		var high, low int
+		seen := false
+		for i, t := range travellers {
+			if t.Accessory == nil {
+				continue
+			}
+			value := accessoryStatValue(t.Accessory, stat)
+			comparison.Values[i] = &value
+			if !seen || value > high {
+				high = value
+			}
+			if !seen || value < low {
+				low = value
+			}
+			seen = true
+		}
+
+		if seen && high != low {
+			for i, t := range travellers {
+				switch {
+				case comparison.Values[i] == nil:
+				case *comparison.Values[i] == high:
+					comparison.Best = append(comparison.Best, t.ID)
+				case *comparison.Values[i] == low:
+					comparison.Worst = append(comparison.Worst, t.ID)
+				}
+			}
+		}
+
+		res.AccessoryStats = append(res.AccessoryStats, comparison)
+	}
+
+	res.Differences = []FieldComparison{
+		compareField(travellers, "job", func(t *Traveller) string { return constants.GetJobName(t.JobID) }),
+		compareField(travellers, "influence", func(t *Traveller) string { return constants.GetInfluenceName(t.InfluenceID) }),
+		compareField(travellers, "rarity", func(t *Traveller) string { return strconv.Itoa(t.Rarity) }),
+		compareField(travellers, "release_date", func(t *Traveller) string { return formatReleaseDate(t.ReleaseDate) }),
+	}
+
+	return res
+}
+
+func compareField(travellers []*Traveller, field string, value func(*Traveller) string) FieldComparison {
+	comparison := FieldComparison{
+		Field:  field,
+		Values: make([]string, len(travellers)),
+	}
+	for i, t := range travellers {
+		comparison.Values[i] = value(t)
+		if comparison.Values[i] != comparison.Values[0] {
+			comparison.Differs = true
+		}
+	}
+	return comparison
+}
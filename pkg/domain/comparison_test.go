@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"lizobly/ctc-db-api/pkg/constants"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCompareTravellers tests side-by-side alignment, stat markers, and field differences
+func TestCompareTravellers(t *testing.T) {
+	travellers := []*Traveller{
+		{
+			CommonModel: CommonModel{ID: 1}, Name: "Fiore", Rarity: 5, InfluenceID: constants.InfluenceFameID, JobID: constants.JobWarriorID,
+			ReleaseDate: time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC),
+			Accessory:   &Accessory{Name: "Blade", PAtk: 100, HP: 200},
+		},
+		{
+			CommonModel: CommonModel{ID: 2}, Name: "Viola", Rarity: 5, InfluenceID: constants.InfluenceWealthID, JobID: constants.JobDancerID,
+			ReleaseDate: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			CommonModel: CommonModel{ID: 3}, Name: "Tressa", Rarity: 4, InfluenceID: constants.InfluenceFameID, JobID: constants.JobMerchantID,
+			ReleaseDate: time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC),
+			Accessory:   &Accessory{Name: "Purse", PAtk: 40, HP: 200, SP: 30},
+		},
+	}
+
+	res := CompareTravellers(travellers)
+
+	t.Run("keeps traveller order", func(t *testing.T) {
+		assert.Len(t, res.Travellers, 3)
+		assert.Equal(t, "Fiore", res.Travellers[0].Name)
+		assert.Equal(t, "Viola", res.Travellers[1].Name)
+		assert.Equal(t, "Tressa", res.Travellers[2].Name)
+	})
+
+	t.Run("aligns accessory stats per traveller", func(t *testing.T) {
+		assert.Len(t, res.AccessoryStats, len(accessoryStats))
+		patk := res.AccessoryStats[2]
+		assert.Equal(t, "patk", patk.Stat)
+		assert.Equal(t, 100, *patk.Values[0])
+		assert.Nil(t, patk.Values[1])
+		assert.Equal(t, 40, *patk.Values[2])
+		assert.Equal(t, []int64{1}, patk.Best)
+		assert.Equal(t, []int64{3}, patk.Worst)
+	})
+
+	t.Run("no markers when equipped values are equal", func(t *testing.T) {
+		hp := res.AccessoryStats[0]
+		assert.Equal(t, "hp", hp.Stat)
+		assert.Empty(t, hp.Best)
+		assert.Empty(t, hp.Worst)
+	})
+
+	t.Run("zero stat still counts as worst", func(t *testing.T) {
+		sp := res.AccessoryStats[1]
+		assert.Equal(t, []int64{3}, sp.Best)
+		assert.Equal(t, []int64{1}, sp.Worst)
+	})
+
+	t.Run("reports field differences", func(t *testing.T) {
+		differs := map[string]bool{}
+		for _, d := range res.Differences {
+			differs[d.Field] = d.Differs
+		}
+		assert.Equal(t, map[string]bool{"job": true, "influence": true, "rarity": true, "release_date": true}, differs)
+		assert.Equal(t, []string{"Warrior", "Dancer", "Merchant"}, res.Differences[0].Values)
+		assert.Equal(t, []string{"15-05-2023", "01-10-2024", "15-05-2023"}, res.Differences[3].Values)
+	})
+
+	t.Run("identical fields do not differ", func(t *testing.T) {
+		same := CompareTravellers([]*Traveller{travellers[0], travellers[0]})
+		for _, d := range same.Differences {
+			assert.False(t, d.Differs, d.Field)
+		}
+	})
+}
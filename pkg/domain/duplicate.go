@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"lizobly/ctc-db-api/pkg/constants"
+	"sort"
+	"strconv"
+)
+
+// Request DTOs
+
+type DuplicateCandidateRequest struct {
+	MinScore float64 `query:"min_score" validate:"omitempty,gt=0,lte=100"`
+	Limit    int     `query:"limit" validate:"omitempty,gte=1,lte=100"`
+}
+
+// DefaultDuplicateMinScore is the lowest score reported when no min_score is given
+const DefaultDuplicateMinScore = 60
+
+// DefaultDuplicateLimit is the number of candidates reported when no limit is given
+const DefaultDuplicateLimit = 20
+
+// DuplicateNameThreshold is the trigram similarity of name keys, from 0 to 1, a pair of
+// travellers needs before it is scored at all
+const DuplicateNameThreshold = 0.4
+
+// Weights of the duplicate score: the name similarity and the matching attributes, out of 100
+const (
+	DuplicateNameWeight      = 70
+	DuplicateAttributeWeight = 30
+)
+
+// SimilarPair is a pair of travellers whose name keys resemble each other, as found by the repository
+type SimilarPair struct {
+	FirstID        int64
+	SecondID       int64
+	NameSimilarity float64
+}
+
+// Sides of a merge whose accessory is kept when both travellers have one
+const (
+	MergeKeepTarget = "target"
+	MergeKeepSource = "source"
+)
+
+// MergeTravellerRequest names the traveller merged into the one in the path
+type MergeTravellerRequest struct {
+	SourceID int64 `json:"source_id" validate:"required,gte=1" example:"12"`
+	// KeepAccessory picks the accessory the merged traveller keeps; it is required when both have one
+	KeepAccessory string `json:"keep_accessory" validate:"omitempty,oneof=target source" example:"target"`
+
+	// Version is the expected current version of the target taken from If-Match; zero makes the merge unconditional
+	Version int64 `json:"-"`
+}
+
+// Response DTOs
+
+// DuplicateCandidate is a pair of travellers that may be the same one entered twice
+type DuplicateCandidate struct {
+	Travellers     []TravellerListItemResponse `json:"travellers"`
+	Score          float64                     `json:"score" example:"92.5"`
+	NameSimilarity float64                     `json:"name_similarity" example:"0.89"`
+	Matches        []string                    `json:"matches" example:"job,influence,rarity"`
+}
+
+type DuplicateReportResponse struct {
+	Candidates []DuplicateCandidate `json:"candidates"`
+}
+
+// duplicateAttributes are the attributes compared between duplicate candidates
+var duplicateAttributes = []struct {
+	field string
+	value func(t *Traveller) string
+}{
+	{"job", func(t *Traveller) string { return constants.GetJobName(t.JobID) }},
+	{"influence", func(t *Traveller) string { return constants.GetInfluenceName(t.InfluenceID) }},
+	{"rarity", func(t *Traveller) string { return strconv.Itoa(t.Rarity) }},
+	{"release_date", func(t *Traveller) string { return formatReleaseDate(t.ReleaseDate) }},
+}
+
+// DuplicateAttributeFields lists the attributes ScoreDuplicate compares, so a query can score
+// pairs the same way
+func DuplicateAttributeFields() []string {
+	fields := make([]string, len(duplicateAttributes))
+	for i, attribute := range duplicateAttributes {
+		fields[i] = attribute.field
+	}
+	return fields
+}
+
+// ScoreDuplicate scores how likely two travellers are duplicates, from 0 to 100. Name similarity
+// weighs the most; each attribute both have and share adds the rest.
+func ScoreDuplicate(first, second *Traveller, nameSimilarity float64) DuplicateCandidate {
+	candidate := DuplicateCandidate{
+		Travellers:     []TravellerListItemResponse{ToTravellerListItemResponse(first), ToTravellerListItemResponse(second)},
+		NameSimilarity: roundScore(nameSimilarity),
+		Matches:        []string{},
+	}
+
+	for _, attribute := range duplicateAttributes {
+		value := attribute.value(first)
+		// An unset attribute, like a missing release date, is no evidence either way
+		if value != "" && value == attribute.value(second) {
+			candidate.Matches = append(candidate.Matches, attribute.field)
+		}
+	}
+
+	score := nameSimilarity*DuplicateNameWeight +
+		float64(len(candidate.Matches))/float64(len(duplicateAttributes))*DuplicateAttributeWeight
+	candidate.Score = roundScore(score)
+	return candidate
+}
+
+// RankDuplicates keeps the candidates scoring at least minScore, most likely first, up to limit
+func RankDuplicates(candidates []DuplicateCandidate, minScore float64, limit int) []DuplicateCandidate {
+	result := make([]DuplicateCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if c.Score >= minScore {
+			result = append(result, c)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// MergeTravellers folds source into target: attributes target leaves empty are taken from
+// source, and target takes over source's accessory if it has none. When both have an accessory,
+// keepAccessory picks the one target ends up with; without it the merge is refused, as the
+// other accessory would lose its traveller.
+func MergeTravellers(target, source *Traveller, keepAccessory string) error {
+	if target.AccessoryID != nil && source.AccessoryID != nil && keepAccessory == "" {
+		return NewValidationError([]FieldError{
+			{Field: "keep_accessory", Message: "both travellers have an accessory, keep_accessory must name the one to keep"},
+		})
+	}
+
+	if target.Banner == "" {
+		target.Banner = source.Banner
+	}
+	if target.ReleaseDate.IsZero() {
+		target.ReleaseDate = source.ReleaseDate
+	}
+	if source.AccessoryID != nil && (target.AccessoryID == nil || keepAccessory == MergeKeepSource) {
+		target.AccessoryID = source.AccessoryID
+	}
+	return nil
+}
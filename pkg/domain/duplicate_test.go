@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"lizobly/ctc-db-api/pkg/constants"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestScoreDuplicate tests scoring a pair of duplicate candidates
+func TestScoreDuplicate(t *testing.T) {
+	released := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	viola := &Traveller{CommonModel: CommonModel{ID: 1}, Name: "Viola", Rarity: 5, JobID: constants.JobDancerID, InfluenceID: constants.InfluenceFameID, ReleaseDate: released}
+
+	t.Run("every attribute matches", func(t *testing.T) {
+		entered := &Traveller{CommonModel: CommonModel{ID: 2}, Name: "Viola.", Rarity: 5, JobID: constants.JobDancerID, InfluenceID: constants.InfluenceFameID, ReleaseDate: released}
+
+		got := ScoreDuplicate(viola, entered, 0.75)
+
+		assert.Equal(t, []string{"job", "influence", "rarity", "release_date"}, got.Matches)
+		assert.Equal(t, 82.5, got.Score)
+		assert.Equal(t, 0.75, got.NameSimilarity)
+		assert.Equal(t, int64(1), got.Travellers[0].ID)
+		assert.Equal(t, int64(2), got.Travellers[1].ID)
+	})
+
+	t.Run("missing release dates don't count as a match", func(t *testing.T) {
+		first := &Traveller{Name: "Fiore", Rarity: 4, JobID: constants.JobHunterID}
+		second := &Traveller{Name: "Fiorre", Rarity: 5, JobID: constants.JobHunterID}
+
+		got := ScoreDuplicate(first, second, 0.5)
+
+		assert.Equal(t, []string{"job"}, got.Matches)
+		assert.Equal(t, 42.5, got.Score)
+	})
+}
+
+// TestRankDuplicates tests filtering, ordering and limiting duplicate candidates
+func TestRankDuplicates(t *testing.T) {
+	candidates := []DuplicateCandidate{{Score: 61}, {Score: 95}, {Score: 40}, {Score: 72}}
+
+	assert.Equal(t, []DuplicateCandidate{{Score: 95}, {Score: 72}}, RankDuplicates(candidates, 60, 2))
+	assert.Equal(t, []DuplicateCandidate{{Score: 95}, {Score: 72}, {Score: 61}}, RankDuplicates(candidates, 60, 0))
+	assert.Empty(t, RankDuplicates(candidates, 99, 0))
+}
+
+// TestMergeTravellers tests folding one traveller into another
+func TestMergeTravellers(t *testing.T) {
+	released := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	accessoryID, otherAccessoryID := 7, 9
+
+	t.Run("empty attributes and the accessory are taken from source", func(t *testing.T) {
+		target := &Traveller{Name: "Viola", Rarity: 5}
+		source := &Traveller{Name: "viola", Rarity: 4, Banner: "Standard Banner", ReleaseDate: released, AccessoryID: &accessoryID}
+
+		err := MergeTravellers(target, source, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &Traveller{Name: "Viola", Rarity: 5, Banner: "Standard Banner", ReleaseDate: released, AccessoryID: &accessoryID}, target)
+	})
+
+	t.Run("target keeps what it has", func(t *testing.T) {
+		target := &Traveller{Name: "Viola", Banner: "Viola's Banner", AccessoryID: &otherAccessoryID}
+		source := &Traveller{Name: "viola", Banner: "Standard Banner", ReleaseDate: released, AccessoryID: &accessoryID}
+
+		err := MergeTravellers(target, source, MergeKeepTarget)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Viola's Banner", target.Banner)
+		assert.Equal(t, released, target.ReleaseDate)
+		assert.Equal(t, &otherAccessoryID, target.AccessoryID)
+	})
+
+	t.Run("keeps the source's accessory when asked", func(t *testing.T) {
+		target := &Traveller{Name: "Viola", AccessoryID: &otherAccessoryID}
+		source := &Traveller{Name: "viola", AccessoryID: &accessoryID}
+
+		err := MergeTravellers(target, source, MergeKeepSource)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &accessoryID, target.AccessoryID)
+	})
+
+	t.Run("both accessories without a choice", func(t *testing.T) {
+		target := &Traveller{Name: "Viola", Banner: "Viola's Banner", AccessoryID: &otherAccessoryID}
+		source := &Traveller{Name: "viola", Banner: "Standard Banner", AccessoryID: &accessoryID}
+
+		err := MergeTravellers(target, source, "")
+
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+		assert.Equal(t, "keep_accessory", ve.Errors[0].Field)
+		assert.Equal(t, &otherAccessoryID, target.AccessoryID)
+	})
+}
@@ -31,8 +31,8 @@ func NewNotFoundError(resource string, id interface{}, cause error) error {
 
 // FieldError represents a single field validation error
 type FieldError struct {
-	Field   string
-	Message string
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 // ValidationError represents a validation error
@@ -119,3 +119,22 @@ func (e *TimeoutError) Unwrap() error {
 func NewTimeoutError(message string, cause error) error {
 	return &TimeoutError{Message: message, cause: cause}
 }
+
+// PreconditionFailedError represents a conditional write whose expected version no longer matches
+type PreconditionFailedError struct {
+	Message string
+	cause   error
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return e.Message
+}
+
+func (e *PreconditionFailedError) Unwrap() error {
+	return e.cause
+}
+
+// NewPreconditionFailedError creates a new PreconditionFailedError
+func NewPreconditionFailedError(message string, cause error) error {
+	return &PreconditionFailedError{Message: message, cause: cause}
+}
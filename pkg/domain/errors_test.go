@@ -394,3 +394,21 @@ func TestErrorTypes_Differentiation(t *testing.T) {
 		t.Error("authErr should not be ValidationError")
 	}
 }
+
+// TestNewPreconditionFailedError tests PreconditionFailedError creation and unwrapping
+func TestNewPreconditionFailedError(t *testing.T) {
+	cause := errors.New("0 rows affected")
+	err := NewPreconditionFailedError("resource has been modified", cause)
+
+	if err.Error() != "resource has been modified" {
+		t.Errorf("expected 'resource has been modified', got '%s'", err.Error())
+	}
+
+	var pfe *PreconditionFailedError
+	if !errors.As(err, &pfe) {
+		t.Fatal("errors.As should return true for PreconditionFailedError")
+	}
+	if !errors.Is(err, cause) {
+		t.Error("errors.Is should find the wrapped cause")
+	}
+}
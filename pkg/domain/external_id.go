@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// Entity types an external ID can map to
+const (
+	ExternalEntityTraveller = "traveller"
+	ExternalEntityAccessory = "accessory"
+)
+
+// ExternalID maps the ID a record has in an external source, such as a community database,
+// to the traveller or accessory it was synced into. Each (entity type, source, external ID)
+// maps to one entity.
+type ExternalID struct {
+	ID         int64     `gorm:"column:id"`
+	EntityType string    `gorm:"column:entity_type"`
+	EntityID   *int64    `gorm:"column:entity_id"`
+	Source     string    `gorm:"column:source"`
+	ExternalID string    `gorm:"column:external_id"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+	UpdatedAt  time.Time `gorm:"column:updated_at"`
+}
+
+func (ExternalID) TableName() string {
+	return "m_external_id"
+}
+
+// Request DTOs
+
+// ExternalKey identifies a record by its source and the ID the source gives it
+type ExternalKey struct {
+	Source     string `validate:"required,max=50"`
+	ExternalID string `validate:"required,max=100"`
+}
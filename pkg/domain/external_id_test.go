@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestExternalID_TableName tests table name method
+func TestExternalID_TableName(t *testing.T) {
+	externalID := ExternalID{}
+	assert.Equal(t, "m_external_id", externalID.TableName())
+}
@@ -4,5 +4,7 @@ import "github.com/golang-jwt/jwt/v5"
 
 type JWTClaims struct {
 	Username string `json:"username"`
+	// Editor lets the holder read unpublished travellers and accessories
+	Editor bool `json:"editor,omitempty"`
 	jwt.RegisteredClaims
 }
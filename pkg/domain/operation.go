@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/constants"
+	"net/url"
+	"time"
+)
+
+// Operation statuses
+const (
+	OperationStatusRunning   = "running"
+	OperationStatusSucceeded = "succeeded"
+	OperationStatusFailed    = "failed"
+)
+
+// OperationFunc is the work of an asynchronous operation. It returns the path of the resource
+// it wrote.
+type OperationFunc func(ctx context.Context) (location string, err error)
+
+// Operation is a write accepted with Prefer: respond-async, tracked until it finishes
+type Operation struct {
+	ID         string
+	Name       string
+	Status     string
+	Location   string
+	Err        error
+	CreatedAt  time.Time
+	FinishedAt *time.Time
+}
+
+// Response DTOs
+
+// OperationResponse is the status of an asynchronous operation. Location is the resource it
+// wrote once it succeeded, and Error is how it failed otherwise.
+type OperationResponse struct {
+	ID         string          `json:"id" example:"5f0c6f43-9d5b-4b6e-8a49-8d1d0c2b7a61"`
+	Name       string          `json:"name" example:"create traveller"`
+	Status     string          `json:"status" example:"succeeded"`
+	Location   string          `json:"location,omitempty" example:"/api/v1/travellers/1"`
+	Error      *OperationError `json:"error,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+	Links      ResourceLinks   `json:"links"`
+}
+
+// OperationError is the failure of an operation, with the status and message the request
+// would have got had it run synchronously
+type OperationError struct {
+	Status  int          `json:"status" example:"412"`
+	Message string       `json:"message" example:"resource was modified"`
+	Errors  []FieldError `json:"errors,omitempty"`
+}
+
+// OperationPath returns the URL path of an operation's status
+func OperationPath(id string) string {
+	return constants.APIBasePath + "/operations/" + url.PathEscape(id)
+}
+
+// Mapper functions
+
+// ToOperationResponse maps an operation to its response. Error is left for the caller, since
+// turning an error into a status is up to the controller.
+func ToOperationResponse(op *Operation) OperationResponse {
+	return OperationResponse{
+		ID:         op.ID,
+		Name:       op.Name,
+		Status:     op.Status,
+		Location:   op.Location,
+		CreatedAt:  op.CreatedAt,
+		FinishedAt: op.FinishedAt,
+		Links:      ResourceLinks{Self: OperationPath(op.ID)},
+	}
+}
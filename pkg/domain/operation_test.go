@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestToOperationResponse tests that the mapper leaves the error to the controller
+func TestToOperationResponse(t *testing.T) {
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	op := &Operation{
+		ID:        "5f0c6f43",
+		Name:      "create traveller",
+		Status:    OperationStatusFailed,
+		Err:       errors.New("database connection failed"),
+		CreatedAt: createdAt,
+	}
+
+	assert.Equal(t, OperationResponse{
+		ID:        "5f0c6f43",
+		Name:      "create traveller",
+		Status:    OperationStatusFailed,
+		CreatedAt: createdAt,
+		Links:     ResourceLinks{Self: "/api/v1/operations/5f0c6f43"},
+	}, ToOperationResponse(op))
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"time"
+)
+
+// Publication statuses. Drafts are never published on their own; scheduled records are
+// published by the publisher once their publish time has come.
+const (
+	PublishStatusDraft     = "draft"
+	PublishStatusScheduled = "scheduled"
+	PublishStatusPublished = "published"
+)
+
+// PublishableTypes lists every entity type with a publication status
+var PublishableTypes = []string{TrashTypeTraveller, TrashTypeAccessory}
+
+// Publishable is implemented by models only editors can read before they are published
+type Publishable interface {
+	IsPublished() bool
+}
+
+// Publication is the release state of a record. Records written before the workflow existed,
+// and writes that don't set it, are published.
+type Publication struct {
+	Status    string     `json:"status" gorm:"column:status;default:published"`
+	PublishAt *time.Time `json:"publish_at,omitempty" gorm:"column:publish_at"`
+}
+
+func (p Publication) IsPublished() bool {
+	return p.Status == "" || p.Status == PublishStatusPublished
+}
+
+// Columns returns the columns to write for the publication, none if it is unset so an update
+// keeps the stored one
+func (p Publication) Columns() map[string]interface{} {
+	if p.Status == "" {
+		return nil
+	}
+	return map[string]interface{}{
+		"status":     p.Status,
+		"publish_at": p.PublishAt,
+	}
+}
+
+// NewPublication works out the publication a write asks for. Without a status, a publish time
+// schedules the record and no publish time leaves the publication unset. A record scheduled
+// without a publish time is published on releaseDate, so one of them is required.
+func NewPublication(status, publishAt string, releaseDate time.Time) (Publication, error) {
+	var at *time.Time
+	if publishAt != "" {
+		parsed, err := time.Parse(time.RFC3339, publishAt)
+		if err != nil {
+			return Publication{}, NewValidationError([]FieldError{
+				{Field: "publish_at", Message: "publish_at must be an RFC 3339 timestamp, e.g. 2024-10-01T09:00:00Z"},
+			})
+		}
+		at = &parsed
+	}
+
+	if status == "" && at != nil {
+		status = PublishStatusScheduled
+	}
+	if status == PublishStatusScheduled && at == nil {
+		if releaseDate.IsZero() {
+			return Publication{}, NewValidationError([]FieldError{
+				{Field: "publish_at", Message: "publish_at or release_date is required to schedule publishing"},
+			})
+		}
+		at = &releaseDate
+	}
+	return Publication{Status: status, PublishAt: at}, nil
+}
+
+// formatPublishAt formats a publish time for update requests, leaving an unset one empty
+func formatPublishAt(at *time.Time) string {
+	if at == nil {
+		return ""
+	}
+	return at.Format(time.RFC3339)
+}
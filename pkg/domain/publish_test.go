@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewPublication tests working out the publication a write asks for
+func TestNewPublication(t *testing.T) {
+	releaseDate := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	publishAt := time.Date(2024, 10, 2, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		status    string
+		publishAt string
+		release   time.Time
+		want      Publication
+		wantErr   bool
+	}{
+		{name: "unset", release: releaseDate, want: Publication{}},
+		{name: "draft", status: PublishStatusDraft, release: releaseDate, want: Publication{Status: PublishStatusDraft}},
+		{name: "publish_at schedules", publishAt: "2024-10-02T09:00:00Z", release: releaseDate,
+			want: Publication{Status: PublishStatusScheduled, PublishAt: &publishAt}},
+		{name: "scheduled defaults to the release date", status: PublishStatusScheduled, release: releaseDate,
+			want: Publication{Status: PublishStatusScheduled, PublishAt: &releaseDate}},
+		{name: "scheduled without any date", status: PublishStatusScheduled, wantErr: true},
+		{name: "invalid publish_at", publishAt: "tomorrow", release: releaseDate, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPublication(tt.status, tt.publishAt, tt.release)
+			if tt.wantErr {
+				var ve *ValidationError
+				assert.ErrorAs(t, err, &ve)
+				assert.Equal(t, "publish_at", ve.Errors[0].Field)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestPublication_IsPublished tests which records readers see
+func TestPublication_IsPublished(t *testing.T) {
+	assert.True(t, Publication{}.IsPublished())
+	assert.True(t, Publication{Status: PublishStatusPublished}.IsPublished())
+	assert.False(t, Publication{Status: PublishStatusDraft}.IsPublished())
+	assert.False(t, Publication{Status: PublishStatusScheduled}.IsPublished())
+}
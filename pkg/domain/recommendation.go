@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"fmt"
+	"lizobly/ctc-db-api/pkg/constants"
+	"math"
+	"sort"
+	"strings"
+)
+
+// StatWeights maps an accessory stat (json name) to how much it matters for a traveller
+type StatWeights map[string]float64
+
+// Stat names used by the recommendation weight profiles, ordered as in Accessory
+var accessoryStats = []string{"hp", "sp", "patk", "pdef", "eatk", "edef", "spd", "crit"}
+
+// jobStatWeights is the stat-weight profile of each job
+var jobStatWeights = map[int]StatWeights{
+	constants.JobWarriorID:    {"patk": 1.0, "crit": 0.6, "hp": 0.5, "pdef": 0.4, "spd": 0.3},
+	constants.JobMerchantID:   {"patk": 0.6, "sp": 0.6, "hp": 0.5, "spd": 0.4, "pdef": 0.3},
+	constants.JobThiefID:      {"patk": 0.8, "spd": 0.8, "crit": 0.6, "sp": 0.3},
+	constants.JobApothecaryID: {"patk": 0.6, "hp": 0.6, "pdef": 0.4, "edef": 0.4, "sp": 0.3},
+	constants.JobHunterID:     {"patk": 1.0, "crit": 0.7, "spd": 0.4, "sp": 0.3},
+	constants.JobClericID:     {"sp": 0.8, "edef": 0.6, "eatk": 0.6, "hp": 0.5},
+	constants.JobScholarID:    {"eatk": 1.0, "sp": 0.6, "spd": 0.3, "edef": 0.3},
+	constants.JobDancerID:     {"eatk": 0.8, "spd": 0.7, "sp": 0.6, "edef": 0.3},
+}
+
+// GetJobStatWeights returns the stat-weight profile for a job, or nil if the job is unknown
+func GetJobStatWeights(jobID int) StatWeights {
+	return jobStatWeights[jobID]
+}
+
+// Request DTOs
+
+type RecommendAccessoryRequest struct {
+	Limit        int  `query:"limit" validate:"omitempty,gte=1,lte=50"`
+	IncludeOwned bool `query:"include_owned"`
+}
+
+// DefaultRecommendationLimit is the number of candidates returned when no limit is given
+const DefaultRecommendationLimit = 5
+
+// Response DTOs
+
+// StatContribution explains how much a single stat added to a candidate's score
+type StatContribution struct {
+	Stat         string  `json:"stat" example:"patk"`
+	Value        int     `json:"value" example:"120"`
+	Weight       float64 `json:"weight" example:"1"`
+	Contribution float64 `json:"contribution" example:"31.25"`
+}
+
+type AccessoryRecommendation struct {
+	Accessory   AccessoryListItemResponse `json:"accessory"`
+	Score       float64                   `json:"score" example:"78.4"`
+	Summary     string                    `json:"summary" example:"Strongest in patk, crit, hp for Warrior"`
+	Explanation []StatContribution        `json:"explanation"`
+}
+
+type AccessoryRecommendationResponse struct {
+	Traveller       string                    `json:"traveller" example:"Viola"`
+	Job             string                    `json:"job" example:"Dancer"`
+	Weights         StatWeights               `json:"weights"`
+	Recommendations []AccessoryRecommendation `json:"recommendations"`
+}
+
+// accessoryStatValue returns the value of a stat by its json name
+func accessoryStatValue(accessory *Accessory, stat string) int {
+	switch stat {
+	case "hp":
+		return accessory.HP
+	case "sp":
+		return accessory.SP
+	case "patk":
+		return accessory.PAtk
+	case "pdef":
+		return accessory.PDef
+	case "eatk":
+		return accessory.EAtk
+	case "edef":
+		return accessory.EDef
+	case "spd":
+		return accessory.Spd
+	case "crit":
+		return accessory.Crit
+	}
+	return 0
+}
+
+// ScoreAccessories ranks candidates against a stat-weight profile.
+// Each stat is normalised by the best value among the candidates so large stats
+// like HP don't drown out small ones like Crit. Scores range from 0 to 100.
+func ScoreAccessories(candidates []*Accessory, ownerNames map[int64]string, weights StatWeights, jobName string, limit int) []AccessoryRecommendation {
+	maxStat := make(map[string]int, len(accessoryStats))
+	for _, acc := range candidates {
+		for _, stat := range accessoryStats {
+			if v := accessoryStatValue(acc, stat); v > maxStat[stat] {
+				maxStat[stat] = v
+			}
+		}
+	}
+
+	var totalWeight float64
+	for _, w := range weights {
+		totalWeight += w
+	}
+
+	result := make([]AccessoryRecommendation, 0, len(candidates))
+	for _, acc := range candidates {
+		var score float64
+		explanation := make([]StatContribution, 0, len(weights))
+		for _, stat := range accessoryStats {
+			weight, ok := weights[stat]
+			if !ok {
+				continue
+			}
+			value := accessoryStatValue(acc, stat)
+			var contribution float64
+			if maxStat[stat] > 0 && totalWeight > 0 && value > 0 {
+				contribution = float64(value) / float64(maxStat[stat]) * weight / totalWeight * 100
+			}
+			score += contribution
+			explanation = append(explanation, StatContribution{
+				Stat:         stat,
+				Value:        value,
+				Weight:       weight,
+				Contribution: roundScore(contribution),
+			})
+		}
+
+		sort.SliceStable(explanation, func(i, j int) bool {
+			return explanation[i].Contribution > explanation[j].Contribution
+		})
+
+		result = append(result, AccessoryRecommendation{
+			Accessory:   ToAccessoryListItemResponse(acc, ownerNames),
+			Score:       roundScore(score),
+			Summary:     summarizeContributions(explanation, jobName),
+			Explanation: explanation,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].Accessory.Name < result[j].Accessory.Name
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result
+}
+
+// summarizeContributions names the top three stats that contributed to a score
+func summarizeContributions(explanation []StatContribution, jobName string) string {
+	var top []string
+	for _, c := range explanation {
+		if c.Contribution <= 0 || len(top) == 3 {
+			break
+		}
+		top = append(top, c.Stat)
+	}
+	if len(top) == 0 {
+		return fmt.Sprintf("No stats relevant to %s", jobName)
+	}
+	return fmt.Sprintf("Strongest in %s for %s", strings.Join(top, ", "), jobName)
+}
+
+func roundScore(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"lizobly/ctc-db-api/pkg/constants"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetJobStatWeights tests weight profile lookup
+func TestGetJobStatWeights(t *testing.T) {
+	for _, jobID := range []int{
+		constants.JobWarriorID, constants.JobMerchantID, constants.JobThiefID, constants.JobApothecaryID,
+		constants.JobHunterID, constants.JobClericID, constants.JobScholarID, constants.JobDancerID,
+	} {
+		assert.NotEmpty(t, GetJobStatWeights(jobID), "job %d should have a weight profile", jobID)
+	}
+	assert.Nil(t, GetJobStatWeights(0))
+}
+
+// TestScoreAccessories tests candidate ranking and explanations
+func TestScoreAccessories(t *testing.T) {
+	candidates := []*Accessory{
+		{CommonModel: CommonModel{ID: 1}, Name: "Tome", EAtk: 150, SP: 40},
+		{CommonModel: CommonModel{ID: 2}, Name: "Blade", PAtk: 100, Crit: 20},
+		{CommonModel: CommonModel{ID: 3}, Name: "Ring", PAtk: 50, HP: 300},
+	}
+	weights := StatWeights{"patk": 1.0, "crit": 0.5, "hp": 0.5}
+
+	t.Run("ranks by weighted normalised stats", func(t *testing.T) {
+		result := ScoreAccessories(candidates, map[int64]string{2: "Fiore"}, weights, constants.JobWarrior, 0)
+
+		assert.Len(t, result, 3)
+		assert.Equal(t, "Blade", result[0].Accessory.Name)
+		assert.Equal(t, "Fiore", result[0].Accessory.Owner)
+		assert.Equal(t, 75.0, result[0].Score)
+		assert.Equal(t, "Ring", result[1].Accessory.Name)
+		assert.Equal(t, 50.0, result[1].Score)
+		assert.Equal(t, "Tome", result[2].Accessory.Name)
+		assert.Equal(t, 0.0, result[2].Score)
+	})
+
+	t.Run("explains score by stat contribution", func(t *testing.T) {
+		result := ScoreAccessories(candidates, nil, weights, constants.JobWarrior, 1)
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "patk", result[0].Explanation[0].Stat)
+		assert.Equal(t, 50.0, result[0].Explanation[0].Contribution)
+		assert.Equal(t, "Strongest in patk, crit for Warrior", result[0].Summary)
+	})
+
+	t.Run("no relevant stats", func(t *testing.T) {
+		result := ScoreAccessories(candidates[:1], nil, weights, constants.JobWarrior, 0)
+
+		assert.Equal(t, "No stats relevant to Warrior", result[0].Summary)
+	})
+}
@@ -0,0 +1,315 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"lizobly/ctc-db-api/pkg/constants"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Revision actions. Each write of a revisioned row records one revision with its action.
+const (
+	RevisionActionCreate  = "create"
+	RevisionActionUpdate  = "update"
+	RevisionActionDelete  = "delete"
+	RevisionActionRestore = "restore"
+	RevisionActionPurge   = "purge"
+	RevisionActionRevert  = "revert"
+	RevisionActionPublish = "publish"
+)
+
+// Revisioned is implemented by models whose writes are recorded as revisions
+type Revisioned interface {
+	// RevisionType is the entity type revisions of the model are recorded under
+	RevisionType() string
+	// RevisionState is the part of the row a revision records, keyed by column
+	RevisionState() interface{}
+}
+
+// Revision is one write of a revisioned row: its state before and after the write, who made it
+// and in which request. Before is empty for a create and After for a purge.
+type Revision struct {
+	ID         int64     `gorm:"column:id"`
+	EntityType string    `gorm:"column:entity_type"`
+	EntityID   int64     `gorm:"column:entity_id"`
+	Action     string    `gorm:"column:action"`
+	Before     []byte    `gorm:"column:before;type:jsonb"`
+	After      []byte    `gorm:"column:after;type:jsonb"`
+	Actor      string    `gorm:"column:actor"`
+	RequestID  string    `gorm:"column:request_id"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+}
+
+func (Revision) TableName() string {
+	return "m_revision"
+}
+
+// TravellerState is the revisioned state of a traveller
+type TravellerState struct {
+	Name        string `json:"name"`
+	Rarity      int    `json:"rarity"`
+	Banner      string `json:"banner"`
+	ReleaseDate string `json:"release_date"`
+	InfluenceID int    `json:"influence_id"`
+	JobID       int    `json:"job_id"`
+	AccessoryID *int   `json:"accessory_id"`
+	Status      string `json:"status"`
+}
+
+func (Traveller) RevisionType() string {
+	return TrashTypeTraveller
+}
+
+func (t Traveller) RevisionState() interface{} {
+	return TravellerState{
+		Name:        t.Name,
+		Rarity:      t.Rarity,
+		Banner:      t.Banner,
+		ReleaseDate: formatReleaseDate(t.ReleaseDate),
+		InfluenceID: t.InfluenceID,
+		JobID:       t.JobID,
+		AccessoryID: t.AccessoryID,
+		Status:      t.Status,
+	}
+}
+
+// Columns returns the columns to write to put a traveller in this state. A cleared release date
+// is written as NULL.
+func (s TravellerState) Columns() (map[string]interface{}, error) {
+	var releaseDate interface{}
+	if s.ReleaseDate != "" {
+		parsed, err := time.Parse(constants.DateFormat, s.ReleaseDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid release date in revision: %w", err)
+		}
+		releaseDate = parsed
+	}
+
+	return map[string]interface{}{
+		"name":         s.Name,
+		"rarity":       s.Rarity,
+		"banner":       s.Banner,
+		"release_date": releaseDate,
+		"influence_id": s.InfluenceID,
+		"job_id":       s.JobID,
+		"accessory_id": s.AccessoryID,
+		"status":       revisionStatus(s.Status),
+	}, nil
+}
+
+// AccessoryState is the revisioned state of an accessory
+type AccessoryState struct {
+	Name   string `json:"name"`
+	HP     int    `json:"hp"`
+	SP     int    `json:"sp"`
+	PAtk   int    `json:"patk"`
+	PDef   int    `json:"pdef"`
+	EAtk   int    `json:"eatk"`
+	EDef   int    `json:"edef"`
+	Spd    int    `json:"spd"`
+	Crit   int    `json:"crit"`
+	Effect string `json:"effect"`
+	Status string `json:"status"`
+}
+
+func (Accessory) RevisionType() string {
+	return TrashTypeAccessory
+}
+
+func (a Accessory) RevisionState() interface{} {
+	return AccessoryState{
+		Name:   a.Name,
+		HP:     a.HP,
+		SP:     a.SP,
+		PAtk:   a.PAtk,
+		PDef:   a.PDef,
+		EAtk:   a.EAtk,
+		EDef:   a.EDef,
+		Spd:    a.Spd,
+		Crit:   a.Crit,
+		Effect: a.Effect,
+		Status: a.Status,
+	}
+}
+
+// Columns returns the columns to write to put an accessory in this state
+func (s AccessoryState) Columns() map[string]interface{} {
+	return map[string]interface{}{
+		"name":   s.Name,
+		"hp":     s.HP,
+		"sp":     s.SP,
+		"patk":   s.PAtk,
+		"pdef":   s.PDef,
+		"eatk":   s.EAtk,
+		"edef":   s.EDef,
+		"spd":    s.Spd,
+		"crit":   s.Crit,
+		"effect": s.Effect,
+		"status": revisionStatus(s.Status),
+	}
+}
+
+// Changes lists the fields the revision changed, ordered by field. Every field of the recorded
+// state counts as changed for a create or a purge.
+func (r Revision) Changes() ([]FieldChange, error) {
+	before, err := decodeState(r.Before)
+	if err != nil {
+		return nil, err
+	}
+	after, err := decodeState(r.After)
+	if err != nil {
+		return nil, err
+	}
+	return fieldChanges(before, after), nil
+}
+
+// fieldChanges lists the fields whose values differ between two states, ordered by field
+func fieldChanges(before, after map[string]interface{}) []FieldChange {
+	fields := make([]string, 0, len(before)+len(after))
+	for field := range before {
+		fields = append(fields, field)
+	}
+	for field := range after {
+		if _, ok := before[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := make([]FieldChange, 0, len(fields))
+	for _, field := range fields {
+		b, inBefore := before[field]
+		a, inAfter := after[field]
+		if inBefore && inAfter && reflect.DeepEqual(b, a) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: field, Before: b, After: a})
+	}
+	return changes
+}
+
+// Revert decodes into dest the current state with the fields the revision changed set back to
+// their values before it. A field changed again since is a conflict, so later edits are never
+// silently undone. Only revisions that changed fields of a row that still exists can be reverted.
+func (r Revision) Revert(current interface{}, dest interface{}) error {
+	changes, err := r.Changes()
+	if err != nil {
+		return err
+	}
+	if r.Before == nil || r.After == nil || len(changes) == 0 {
+		return NewValidationError([]FieldError{
+			{Field: "revision", Message: fmt.Sprintf("a %s revision has no field changes to revert", r.Action)},
+		})
+	}
+
+	encoded, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	state, err := decodeState(encoded)
+	if err != nil {
+		return err
+	}
+
+	var conflicts []string
+	for _, change := range changes {
+		if !reflect.DeepEqual(state[change.Field], change.After) {
+			conflicts = append(conflicts, change.Field)
+			continue
+		}
+		state[change.Field] = change.Before
+	}
+	if len(conflicts) > 0 {
+		return NewConflictError(fmt.Sprintf("%s changed since revision %d", strings.Join(conflicts, ", "), r.ID), nil)
+	}
+
+	encoded, err = json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded, dest)
+}
+
+// ParseAsOf parses the as_of query parameter of point-in-time reads. An empty value reads the
+// present and parses to the zero time.
+func ParseAsOf(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, NewValidationError([]FieldError{
+			{Field: "as_of", Message: "as_of must be an RFC 3339 timestamp, e.g. 2024-10-01T09:00:00Z"},
+		})
+	}
+	return at, nil
+}
+
+// decodeState decodes a recorded state into its fields; an empty state has none
+func decodeState(raw []byte) (map[string]interface{}, error) {
+	state := map[string]interface{}{}
+	if len(raw) == 0 {
+		return state, nil
+	}
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, fmt.Errorf("invalid revision state: %w", err)
+	}
+	return state, nil
+}
+
+// Request DTOs
+
+type RevertRevisionRequest struct {
+	Revision int64 `query:"revision" validate:"required,gt=0"`
+}
+
+// Response DTOs
+
+// FieldChange is one field a revision changed. Before is absent for a create and After for a purge.
+type FieldChange struct {
+	Field  string      `json:"field" example:"rarity"`
+	Before interface{} `json:"before,omitempty" swaggertype:"string" example:"4"`
+	After  interface{} `json:"after,omitempty" swaggertype:"string" example:"5"`
+}
+
+type RevisionResponse struct {
+	ID         int64         `json:"id" example:"31"`
+	EntityType string        `json:"entity_type" example:"traveller"`
+	EntityID   int64         `json:"entity_id" example:"1"`
+	Action     string        `json:"action" example:"update"`
+	Actor      string        `json:"actor,omitempty" example:"isla"`
+	RequestID  string        `json:"request_id,omitempty" example:"0b9e4f3a-5d2c-4e8e-9f0a-1c2d3e4f5a6b"`
+	CreatedAt  time.Time     `json:"created_at" example:"2024-10-02T18:00:00Z"`
+	Changes    []FieldChange `json:"changes"`
+}
+
+// Mapper functions
+
+func ToRevisionResponse(revision Revision) (RevisionResponse, error) {
+	changes, err := revision.Changes()
+	if err != nil {
+		return RevisionResponse{}, err
+	}
+	return RevisionResponse{
+		ID:         revision.ID,
+		EntityType: revision.EntityType,
+		EntityID:   revision.EntityID,
+		Action:     revision.Action,
+		Actor:      revision.Actor,
+		RequestID:  revision.RequestID,
+		CreatedAt:  revision.CreatedAt,
+		Changes:    changes,
+	}, nil
+}
+
+// revisionStatus is the publication status a revision recorded. Revisions from before the
+// publication workflow don't record one; their records were published.
+func revisionStatus(status string) string {
+	if status == "" {
+		return PublishStatusPublished
+	}
+	return status
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRevision_Changes tests listing the fields a revision changed
+func TestRevision_Changes(t *testing.T) {
+	t.Run("update lists only changed fields", func(t *testing.T) {
+		revision := Revision{
+			Before: []byte(`{"name":"Viola","rarity":4,"accessory_id":null}`),
+			After:  []byte(`{"name": "Viola", "rarity": 5, "accessory_id": 9}`),
+		}
+		changes, err := revision.Changes()
+		assert.NoError(t, err)
+		assert.Equal(t, []FieldChange{
+			{Field: "accessory_id", Before: nil, After: float64(9)},
+			{Field: "rarity", Before: float64(4), After: float64(5)},
+		}, changes)
+	})
+
+	t.Run("create lists every field", func(t *testing.T) {
+		revision := Revision{Action: RevisionActionCreate, After: []byte(`{"name":"Viola","rarity":5}`)}
+		changes, err := revision.Changes()
+		assert.NoError(t, err)
+		assert.Equal(t, []FieldChange{
+			{Field: "name", After: "Viola"},
+			{Field: "rarity", After: float64(5)},
+		}, changes)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		_, err := Revision{Before: []byte(`[`)}.Changes()
+		assert.Error(t, err)
+	})
+}
+
+// TestRevision_Revert tests undoing a revision against the current state
+func TestRevision_Revert(t *testing.T) {
+	accessoryID := 9
+	current := Traveller{Name: "Viola", Rarity: 5, Banner: "Standard Banner", ReleaseDate: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), InfluenceID: 1, JobID: 2, AccessoryID: &accessoryID}
+	revision := Revision{
+		ID:     12,
+		Action: RevisionActionUpdate,
+		Before: []byte(`{"name":"Viola","rarity":4,"banner":"Festival Banner"}`),
+		After:  []byte(`{"name":"Viola","rarity":5,"banner":"Standard Banner"}`),
+	}
+
+	t.Run("sets changed fields back and keeps the rest", func(t *testing.T) {
+		var state TravellerState
+		assert.NoError(t, revision.Revert(current.RevisionState(), &state))
+		assert.Equal(t, TravellerState{Name: "Viola", Rarity: 4, Banner: "Festival Banner", ReleaseDate: "01-10-2024", InfluenceID: 1, JobID: 2, AccessoryID: &accessoryID}, state)
+
+		columns, err := state.Columns()
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), columns["release_date"])
+	})
+
+	t.Run("fields changed since conflict", func(t *testing.T) {
+		changed := current
+		changed.Rarity = 3
+		var state TravellerState
+		err := revision.Revert(changed.RevisionState(), &state)
+		var ce *ConflictError
+		assert.True(t, errors.As(err, &ce))
+		assert.Equal(t, "rarity changed since revision 12", ce.Message)
+	})
+
+	for _, r := range []Revision{
+		{Action: RevisionActionCreate, After: revision.After},
+		{Action: RevisionActionDelete, Before: revision.After, After: revision.After},
+	} {
+		t.Run("rejects "+r.Action, func(t *testing.T) {
+			var state TravellerState
+			err := r.Revert(current.RevisionState(), &state)
+			var ve *ValidationError
+			assert.True(t, errors.As(err, &ve))
+		})
+	}
+}
+
+// TestTravellerState_Columns tests writing a cleared release date
+func TestTravellerState_Columns(t *testing.T) {
+	columns, err := TravellerState{Name: "Viola"}.Columns()
+	assert.NoError(t, err)
+	assert.Nil(t, columns["release_date"])
+	assert.Nil(t, columns["accessory_id"])
+}
+
+// TestParseAsOf tests parsing the as_of query parameter
+func TestParseAsOf(t *testing.T) {
+	at, err := ParseAsOf("2024-10-01T09:00:00+07:00")
+	assert.NoError(t, err)
+	assert.True(t, at.Equal(time.Date(2024, 10, 1, 2, 0, 0, 0, time.UTC)))
+
+	at, err = ParseAsOf("")
+	assert.NoError(t, err)
+	assert.True(t, at.IsZero())
+
+	_, err = ParseAsOf("01-10-2024")
+	var ve *ValidationError
+	assert.True(t, errors.As(err, &ve))
+	assert.Equal(t, "as_of", ve.Errors[0].Field)
+}
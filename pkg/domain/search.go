@@ -0,0 +1,74 @@
+package domain
+
+// Search result types, in the order their groups are returned
+const (
+	SearchTypeTraveller = "traveller"
+	SearchTypeAccessory = "accessory"
+)
+
+// SearchTypes lists every searchable entity type
+var SearchTypes = []string{SearchTypeTraveller, SearchTypeAccessory}
+
+// Request DTOs
+
+type SearchRequest struct {
+	Query string `query:"q" validate:"required,max=200"`
+	Type  string `query:"type" validate:"omitempty,oneofcsv=traveller accessory"`
+
+	// Parsed values populated by the service
+	Types []string `json:"-"`
+}
+
+// DefaultSuggestLimit is the number of suggestions returned when no limit is given
+const DefaultSuggestLimit = 5
+
+type SuggestRequest struct {
+	Query string `query:"q" validate:"required,max=100"`
+	Type  string `query:"type" validate:"omitempty,oneof=traveller accessory"`
+	Limit int    `query:"limit" validate:"omitempty,min=1,max=20"`
+}
+
+// Response DTOs
+
+// SearchHit is a single ranked match. Snippet is the matching text, HTML-escaped, with the
+// matched words wrapped in <mark> tags.
+type SearchHit struct {
+	ID      int64   `json:"id" example:"1"`
+	Type    string  `json:"type" example:"accessory"`
+	Slug    string  `json:"slug" example:"crown-of-wisdom"`
+	Name    string  `json:"name" example:"Crown of Wisdom"`
+	Rank    float64 `json:"rank" example:"0.42"`
+	Snippet string  `json:"snippet" example:"Crown of Wisdom · Increases <mark>elemental</mark> damage by 15%"`
+}
+
+// SearchGroup is one page of matches of a single entity type, best match first
+type SearchGroup struct {
+	Type       string      `json:"type" example:"accessory"`
+	Data       []SearchHit `json:"data"`
+	Total      int64       `json:"total" example:"3"`
+	TotalPages int         `json:"total_pages" example:"1"`
+}
+
+// SearchResponse groups the matches by entity type. Page and page size apply to every group.
+type SearchResponse struct {
+	Query    string        `json:"query" example:"element"`
+	Page     int           `json:"page" example:"1"`
+	PageSize int           `json:"page_size" example:"10"`
+	Groups   []SearchGroup `json:"groups"`
+}
+
+// Suggestion is a name completion. Score is the trigram word similarity between the
+// query and the name, from 0 to 1.
+type Suggestion struct {
+	ID    int64   `json:"id" example:"1"`
+	Slug  string  `json:"slug" example:"fiore"`
+	Name  string  `json:"name" example:"Fiore"`
+	Score float64 `json:"score" example:"0.8"`
+}
+
+// SuggestResponse lists name completions, names starting with the query first
+type SuggestResponse struct {
+	Query       string       `json:"query" example:"fio"`
+	Type        string       `json:"type" example:"traveller"`
+	Suggestions []Suggestion `json:"suggestions"`
+}
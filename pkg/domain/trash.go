@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Entity types that can be in the trash, in the order they are purged. Travellers go first,
+// so an accessory is never purged while a trashed traveller still holds it.
+const (
+	TrashTypeTraveller = "traveller"
+	TrashTypeAccessory = "accessory"
+)
+
+// TrashTypes lists every entity type that can be in the trash
+var TrashTypes = []string{TrashTypeTraveller, TrashTypeAccessory}
+
+// DefaultTrashRetention is how long deleted records are kept before the retention job purges them
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// Request DTOs
+
+type ListTrashRequest struct {
+	Type string `query:"type" validate:"omitempty,oneof=traveller accessory"`
+}
+
+type PurgeTrashRequest struct {
+	Type      string `query:"type" validate:"omitempty,oneof=traveller accessory"`
+	OlderThan string `query:"older_than" validate:"omitempty,max=20"`
+
+	// Parsed values populated by the service
+	DeletedBefore time.Time `json:"-"`
+}
+
+// ParseOlderThan sets DeletedBefore to now minus OlderThan, a duration such as 720h; an empty
+// OlderThan uses the retention period
+func (r *PurgeTrashRequest) ParseOlderThan(now time.Time, retention time.Duration) error {
+	age := retention
+	if r.OlderThan != "" {
+		parsed, err := time.ParseDuration(r.OlderThan)
+		if err != nil || parsed < 0 {
+			return NewValidationError([]FieldError{
+				{Field: "older_than", Message: fmt.Sprintf("older_than must be a non-negative duration such as 720h, got '%s'", r.OlderThan)},
+			})
+		}
+		age = parsed
+	}
+	r.DeletedBefore = now.Add(-age)
+	return nil
+}
+
+// Response DTOs
+
+// TrashItem is a deleted record waiting to be restored or purged
+type TrashItem struct {
+	ID        int64     `json:"id" example:"12"`
+	Type      string    `json:"type" example:"traveller"`
+	Slug      string    `json:"slug" example:"viola"`
+	Name      string    `json:"name" example:"Viola"`
+	DeletedAt time.Time `json:"deleted_at" example:"2024-10-01T09:30:00Z"`
+	DeletedBy *string   `json:"deleted_by" example:"isla"`
+}
+
+// PurgeTrashResponse counts the records purged of each type
+type PurgeTrashResponse struct {
+	DeletedBefore time.Time        `json:"deleted_before" example:"2024-09-01T09:30:00Z"`
+	Purged        map[string]int64 `json:"purged"`
+}
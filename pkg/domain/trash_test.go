@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPurgeTrashRequest_ParseOlderThan tests turning the requested age into a cutoff
+func TestPurgeTrashRequest_ParseOlderThan(t *testing.T) {
+	now := time.Date(2024, 10, 31, 12, 0, 0, 0, time.UTC)
+
+	t.Run("defaults to the retention period", func(t *testing.T) {
+		var request PurgeTrashRequest
+		assert.NoError(t, request.ParseOlderThan(now, 30*24*time.Hour))
+		assert.Equal(t, time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC), request.DeletedBefore)
+	})
+
+	t.Run("zero purges everything deleted until now", func(t *testing.T) {
+		request := PurgeTrashRequest{OlderThan: "0s"}
+		assert.NoError(t, request.ParseOlderThan(now, DefaultTrashRetention))
+		assert.Equal(t, now, request.DeletedBefore)
+	})
+
+	for _, olderThan := range []string{"30d", "-1h"} {
+		t.Run("rejects "+olderThan, func(t *testing.T) {
+			request := PurgeTrashRequest{OlderThan: olderThan}
+			err := request.ParseOlderThan(now, DefaultTrashRetention)
+			var ve *ValidationError
+			assert.True(t, errors.As(err, &ve))
+		})
+	}
+}
@@ -2,12 +2,17 @@ package domain
 
 import (
 	"lizobly/ctc-db-api/pkg/constants"
+	"lizobly/ctc-db-api/pkg/filter"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type Traveller struct {
 	CommonModel
 	Name        string     `json:"name" gorm:"name"`
+	NameKey     string     `json:"-" gorm:"column:name_key"`
+	Slug        string     `json:"slug" gorm:"slug"`
 	Rarity      int        `json:"rarity" gorm:"rarity"`
 	Banner      string     `json:"banner" gorm:"banner"`
 	ReleaseDate time.Time  `json:"release_date" gorm:"release_date"`
@@ -17,6 +22,7 @@ type Traveller struct {
 	Job         Job        `json:"job" gorm:"foreignKey:job_id"`
 	AccessoryID *int       `json:"-" gorm:"accessory_id"`
 	Accessory   *Accessory `json:"accessory,omitempty" gorm:"foreignKey:accessory_id"`
+	Publication
 }
 
 func (Traveller) TableName() string {
@@ -30,6 +36,8 @@ type CreateTravellerRequest struct {
 	ReleaseDate string                  `json:"release_date" validate:"omitempty,datetime=02-01-2006" example:"01-10-2024"`
 	Influence   string                  `json:"influence" validate:"required,influence" example:"Wind"`
 	Job         string                  `json:"job" validate:"required,job" example:"Dancer"`
+	Status      string                  `json:"status" validate:"omitempty,oneof=draft scheduled published" example:"scheduled"`
+	PublishAt   string                  `json:"publish_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2024-10-01T09:00:00Z"`
 	Accessory   *CreateAccessoryRequest `json:"accessory" validate:"omitempty"`
 }
 
@@ -40,61 +48,165 @@ type UpdateTravellerRequest struct {
 	ReleaseDate string                  `json:"release_date" validate:"omitempty,datetime=02-01-2006" example:"01-10-2024"`
 	Influence   string                  `json:"influence" validate:"required,influence" example:"Wind"`
 	Job         string                  `json:"job" validate:"required,job" example:"Dancer"`
+	Status      string                  `json:"status" validate:"omitempty,oneof=draft scheduled published" example:"scheduled"`
+	PublishAt   string                  `json:"publish_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2024-10-01T09:00:00Z"`
 	Accessory   *UpdateAccessoryRequest `json:"accessory" validate:"omitempty"`
+
+	// Version is the expected current version taken from If-Match; zero makes the update unconditional
+	Version int64 `json:"-"`
 }
 
 // Request DTOs
 
 type ListTravellerRequest struct {
-	Name        string `query:"name"`
-	Influence   string `query:"influence" validate:"omitempty,influence" json:"-"`
-	Job         string `query:"job" validate:"omitempty,job" json:"-"`
-	InfluenceID int    `json:"-"`
-	JobID       int    `json:"-"`
+	Name           string `query:"name"`
+	Influence      string `query:"influence" validate:"omitempty,influences" json:"-"`
+	Job            string `query:"job" validate:"omitempty,jobs" json:"-"`
+	RarityMin      string `query:"rarity_min" validate:"omitempty,oneof=1 2 3 4 5"`
+	RarityMax      string `query:"rarity_max" validate:"omitempty,oneof=1 2 3 4 5"`
+	ReleasedAfter  string `query:"released_after" validate:"omitempty,datetime=02-01-2006"`
+	ReleasedBefore string `query:"released_before" validate:"omitempty,datetime=02-01-2006"`
+	HasAccessory   string `query:"has_accessory" validate:"omitempty,boolean"`
+	Banner         string `query:"banner"`
+	OrderBy        string `query:"order_by" validate:"omitempty,oneofcsv=name rarity release_date created_at updated_at influence job"`
+	OrderDir       string `query:"order_dir" validate:"omitempty,oneofcsv=asc desc"`
+	Facets         string `query:"facets" validate:"omitempty,oneofcsv=job influence rarity"`
+	Filter         string `query:"filter" validate:"omitempty,max=1000"`
+	Include        string `query:"include" validate:"omitempty,oneofcsv=accessory"`
+	AsOf           string `query:"as_of"`
+
+	// Parsed values populated by the service
+	InfluenceIDs       []int             `json:"-"`
+	JobIDs             []int             `json:"-"`
+	RarityMinValue     int               `json:"-"`
+	RarityMaxValue     int               `json:"-"`
+	ReleasedAfterDate  time.Time         `json:"-"`
+	ReleasedBeforeDate time.Time         `json:"-"`
+	HasAccessoryValue  *bool             `json:"-"`
+	Sort               []SortField       `json:"-"`
+	FacetFields        []string          `json:"-"`
+	FilterCondition    *filter.Condition `json:"-"`
+	Relations          TravellerInclude  `json:"-"`
+	AsOfTime           time.Time         `json:"-"`
+}
+
+// TravellerIncludeRequest holds the include parameter of single and batch traveller reads
+type TravellerIncludeRequest struct {
+	Include string `query:"include" validate:"omitempty,oneofcsv=accessory"`
+}
+
+// TravellerInclude selects the relations a read loads with each traveller
+type TravellerInclude struct {
+	Accessory bool
+}
+
+// TravellerIncludeAll loads every relation, for reads that render or edit whole travellers
+var TravellerIncludeAll = TravellerInclude{Accessory: true}
+
+// ParseTravellerInclude returns the relations named in a comma-separated include parameter
+func ParseTravellerInclude(include string) TravellerInclude {
+	var relations TravellerInclude
+	for _, name := range strings.Split(include, ",") {
+		if strings.TrimSpace(name) == "accessory" {
+			relations.Accessory = true
+		}
+	}
+	return relations
 }
 
 // Response DTOs
 
 type TravellerListItemResponse struct {
+	ID          int64  `json:"id"`
+	Slug        string `json:"slug"`
 	Name        string `json:"name"`
 	Rarity      int    `json:"rarity"`
 	Banner      string `json:"banner"`
 	ReleaseDate string `json:"release_date"`
 	Influence   string `json:"influence"`
 	Job         string `json:"job"`
+	Status      string `json:"status"`
+	// Accessory is only loaded when requested with include=accessory
+	Accessory *AccessoryResponse `json:"accessory,omitempty"`
+	Links     ResourceLinks      `json:"links"`
 }
 
 type TravellerResponse struct {
+	ID          int64              `json:"id" example:"1"`
+	Slug        string             `json:"slug" example:"viola"`
 	Name        string             `json:"name" example:"Viola"`
 	Rarity      int                `json:"rarity" example:"5"`
 	Banner      string             `json:"banner" example:"Standard Banner"`
 	ReleaseDate string             `json:"release_date" example:"01-10-2024"`
 	Influence   string             `json:"influence" example:"Wind"`
 	Job         string             `json:"job" example:"Dancer"`
+	Status      string             `json:"status" example:"published"`
+	PublishAt   *time.Time         `json:"publish_at,omitempty" example:"2024-10-01T09:00:00Z"`
 	Accessory   *AccessoryResponse `json:"accessory,omitempty"`
+	Audit       *AuditInfo         `json:"audit,omitempty"`
+	Links       ResourceLinks      `json:"links"`
+}
+
+// TravellerPath returns the URL path of a traveller
+func TravellerPath(id int64) string {
+	return constants.APIBasePath + "/travellers/" + strconv.FormatInt(id, 10)
 }
 
 // Mapper functions
 
 func ToTravellerListItemResponse(traveller *Traveller) TravellerListItemResponse {
 	return TravellerListItemResponse{
+		ID:          traveller.ID,
+		Slug:        traveller.Slug,
 		Name:        traveller.Name,
 		Rarity:      traveller.Rarity,
 		Banner:      traveller.Banner,
-		ReleaseDate: traveller.ReleaseDate.Format("02-01-2006"),
+		ReleaseDate: formatReleaseDate(traveller.ReleaseDate),
 		Influence:   constants.GetInfluenceName(traveller.InfluenceID),
 		Job:         constants.GetJobName(traveller.JobID),
+		Status:      traveller.Status,
+		Accessory:   ToAccessoryResponse(traveller.Accessory),
+		Links:       ResourceLinks{Self: TravellerPath(traveller.ID)},
 	}
 }
 
 func ToTravellerResponse(traveller *Traveller) TravellerResponse {
 	return TravellerResponse{
+		ID:          traveller.ID,
+		Slug:        traveller.Slug,
 		Name:        traveller.Name,
 		Rarity:      traveller.Rarity,
 		Banner:      traveller.Banner,
-		ReleaseDate: traveller.ReleaseDate.Format("02-01-2006"),
+		ReleaseDate: formatReleaseDate(traveller.ReleaseDate),
 		Influence:   constants.GetInfluenceName(traveller.InfluenceID),
 		Job:         constants.GetJobName(traveller.JobID),
+		Status:      traveller.Status,
+		PublishAt:   traveller.PublishAt,
 		Accessory:   ToAccessoryResponse(traveller.Accessory),
+		Audit:       ToAuditInfo(traveller.CommonModel),
+		Links:       ResourceLinks{Self: TravellerPath(traveller.ID)},
+	}
+}
+
+// ToUpdateTravellerRequest renders a traveller as an update request, the document PATCH is applied to
+func ToUpdateTravellerRequest(traveller *Traveller) UpdateTravellerRequest {
+	return UpdateTravellerRequest{
+		Name:        traveller.Name,
+		Rarity:      traveller.Rarity,
+		Banner:      traveller.Banner,
+		ReleaseDate: formatReleaseDate(traveller.ReleaseDate),
+		Influence:   constants.GetInfluenceName(traveller.InfluenceID),
+		Job:         constants.GetJobName(traveller.JobID),
+		Status:      traveller.Status,
+		PublishAt:   formatPublishAt(traveller.PublishAt),
+		Accessory:   ToUpdateAccessoryRequest(traveller.Accessory),
+	}
+}
+
+// formatReleaseDate formats a release date, leaving cleared (zero) dates empty
+func formatReleaseDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
 	}
+	return date.Format(constants.DateFormat)
 }
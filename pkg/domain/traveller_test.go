@@ -24,6 +24,7 @@ func TestToTravellerListItemResponse(t *testing.T) {
 				ReleaseDate: time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC),
 				InfluenceID: constants.InfluenceWealthID,
 				JobID:       constants.JobApothecaryID,
+				Accessory:   &Accessory{Name: "Herbal Charm", HP: 120},
 			},
 			expected: TravellerListItemResponse{
 				Name:        "Alfyn",
@@ -32,6 +33,7 @@ func TestToTravellerListItemResponse(t *testing.T) {
 				ReleaseDate: "15-06-2024",
 				Influence:   constants.InfluenceWealth,
 				Job:         constants.JobApothecary,
+				Accessory:   &AccessoryResponse{Name: "Herbal Charm", HP: 120},
 			},
 		},
 		{
@@ -65,6 +67,7 @@ func TestToTravellerListItemResponse(t *testing.T) {
 			assert.Equal(t, tt.expected.ReleaseDate, result.ReleaseDate)
 			assert.Equal(t, tt.expected.Influence, result.Influence)
 			assert.Equal(t, tt.expected.Job, result.Job)
+			assert.Equal(t, tt.expected.Accessory, result.Accessory)
 		})
 	}
 }
@@ -126,6 +129,41 @@ func TestToTravellerResponse(t *testing.T) {
 				assert.Nil(t, result.Accessory)
 			},
 		},
+		{
+			name: "traveller with cleared release date",
+			traveller: &Traveller{
+				Name:        "Ochette",
+				Rarity:      5,
+				InfluenceID: constants.InfluencePowerID,
+				JobID:       constants.JobHunterID,
+			},
+			validate: func(t *testing.T, result TravellerResponse) {
+				assert.Equal(t, "Ochette", result.Name)
+				assert.Empty(t, result.ReleaseDate)
+				assert.Empty(t, result.Banner)
+				assert.Nil(t, result.Audit)
+			},
+		},
+		{
+			name: "traveller with audit columns",
+			traveller: &Traveller{
+				CommonModel: CommonModel{
+					CreatedBy: "isla",
+					CreatedAt: time.Date(2024, 10, 1, 9, 30, 0, 0, time.UTC),
+					UpdatedBy: "admin",
+					UpdatedAt: time.Date(2024, 10, 2, 18, 0, 0, 0, time.UTC),
+				},
+				Name: "Viola",
+			},
+			validate: func(t *testing.T, result TravellerResponse) {
+				assert.Equal(t, &AuditInfo{
+					CreatedBy: "isla",
+					CreatedAt: time.Date(2024, 10, 1, 9, 30, 0, 0, time.UTC),
+					UpdatedBy: "admin",
+					UpdatedAt: time.Date(2024, 10, 2, 18, 0, 0, 0, time.UTC),
+				}, result.Audit)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -136,8 +174,52 @@ func TestToTravellerResponse(t *testing.T) {
 	}
 }
 
+// TestToUpdateTravellerRequest tests rendering a traveller as a PATCH target document
+func TestToUpdateTravellerRequest(t *testing.T) {
+	t.Run("traveller with accessory", func(t *testing.T) {
+		result := ToUpdateTravellerRequest(&Traveller{
+			Name:        "Temenos",
+			Rarity:      5,
+			Banner:      "Cleric Banner",
+			ReleaseDate: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+			InfluenceID: constants.InfluenceFameID,
+			JobID:       constants.JobClericID,
+			Accessory:   &Accessory{Name: "Holy Staff", HP: 100, Effect: "Increases healing"},
+		})
+
+		assert.Equal(t, UpdateTravellerRequest{
+			Name:        "Temenos",
+			Rarity:      5,
+			Banner:      "Cleric Banner",
+			ReleaseDate: "20-03-2024",
+			Influence:   constants.InfluenceFame,
+			Job:         constants.JobCleric,
+			Accessory:   &UpdateAccessoryRequest{Name: "Holy Staff", HP: 100, Effect: "Increases healing"},
+		}, result)
+	})
+
+	t.Run("cleared fields stay empty", func(t *testing.T) {
+		result := ToUpdateTravellerRequest(&Traveller{
+			Name:        "Hikari",
+			Rarity:      4,
+			InfluenceID: constants.InfluenceDominanceID,
+			JobID:       constants.JobWarriorID,
+		})
+
+		assert.Empty(t, result.Banner)
+		assert.Empty(t, result.ReleaseDate)
+		assert.Nil(t, result.Accessory)
+	})
+}
+
 // TestTraveller_TableName tests table name method
 func TestTraveller_TableName(t *testing.T) {
 	traveller := Traveller{}
 	assert.Equal(t, "m_traveller", traveller.TableName())
 }
+
+// TestTravellerPath tests the self link of travellers
+func TestTravellerPath(t *testing.T) {
+	assert.Equal(t, "/api/v1/travellers/42", TravellerPath(42))
+	assert.Equal(t, "/api/v1/travellers/42", ToTravellerListItemResponse(&Traveller{CommonModel: CommonModel{ID: 42}}).Links.Self)
+}
@@ -5,6 +5,7 @@ type User struct {
 	Username string `json:"username" gorm:"username"`
 	Password string `json:"password" gorm:"password"`
 	Token    string `json:"token" gorm:"token"`
+	Editor   bool   `json:"editor" gorm:"column:is_editor"`
 }
 
 func (User) TableName() string {
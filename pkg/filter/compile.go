@@ -0,0 +1,261 @@
+package filter
+
+import (
+	"lizobly/ctc-db-api/pkg/constants"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind decides how a field's values are parsed and which operators it accepts
+type Kind int
+
+const (
+	// String supports =, !=, ~, !~ and in
+	String Kind = iota
+	// Number supports =, !=, <, <=, >, >= and in on integers
+	Number
+	// Date supports the Number operators on DD-MM-YYYY dates
+	Date
+	// Bool supports = and != with true or false
+	Bool
+	// Enum supports =, != and in on names mapped to stored values by Field.Lookup
+	Enum
+)
+
+var kindOperators = map[Kind][]Operator{
+	String: {OpEqual, OpNotEqual, OpContains, OpNotContains, OpIn},
+	Number: {OpEqual, OpNotEqual, OpLess, OpLessEqual, OpGreater, OpGreaterEqual, OpIn},
+	Date:   {OpEqual, OpNotEqual, OpLess, OpLessEqual, OpGreater, OpGreaterEqual, OpIn},
+	Bool:   {OpEqual, OpNotEqual},
+	Enum:   {OpEqual, OpNotEqual, OpIn},
+}
+
+// Field is a filterable field. Column is trusted SQL and may be an expression.
+type Field struct {
+	Column string
+	Kind   Kind
+	// Lookup maps an Enum name to its stored value; ok is false for unknown names
+	Lookup func(name string) (value interface{}, ok bool)
+}
+
+// Schema whitelists the fields an entity can be filtered by, keyed by the name used in expressions
+type Schema map[string]Field
+
+// Condition is a compiled expression, ready for gorm's Where(SQL, Args...)
+type Condition struct {
+	SQL  string
+	Args []interface{}
+
+	// terms are the expression's top-level "and" terms, compiled one by one for Without
+	terms []term
+}
+
+// term is a top-level "and" term of a condition and the fields it tests
+type term struct {
+	sql    string
+	args   []interface{}
+	fields []string
+}
+
+// Compile parses an expression and compiles it against the schema
+func (s Schema) Compile(input string) (*Condition, error) {
+	node, err := Parse(input)
+	if err != nil {
+		return nil, err
+	}
+
+	cond := &Condition{}
+	cond.SQL, err = s.compile(node, &cond.Args)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range conjuncts(node) {
+		t := term{fields: testedFields(n, nil)}
+		// Each term compiled as part of the whole expression already
+		t.sql, _ = s.compile(n, &t.args)
+		cond.terms = append(cond.terms, t)
+	}
+	return cond, nil
+}
+
+// Without returns the condition less its top-level "and" terms that test only field, so a
+// facet on field can count every value the rest of the expression allows. ok is false when a
+// term tests field together with other fields, as dropping it would drop them too. The
+// condition is nil when no term is left; a nil condition stays nil.
+func (c *Condition) Without(field string) (cond *Condition, ok bool) {
+	if c == nil {
+		return nil, true
+	}
+
+	var kept []term
+	for _, t := range c.terms {
+		if !slices.Contains(t.fields, field) {
+			kept = append(kept, t)
+			continue
+		}
+		if len(t.fields) > 1 {
+			return nil, false
+		}
+	}
+	switch len(kept) {
+	case len(c.terms):
+		return c, true
+	case 0:
+		return nil, true
+	}
+
+	sqls := make([]string, len(kept))
+	cond = &Condition{terms: kept}
+	for i, t := range kept {
+		sqls[i] = t.sql
+		cond.Args = append(cond.Args, t.args...)
+	}
+	cond.SQL = sqls[0]
+	if len(sqls) > 1 {
+		cond.SQL = "(" + strings.Join(sqls, " AND ") + ")"
+	}
+	return cond, true
+}
+
+// conjuncts splits an expression into its top-level "and" terms
+func conjuncts(node Node) []Node {
+	if n, ok := node.(Logical); ok && n.Op == "and" {
+		return append(conjuncts(n.Left), conjuncts(n.Right)...)
+	}
+	return []Node{node}
+}
+
+// testedFields adds the fields an expression tests to fields, each once
+func testedFields(node Node, fields []string) []string {
+	switch n := node.(type) {
+	case Logical:
+		return testedFields(n.Right, testedFields(n.Left, fields))
+	case Not:
+		return testedFields(n.Expr, fields)
+	case Comparison:
+		if !slices.Contains(fields, n.Field) {
+			fields = append(fields, n.Field)
+		}
+	}
+	return fields
+}
+
+func (s Schema) compile(node Node, args *[]interface{}) (string, error) {
+	switch n := node.(type) {
+	case Logical:
+		left, err := s.compile(n.Left, args)
+		if err != nil {
+			return "", err
+		}
+		right, err := s.compile(n.Right, args)
+		if err != nil {
+			return "", err
+		}
+		return "(" + left + " " + strings.ToUpper(n.Op) + " " + right + ")", nil
+	case Not:
+		expr, err := s.compile(n.Expr, args)
+		if err != nil {
+			return "", err
+		}
+		return "NOT (" + expr + ")", nil
+	case Comparison:
+		return s.compileComparison(n, args)
+	}
+	return "", errorf(0, "unsupported expression")
+}
+
+func (s Schema) compileComparison(c Comparison, args *[]interface{}) (string, error) {
+	field, ok := s[c.Field]
+	if !ok {
+		return "", errorf(c.Pos, "unknown field %q", c.Field)
+	}
+	if !operatorAllowed(field.Kind, c.Op) {
+		return "", errorf(c.Pos, "operator %q is not supported for %q", c.Op, c.Field)
+	}
+
+	values := make([]interface{}, len(c.Values))
+	for i, raw := range c.Values {
+		value, err := field.parseValue(raw)
+		if err != nil {
+			return "", errorf(c.Pos, "invalid value %q for %q: %s", raw, c.Field, err.Message)
+		}
+		values[i] = value
+	}
+
+	switch c.Op {
+	case OpIn:
+		*args = append(*args, values)
+		return field.Column + " IN ?", nil
+	case OpContains, OpNotContains:
+		*args = append(*args, ContainsPattern(c.Values[0]))
+		if c.Op == OpNotContains {
+			return "LOWER(" + field.Column + ") NOT LIKE LOWER(?)", nil
+		}
+		return "LOWER(" + field.Column + ") LIKE LOWER(?)", nil
+	}
+
+	*args = append(*args, values[0])
+	return field.Column + " " + string(c.Op) + " ?", nil
+}
+
+func operatorAllowed(kind Kind, op Operator) bool {
+	for _, allowed := range kindOperators[kind] {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (f Field) parseValue(raw string) (interface{}, *Error) {
+	switch f.Kind {
+	case Number:
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, &Error{Message: "expected an integer"}
+		}
+		return value, nil
+	case Date:
+		value, err := time.Parse(constants.DateFormat, raw)
+		if err != nil {
+			return nil, &Error{Message: "expected a DD-MM-YYYY date"}
+		}
+		return value, nil
+	case Bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, &Error{Message: "expected true or false"}
+		}
+		return value, nil
+	case Enum:
+		value, ok := f.Lookup(raw)
+		if !ok {
+			return nil, &Error{Message: "unknown value"}
+		}
+		return value, nil
+	}
+	return raw, nil
+}
+
+// IDLookup adapts a name to id function that returns 0 for unknown names into a Field.Lookup
+func IDLookup(get func(name string) int) func(string) (interface{}, bool) {
+	return func(name string) (interface{}, bool) {
+		id := get(name)
+		return id, id != 0
+	}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike escapes LIKE wildcards so user input only matches literally.
+// It relies on backslash, PostgreSQL's default LIKE escape character.
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// ContainsPattern returns a LIKE pattern matching values that contain s literally
+func ContainsPattern(s string) string {
+	return "%" + EscapeLike(s) + "%"
+}
@@ -0,0 +1,139 @@
+package filter
+
+import (
+	"lizobly/ctc-db-api/pkg/constants"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSchema = Schema{
+	"name":   {Column: "t.name", Kind: String},
+	"rarity": {Column: "t.rarity", Kind: Number},
+	"date":   {Column: "t.release_date", Kind: Date},
+	"owned":  {Column: "(t.accessory_id IS NOT NULL)", Kind: Bool},
+	"job": {Column: "t.job_id", Kind: Enum, Lookup: func(name string) (interface{}, bool) {
+		id := constants.GetJobID(name)
+		return id, id != 0
+	}},
+}
+
+func TestCompile(t *testing.T) {
+	t.Run("compiles to parameterised sql", func(t *testing.T) {
+		cond, err := testSchema.Compile(`rarity>=4 and job in (Warrior,Dancer) and name~"vi"`)
+		require.NoError(t, err)
+		assert.Equal(t, "((t.rarity >= ? AND t.job_id IN ?) AND LOWER(t.name) LIKE LOWER(?))", cond.SQL)
+		assert.Equal(t, []interface{}{
+			4,
+			[]interface{}{constants.JobWarriorID, constants.JobDancerID},
+			"%vi%",
+		}, cond.Args)
+	})
+
+	t.Run("not, dates and bools", func(t *testing.T) {
+		cond, err := testSchema.Compile(`not (date<01-01-2024 or owned=false)`)
+		require.NoError(t, err)
+		assert.Equal(t, "NOT ((t.release_date < ? OR (t.accessory_id IS NOT NULL) = ?))", cond.SQL)
+		assert.Equal(t, []interface{}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), false}, cond.Args)
+	})
+
+	t.Run("escapes like wildcards", func(t *testing.T) {
+		cond, err := testSchema.Compile(`name!~"50%_off\\"`)
+		require.NoError(t, err)
+		assert.Equal(t, "LOWER(t.name) NOT LIKE LOWER(?)", cond.SQL)
+		assert.Equal(t, []interface{}{`%50\%\_off\\%`}, cond.Args)
+	})
+
+	t.Run("values never reach the sql", func(t *testing.T) {
+		cond, err := testSchema.Compile(`name="x' OR 1=1 --"`)
+		require.NoError(t, err)
+		assert.Equal(t, "t.name = ?", cond.SQL)
+	})
+
+	errorCases := []struct {
+		name    string
+		input   string
+		message string
+	}{
+		{"unknown field", "id=1", `unknown field "id"`},
+		{"operator not allowed", "rarity~4", `operator "~" is not supported for "rarity"`},
+		{"contains on enum", "job~War", `operator "~" is not supported for "job"`},
+		{"bad number", "rarity=high", `invalid value "high" for "rarity"`},
+		{"bad date", "date>2024-01-01", `invalid value "2024-01-01" for "date"`},
+		{"unknown enum", "job in (Warrior,Pirate)", `invalid value "Pirate" for "job"`},
+		{"parse error", "rarity>", "expected a value"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := testSchema.Compile(tc.input)
+			var ferr *Error
+			require.ErrorAs(t, err, &ferr)
+			assert.Contains(t, ferr.Message, tc.message)
+		})
+	}
+}
+
+func TestConditionWithout(t *testing.T) {
+	t.Run("drops the and terms that test only the field", func(t *testing.T) {
+		cond, err := testSchema.Compile(`job=Warrior and rarity>=4 and (job=Dancer or not job=Cleric) and name~"vi"`)
+		require.NoError(t, err)
+
+		without, ok := cond.Without("job")
+		require.True(t, ok)
+		assert.Equal(t, "(t.rarity >= ? AND LOWER(t.name) LIKE LOWER(?))", without.SQL)
+		assert.Equal(t, []interface{}{4, "%vi%"}, without.Args)
+	})
+
+	t.Run("a single term left is not wrapped", func(t *testing.T) {
+		cond, err := testSchema.Compile(`rarity>=4 and job=Warrior`)
+		require.NoError(t, err)
+
+		without, ok := cond.Without("job")
+		require.True(t, ok)
+		assert.Equal(t, "t.rarity >= ?", without.SQL)
+		assert.Equal(t, []interface{}{4}, without.Args)
+	})
+
+	t.Run("untouched when no term tests the field", func(t *testing.T) {
+		cond, err := testSchema.Compile(`rarity>=4 and name~"vi"`)
+		require.NoError(t, err)
+
+		without, ok := cond.Without("job")
+		require.True(t, ok)
+		assert.Same(t, cond, without)
+	})
+
+	t.Run("nothing left", func(t *testing.T) {
+		cond, err := testSchema.Compile(`job=Warrior`)
+		require.NoError(t, err)
+
+		without, ok := cond.Without("job")
+		assert.True(t, ok)
+		assert.Nil(t, without)
+
+		without, ok = (*Condition)(nil).Without("job")
+		assert.True(t, ok)
+		assert.Nil(t, without)
+	})
+
+	t.Run("field mixed with other fields in a term", func(t *testing.T) {
+		cond, err := testSchema.Compile(`rarity>=4 and (job=Warrior or owned=true)`)
+		require.NoError(t, err)
+
+		_, ok := cond.Without("job")
+		assert.False(t, ok)
+
+		without, ok := cond.Without("rarity")
+		require.True(t, ok)
+		assert.Equal(t, "(t.job_id = ? OR (t.accessory_id IS NOT NULL) = ?)", without.SQL)
+	})
+}
+
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, `100\%`, EscapeLike("100%"))
+	assert.Equal(t, `a\_b`, EscapeLike("a_b"))
+	assert.Equal(t, `c:\\x`, EscapeLike(`c:\x`))
+	assert.Equal(t, "%vi%", ContainsPattern("vi"))
+}
@@ -0,0 +1,320 @@
+// Package filter parses list filter expressions such as
+//
+//	rarity>=4 and job in (Warrior,Dancer) and name~"vi"
+//
+// and compiles them to parameterised SQL against a per-entity whitelist of fields.
+//
+// Grammar (keywords are case insensitive):
+//
+//	expr       = term { "or" term }
+//	term       = factor { "and" factor }
+//	factor     = "not" factor | "(" expr ")" | comparison
+//	comparison = field op value | field "in" "(" value { "," value } ")"
+//	op         = "=" | "!=" | "<" | "<=" | ">" | ">=" | "~" | "!~"
+//	value      = word | "quoted string"
+//
+// "~" is a case-insensitive contains match. Quoted strings support \" and \\ escapes.
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxLength caps the size of an expression so a single request can't build a huge query
+const MaxLength = 1000
+
+// Operator is a comparison operator
+type Operator string
+
+const (
+	OpEqual        Operator = "="
+	OpNotEqual     Operator = "!="
+	OpLess         Operator = "<"
+	OpLessEqual    Operator = "<="
+	OpGreater      Operator = ">"
+	OpGreaterEqual Operator = ">="
+	OpContains     Operator = "~"
+	OpNotContains  Operator = "!~"
+	OpIn           Operator = "in"
+)
+
+// Error is a parse or compile error at a byte offset of the expression
+type Error struct {
+	Pos     int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Pos)
+}
+
+func errorf(pos int, format string, args ...interface{}) *Error {
+	return &Error{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// Node is a parsed expression
+type Node interface {
+	node()
+}
+
+// Logical joins two expressions with "and" or "or"
+type Logical struct {
+	Op          string
+	Left, Right Node
+}
+
+// Not negates an expression
+type Not struct {
+	Expr Node
+}
+
+// Comparison tests a field against one value, or a list of values for "in"
+type Comparison struct {
+	Field  string
+	Op     Operator
+	Values []string
+	Pos    int
+}
+
+func (Logical) node()    {}
+func (Not) node()        {}
+func (Comparison) node() {}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// Parse parses an expression into a syntax tree
+func Parse(input string) (Node, error) {
+	if len(input) > MaxLength {
+		return nil, errorf(MaxLength, "expression is longer than %d characters", MaxLength)
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, errorf(0, "expression is empty")
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, errorf(tok.pos, "unexpected %q", tok.text)
+	}
+	return node, nil
+}
+
+const operatorChars = "=!<>~"
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(input); {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: i})
+			i++
+		case c == '"':
+			start := i
+			var sb strings.Builder
+			i++
+			for {
+				if i >= len(input) {
+					return nil, errorf(start, "unterminated string")
+				}
+				if input[i] == '\\' && i+1 < len(input) && (input[i+1] == '"' || input[i+1] == '\\') {
+					sb.WriteByte(input[i+1])
+					i += 2
+					continue
+				}
+				if input[i] == '"' {
+					i++
+					break
+				}
+				sb.WriteByte(input[i])
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenString, text: sb.String(), pos: start})
+		case strings.IndexByte(operatorChars, c) >= 0:
+			start := i
+			for i < len(input) && strings.IndexByte(operatorChars, input[i]) >= 0 {
+				i++
+			}
+			op := input[start:i]
+			switch Operator(op) {
+			case OpEqual, OpNotEqual, OpLess, OpLessEqual, OpGreater, OpGreaterEqual, OpContains, OpNotContains:
+			default:
+				return nil, errorf(start, "unknown operator %q", op)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: start})
+		default:
+			start := i
+			for i < len(input) && !strings.ContainsRune(" \t\n\r(),\""+operatorChars, rune(input[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: input[start:i], pos: start})
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(input)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// keyword reports whether the next token is the given bare keyword
+func (p *parser) keyword(word string) bool {
+	tok := p.peek()
+	return tok.kind == tokenWord && strings.EqualFold(tok.text, word)
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = Logical{Op: "or", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		p.next()
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = Logical{Op: "and", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseFactor() (Node, error) {
+	if p.keyword("not") {
+		p.next()
+		expr, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expr: expr}, nil
+	}
+
+	if p.peek().kind == tokenLParen {
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.kind != tokenRParen {
+			return nil, errorf(tok.pos, "expected ')'")
+		}
+		return expr, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Node, error) {
+	field := p.next()
+	if field.kind != tokenWord {
+		return nil, errorf(field.pos, "expected a field name")
+	}
+	comparison := Comparison{Field: field.text, Pos: field.pos}
+
+	if p.keyword("in") {
+		p.next()
+		comparison.Op = OpIn
+		if tok := p.next(); tok.kind != tokenLParen {
+			return nil, errorf(tok.pos, "expected '(' after in")
+		}
+		for {
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			comparison.Values = append(comparison.Values, value)
+			tok := p.next()
+			if tok.kind == tokenRParen {
+				break
+			}
+			if tok.kind != tokenComma {
+				return nil, errorf(tok.pos, "expected ',' or ')'")
+			}
+		}
+		return comparison, nil
+	}
+
+	op := p.next()
+	if op.kind != tokenOperator {
+		return nil, errorf(op.pos, "expected an operator after %q", field.text)
+	}
+	comparison.Op = Operator(op.text)
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	comparison.Values = []string{value}
+	return comparison, nil
+}
+
+func (p *parser) parseValue() (string, error) {
+	tok := p.next()
+	if tok.kind != tokenWord && tok.kind != tokenString {
+		return "", errorf(tok.pos, "expected a value")
+	}
+	return tok.text, nil
+}
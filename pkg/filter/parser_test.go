@@ -0,0 +1,73 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("precedence and binds tighter than or", func(t *testing.T) {
+		node, err := Parse(`rarity>=4 or job=Warrior and name~"vi"`)
+		require.NoError(t, err)
+
+		or, ok := node.(Logical)
+		require.True(t, ok)
+		assert.Equal(t, "or", or.Op)
+		assert.Equal(t, Comparison{Field: "rarity", Op: OpGreaterEqual, Values: []string{"4"}, Pos: 0}, or.Left)
+
+		and, ok := or.Right.(Logical)
+		require.True(t, ok)
+		assert.Equal(t, "and", and.Op)
+		assert.Equal(t, Comparison{Field: "name", Op: OpContains, Values: []string{"vi"}, Pos: 29}, and.Right)
+	})
+
+	t.Run("in list, not and parentheses", func(t *testing.T) {
+		node, err := Parse(`NOT (job IN (Warrior, "Dancer") Or rarity<3)`)
+		require.NoError(t, err)
+
+		not, ok := node.(Not)
+		require.True(t, ok)
+		or, ok := not.Expr.(Logical)
+		require.True(t, ok)
+		assert.Equal(t, Comparison{Field: "job", Op: OpIn, Values: []string{"Warrior", "Dancer"}, Pos: 5}, or.Left)
+	})
+
+	t.Run("quoted string escapes", func(t *testing.T) {
+		node, err := Parse(`name="a \"b\" \\ c, (d) and e"`)
+		require.NoError(t, err)
+		assert.Equal(t, []string{`a "b" \ c, (d) and e`}, node.(Comparison).Values)
+	})
+
+	errorCases := []struct {
+		name  string
+		input string
+		pos   int
+	}{
+		{"empty", "  ", 0},
+		{"unknown operator", "rarity=>4", 6},
+		{"missing operator", "rarity 4", 7},
+		{"missing value", "rarity>=", 8},
+		{"unterminated string", `name="vi`, 5},
+		{"unclosed parenthesis", "(rarity>4", 9},
+		{"trailing tokens", "rarity>4 job=Warrior", 9},
+		{"in without list", "job in Warrior", 7},
+		{"in list without close", "job in (Warrior Dancer)", 16},
+		{"dangling and", "rarity>4 and", 12},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.input)
+			var ferr *Error
+			require.ErrorAs(t, err, &ferr)
+			assert.Equal(t, tc.pos, ferr.Pos)
+		})
+	}
+
+	t.Run("rejects long expressions", func(t *testing.T) {
+		input := "name=" + string(make([]byte, MaxLength))
+		_, err := Parse(input)
+		assert.ErrorContains(t, err, "longer than")
+	})
+}
@@ -0,0 +1,16 @@
+package helpers
+
+// BatchResponse is the result of a multi-get: the records found, in request order,
+// and the requested ids that don't exist
+type BatchResponse[T any] struct {
+	Data     []T   `json:"data"`
+	NotFound []int `json:"not_found"`
+}
+
+// Select renders each record with only the selected fields
+func (b BatchResponse[T]) Select(fields Fieldset) BatchResponse[Sparse] {
+	return BatchResponse[Sparse]{
+		Data:     selectEach(fields, b.Data),
+		NotFound: b.NotFound,
+	}
+}
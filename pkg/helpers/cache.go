@@ -3,30 +3,76 @@ package helpers
 import (
 	"fmt"
 	"lizobly/ctc-db-api/pkg/constants"
+	"lizobly/ctc-db-api/pkg/logging"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
 
 // SetCacheHeaders sets Cache-Control, ETag, and Last-Modified headers for a resource.
+// Editors can read drafts, so what they are sent is kept out of shared caches.
 // Returns true if the client's cached version is still valid (304 Not Modified should be returned).
 func SetCacheHeaders(ctx echo.Context, etag string, lastModified string, maxAge int) bool {
-	// Set cache headers
-	ctx.Response().Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
-	ctx.Response().Header().Set("ETag", etag)
-	ctx.Response().Header().Set("Last-Modified", lastModified)
+	setCacheHeaders(ctx, etag, lastModified, maxAge)
 
 	// Check if client has valid cached version
-	if ctx.Request().Header.Get("If-None-Match") == etag {
-		return true
+	return notModified(ctx.Request(), etag, lastModified)
+}
+
+// SetListCacheHeaders sets Cache-Control, ETag, and Last-Modified headers for a page of a list endpoint.
+// Returns true if the client's cached page is still valid (304 Not Modified should be returned).
+// If-Modified-Since is ignored: a list's Last-Modified is its newest matching item, which doesn't
+// move when an item is deleted or unpublished, so only the ETag can tell the page is unchanged.
+func SetListCacheHeaders(ctx echo.Context, etag string, lastModified string) bool {
+	setCacheHeaders(ctx, etag, lastModified, constants.CacheMaxAgeList)
+
+	return notModified(ctx.Request(), etag, "")
+}
+
+func setCacheHeaders(ctx echo.Context, etag string, lastModified string, maxAge int) {
+	visibility := "public"
+	if logging.IsEditor(ctx.Request().Context()) {
+		visibility = "private"
+	}
+	ctx.Response().Header().Set("Cache-Control", fmt.Sprintf("%s, max-age=%d", visibility, maxAge))
+	ctx.Response().Header().Set("ETag", etag)
+	if lastModified != "" {
+		ctx.Response().Header().Set("Last-Modified", lastModified)
 	}
+}
 
-	return false
+// notModified evaluates If-None-Match, or If-Modified-Since when no If-None-Match was sent (RFC 7232, section 6).
+func notModified(req *http.Request, etag string, lastModified string) bool {
+	if ifNoneMatch := req.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return etagListMatches(ifNoneMatch, etag)
+	}
+
+	ifModifiedSince := req.Header.Get("If-Modified-Since")
+	if ifModifiedSince == "" || lastModified == "" {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
 }
 
-// SetListCacheHeaders sets Cache-Control header for list endpoints.
-func SetListCacheHeaders(ctx echo.Context) {
-	ctx.Response().Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", constants.CacheMaxAgeList))
+// etagListMatches reports whether an If-None-Match value lists etag, using weak comparison.
+func etagListMatches(header string, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
 }
 
 // CheckETagMatch checks if the client's If-Match header matches the current ETag.
@@ -50,6 +96,6 @@ func RespondNotModified(ctx echo.Context) error {
 // RespondPreconditionFailed sends a 412 Precondition Failed response with a message.
 func RespondPreconditionFailed(ctx echo.Context) error {
 	return ctx.JSON(http.StatusPreconditionFailed, map[string]string{
-		"error": "Resource has been modified by another request. Please refresh and try again.",
+		"error": constants.MessagePreconditionFailed,
 	})
 }
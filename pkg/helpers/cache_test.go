@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"lizobly/ctc-db-api/pkg/logging"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -16,6 +17,8 @@ func TestSetCacheHeaders(t *testing.T) {
 		lastModified     string
 		maxAge           int
 		ifNoneMatchValue string
+		ifModifiedSince  string
+		editor           bool
 		expectedResult   bool
 		expectedCC       string
 	}{
@@ -46,6 +49,68 @@ func TestSetCacheHeaders(t *testing.T) {
 			expectedResult:   false,
 			expectedCC:       "public, max-age=3600",
 		},
+		{
+			name:             "matches weak tag in an If-None-Match list",
+			etag:             `"abc123"`,
+			lastModified:     "Mon, 27 Jan 2026 10:00:00 GMT",
+			maxAge:           3600,
+			ifNoneMatchValue: `"old123", W/"abc123"`,
+			expectedResult:   true,
+			expectedCC:       "public, max-age=3600",
+		},
+		{
+			name:            "returns true when not modified since",
+			etag:            `"abc123"`,
+			lastModified:    "Mon, 27 Jan 2026 10:00:00 GMT",
+			maxAge:          3600,
+			ifModifiedSince: "Mon, 27 Jan 2026 10:00:00 GMT",
+			expectedResult:  true,
+			expectedCC:      "public, max-age=3600",
+		},
+		{
+			name:            "returns false when modified since",
+			etag:            `"abc123"`,
+			lastModified:    "Mon, 27 Jan 2026 10:00:00 GMT",
+			maxAge:          3600,
+			ifModifiedSince: "Mon, 27 Jan 2026 09:59:59 GMT",
+			expectedResult:  false,
+			expectedCC:      "public, max-age=3600",
+		},
+		{
+			name:             "If-None-Match takes precedence over If-Modified-Since",
+			etag:             `"new456"`,
+			lastModified:     "Mon, 27 Jan 2026 10:00:00 GMT",
+			maxAge:           3600,
+			ifNoneMatchValue: `"old123"`,
+			ifModifiedSince:  "Mon, 27 Jan 2026 10:00:00 GMT",
+			expectedResult:   false,
+			expectedCC:       "public, max-age=3600",
+		},
+		{
+			name:            "ignores invalid If-Modified-Since",
+			etag:            `"abc123"`,
+			lastModified:    "Mon, 27 Jan 2026 10:00:00 GMT",
+			maxAge:          3600,
+			ifModifiedSince: "yesterday",
+			expectedResult:  false,
+			expectedCC:      "public, max-age=3600",
+		},
+		{
+			name:            "omits Last-Modified when unknown",
+			etag:            `"abc123"`,
+			maxAge:          3600,
+			ifModifiedSince: "Mon, 27 Jan 2026 10:00:00 GMT",
+			expectedResult:  false,
+			expectedCC:      "public, max-age=3600",
+		},
+		{
+			name:           "keeps editors' responses private",
+			etag:           `"abc123"`,
+			maxAge:         3600,
+			editor:         true,
+			expectedResult: false,
+			expectedCC:     "private, max-age=3600",
+		},
 	}
 
 	for _, tt := range tests {
@@ -55,6 +120,12 @@ func TestSetCacheHeaders(t *testing.T) {
 			if tt.ifNoneMatchValue != "" {
 				req.Header.Set("If-None-Match", tt.ifNoneMatchValue)
 			}
+			if tt.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+			if tt.editor {
+				req = req.WithContext(logging.WithEditor(req.Context()))
+			}
 			rec := httptest.NewRecorder()
 			ctx := e.NewContext(req, rec)
 
@@ -75,9 +146,33 @@ func TestSetListCacheHeaders(t *testing.T) {
 		rec := httptest.NewRecorder()
 		ctx := e.NewContext(req, rec)
 
-		SetListCacheHeaders(ctx)
+		result := SetListCacheHeaders(ctx, `"abc123"`, "Mon, 27 Jan 2026 10:00:00 GMT")
 
+		assert.False(t, result)
 		assert.Contains(t, rec.Header().Get("Cache-Control"), "public, max-age=")
+		assert.Equal(t, `"abc123"`, rec.Header().Get("ETag"))
+		assert.Equal(t, "Mon, 27 Jan 2026 10:00:00 GMT", rec.Header().Get("Last-Modified"))
+	})
+
+	t.Run("returns true when client has valid cached page", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("If-None-Match", `"abc123"`)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		assert.True(t, SetListCacheHeaders(ctx, `"abc123"`, ""))
+	})
+
+	t.Run("ignores If-Modified-Since, which misses deletes", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("If-Modified-Since", "Tue, 27 Jan 2026 10:00:00 GMT")
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		assert.False(t, SetListCacheHeaders(ctx, `"abc123"`, "Mon, 26 Jan 2026 10:00:00 GMT"))
+		assert.Equal(t, "Mon, 26 Jan 2026 10:00:00 GMT", rec.Header().Get("Last-Modified"))
 	})
 }
 
@@ -150,6 +245,6 @@ func TestRespondPreconditionFailed(t *testing.T) {
 
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
-		assert.Contains(t, rec.Body.String(), "Resource has been modified")
+		assert.JSONEq(t, `{"error":"Resource has been modified by another request. Please refresh and try again."}`, rec.Body.String())
 	})
 }
@@ -0,0 +1,267 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidCursor is returned for cursors that are malformed, tampered with, or issued for another ordering
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CursorParams holds keyset pagination request parameters
+type CursorParams struct {
+	Cursor       string `query:"cursor"`
+	Limit        int    `query:"limit"`
+	IncludeTotal bool   `query:"include_total"`
+}
+
+// Normalize sets defaults and caps the limit like PaginationParams does for page sizes
+func (p *CursorParams) Normalize() {
+	if p.Limit < 1 {
+		p.Limit = DefaultPageSize
+	}
+	if p.Limit > MaxPageSize {
+		p.Limit = MaxPageSize
+	}
+}
+
+// Cursor marks a position in an ordered list: the sort key values of a boundary row followed by its ID
+type Cursor struct {
+	Keys []json.RawMessage `json:"k"`
+	// Backward selects the rows before the boundary instead of after it
+	Backward bool `json:"b,omitempty"`
+	// Order identifies the ordering the cursor was issued for
+	Order string `json:"o"`
+}
+
+// NewCursor builds a cursor from the boundary row's key values
+func NewCursor(order string, backward bool, keys ...interface{}) (Cursor, error) {
+	cursor := Cursor{Keys: make([]json.RawMessage, len(keys)), Backward: backward, Order: order}
+	for i, key := range keys {
+		raw, err := json.Marshal(key)
+		if err != nil {
+			return Cursor{}, err
+		}
+		cursor.Keys[i] = raw
+	}
+	return cursor, nil
+}
+
+// Values decodes the keys into values of the same types as samples, so they bind as typed SQL parameters
+func (c Cursor) Values(samples ...interface{}) ([]interface{}, error) {
+	if len(c.Keys) != len(samples) {
+		return nil, ErrInvalidCursor
+	}
+	values := make([]interface{}, len(samples))
+	for i, sample := range samples {
+		value := reflect.New(reflect.TypeOf(sample))
+		if err := json.Unmarshal(c.Keys[i], value.Interface()); err != nil {
+			return nil, ErrInvalidCursor
+		}
+		values[i] = value.Elem().Interface()
+	}
+	return values, nil
+}
+
+// CursorCodec turns cursors into opaque tokens signed with HMAC-SHA256, so clients can't forge positions
+type CursorCodec struct {
+	secret []byte
+}
+
+func NewCursorCodec(secret string) *CursorCodec {
+	return &CursorCodec{secret: []byte(secret)}
+}
+
+// Encode returns the token for a cursor
+func (c *CursorCodec) Encode(cursor Cursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(c.sign(payload)), nil
+}
+
+// Decode verifies a token and returns its cursor. The cursor must have been issued for order.
+func (c *CursorCodec) Decode(token, order string) (Cursor, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, c.sign(payload)) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err = json.Unmarshal(payload, &cursor); err != nil || cursor.Order != order {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// PageCursors returns the tokens for the pages next to one read with current, which is nil for
+// the first page. count rows were read, hasMore reports whether more follow in the direction
+// read, and keys returns the cursor keys of the row at an index.
+func (c *CursorCodec) PageCursors(current *Cursor, order string, count int, hasMore bool, keys func(i int) []interface{}) (next, prev string, err error) {
+	backward := current != nil && current.Backward
+
+	// Nothing to anchor on, so turn around at the requested position
+	if count == 0 {
+		if current == nil {
+			return
+		}
+		turned := *current
+		turned.Backward = !backward
+		token, err := c.Encode(turned)
+		if backward {
+			return token, "", err
+		}
+		return "", token, err
+	}
+
+	// Reading backward always came from a later page, and reading forward from a cursor from an earlier one
+	if hasMore || backward {
+		next, err = c.encodeKeys(order, false, keys(count-1))
+		if err != nil {
+			return
+		}
+	}
+	if (hasMore && backward) || (current != nil && !backward) {
+		prev, err = c.encodeKeys(order, true, keys(0))
+	}
+	return
+}
+
+func (c *CursorCodec) encodeKeys(order string, backward bool, keys []interface{}) (string, error) {
+	cursor, err := NewCursor(order, backward, keys...)
+	if err != nil {
+		return "", err
+	}
+	return c.Encode(cursor)
+}
+
+func (c *CursorCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// KeysetColumn is one column of a keyset ordering; the last one must be unique
+type KeysetColumn struct {
+	Column string
+	Desc   bool
+	// Nullable columns sort NULLs after every value in the column's direction. Their cursor key
+	// is a pointer, nil for NULL.
+	Nullable bool
+}
+
+// KeysetCondition selects the rows that come after values in the ordering, or before them when
+// backward is set. For columns a, b it expands to (a > ?) OR (a = ? AND b > ?), with the
+// comparison flipped for descending columns, so mixed directions work. Nullable columns also
+// match NULLs where they sort after the value, and compare a NULL value with IS NULL.
+func KeysetCondition(columns []KeysetColumn, values []interface{}, backward bool) clause.Expr {
+	var branches []string
+	var vars []interface{}
+	var equal strings.Builder
+	var equalVars []interface{}
+	for i, column := range columns {
+		value, null := keysetValue(values[i])
+
+		op := " > ?"
+		if column.Desc != backward {
+			op = " < ?"
+		}
+		var after string
+		var afterVars []interface{}
+		switch {
+		case !column.Nullable || (!null && backward):
+			after = column.Column + op
+			afterVars = []interface{}{value}
+		case !null:
+			// NULLs sort after every value
+			after = "(" + column.Column + op + " OR " + column.Column + " IS NULL)"
+			afterVars = []interface{}{value}
+		case backward:
+			// Every value sorts before NULL
+			after = column.Column + " IS NOT NULL"
+		}
+		// Nothing sorts after NULL going forward, so a NULL value only narrows the later columns
+		if after != "" {
+			branches = append(branches, "("+equal.String()+after+")")
+			vars = append(append(vars, equalVars...), afterVars...)
+		}
+
+		if null {
+			equal.WriteString(column.Column + " IS NULL AND ")
+		} else {
+			equal.WriteString(column.Column + " = ? AND ")
+			equalVars = append(equalVars, value)
+		}
+	}
+	return clause.Expr{SQL: "(" + strings.Join(branches, " OR ") + ")", Vars: vars}
+}
+
+// keysetValue dereferences a nullable column's cursor key, reporting whether it is NULL
+func keysetValue(value interface{}) (interface{}, bool) {
+	if value == nil {
+		return nil, true
+	}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, true
+		}
+		return v.Elem().Interface(), false
+	}
+	return value, false
+}
+
+// KeysetOrder orders by the keyset columns, reversed when reading backward
+func KeysetOrder(columns []KeysetColumn, backward bool) clause.OrderBy {
+	terms := make([]string, len(columns))
+	for i, column := range columns {
+		terms[i] = column.Column
+		if column.Desc != backward {
+			terms[i] += " DESC"
+		}
+		if column.Nullable && backward {
+			terms[i] += " NULLS FIRST"
+		} else if column.Nullable {
+			terms[i] += " NULLS LAST"
+		}
+	}
+	return clause.OrderBy{Expression: clause.Expr{SQL: strings.Join(terms, ",")}}
+}
+
+// CursorResponse is a page of a keyset-paginated list. Total is only set when requested.
+type CursorResponse[T any] struct {
+	Data       []T    `json:"data"`
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	Total      *int64 `json:"total,omitempty"`
+	// Links point to the neighbouring pages; set by SetCursorLinks
+	Links *PageLinks `json:"links,omitempty"`
+}
+
+// Select renders each item with only the selected fields
+func (r CursorResponse[T]) Select(fields Fieldset) CursorResponse[Sparse] {
+	return CursorResponse[Sparse]{
+		Data:       selectEach(fields, r.Data),
+		Limit:      r.Limit,
+		NextCursor: r.NextCursor,
+		PrevCursor: r.PrevCursor,
+		Total:      r.Total,
+		Links:      r.Links,
+	}
+}
@@ -0,0 +1,182 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/clause"
+)
+
+func TestCursorParams_Normalize(t *testing.T) {
+	p := CursorParams{}
+	p.Normalize()
+	assert.Equal(t, DefaultPageSize, p.Limit)
+
+	p = CursorParams{Limit: 500}
+	p.Normalize()
+	assert.Equal(t, MaxPageSize, p.Limit)
+}
+
+func TestCursorCodec(t *testing.T) {
+	codec := NewCursorCodec("secret")
+	released := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	cursor, err := NewCursor("rarity:desc,release_date,id", true, 5, released, int64(42))
+	require.NoError(t, err)
+	token, err := codec.Encode(cursor)
+	require.NoError(t, err)
+
+	t.Run("round trip keeps key types", func(t *testing.T) {
+		decoded, err := codec.Decode(token, "rarity:desc,release_date,id")
+		require.NoError(t, err)
+		assert.True(t, decoded.Backward)
+
+		values, err := decoded.Values(0, time.Time{}, int64(0))
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{5, released, int64(42)}, values)
+	})
+
+	t.Run("rejects a different ordering", func(t *testing.T) {
+		_, err := codec.Decode(token, "id")
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("rejects a tampered payload", func(t *testing.T) {
+		other, _ := NewCursor("rarity:desc,release_date,id", true, 1, released, int64(1))
+		otherToken, _ := codec.Encode(other)
+		otherPayload, _, _ := strings.Cut(otherToken, ".")
+		_, signature, _ := strings.Cut(token, ".")
+		forged := otherPayload + "." + signature
+		_, err := codec.Decode(forged, "rarity:desc,release_date,id")
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("rejects another secret", func(t *testing.T) {
+		_, err := NewCursorCodec("other").Decode(token, "rarity:desc,release_date,id")
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("rejects garbage", func(t *testing.T) {
+		for _, garbage := range []string{"", "abc", "a.b", "!!.!!"} {
+			_, err := codec.Decode(garbage, "id")
+			assert.ErrorIs(t, err, ErrInvalidCursor, garbage)
+		}
+	})
+
+	t.Run("rejects keys of the wrong shape", func(t *testing.T) {
+		decoded, _ := codec.Decode(token, "rarity:desc,release_date,id")
+		_, err := decoded.Values(int64(0))
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+		_, err = decoded.Values("", 0, int64(0))
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
+
+func TestCursorCodec_PageCursors(t *testing.T) {
+	codec := NewCursorCodec("secret")
+	keys := func(i int) []interface{} { return []interface{}{int64(i + 1)} }
+	decode := func(token string) Cursor {
+		cursor, err := codec.Decode(token, "id")
+		require.NoError(t, err)
+		return cursor
+	}
+
+	t.Run("first page", func(t *testing.T) {
+		next, prev, err := codec.PageCursors(nil, "id", 3, true, keys)
+		require.NoError(t, err)
+		assert.Empty(t, prev)
+		assert.Equal(t, []byte("3"), []byte(decode(next).Keys[0]))
+		assert.False(t, decode(next).Backward)
+	})
+
+	t.Run("only page", func(t *testing.T) {
+		next, prev, err := codec.PageCursors(nil, "id", 3, false, keys)
+		require.NoError(t, err)
+		assert.Empty(t, next)
+		assert.Empty(t, prev)
+	})
+
+	t.Run("last page read forward", func(t *testing.T) {
+		current, _ := NewCursor("id", false, int64(9))
+		next, prev, err := codec.PageCursors(&current, "id", 2, false, keys)
+		require.NoError(t, err)
+		assert.Empty(t, next)
+		assert.True(t, decode(prev).Backward)
+		assert.Equal(t, []byte("1"), []byte(decode(prev).Keys[0]))
+	})
+
+	t.Run("first page read backward", func(t *testing.T) {
+		current, _ := NewCursor("id", true, int64(4))
+		next, prev, err := codec.PageCursors(&current, "id", 3, false, keys)
+		require.NoError(t, err)
+		assert.Empty(t, prev)
+		assert.Equal(t, []byte("3"), []byte(decode(next).Keys[0]))
+	})
+
+	t.Run("empty page turns around", func(t *testing.T) {
+		current, _ := NewCursor("id", false, int64(9))
+		next, prev, err := codec.PageCursors(&current, "id", 0, false, keys)
+		require.NoError(t, err)
+		assert.Empty(t, next)
+		assert.True(t, decode(prev).Backward)
+		assert.Equal(t, []byte("9"), []byte(decode(prev).Keys[0]))
+	})
+}
+
+func TestKeysetCondition(t *testing.T) {
+	columns := []KeysetColumn{{Column: "rarity", Desc: true}, {Column: "name"}, {Column: "id"}}
+
+	t.Run("forward with mixed directions", func(t *testing.T) {
+		expr := KeysetCondition(columns, []interface{}{5, "Fiore", int64(3)}, false)
+		assert.Equal(t, "((rarity < ?) OR (rarity = ? AND name > ?) OR (rarity = ? AND name = ? AND id > ?))", expr.SQL)
+		assert.Equal(t, []interface{}{5, 5, "Fiore", 5, "Fiore", int64(3)}, expr.Vars)
+	})
+
+	t.Run("backward flips every comparison", func(t *testing.T) {
+		expr := KeysetCondition(columns, []interface{}{5, "Fiore", int64(3)}, true)
+		assert.Equal(t, "((rarity > ?) OR (rarity = ? AND name < ?) OR (rarity = ? AND name = ? AND id < ?))", expr.SQL)
+	})
+
+	t.Run("order is reversed backward", func(t *testing.T) {
+		assert.Equal(t, clause.Expr{SQL: "rarity DESC,name,id"}, KeysetOrder(columns, false).Expression)
+		assert.Equal(t, clause.Expr{SQL: "rarity,name DESC,id DESC"}, KeysetOrder(columns, true).Expression)
+	})
+}
+
+func TestKeysetCondition_Nullable(t *testing.T) {
+	columns := []KeysetColumn{{Column: "release_date", Nullable: true}, {Column: "id"}}
+	releaseDate := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("forward from a value includes NULLs", func(t *testing.T) {
+		expr := KeysetCondition(columns, []interface{}{&releaseDate, int64(3)}, false)
+		assert.Equal(t, "(((release_date > ? OR release_date IS NULL)) OR (release_date = ? AND id > ?))", expr.SQL)
+		assert.Equal(t, []interface{}{releaseDate, releaseDate, int64(3)}, expr.Vars)
+	})
+
+	t.Run("backward from a value excludes NULLs", func(t *testing.T) {
+		expr := KeysetCondition(columns, []interface{}{&releaseDate, int64(3)}, true)
+		assert.Equal(t, "((release_date < ?) OR (release_date = ? AND id < ?))", expr.SQL)
+	})
+
+	t.Run("forward from NULL stays among NULLs", func(t *testing.T) {
+		expr := KeysetCondition(columns, []interface{}{(*time.Time)(nil), int64(3)}, false)
+		assert.Equal(t, "((release_date IS NULL AND id > ?))", expr.SQL)
+		assert.Equal(t, []interface{}{int64(3)}, expr.Vars)
+	})
+
+	t.Run("backward from NULL includes every value", func(t *testing.T) {
+		expr := KeysetCondition(columns, []interface{}{(*time.Time)(nil), int64(3)}, true)
+		assert.Equal(t, "((release_date IS NOT NULL) OR (release_date IS NULL AND id < ?))", expr.SQL)
+	})
+
+	t.Run("NULLs sort last in either direction", func(t *testing.T) {
+		assert.Equal(t, clause.Expr{SQL: "release_date NULLS LAST,id"}, KeysetOrder(columns, false).Expression)
+		assert.Equal(t, clause.Expr{SQL: "release_date DESC NULLS FIRST,id DESC"}, KeysetOrder(columns, true).Expression)
+
+		desc := []KeysetColumn{{Column: "release_date", Desc: true, Nullable: true}, {Column: "id"}}
+		assert.Equal(t, clause.Expr{SQL: "release_date DESC NULLS LAST,id"}, KeysetOrder(desc, false).Expression)
+	})
+}
@@ -0,0 +1,62 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// constraintDialector keeps the driver error behind the gorm errors it translates to, so callers
+// can still tell which constraint a write violated
+type constraintDialector struct {
+	gorm.Dialector
+}
+
+// NewDialector wraps a dialector so translated errors, such as gorm.ErrDuplicatedKey, also wrap
+// the postgres error they came from. Use it with TranslateError.
+func NewDialector(dialector gorm.Dialector) gorm.Dialector {
+	return constraintDialector{Dialector: dialector}
+}
+
+func (d constraintDialector) Translate(err error) error {
+	translator, ok := d.Dialector.(gorm.ErrorTranslator)
+	if !ok {
+		return err
+	}
+
+	pgErr, ok := err.(*pgconn.PgError)
+	if !ok {
+		return translator.Translate(err)
+	}
+	if translated := translator.Translate(pgErr); translated != error(pgErr) {
+		return fmt.Errorf("%w: %w", translated, pgErr)
+	}
+	return pgErr
+}
+
+// SavePoint keeps nested transactions on savepoints, which gorm only uses when the dialector has them
+func (d constraintDialector) SavePoint(tx *gorm.DB, name string) error {
+	if savePointer, ok := d.Dialector.(gorm.SavePointerDialectorInterface); ok {
+		return savePointer.SavePoint(tx, name)
+	}
+	return gorm.ErrUnsupportedDriver
+}
+
+func (d constraintDialector) RollbackTo(tx *gorm.DB, name string) error {
+	if savePointer, ok := d.Dialector.(gorm.SavePointerDialectorInterface); ok {
+		return savePointer.RollbackTo(tx, name)
+	}
+	return gorm.ErrUnsupportedDriver
+}
+
+// ViolatedConstraint returns the name of the constraint a database error violated, or "" when
+// it names none
+func ViolatedConstraint(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.ConstraintName
+	}
+	return ""
+}
@@ -0,0 +1,40 @@
+package helpers
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestNewDialector(t *testing.T) {
+	db, mock, err := NewMockDB()
+	require.NoError(t, err)
+
+	t.Run("translated errors keep the violated constraint", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE m_traveller SET slug = 'viola'`)).
+			WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "m_traveller_slug_idx"})
+
+		err := db.Exec(`UPDATE m_traveller SET slug = 'viola'`).Error
+		assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+		assert.Equal(t, "m_traveller_slug_idx", ViolatedConstraint(err))
+		assert.True(t, IsSlugConflict(err))
+	})
+
+	t.Run("other postgres errors are unchanged", func(t *testing.T) {
+		pgErr := &pgconn.PgError{Code: "57014"}
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE m_traveller SET slug = 'viola'`)).WillReturnError(pgErr)
+
+		err := db.Exec(`UPDATE m_traveller SET slug = 'viola'`).Error
+		assert.Equal(t, error(pgErr), err)
+	})
+
+	t.Run("errors from other sources name no constraint", func(t *testing.T) {
+		assert.Equal(t, "", ViolatedConstraint(errors.New("connection reset")))
+		assert.False(t, IsSlugConflict(gorm.ErrDuplicatedKey))
+	})
+}
@@ -36,3 +36,13 @@ func EnvWithDefaultFloat(key string, defaultValue float64) float64 {
 	}
 	return defaultValue
 }
+
+// EnvWithDefaultInt returns int from env or default
+func EnvWithDefaultInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
@@ -135,3 +135,49 @@ func TestEnvWithDefaultFloat(t *testing.T) {
 		})
 	}
 }
+
+func TestEnvWithDefaultInt(t *testing.T) {
+	tests := []struct {
+		name         string
+		envKey       string
+		envValue     string
+		setEnv       bool
+		defaultValue int
+		expected     int
+	}{
+		{
+			name:         "success get value",
+			envKey:       "test int key",
+			envValue:     "8",
+			setEnv:       true,
+			defaultValue: 5,
+			expected:     8,
+		},
+		{
+			name:         "success get default",
+			envKey:       "nonexistent int value",
+			setEnv:       false,
+			defaultValue: 5,
+			expected:     5,
+		},
+		{
+			name:         "invalid int falls back to default",
+			envKey:       "invalid int key",
+			envValue:     "eight",
+			setEnv:       true,
+			defaultValue: 5,
+			expected:     5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setEnv {
+				t.Setenv(tt.envKey, tt.envValue)
+			}
+
+			got := EnvWithDefaultInt(tt.envKey, tt.defaultValue)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Fieldset is a sparse fieldset: the response fields a client asked for with the fields
+// parameter. A nil Fieldset selects every field.
+type Fieldset map[string]bool
+
+// ParseFieldset parses a comma-separated fields parameter, checking each name against the JSON
+// fields of sample. id and links are always selected so items stay addressable.
+func ParseFieldset(raw string, sample interface{}) (Fieldset, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(sample))
+	fields := Fieldset{"id": true}
+	if known["links"] {
+		fields["links"] = true
+	}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if !known[name] {
+			return nil, fmt.Errorf("unknown field '%s'", name)
+		}
+		fields[name] = true
+	}
+	return fields, nil
+}
+
+// Select wraps v so it renders with only the selected fields
+func (f Fieldset) Select(v interface{}) Sparse {
+	return Sparse{Value: v, Fields: f}
+}
+
+// Sparse is a response item rendered with only some of its fields. Fields left out by
+// omitempty stay out, and a nil Fields renders the item unchanged.
+type Sparse struct {
+	Value  interface{}
+	Fields Fieldset
+}
+
+func (s Sparse) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(s.Value)
+	if err != nil || s.Fields == nil {
+		return data, err
+	}
+
+	var all map[string]json.RawMessage
+	err = json.Unmarshal(data, &all)
+	if err != nil {
+		return nil, err
+	}
+	for name := range all {
+		if !s.Fields[name] {
+			delete(all, name)
+		}
+	}
+	return json.Marshal(all)
+}
+
+func selectEach[T any](f Fieldset, items []T) []Sparse {
+	result := make([]Sparse, len(items))
+	for i, item := range items {
+		result[i] = f.Select(item)
+	}
+	return result
+}
+
+// jsonFieldNames returns the names a struct type's fields are encoded under
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
@@ -0,0 +1,87 @@
+package helpers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fieldsItem struct {
+	ID      int64    `json:"id"`
+	Name    string   `json:"name"`
+	Rarity  int      `json:"rarity"`
+	Partner *string  `json:"partner,omitempty"`
+	Secret  string   `json:"-"`
+	Tags    []string `json:"tags"`
+}
+
+// TestParseFieldset tests parsing and checking of the fields parameter
+func TestParseFieldset(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    Fieldset
+		wantErr string
+	}{
+		{
+			name: "empty selects everything",
+			raw:  "",
+			want: nil,
+		},
+		{
+			name: "id is always selected",
+			raw:  "name, rarity",
+			want: Fieldset{"id": true, "name": true, "rarity": true},
+		},
+		{
+			name:    "unknown field",
+			raw:     "name,power",
+			wantErr: "unknown field 'power'",
+		},
+		{
+			name:    "hidden field",
+			raw:     "Secret",
+			wantErr: "unknown field 'Secret'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFieldset(tt.raw, fieldsItem{})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestSparse_MarshalJSON tests rendering items with a fieldset
+func TestSparse_MarshalJSON(t *testing.T) {
+	item := fieldsItem{ID: 9007199254740993, Name: "Viola", Rarity: 5, Tags: []string{"wind"}}
+
+	t.Run("nil fieldset renders the item unchanged", func(t *testing.T) {
+		got, err := json.Marshal(Fieldset(nil).Select(item))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"id":9007199254740993,"name":"Viola","rarity":5,"tags":["wind"]}`, string(got))
+	})
+
+	t.Run("only selected fields, omitted ones stay out", func(t *testing.T) {
+		got, err := json.Marshal(Fieldset{"id": true, "rarity": true, "partner": true}.Select(&item))
+		assert.NoError(t, err)
+		assert.Equal(t, `{"id":9007199254740993,"rarity":5}`, string(got))
+	})
+}
+
+// TestPaginatedResponse_Select tests that selecting fields keeps the page metadata
+func TestPaginatedResponse_Select(t *testing.T) {
+	page := NewPaginatedResponse([]fieldsItem{{ID: 1, Name: "Viola"}}, PaginationParams{Page: 1, PageSize: 10}, 1)
+	page.DidYouMean = []string{"Viola"}
+
+	got, err := json.Marshal(page.Select(Fieldset{"id": true, "name": true}))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"data":[{"id":1,"name":"Viola"}],"page":1,"page_size":10,"total":1,"total_pages":1,"did_you_mean":["Viola"]}`, string(got))
+}
@@ -0,0 +1,102 @@
+package helpers
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PageLinks are the URLs of the pages around a page of a list. They are relative to the
+// request and keep all of its other query parameters, so filters carry over.
+type PageLinks struct {
+	Self  string `json:"self" example:"/api/v1/travellers?page=2&page_size=10&rarity_min=4"`
+	First string `json:"first,omitempty" example:"/api/v1/travellers?page=1&page_size=10&rarity_min=4"`
+	Prev  string `json:"prev,omitempty" example:"/api/v1/travellers?page=1&page_size=10&rarity_min=4"`
+	Next  string `json:"next,omitempty" example:"/api/v1/travellers?page=3&page_size=10&rarity_min=4"`
+	Last  string `json:"last,omitempty" example:"/api/v1/travellers?page=5&page_size=10&rarity_min=4"`
+}
+
+// SetPageLinks fills in the links of a page and sends them as an RFC 8288 Link header
+func SetPageLinks[T any](ctx echo.Context, page *PaginatedResponse[T]) {
+	link := func(n int) string {
+		return pageURL(ctx, map[string]string{
+			"page":      strconv.Itoa(n),
+			"page_size": strconv.Itoa(page.PageSize),
+		})
+	}
+
+	// An empty list still has a first and last page, the empty one
+	last := max(page.TotalPages, 1)
+	links := PageLinks{
+		Self:  link(page.Page),
+		First: link(1),
+		Last:  link(last),
+	}
+	if page.Page > 1 {
+		// Past the end, the previous page is the last one that exists
+		links.Prev = link(min(page.Page-1, last))
+	}
+	if page.Page < page.TotalPages {
+		links.Next = link(page.Page + 1)
+	}
+
+	page.Links = &links
+	setLinkHeader(ctx, links)
+}
+
+// SetCursorLinks fills in the links of a keyset-paginated page and sends them as an RFC 8288
+// Link header. Keyset pages have no last link, since reaching the end means reading through.
+func SetCursorLinks[T any](ctx echo.Context, page *CursorResponse[T]) {
+	link := func(cursor string) string {
+		return pageURL(ctx, map[string]string{
+			"cursor": cursor,
+			"limit":  strconv.Itoa(page.Limit),
+		})
+	}
+
+	links := PageLinks{
+		Self:  pageURL(ctx, map[string]string{"limit": strconv.Itoa(page.Limit)}),
+		First: link(""),
+	}
+	if page.PrevCursor != "" {
+		links.Prev = link(page.PrevCursor)
+	}
+	if page.NextCursor != "" {
+		links.Next = link(page.NextCursor)
+	}
+
+	page.Links = &links
+	setLinkHeader(ctx, links)
+}
+
+// pageURL returns the request path and query with the given parameters replaced
+func pageURL(ctx echo.Context, params map[string]string) string {
+	query := ctx.QueryParams()
+	next := make(url.Values, len(query)+len(params))
+	for key, values := range query {
+		next[key] = values
+	}
+	for key, value := range params {
+		next.Set(key, value)
+	}
+	return ctx.Request().URL.Path + "?" + next.Encode()
+}
+
+func setLinkHeader(ctx echo.Context, links PageLinks) {
+	var values []string
+	for _, link := range []struct{ rel, target string }{
+		{"first", links.First},
+		{"prev", links.Prev},
+		{"next", links.Next},
+		{"last", links.Last},
+	} {
+		if link.target != "" {
+			values = append(values, "<"+link.target+`>; rel="`+link.rel+`"`)
+		}
+	}
+	if len(values) > 0 {
+		ctx.Response().Header().Set("Link", strings.Join(values, ", "))
+	}
+}
@@ -0,0 +1,95 @@
+package helpers
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSetPageLinks tests page links and the Link header for offset pagination
+func TestSetPageLinks(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      url.Values
+		page       int
+		total      int64
+		wantLinks  PageLinks
+		wantHeader string
+	}{
+		{
+			name:  "middle page keeps filters",
+			query: url.Values{"page": {"2"}, "rarity_min": {"4"}, "job": {"Warrior,Dancer"}},
+			page:  2,
+			total: 25,
+			wantLinks: PageLinks{
+				Self:  "/api/v1/travellers?job=Warrior%2CDancer&page=2&page_size=10&rarity_min=4",
+				First: "/api/v1/travellers?job=Warrior%2CDancer&page=1&page_size=10&rarity_min=4",
+				Prev:  "/api/v1/travellers?job=Warrior%2CDancer&page=1&page_size=10&rarity_min=4",
+				Next:  "/api/v1/travellers?job=Warrior%2CDancer&page=3&page_size=10&rarity_min=4",
+				Last:  "/api/v1/travellers?job=Warrior%2CDancer&page=3&page_size=10&rarity_min=4",
+			},
+			wantHeader: `</api/v1/travellers?job=Warrior%2CDancer&page=1&page_size=10&rarity_min=4>; rel="first", ` +
+				`</api/v1/travellers?job=Warrior%2CDancer&page=1&page_size=10&rarity_min=4>; rel="prev", ` +
+				`</api/v1/travellers?job=Warrior%2CDancer&page=3&page_size=10&rarity_min=4>; rel="next", ` +
+				`</api/v1/travellers?job=Warrior%2CDancer&page=3&page_size=10&rarity_min=4>; rel="last"`,
+		},
+		{
+			name:  "empty list",
+			query: url.Values{"name": {"nobody"}},
+			page:  1,
+			total: 0,
+			wantLinks: PageLinks{
+				Self:  "/api/v1/travellers?name=nobody&page=1&page_size=10",
+				First: "/api/v1/travellers?name=nobody&page=1&page_size=10",
+				Last:  "/api/v1/travellers?name=nobody&page=1&page_size=10",
+			},
+			wantHeader: `</api/v1/travellers?name=nobody&page=1&page_size=10>; rel="first", ` +
+				`</api/v1/travellers?name=nobody&page=1&page_size=10>; rel="last"`,
+		},
+		{
+			name:  "past the end points back to the last page",
+			query: url.Values{"page": {"9"}},
+			page:  9,
+			total: 15,
+			wantLinks: PageLinks{
+				Self:  "/api/v1/travellers?page=9&page_size=10",
+				First: "/api/v1/travellers?page=1&page_size=10",
+				Prev:  "/api/v1/travellers?page=2&page_size=10",
+				Last:  "/api/v1/travellers?page=2&page_size=10",
+			},
+			wantHeader: `</api/v1/travellers?page=1&page_size=10>; rel="first", ` +
+				`</api/v1/travellers?page=2&page_size=10>; rel="prev", ` +
+				`</api/v1/travellers?page=2&page_size=10>; rel="last"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ctx := GetHTTPTestRecorder(t, http.MethodGet, "/api/v1/travellers", nil, tt.query, nil)
+			page := NewPaginatedResponse([]int{}, PaginationParams{Page: tt.page, PageSize: 10}, tt.total)
+
+			SetPageLinks(ctx, &page)
+
+			assert.Equal(t, tt.wantLinks, *page.Links)
+			assert.Equal(t, tt.wantHeader, ctx.Response().Header().Get("Link"))
+		})
+	}
+}
+
+// TestSetCursorLinks tests page links and the Link header for keyset pagination
+func TestSetCursorLinks(t *testing.T) {
+	_, ctx := GetHTTPTestRecorder(t, http.MethodGet, "/api/v1/accessories", nil, url.Values{"cursor": {"abc.def"}, "order_by": {"patk"}}, nil)
+	page := CursorResponse[int]{Limit: 10, NextCursor: "ghi.jkl"}
+
+	SetCursorLinks(ctx, &page)
+
+	assert.Equal(t, PageLinks{
+		Self:  "/api/v1/accessories?cursor=abc.def&limit=10&order_by=patk",
+		First: "/api/v1/accessories?cursor=&limit=10&order_by=patk",
+		Next:  "/api/v1/accessories?cursor=ghi.jkl&limit=10&order_by=patk",
+	}, *page.Links)
+	assert.Equal(t, `</api/v1/accessories?cursor=&limit=10&order_by=patk>; rel="first", `+
+		`</api/v1/accessories?cursor=ghi.jkl&limit=10&order_by=patk>; rel="next"`, ctx.Response().Header().Get("Link"))
+}
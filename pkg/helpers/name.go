@@ -0,0 +1,21 @@
+package helpers
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeName tidies a name for storage: it is NFC-normalised, so precomposed and combining
+// accents store alike, trimmed, and runs of whitespace inside it become a single space
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(name)), " ")
+}
+
+// NameKey returns the key names are unique by: the normalised name, case-folded.
+// "Viola", "viola " and "VIOLA" all share one key.
+func NameKey(name string) string {
+	// Folding can decompose some characters, so normalise once more
+	return norm.NFC.String(cases.Fold().String(NormalizeName(name)))
+}
@@ -0,0 +1,47 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "unchanged", input: "Viola", want: "Viola"},
+		{name: "trimmed", input: " Viola  ", want: "Viola"},
+		{name: "inner whitespace collapses", input: "Crown \t of  Wisdom", want: "Crown of Wisdom"},
+		{name: "combining accent is composed", input: "Agne\u0300s", want: "Agn\u00e8s"},
+		{name: "case is kept", input: "H'aanit EX", want: "H'aanit EX"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeName(tt.input))
+		})
+	}
+}
+
+func TestNameKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "lowercased", input: "Viola", want: "viola"},
+		{name: "spacing and case variants share a key", input: " VIOLA ", want: "viola"},
+		{name: "precomposed accent", input: "Agn\u00e8s", want: "agn\u00e8s"},
+		{name: "combining accent", input: "AGNE\u0300S", want: "agn\u00e8s"},
+		{name: "full case folding", input: "Straße", want: "strasse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NameKey(tt.input))
+		})
+	}
+}
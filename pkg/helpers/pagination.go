@@ -1,5 +1,13 @@
 package helpers
 
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
 // PaginationParams holds pagination request parameters
 type PaginationParams struct {
 	Page     int `query:"page"`
@@ -46,6 +54,35 @@ type PaginatedResponse[T any] struct {
 	PageSize   int   `json:"page_size"`
 	Total      int64 `json:"total"`
 	TotalPages int   `json:"total_pages"`
+	// Facets holds per-value counts for the facets requested with the facets parameter
+	Facets map[string][]FacetCount `json:"facets,omitempty"`
+	// DidYouMean suggests close names when a name search matched nothing
+	DidYouMean []string `json:"did_you_mean,omitempty"`
+	// Links point to the neighbouring pages; set by SetPageLinks
+	Links *PageLinks `json:"links,omitempty"`
+	// UpdatedAt is the newest modification in the whole filtered set, not just this page
+	UpdatedAt time.Time `json:"-"`
+}
+
+// Select renders each item with only the selected fields
+func (p PaginatedResponse[T]) Select(fields Fieldset) PaginatedResponse[Sparse] {
+	return PaginatedResponse[Sparse]{
+		Data:       selectEach(fields, p.Data),
+		Page:       p.Page,
+		PageSize:   p.PageSize,
+		Total:      p.Total,
+		TotalPages: p.TotalPages,
+		Facets:     p.Facets,
+		DidYouMean: p.DidYouMean,
+		Links:      p.Links,
+		UpdatedAt:  p.UpdatedAt,
+	}
+}
+
+// FacetCount is the number of items matching the other active filters that have Value
+type FacetCount struct {
+	Value string `json:"value" example:"Warrior"`
+	Count int64  `json:"count" example:"12"`
 }
 
 // NewPaginatedResponse creates a new paginated response
@@ -58,3 +95,31 @@ func NewPaginatedResponse[T any](data []T, params PaginationParams, total int64)
 		TotalPages: CalculateTotalPages(total, params.PageSize),
 	}
 }
+
+// ETag identifies this page of the collection for the given query. It changes whenever the
+// filter, the page, the number of matching rows, the newest modification among them, the
+// facet counts, or the name suggestions change. Facets and suggestions are included because
+// they come from rows outside the filtered set.
+func (p PaginatedResponse[T]) ETag(query url.Values) string {
+	// Pagination is hashed in its normalized form so page=0 and no page share a tag
+	filter := url.Values{}
+	for key, values := range query {
+		if key == "page" || key == "page_size" {
+			continue
+		}
+		filter[key] = values
+	}
+
+	// fmt prints maps with sorted keys, so equal facets always hash the same
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%d|%d|%d|%v|%q",
+		filter.Encode(), p.Page, p.PageSize, p.Total, p.UpdatedAt.UnixNano(), p.Facets, p.DidYouMean)))
+	return fmt.Sprintf(`"%x"`, sum[:16])
+}
+
+// LastModified returns UpdatedAt in HTTP-date format, or an empty string for an empty collection
+func (p PaginatedResponse[T]) LastModified() string {
+	if p.UpdatedAt.IsZero() {
+		return ""
+	}
+	return p.UpdatedAt.UTC().Format(http.TimeFormat)
+}
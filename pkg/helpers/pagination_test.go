@@ -1,7 +1,9 @@
 package helpers
 
 import (
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -227,3 +229,73 @@ func TestNewPaginatedResponse_CreatesValidResponse(t *testing.T) {
 	assert.Equal(t, int64(3), response.Total)
 	assert.Equal(t, 1, response.TotalPages)
 }
+
+// TestPaginatedResponse_ETag tests the collection ETag tracks query, page, and result set changes
+func TestPaginatedResponse_ETag(t *testing.T) {
+	updatedAt := time.Date(2026, 1, 27, 10, 0, 0, 0, time.UTC)
+	base := NewPaginatedResponse([]int{1, 2}, PaginationParams{Page: 1, PageSize: 10}, 2)
+	base.UpdatedAt = updatedAt
+	query := url.Values{"name": {"fiore"}, "page": {"1"}}
+	etag := base.ETag(query)
+
+	t.Run("is a quoted strong tag", func(t *testing.T) {
+		assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+	})
+
+	t.Run("is stable for the same query and result set", func(t *testing.T) {
+		assert.Equal(t, etag, base.ETag(url.Values{"page": {"1"}, "name": {"fiore"}}))
+	})
+
+	t.Run("ignores raw pagination parameters", func(t *testing.T) {
+		assert.Equal(t, etag, base.ETag(url.Values{"name": {"fiore"}, "page": {"0"}}))
+	})
+
+	t.Run("changes with the filter", func(t *testing.T) {
+		assert.NotEqual(t, etag, base.ETag(url.Values{"name": {"viola"}}))
+	})
+
+	t.Run("changes with the page", func(t *testing.T) {
+		other := base
+		other.Page = 2
+		assert.NotEqual(t, etag, other.ETag(query))
+	})
+
+	t.Run("changes with the total", func(t *testing.T) {
+		other := base
+		other.Total = 1
+		assert.NotEqual(t, etag, other.ETag(query))
+	})
+
+	t.Run("changes with the facet counts", func(t *testing.T) {
+		other := base
+		other.Facets = map[string][]FacetCount{"job": {{Value: "Warrior", Count: 1}}}
+		changed := other
+		changed.Facets = map[string][]FacetCount{"job": {{Value: "Warrior", Count: 2}}}
+		assert.NotEqual(t, etag, other.ETag(query))
+		assert.NotEqual(t, other.ETag(query), changed.ETag(query))
+	})
+
+	t.Run("changes with the name suggestions", func(t *testing.T) {
+		other := base
+		other.DidYouMean = []string{"Fiore"}
+		assert.NotEqual(t, etag, other.ETag(query))
+	})
+
+	t.Run("changes with the newest modification", func(t *testing.T) {
+		other := base
+		other.UpdatedAt = updatedAt.Add(time.Millisecond)
+		assert.NotEqual(t, etag, other.ETag(query))
+	})
+}
+
+// TestPaginatedResponse_LastModified tests Last-Modified formatting for list responses
+func TestPaginatedResponse_LastModified(t *testing.T) {
+	t.Run("formats as HTTP-date", func(t *testing.T) {
+		res := PaginatedResponse[int]{UpdatedAt: time.Date(2026, 1, 27, 17, 0, 0, 0, time.FixedZone("WIB", 7*3600))}
+		assert.Equal(t, "Tue, 27 Jan 2026 10:00:00 GMT", res.LastModified())
+	})
+
+	t.Run("empty for an empty collection", func(t *testing.T) {
+		assert.Equal(t, "", PaginatedResponse[int]{}.LastModified())
+	})
+}
@@ -0,0 +1,59 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+
+	jsonpatch "github.com/evanphx/json-patch"
+)
+
+// Patch media types accepted by PATCH endpoints
+const (
+	MIMEMergePatch = "application/merge-patch+json"
+	MIMEJSONPatch  = "application/json-patch+json"
+)
+
+var (
+	// ErrUnsupportedPatchType is returned when the Content-Type is not a supported patch format
+	ErrUnsupportedPatchType = errors.New("unsupported patch content type")
+	// ErrMalformedPatch is returned when the patch document cannot be parsed
+	ErrMalformedPatch = errors.New("malformed patch document")
+	// ErrPatchNotApplicable is returned when a JSON Patch operation fails against the current document
+	ErrPatchNotApplicable = errors.New("patch cannot be applied to the current resource")
+)
+
+// PatchMediaType returns the patch format named by a Content-Type header, ignoring parameters
+func PatchMediaType(contentType string) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", ErrUnsupportedPatchType
+	}
+	if mediaType != MIMEMergePatch && mediaType != MIMEJSONPatch {
+		return "", ErrUnsupportedPatchType
+	}
+	return mediaType, nil
+}
+
+// ApplyPatch applies an RFC 7396 merge patch or an RFC 6902 JSON patch to a JSON document
+func ApplyPatch(mediaType string, original, patch []byte) ([]byte, error) {
+	switch mediaType {
+	case MIMEMergePatch:
+		patched, err := jsonpatch.MergePatch(original, patch)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMalformedPatch, err)
+		}
+		return patched, nil
+	case MIMEJSONPatch:
+		ops, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMalformedPatch, err)
+		}
+		patched, err := ops.Apply(original)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrPatchNotApplicable, err)
+		}
+		return patched, nil
+	}
+	return nil, ErrUnsupportedPatchType
+}
@@ -0,0 +1,103 @@
+package helpers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchMediaType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		want        string
+		wantErr     bool
+	}{
+		{name: "merge patch", contentType: "application/merge-patch+json", want: MIMEMergePatch},
+		{name: "json patch", contentType: "application/json-patch+json", want: MIMEJSONPatch},
+		{name: "parameters ignored", contentType: "application/merge-patch+json; charset=utf-8", want: MIMEMergePatch},
+		{name: "plain json rejected", contentType: "application/json", wantErr: true},
+		{name: "empty rejected", contentType: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PatchMediaType(tt.contentType)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnsupportedPatchType)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	original := []byte(`{"name":"Fiore","banner":"General","accessory":{"name":"Crown","hp":150}}`)
+
+	tests := []struct {
+		name      string
+		mediaType string
+		patch     string
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "merge patch null removes field",
+			mediaType: MIMEMergePatch,
+			patch:     `{"banner":null}`,
+			want:      `{"name":"Fiore","accessory":{"name":"Crown","hp":150}}`,
+		},
+		{
+			name:      "merge patch merges nested objects",
+			mediaType: MIMEMergePatch,
+			patch:     `{"accessory":{"hp":200}}`,
+			want:      `{"name":"Fiore","banner":"General","accessory":{"name":"Crown","hp":200}}`,
+		},
+		{
+			name:      "merge patch malformed",
+			mediaType: MIMEMergePatch,
+			patch:     `{"banner":`,
+			wantErr:   ErrMalformedPatch,
+		},
+		{
+			name:      "json patch operations",
+			mediaType: MIMEJSONPatch,
+			patch:     `[{"op":"replace","path":"/name","value":"Viola"},{"op":"remove","path":"/accessory"}]`,
+			want:      `{"name":"Viola","banner":"General"}`,
+		},
+		{
+			name:      "json patch malformed",
+			mediaType: MIMEJSONPatch,
+			patch:     `{"op":"remove","path":"/banner"}`,
+			wantErr:   ErrMalformedPatch,
+		},
+		{
+			name:      "json patch failed test",
+			mediaType: MIMEJSONPatch,
+			patch:     `[{"op":"test","path":"/name","value":"Viola"}]`,
+			wantErr:   ErrPatchNotApplicable,
+		},
+		{
+			name:      "unsupported media type",
+			mediaType: "application/json",
+			patch:     `{}`,
+			wantErr:   ErrUnsupportedPatchType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyPatch(tt.mediaType, original, []byte(tt.patch))
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "expected %v, got %v", tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
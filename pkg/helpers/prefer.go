@@ -0,0 +1,56 @@
+package helpers
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Values of the return preference
+const (
+	PreferReturnMinimal        = "minimal"
+	PreferReturnRepresentation = "representation"
+)
+
+// Preferences are the preferences of a request's Prefer headers (RFC 7240) that the API honours
+type Preferences struct {
+	// Return is minimal or representation, or empty when the client has no preference
+	Return string
+	// RespondAsync asks for 202 Accepted and a status monitor instead of waiting for the result
+	RespondAsync bool
+}
+
+// ParsePrefer reads the Prefer headers of a request. Unknown preferences and values are
+// ignored, as RFC 7240 asks, and when a preference is repeated the first one counts.
+func ParsePrefer(ctx echo.Context) Preferences {
+	var prefs Preferences
+	seen := make(map[string]bool)
+	for _, header := range ctx.Request().Header.Values("Prefer") {
+		for _, pref := range strings.Split(header, ",") {
+			// Parameters after ; do not change any preference we honour
+			pref, _, _ = strings.Cut(pref, ";")
+			token, value, _ := strings.Cut(pref, "=")
+			token = strings.ToLower(strings.TrimSpace(token))
+			value = strings.Trim(strings.TrimSpace(value), `"`)
+			if token == "" || seen[token] {
+				continue
+			}
+			seen[token] = true
+
+			switch token {
+			case "return":
+				if value == PreferReturnMinimal || value == PreferReturnRepresentation {
+					prefs.Return = value
+				}
+			case "respond-async":
+				prefs.RespondAsync = true
+			}
+		}
+	}
+	return prefs
+}
+
+// SetPreferenceApplied tells the client which of its preferences the response honours
+func SetPreferenceApplied(ctx echo.Context, preference string) {
+	ctx.Response().Header().Add("Preference-Applied", preference)
+}
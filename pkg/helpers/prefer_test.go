@@ -0,0 +1,62 @@
+package helpers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParsePrefer tests reading the preferences of Prefer headers
+func TestParsePrefer(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+		want    Preferences
+	}{
+		{
+			name:    "no header",
+			headers: nil,
+			want:    Preferences{},
+		},
+		{
+			name:    "return minimal",
+			headers: []string{"return=minimal"},
+			want:    Preferences{Return: PreferReturnMinimal},
+		},
+		{
+			name:    "quoted value, parameters and case",
+			headers: []string{`Return="representation"; foo=bar`},
+			want:    Preferences{Return: PreferReturnRepresentation},
+		},
+		{
+			name:    "several preferences in one header",
+			headers: []string{"respond-async, wait=10, return=minimal"},
+			want:    Preferences{Return: PreferReturnMinimal, RespondAsync: true},
+		},
+		{
+			name:    "first of a repeated preference counts",
+			headers: []string{"return=representation", "return=minimal"},
+			want:    Preferences{Return: PreferReturnRepresentation},
+		},
+		{
+			name:    "unknown return value is ignored",
+			headers: []string{"return=everything"},
+			want:    Preferences{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			for _, header := range tt.headers {
+				req.Header.Add("Prefer", header)
+			}
+			ctx := echo.New().NewContext(req, httptest.NewRecorder())
+
+			assert.Equal(t, tt.want, ParsePrefer(ctx))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+)
+
+// Slugify converts a name into a lowercase, URL-safe slug.
+// Accents are stripped and any run of other characters becomes a single hyphen.
+func Slugify(name string) string {
+	// Decompose accented characters so their base letters survive
+	decomposed := norm.NFD.String(strings.ToLower(name))
+
+	var b strings.Builder
+	lastDash := true
+	for _, r := range decomposed {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+			lastDash = false
+		default:
+			if !lastDash {
+				b.WriteByte('-')
+				lastDash = true
+			}
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// slugIndexSuffix ends the name of each table's unique index on slug, <table>_slug_idx
+const slugIndexSuffix = "_slug_idx"
+
+// maxSlugAttempts bounds how often a write is retried while concurrent writes take its slug
+const maxSlugAttempts = 5
+
+// UniqueSlug returns a slug for name that is not used by any other row of table,
+// appending -2, -3, ... when needed. Soft-deleted rows are included so restored
+// records never collide. Names without any slug characters fall back to the table name.
+// A concurrent write can take the slug before it is saved, so save it with RetrySlugConflict.
+func UniqueSlug(db *gorm.DB, table, name string, excludeID int64) (string, error) {
+	base := Slugify(name)
+	if base == "" {
+		base = strings.TrimPrefix(table, "m_")
+	}
+
+	var taken []string
+	err := db.Table(table).
+		Where("(slug = ? OR slug LIKE ?) AND id <> ?", base, base+"-%", excludeID).
+		Pluck("slug", &taken).Error
+	if err != nil {
+		return "", err
+	}
+
+	used := make(map[string]bool, len(taken))
+	for _, slug := range taken {
+		used[slug] = true
+	}
+
+	slug := base
+	for i := 2; used[slug]; i++ {
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	return slug, nil
+}
+
+// SyncSlug returns the slug to store when the row with id is saved under name.
+// The current slug is kept unless the name changed or the row has no slug yet.
+func SyncSlug(db *gorm.DB, table string, id int64, name string) (string, error) {
+	var current struct {
+		Name string
+		Slug string
+	}
+	err := db.Table(table).Select("name", "slug").Where("id = ?", id).Limit(1).Find(&current).Error
+	if err != nil {
+		return "", err
+	}
+
+	if current.Name == name && current.Slug != "" {
+		return current.Slug, nil
+	}
+
+	return UniqueSlug(db, table, name, id)
+}
+
+// SlugIndex returns the name of table's unique index on slug
+func SlugIndex(table string) string {
+	return table + slugIndexSuffix
+}
+
+// IsSlugConflict reports whether err is a violation of a table's unique slug index
+func IsSlugConflict(err error) bool {
+	return errors.Is(err, gorm.ErrDuplicatedKey) && strings.HasSuffix(ViolatedConstraint(err), slugIndexSuffix)
+}
+
+// RetrySlugConflict calls write again when a concurrent write took a slug it picked with
+// UniqueSlug or SyncSlug, so the slug index rejected it. write picks its slugs itself, so each
+// attempt sees the slugs taken so far. It must not fail inside a transaction it doesn't roll
+// back, as postgres aborts the rest of the transaction; SlugTransaction handles that.
+func RetrySlugConflict(write func() error) (err error) {
+	for attempt := 1; ; attempt++ {
+		err = write()
+		if attempt == maxSlugAttempts || !IsSlugConflict(err) {
+			return err
+		}
+	}
+}
+
+// SlugTransaction runs fc in a transaction like db.Transaction, again when a slug it picked
+// was taken concurrently. Within a transaction each attempt rolls back to its own savepoint.
+func SlugTransaction(db *gorm.DB, fc func(tx *gorm.DB) error) error {
+	return RetrySlugConflict(func() error {
+		return db.Transaction(fc)
+	})
+}
@@ -0,0 +1,177 @@
+package helpers
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "single word", input: "Viola", want: "viola"},
+		{name: "spaces become hyphens", input: "Crown of Wisdom", want: "crown-of-wisdom"},
+		{name: "accents are stripped", input: "Agnès", want: "agnes"},
+		{name: "punctuation collapses", input: "H'aanit  (EX)", want: "h-aanit-ex"},
+		{name: "leading and trailing separators trimmed", input: "  --Fiore!-- ", want: "fiore"},
+		{name: "digits kept", input: "Sword 2", want: "sword-2"},
+		{name: "no slug characters", input: "!!!", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Slugify(tt.input))
+		})
+	}
+}
+
+func TestUniqueSlug(t *testing.T) {
+	query := regexp.QuoteMeta(`SELECT "slug" FROM "m_traveller" WHERE (slug = $1 OR slug LIKE $2) AND id <> $3`)
+
+	tests := []struct {
+		name      string
+		input     string
+		excludeID int64
+		base      string
+		taken     []string
+		want      string
+	}{
+		{name: "free slug", input: "Viola", base: "viola", want: "viola"},
+		{name: "taken slug gets suffix", input: "Viola", base: "viola", taken: []string{"viola"}, want: "viola-2"},
+		{name: "first free suffix", input: "Viola", base: "viola", taken: []string{"viola", "viola-2", "viola-4"}, want: "viola-3"},
+		{name: "suffix only matches exact base", input: "Viola", base: "viola", taken: []string{"viola-violet"}, want: "viola"},
+		{name: "excluded row", input: "Viola", excludeID: 7, base: "viola", want: "viola"},
+		{name: "empty base falls back to table name", input: "!!!", base: "traveller", want: "traveller"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := NewMockDB()
+			require.NoError(t, err)
+
+			rows := mock.NewRows([]string{"slug"})
+			for _, slug := range tt.taken {
+				rows.AddRow(slug)
+			}
+			mock.ExpectQuery(query).WithArgs(tt.base, tt.base+"-%", tt.excludeID).WillReturnRows(rows)
+
+			got, err := UniqueSlug(db, "m_traveller", tt.input, tt.excludeID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSyncSlug(t *testing.T) {
+	selectQuery := regexp.QuoteMeta(`SELECT "name","slug" FROM "m_accessory" WHERE id = $1 LIMIT $2`)
+	uniqueQuery := regexp.QuoteMeta(`SELECT "slug" FROM "m_accessory" WHERE (slug = $1 OR slug LIKE $2) AND id <> $3`)
+
+	t.Run("unchanged name keeps slug", func(t *testing.T) {
+		db, mock, err := NewMockDB()
+		require.NoError(t, err)
+
+		mock.ExpectQuery(selectQuery).WithArgs(3, 1).
+			WillReturnRows(mock.NewRows([]string{"name", "slug"}).AddRow("Crown of Wisdom", "crown-of-wisdom-2"))
+
+		got, err := SyncSlug(db, "m_accessory", 3, "Crown of Wisdom")
+		require.NoError(t, err)
+		assert.Equal(t, "crown-of-wisdom-2", got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rename regenerates slug", func(t *testing.T) {
+		db, mock, err := NewMockDB()
+		require.NoError(t, err)
+
+		mock.ExpectQuery(selectQuery).WithArgs(3, 1).
+			WillReturnRows(mock.NewRows([]string{"name", "slug"}).AddRow("Crown of Wisdom", "crown-of-wisdom"))
+		mock.ExpectQuery(uniqueQuery).WithArgs("crown-of-valor", "crown-of-valor-%", 3).
+			WillReturnRows(mock.NewRows([]string{"slug"}).AddRow("crown-of-valor"))
+
+		got, err := SyncSlug(db, "m_accessory", 3, "Crown of Valor")
+		require.NoError(t, err)
+		assert.Equal(t, "crown-of-valor-2", got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing slug is generated", func(t *testing.T) {
+		db, mock, err := NewMockDB()
+		require.NoError(t, err)
+
+		mock.ExpectQuery(selectQuery).WithArgs(3, 1).
+			WillReturnRows(mock.NewRows([]string{"name", "slug"}).AddRow("Crown of Wisdom", ""))
+		mock.ExpectQuery(uniqueQuery).WithArgs("crown-of-wisdom", "crown-of-wisdom-%", 3).
+			WillReturnRows(mock.NewRows([]string{"slug"}))
+
+		got, err := SyncSlug(db, "m_accessory", 3, "Crown of Wisdom")
+		require.NoError(t, err)
+		assert.Equal(t, "crown-of-wisdom", got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRetrySlugConflict(t *testing.T) {
+	slugTaken := fmt.Errorf("%w: %w", gorm.ErrDuplicatedKey, &pgconn.PgError{Code: "23505", ConstraintName: SlugIndex("m_traveller")})
+	nameTaken := fmt.Errorf("%w: %w", gorm.ErrDuplicatedKey, &pgconn.PgError{Code: "23505", ConstraintName: "m_traveller_name_key_idx"})
+
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		{name: "success", errs: []error{nil}, wantCalls: 1},
+		{name: "slug taken then free", errs: []error{slugTaken, nil}, wantCalls: 2},
+		{name: "other duplicate is not retried", errs: []error{nameTaken}, wantCalls: 1, wantErr: nameTaken},
+		{name: "gives up after max attempts", errs: []error{slugTaken, slugTaken, slugTaken, slugTaken, slugTaken, nil}, wantCalls: maxSlugAttempts, wantErr: slugTaken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := RetrySlugConflict(func() error {
+				calls++
+				return tt.errs[calls-1]
+			})
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestSlugTransaction(t *testing.T) {
+	db, mock, err := NewMockDB()
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO m_accessory (slug) VALUES ('crown')`)).
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: SlugIndex("m_accessory")})
+	mock.ExpectExec(`ROLLBACK TO SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO m_accessory (slug) VALUES ('crown-2')`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Within a transaction a failed attempt rolls back to its savepoint, leaving the transaction usable
+	slugs := []string{"crown", "crown-2"}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		return SlugTransaction(tx, func(tx *gorm.DB) error {
+			slug := slugs[0]
+			slugs = slugs[1:]
+			return tx.Exec(`INSERT INTO m_accessory (slug) VALUES ('` + slug + `')`).Error
+		})
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
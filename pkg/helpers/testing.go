@@ -26,9 +26,9 @@ func NewMockDB() (*gorm.DB, sqlmock.Sqlmock, error) {
 		return nil, nil, err
 	}
 
-	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+	gormDB, err := gorm.Open(NewDialector(postgres.New(postgres.Config{
 		Conn: sqlDB,
-	}), &gorm.Config{
+	})), &gorm.Config{
 		TranslateError: true,
 	})
 	if err != nil {
@@ -79,9 +79,15 @@ func GetHTTPTestRecorder(t *testing.T, method, url string, requestBody interface
 	rec := httptest.NewRecorder()
 	ctx := e.NewContext(req, rec)
 
-	for key, value := range pathParam {
-		ctx.SetParamNames(key)
-		ctx.SetParamValues(value)
+	if len(pathParam) > 0 {
+		names := make([]string, 0, len(pathParam))
+		values := make([]string, 0, len(pathParam))
+		for key, value := range pathParam {
+			names = append(names, key)
+			values = append(values, value)
+		}
+		ctx.SetParamNames(names...)
+		ctx.SetParamValues(values...)
 	}
 
 	ctx.Set("validator", validator)
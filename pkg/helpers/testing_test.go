@@ -114,6 +114,17 @@ func TestGetHTTPTestRecorder(t *testing.T) {
 				"id": "456",
 			},
 		},
+		{
+			name:        "GET with several path params",
+			method:      http.MethodGet,
+			url:         "/api/v1/travellers/by-external/:source/:id",
+			requestBody: nil,
+			queryParams: nil,
+			pathParams: map[string]string{
+				"source": "cotc-wiki",
+				"id":     "viola-5",
+			},
+		},
 	}
 
 	for _, tt := range tests {
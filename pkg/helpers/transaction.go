@@ -0,0 +1,25 @@
+package helpers
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// transactionKey carries a transaction that repositories called with the context join
+type transactionKey struct{}
+
+// WithTransaction returns a context whose repository writes run in tx, so they commit or roll
+// back with the caller's own statements. Repositories starting a transaction of their own get a
+// savepoint in tx instead.
+func WithTransaction(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, transactionKey{}, tx)
+}
+
+// Conn returns the transaction carried by ctx, or db when there is none, bound to ctx
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,50 @@
+package helpers
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestConn(t *testing.T) {
+	db, mock, err := NewMockDB()
+	require.NoError(t, err)
+
+	t.Run("without a transaction", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE m_traveller SET version = version + 1`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, Conn(context.TODO(), db).Exec(`UPDATE m_traveller SET version = version + 1`).Error)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("joins the context's transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE m_traveller SET version = version + 1`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE m_accessory SET version = version + 1`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectRollback()
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			ctx := WithTransaction(context.TODO(), tx)
+			if err := Conn(ctx, db).Exec(`UPDATE m_traveller SET version = version + 1`).Error; err != nil {
+				return err
+			}
+			if err := Conn(ctx, db).Transaction(func(nested *gorm.DB) error {
+				return nested.Exec(`UPDATE m_accessory SET version = version + 1`).Error
+			}); err != nil {
+				return err
+			}
+			return gorm.ErrInvalidTransaction
+		})
+		assert.ErrorIs(t, err, gorm.ErrInvalidTransaction)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
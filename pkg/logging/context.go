@@ -10,6 +10,7 @@ const (
 	traceIDKey   contextKey = "trace_id"   // Future: OTel trace ID
 	spanIDKey    contextKey = "span_id"    // Future: OTel span ID
 	userIDKey    contextKey = "user_id"    // From JWT claims
+	editorKey    contextKey = "editor"     // From JWT claims
 )
 
 // WithRequestID adds a request ID to the context
@@ -38,6 +39,17 @@ func GetUserID(ctx context.Context) string {
 	return ""
 }
 
+// WithEditor marks the context's user as an editor, who can read unpublished records
+func WithEditor(ctx context.Context) context.Context {
+	return context.WithValue(ctx, editorKey, true)
+}
+
+// IsEditor reports whether the context's user is an editor
+func IsEditor(ctx context.Context) bool {
+	editor, _ := ctx.Value(editorKey).(bool)
+	return editor
+}
+
 // WithTraceID adds a trace ID to the context (placeholder for future OTel integration)
 func WithTraceID(ctx context.Context, traceID string) context.Context {
 	return context.WithValue(ctx, traceIDKey, traceID)
@@ -97,6 +97,13 @@ func TestGetUserID_ReturnsEmptyStringWhenNotSet(t *testing.T) {
 	assert.Equal(t, "", userID)
 }
 
+// TestWithEditor tests marking the context's user as an editor
+func TestWithEditor(t *testing.T) {
+	assert.False(t, IsEditor(context.Background()))
+	assert.True(t, IsEditor(WithEditor(context.Background())))
+	assert.True(t, IsEditor(WithUserID(WithEditor(context.Background()), "isla")))
+}
+
 // TestWithTraceID tests trace ID context operations
 func TestWithTraceID(t *testing.T) {
 	tests := []struct {
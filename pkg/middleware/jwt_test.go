@@ -214,3 +214,41 @@ func TestJWTMiddleware_SuccessHandler_InjectsUserID(t *testing.T) {
 		})
 	}
 }
+
+func TestJWTMiddleware_SuccessHandler_MarksEditors(t *testing.T) {
+	secretKey := "test-secret-key"
+	t.Setenv("JWT_SECRET_KEY", secretKey)
+
+	for _, editor := range []bool{true, false} {
+		claims := &domain.JWTClaims{
+			Username: "isla",
+			Editor:   editor,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		}
+		tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secretKey))
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+
+		handler := NewJWTMiddleware()(func(c echo.Context) error {
+			assert.Equal(t, editor, logging.IsEditor(c.Request().Context()))
+			return nil
+		})
+		assert.NoError(t, handler(c))
+	}
+}
+
+func TestUnauthenticatedEditorMiddleware(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/travellers", nil)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+
+	handler := NewUnauthenticatedEditorMiddleware()(func(c echo.Context) error {
+		assert.True(t, logging.IsEditor(c.Request().Context()))
+		return nil
+	})
+	assert.NoError(t, handler(c))
+}
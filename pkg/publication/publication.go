@@ -0,0 +1,39 @@
+// Package publication hides the travellers and accessories that aren't published yet from readers
+// who aren't editors. Whether the reader is an editor comes from the context the JWT middleware
+// fills through logging.WithEditor, so it follows every query run with that context, including
+// preloads and those inside transactions.
+package publication
+
+import (
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/logging"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Register adds callbacks to db that limit queries of domain.Publishable models to published
+// rows unless the context's user is an editor. Unscoped queries see every row, as they see
+// deleted ones. Scan and Row run through gorm's row callbacks rather than the query ones, so
+// both are covered.
+func Register(db *gorm.DB) error {
+	if err := db.Callback().Query().Before("gorm:query").Register("publication:query", hideUnpublished); err != nil {
+		return err
+	}
+	return db.Callback().Row().Before("gorm:row").Register("publication:row", hideUnpublished)
+}
+
+func hideUnpublished(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || stmt.Unscoped || logging.IsEditor(stmt.Context) {
+		return
+	}
+	if _, ok := reflect.New(stmt.Schema.ModelType).Interface().(domain.Publishable); !ok {
+		return
+	}
+
+	stmt.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "status"}, Value: domain.PublishStatusPublished},
+	}})
+}
@@ -0,0 +1,109 @@
+package publication
+
+import (
+	"context"
+	"lizobly/ctc-db-api/pkg/domain"
+	"lizobly/ctc-db-api/pkg/helpers"
+	"lizobly/ctc-db-api/pkg/logging"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PublicationSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+}
+
+func TestPublicationSuite(t *testing.T) {
+	suite.Run(t, new(PublicationSuite))
+}
+
+func (s *PublicationSuite) SetupTest() {
+	var err error
+	s.db, s.mock, err = helpers.NewMockDB()
+	if err != nil {
+		s.T().Fatal()
+	}
+	if err = Register(s.db); err != nil {
+		s.T().Fatal(err)
+	}
+}
+
+func (s *PublicationSuite) TestQuery() {
+	s.Run("readers only see published rows", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE rarity = $1 AND "m_traveller"."status" = $2 AND "m_traveller"."deleted_at" IS NULL`)).
+			WithArgs(5, domain.PublishStatusPublished).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		var travellers []domain.Traveller
+		err := s.db.WithContext(context.Background()).Where("rarity = ?", 5).Find(&travellers).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("preloads are limited too", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."status" = $1 AND "m_traveller"."deleted_at" IS NULL`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "accessory_id"}).AddRow(1, 9))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_accessory" WHERE "m_accessory"."id" = $1 AND "m_accessory"."status" = $2 AND "m_accessory"."deleted_at" IS NULL`)).
+			WithArgs(9, domain.PublishStatusPublished).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		var travellers []domain.Traveller
+		err := s.db.WithContext(context.Background()).Preload("Accessory").Find(&travellers).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("scans are limited too", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "m_traveller" WHERE rarity = $1 AND "m_traveller"."status" = $2 AND "m_traveller"."deleted_at" IS NULL`)).
+			WithArgs(5, domain.PublishStatusPublished).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
+
+		var stats struct{ Total int64 }
+		err := s.db.WithContext(context.Background()).Model(&domain.Traveller{}).Select("COUNT(*) AS total").Where("rarity = ?", 5).Scan(&stats).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("editors see every row", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_traveller" WHERE "m_traveller"."deleted_at" IS NULL`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		var travellers []domain.Traveller
+		err := s.db.WithContext(logging.WithEditor(context.Background())).Find(&travellers).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("unscoped queries see every row", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_accessory" WHERE id = $1`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		var accessories []domain.Accessory
+		err := s.db.WithContext(context.Background()).Unscoped().Where("id = ?", 9).Find(&accessories).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("other models are left alone", func() {
+		s.SetupTest()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "m_user" WHERE username = $1 AND "m_user"."deleted_at" IS NULL`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		var users []domain.User
+		err := s.db.WithContext(context.Background()).Where("username = ?", "isla").Find(&users).Error
+		assert.NoError(s.T(), err)
+		assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+	})
+}
@@ -3,6 +3,8 @@ package validator
 import (
 	"fmt"
 	"lizobly/ctc-db-api/pkg/constants"
+	"slices"
+	"strings"
 
 	"github.com/go-playground/locales/en"
 	"github.com/go-playground/locales/id"
@@ -41,6 +43,9 @@ func NewValidator() (*CustomValidator, error) {
 	// Register Custom Validator
 	newValidator.RegisterValidation("influence", ValidateInfluence)
 	newValidator.RegisterValidation("job", ValidateJob)
+	newValidator.RegisterValidation("influences", ValidateInfluences)
+	newValidator.RegisterValidation("jobs", ValidateJobs)
+	newValidator.RegisterValidation("oneofcsv", ValidateOneOfCSV)
 
 	// Register Custom Validator Message
 	newValidator.RegisterTranslation("influence", english, func(ut ut.Translator) error {
@@ -59,6 +64,30 @@ func NewValidator() (*CustomValidator, error) {
 		return t
 	})
 
+	newValidator.RegisterTranslation("influences", english, func(ut ut.Translator) error {
+		return ut.Add("influences", "{0} must be a comma-separated list of valid influence types.", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("influences", fe.Field())
+
+		return t
+	})
+
+	newValidator.RegisterTranslation("jobs", english, func(ut ut.Translator) error {
+		return ut.Add("jobs", "{0} must be a comma-separated list of valid job types.", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("jobs", fe.Field())
+
+		return t
+	})
+
+	newValidator.RegisterTranslation("oneofcsv", english, func(ut ut.Translator) error {
+		return ut.Add("oneofcsv", "{0} must be a comma-separated list of [{1}].", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("oneofcsv", fe.Field(), fe.Param())
+
+		return t
+	})
+
 	return &CustomValidator{
 		Validator:  newValidator,
 		Translator: uni,
@@ -80,3 +109,34 @@ func ValidateInfluence(fl validator.FieldLevel) bool {
 func ValidateJob(fl validator.FieldLevel) bool {
 	return constants.GetJobID(fl.Field().String()) != 0
 }
+
+// ValidateInfluences checks a comma-separated list of influence names
+func ValidateInfluences(fl validator.FieldLevel) bool {
+	for _, value := range strings.Split(fl.Field().String(), ",") {
+		if constants.GetInfluenceID(strings.TrimSpace(value)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateJobs checks a comma-separated list of job names
+func ValidateJobs(fl validator.FieldLevel) bool {
+	for _, value := range strings.Split(fl.Field().String(), ",") {
+		if constants.GetJobID(strings.TrimSpace(value)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateOneOfCSV checks that every comma-separated value is one of the space-separated params
+func ValidateOneOfCSV(fl validator.FieldLevel) bool {
+	allowed := strings.Fields(fl.Param())
+	for _, value := range strings.Split(fl.Field().String(), ",") {
+		if !slices.Contains(allowed, strings.TrimSpace(value)) {
+			return false
+		}
+	}
+	return true
+}
@@ -139,3 +139,65 @@ func (s *ValidatorTestSuite) TestValidateJob() {
 		})
 	}
 }
+
+type TestStructWithOneOfCSV struct {
+	OrderBy string `validate:"omitempty,oneofcsv=name rarity"`
+}
+
+// TestValidateOneOfCSV tests comma-separated list validation
+func (s *ValidatorTestSuite) TestValidateOneOfCSV() {
+	tests := []struct {
+		name      string
+		value     string
+		shouldErr bool
+	}{
+		{name: "empty value", value: "", shouldErr: false},
+		{name: "single valid value", value: "name", shouldErr: false},
+		{name: "multiple valid values", value: "rarity,name", shouldErr: false},
+		{name: "valid values with spaces", value: "rarity, name", shouldErr: false},
+		{name: "one invalid value", value: "rarity,job", shouldErr: true},
+		{name: "empty element", value: "rarity,", shouldErr: true},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			err := s.validator.Validate(TestStructWithOneOfCSV{OrderBy: tt.value})
+			if tt.shouldErr {
+				s.Error(err)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
+
+type TestStructWithLists struct {
+	Influence string `validate:"omitempty,influences"`
+	Job       string `validate:"omitempty,jobs"`
+}
+
+// TestValidateInfluencesAndJobs tests comma-separated influence and job validation
+func (s *ValidatorTestSuite) TestValidateInfluencesAndJobs() {
+	tests := []struct {
+		name      string
+		input     TestStructWithLists
+		shouldErr bool
+	}{
+		{name: "single values", input: TestStructWithLists{Influence: constants.InfluenceFame, Job: constants.JobDancer}, shouldErr: false},
+		{name: "multiple values", input: TestStructWithLists{Influence: "Fame,Power", Job: "Warrior, Dancer"}, shouldErr: false},
+		{name: "invalid influence in list", input: TestStructWithLists{Influence: "Fame,Luck"}, shouldErr: true},
+		{name: "invalid job in list", input: TestStructWithLists{Job: "Warrior,Pirate"}, shouldErr: true},
+		{name: "trailing comma", input: TestStructWithLists{Job: "Warrior,"}, shouldErr: true},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			err := s.validator.Validate(tt.input)
+			if tt.shouldErr {
+				s.Error(err)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}